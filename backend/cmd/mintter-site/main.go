@@ -9,6 +9,7 @@ import (
 	"os"
 
 	"mintter/backend/cmd/mintter-site/sites"
+	"mintter/backend/daemon"
 	"mintter/backend/daemon/storage"
 
 	"github.com/burdiyan/go/mainutil"
@@ -64,6 +65,10 @@ Flags:
 			return err
 		}
 
+		if err := daemon.WaitUnlock(ctx, cfg, dir); err != nil {
+			return err
+		}
+
 		app, err := sites.Load(ctx, rawURL, cfg, dir)
 		if err != nil {
 			return err
@@ -56,9 +56,9 @@ func Load(ctx context.Context, address string, cfg config.Config, dir *storage.D
 	site := NewServer(address, blobsPromise.ReadOnly, nodePromise.ReadOnly, dbPromise.ReadOnly, cfg.Syncing.AllowPush)
 
 	app, err := daemon.Load(ctx, cfg, dir, site, daemon.GenericHandler{
-		Path:    "/.well-known/hypermedia-site",
+		Path:    "/",
 		Handler: site,
-		Mode:    daemon.RouteNav,
+		Mode:    daemon.RouteFallback,
 	})
 	if err != nil {
 		return nil, err
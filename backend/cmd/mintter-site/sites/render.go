@@ -0,0 +1,567 @@
+package sites
+
+import (
+	"context"
+	"embed"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"mintter/backend/core"
+	"mintter/backend/daemon/api/documents/v1alpha/docmodel"
+	documents "mintter/backend/genproto/documents/v1alpha"
+	"mintter/backend/hyper"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+var pageTemplate = template.Must(template.ParseFS(templatesFS, "templates/*.html"))
+
+// errNotFound is returned when the requested site content doesn't exist.
+var errNotFound = errors.New("not found")
+
+// Well-known paths served by the site besides the group content.
+const (
+	pathSiteInfo = "/.well-known/hypermedia-site"
+	pathFeed     = "/feed.xml"
+	pathSitemap  = "/sitemap.xml"
+)
+
+// siteContent is the published content of the group served by the site.
+type siteContent struct {
+	Title       string
+	Description string
+
+	// content maps site paths to hypermedia URLs of the documents.
+	content map[string]string
+	// docPaths maps document IDs to site paths, to resolve links between documents.
+	docPaths map[hyper.EntityID]string
+}
+
+// pageView is the data for the page template.
+type pageView struct {
+	Site   *siteContent
+	Doc    *documents.Document
+	Blocks []*blockView
+}
+
+// blockView is the block prepared for rendering in the template.
+type blockView struct {
+	ID           string
+	Type         string
+	Level        int
+	Text         string
+	Inline       template.HTML
+	Ref          template.URL
+	Name         string
+	Language     string
+	ChildrenType string
+	Children     []*blockView
+}
+
+func (ws *Website) serveContent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+
+	site, err := ws.loadSiteContent(ctx)
+	if err != nil {
+		ws.serveError(w, err)
+		return
+	}
+
+	switch r.URL.Path {
+	case pathFeed:
+		ws.serveFeed(ctx, w, site)
+	case pathSitemap:
+		ws.serveSitemap(ctx, w, site)
+	default:
+		ws.servePage(ctx, w, site, r.URL.Path)
+	}
+}
+
+func (ws *Website) servePage(ctx context.Context, w http.ResponseWriter, site *siteContent, path string) {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	target, ok := site.content[path]
+	if !ok || target == "" {
+		ws.serveError(w, errNotFound)
+		return
+	}
+
+	doc, err := ws.loadDocument(ctx, target)
+	if err != nil {
+		ws.serveError(w, err)
+		return
+	}
+
+	view := &pageView{
+		Site:   site,
+		Doc:    doc,
+		Blocks: site.blockViews(doc.Children, 2),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.ExecuteTemplate(w, "page", view); err != nil {
+		http.Error(w, "Failed to render page: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string     `xml:"id"`
+	Title   string     `xml:"title"`
+	Updated string     `xml:"updated"`
+	Link    atomLink   `xml:"link"`
+	Author  atomAuthor `xml:"author"`
+	Summary string     `xml:"summary,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+func (ws *Website) serveFeed(ctx context.Context, w http.ResponseWriter, site *siteContent) {
+	docs, err := ws.loadAllDocuments(ctx, site)
+	if err != nil {
+		ws.serveError(w, err)
+		return
+	}
+
+	feed := &atomFeed{
+		ID:    ws.url + "/",
+		Title: site.Title,
+		Link: []atomLink{
+			{Href: ws.url + "/"},
+			{Href: ws.url + pathFeed, Rel: "self"},
+		},
+	}
+
+	var updated time.Time
+	for _, p := range docs {
+		t := p.doc.UpdateTime.AsTime()
+		if t.After(updated) {
+			updated = t
+		}
+
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      ws.url + p.path,
+			Title:   p.doc.Title,
+			Updated: t.UTC().Format(time.RFC3339),
+			Link:    atomLink{Href: ws.url + p.path},
+			Author:  atomAuthor{Name: p.doc.Author},
+			Summary: summary(p.doc),
+		})
+	}
+	feed.Updated = updated.UTC().Format(time.RFC3339)
+
+	// Most recently updated documents go first.
+	sort.SliceStable(feed.Entries, func(i, j int) bool {
+		return feed.Entries[i].Updated > feed.Entries[j].Updated
+	})
+
+	ws.serveXML(w, "application/atom+xml; charset=utf-8", feed)
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func (ws *Website) serveSitemap(ctx context.Context, w http.ResponseWriter, site *siteContent) {
+	docs, err := ws.loadAllDocuments(ctx, site)
+	if err != nil {
+		ws.serveError(w, err)
+		return
+	}
+
+	set := &sitemapURLSet{}
+	for _, p := range docs {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:     ws.url + p.path,
+			LastMod: p.doc.UpdateTime.AsTime().UTC().Format("2006-01-02"),
+		})
+	}
+
+	ws.serveXML(w, "application/xml; charset=utf-8", set)
+}
+
+func (ws *Website) serveXML(w http.ResponseWriter, contentType string, v any) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, "Failed to marshal XML: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
+
+func (ws *Website) serveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errNodeNotReadyYet):
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	case errors.Is(err, errNotFound):
+		http.Error(w, "Page not found", http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to load site content: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// loadSiteContent loads the latest published state of the group served by the site.
+func (ws *Website) loadSiteContent(ctx context.Context) (*siteContent, error) {
+	if _, ok := ws.node.Get(); !ok {
+		return nil, errNodeNotReadyYet
+	}
+
+	groupID, err := ws.GetGroupID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group id from the db: %w", err)
+	}
+	if groupID == "" {
+		return nil, fmt.Errorf("site is not initialized yet: %w", errNotFound)
+	}
+
+	blobs, err := ws.blobs.Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := blobs.LoadEntity(ctx, hyper.EntityID(groupID))
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, fmt.Errorf("group %s is not published to the site yet: %w", groupID, errNotFound)
+	}
+
+	site := &siteContent{
+		content:  make(map[string]string),
+		docPaths: make(map[hyper.EntityID]string),
+	}
+
+	if v, ok := e.Get("title"); ok {
+		site.Title, _ = v.(string)
+	}
+	if v, ok := e.Get("description"); ok {
+		site.Description, _ = v.(string)
+	}
+
+	for _, p := range e.State().Keys("content") {
+		v, ok := e.Get("content", p)
+		if !ok {
+			panic("BUG: no content for key " + p)
+		}
+
+		target, _ := v.(string)
+		if target == "" {
+			continue
+		}
+
+		site.content[p] = target
+		if eid, _, err := parseDocumentURL(target); err == nil {
+			site.docPaths[eid] = p
+		}
+	}
+
+	return site, nil
+}
+
+type sitePage struct {
+	path string
+	doc  *documents.Document
+}
+
+// loadAllDocuments loads all the documents published in the site, sorted by path.
+func (ws *Website) loadAllDocuments(ctx context.Context, site *siteContent) ([]sitePage, error) {
+	paths := make([]string, 0, len(site.content))
+	for p := range site.content {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	out := make([]sitePage, 0, len(paths))
+	for _, p := range paths {
+		doc, err := ws.loadDocument(ctx, site.content[p])
+		if err != nil {
+			// Content can point to documents we don't have yet. We skip them instead of failing the whole listing.
+			if errors.Is(err, errNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		out = append(out, sitePage{path: p, doc: doc})
+	}
+
+	return out, nil
+}
+
+// loadDocument loads and hydrates the document referenced by the hypermedia URL.
+func (ws *Website) loadDocument(ctx context.Context, target string) (*documents.Document, error) {
+	eid, version, err := parseDocumentURL(target)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), errNotFound)
+	}
+
+	blobs, err := ws.blobs.Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var e *hyper.Entity
+	if version == "" {
+		e, err = blobs.LoadEntity(ctx, eid)
+	} else {
+		heads, perr := version.Parse()
+		if perr != nil {
+			return nil, fmt.Errorf("bad version in %s: %v: %w", target, perr, errNotFound)
+		}
+		e, err = blobs.LoadEntityFromHeads(ctx, eid, heads...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, fmt.Errorf("document %s is not published to the site: %w", target, errNotFound)
+	}
+
+	changes := e.AppliedChanges()
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("document %s has no changes: %w", target, errNotFound)
+	}
+
+	// The document is never mutated here, so we don't need a signer,
+	// and any delegation from the document itself will do.
+	dm, err := docmodel.New(e, core.KeyPair{}, changes[len(changes)-1].Data.Delegation)
+	if err != nil {
+		return nil, err
+	}
+
+	return dm.Hydrate(ctx, blobs)
+}
+
+// parseDocumentURL parses hypermedia URLs like hm://d/<id>?v=<version>#<fragment>.
+func parseDocumentURL(target string) (hyper.EntityID, hyper.Version, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse content URL %s: %w", target, err)
+	}
+
+	if u.Scheme != "hm" || u.Host != "d" || u.Path == "" {
+		return "", "", fmt.Errorf("content URL %s is not a document", target)
+	}
+
+	return hyper.EntityID("hm://d" + u.Path), hyper.Version(u.Query().Get("v")), nil
+}
+
+func (site *siteContent) blockViews(nodes []*documents.BlockNode, level int) []*blockView {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	out := make([]*blockView, 0, len(nodes))
+	for _, n := range nodes {
+		blk := n.Block
+		bv := &blockView{
+			ID:           blk.Id,
+			Type:         blk.Type,
+			Level:        level,
+			Text:         blk.Text,
+			Inline:       site.inlineHTML(blk),
+			Ref:          site.resolveURL(blk.Ref),
+			Name:         blk.Attributes["name"],
+			Language:     blk.Attributes["language"],
+			ChildrenType: blk.Attributes["childrenType"],
+		}
+
+		childLevel := level
+		if blk.Type == "heading" && level < 6 {
+			childLevel++
+		}
+		bv.Children = site.blockViews(n.Children, childLevel)
+
+		out = append(out, bv)
+	}
+
+	return out
+}
+
+// resolveURL converts references used in documents into links usable from a browser.
+// Documents published in the site are linked by their site paths,
+// and IPFS files are served by the daemon itself.
+func (site *siteContent) resolveURL(ref string) template.URL {
+	if ref == "" {
+		return ""
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	switch u.Scheme {
+	case "hm":
+		if u.Host == "d" {
+			if p, ok := site.docPaths[hyper.EntityID("hm://d"+u.Path)]; ok {
+				if u.Fragment != "" {
+					p += "#" + u.Fragment
+				}
+				return template.URL(p)
+			}
+		}
+		// Other hypermedia links can be opened by the Mintter app.
+		return template.URL(ref)
+	case "ipfs":
+		return template.URL("/ipfs/" + u.Host + u.Path)
+	case "http", "https", "mailto":
+		return template.URL(ref)
+	default:
+		return ""
+	}
+}
+
+// inlineTags are the HTML elements for the supported annotation types.
+var inlineTags = map[string]string{
+	"strong":    "strong",
+	"emphasis":  "em",
+	"underline": "u",
+	"strike":    "s",
+	"code":      "code",
+	"link":      "a",
+}
+
+// inlineHTML renders the text of the block with its annotations.
+// Annotation offsets are in UTF-16 code units.
+func (site *siteContent) inlineHTML(blk *documents.Block) template.HTML {
+	text := utf16.Encode([]rune(blk.Text))
+	size := len(text)
+
+	bounds := map[int]struct{}{0: {}, size: {}}
+	for _, a := range blk.Annotations {
+		for i := range a.Starts {
+			bounds[clamp(int(a.Starts[i]), size)] = struct{}{}
+			if i < len(a.Ends) {
+				bounds[clamp(int(a.Ends[i]), size)] = struct{}{}
+			}
+		}
+	}
+
+	points := make([]int, 0, len(bounds))
+	for b := range bounds {
+		points = append(points, b)
+	}
+	sort.Ints(points)
+
+	var sb strings.Builder
+	for i := 0; i+1 < len(points); i++ {
+		start, end := points[i], points[i+1]
+		chunk := template.HTMLEscapeString(string(utf16.Decode(text[start:end])))
+
+		var closing []string
+		for _, a := range blk.Annotations {
+			if !covers(a, start, end) {
+				continue
+			}
+
+			if a.Type == "inline-embed" {
+				// The text of inline embeds is a placeholder, so we render the reference itself.
+				ref := site.resolveURL(a.Ref)
+				chunk = `<a href="` + template.HTMLEscapeString(string(ref)) + `">` + template.HTMLEscapeString(a.Ref) + `</a>`
+				continue
+			}
+
+			tag, ok := inlineTags[a.Type]
+			if !ok {
+				continue
+			}
+
+			if tag == "a" {
+				sb.WriteString(`<a href="` + template.HTMLEscapeString(string(site.resolveURL(a.Ref))) + `">`)
+			} else {
+				sb.WriteString("<" + tag + ">")
+			}
+			closing = append(closing, "</"+tag+">")
+		}
+
+		sb.WriteString(chunk)
+		for j := len(closing) - 1; j >= 0; j-- {
+			sb.WriteString(closing[j])
+		}
+	}
+
+	return template.HTML(sb.String()) //nolint:gosec // All the text is escaped above.
+}
+
+// covers checks whether any span of the annotation contains the range.
+func covers(a *documents.Annotation, start, end int) bool {
+	for i := range a.Starts {
+		if i >= len(a.Ends) {
+			break
+		}
+		if int(a.Starts[i]) <= start && end <= int(a.Ends[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func clamp(v, size int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > size {
+		return size
+	}
+	return v
+}
+
+// summary returns the text of the first non-empty block of the document.
+func summary(doc *documents.Document) string {
+	var walk func(nodes []*documents.BlockNode) string
+	walk = func(nodes []*documents.BlockNode) string {
+		for _, n := range nodes {
+			if n.Block.Type == "paragraph" && strings.TrimSpace(n.Block.Text) != "" {
+				return n.Block.Text
+			}
+			if s := walk(n.Children); s != "" {
+				return s
+			}
+		}
+		return ""
+	}
+
+	return walk(doc.Children)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -54,11 +55,23 @@ func NewServer(url string, blobs *future.ReadOnly[*hyper.Storage], n *future.Rea
 		allowPush: allowPush,
 	}
 }
+
+// ServeHTTP serves the public site info, and renders the group content as HTML pages,
+// with an Atom feed and a sitemap.
 func (ws *Website) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept")
 	w.Header().Set("Access-Control-Allow-Methods", "OPTIONS, GET")
 
+	if r.URL.Path == pathSiteInfo {
+		ws.serveSiteInfo(w, r)
+		return
+	}
+
+	ws.serveContent(w, r)
+}
+
+func (ws *Website) serveSiteInfo(w http.ResponseWriter, r *http.Request) {
 	siteInfo, err := ws.GetSiteInfo(r.Context(), &groups.GetSiteInfoRequest{})
 	if err != nil {
 		if errors.Is(err, errNodeNotReadyYet) {
@@ -240,20 +253,32 @@ func (ws *Website) GetGroupID(ctx context.Context) (string, error) {
 	return groupID, nil
 }
 
-// PublishBlobs publishes blobs to the website.
-func (ws *Website) PublishBlobs(ctx context.Context, in *groups.PublishBlobsRequest) (*groups.PublishBlobsResponse, error) {
+// Storage limits for the published content.
+const (
+	maxBlobsPerRequest = 10000
+	maxBlobSize        = 4 << 20 // 4MB.
+)
+
+// siteFeatures are the protocol features supported by the site.
+var siteFeatures = []string{
+	"caller-permissions",
+	"html-rendering",
+	"atom-feed",
+	"sitemap",
+}
+
+// GetCallerPermissions returns the permissions of the caller on this site.
+func (ws *Website) GetCallerPermissions(ctx context.Context, in *groups.GetCallerPermissionsRequest) (*groups.CallerPermissions, error) {
+	return ws.callerPermissions(ctx)
+}
+
+func (ws *Website) callerPermissions(ctx context.Context) (*groups.CallerPermissions, error) {
 	n, ok := ws.node.Get()
 	if !ok {
 		return nil, errNodeNotReadyYet
 	}
 
-	// Get caller identity
-	info, ok := rpcpeer.FromContext(ctx)
-	if !ok {
-		return nil, fmt.Errorf("no peer info in context for grpc")
-	}
-
-	pid, err := peer.Decode(info.Addr.String())
+	pid, err := getRemoteID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -267,38 +292,86 @@ func (ws *Website) PublishBlobs(ctx context.Context, in *groups.PublishBlobsRequ
 	if err != nil {
 		return nil, err
 	}
-	if !ws.allowPush { // if force push is not allowed (default) then only group members can push. Everyone otherwise.
-		var role groups.Role
-		if err := db.WithSave(ctx, func(conn *sqlite.Conn) error {
-			// Get the owner's view of the list of members.
-			groupID, err := storage.GetKV(ctx, conn, keySiteGroup)
-			if err != nil || groupID == "" {
-				return fmt.Errorf("error getting groupID on the site, is the site initialized?: %w", err)
-			}
 
-			groupOwner, err := storage.GetKV(ctx, conn, keySiteOwner)
-			if err != nil || groupOwner == "" {
-				return fmt.Errorf("error getting group owner on the site, is the site initialized?: %w", err)
-			}
+	perms := &groups.CallerPermissions{
+		AccountId: callerAccount.String(),
+		StorageLimits: &groups.StorageLimits{
+			MaxBlobsPerRequest: maxBlobsPerRequest,
+			MaxBlobSize:        maxBlobSize,
+		},
+		Features: siteFeatures,
+	}
 
-			if groupOwner == callerAccount.String() {
-				role = groups.Role_OWNER
-			} else {
-				r, err := hypersql.GetGroupRole(conn, groupID, "hm://a/"+callerAccount.String())
-				if err != nil {
-					return err
-				}
-				role = groups.Role(r)
-			}
+	var groupID string
+	if err := db.WithSave(ctx, func(conn *sqlite.Conn) error {
+		// Get the owner's view of the list of members.
+		groupID, err = storage.GetKV(ctx, conn, keySiteGroup)
+		if err != nil {
+			return fmt.Errorf("error getting groupID on the site: %w", err)
+		}
+
+		if groupID == "" {
+			return nil
+		}
+
+		groupOwner, err := storage.GetKV(ctx, conn, keySiteOwner)
+		if err != nil || groupOwner == "" {
+			return fmt.Errorf("error getting group owner on the site, is the site initialized?: %w", err)
+		}
+
+		if groupOwner == callerAccount.String() {
+			perms.Role = groups.Role_OWNER
 			return nil
-		}); err != nil {
-			return nil, err
 		}
 
-		if role != groups.Role_OWNER && role != groups.Role_EDITOR {
-			return nil, status.Errorf(codes.PermissionDenied, "Caller %q does not have enough permissions to publish to this site.", callerAccount.String())
+		r, err := hypersql.GetGroupRole(conn, groupID, "hm://a/"+callerAccount.String())
+		if err != nil {
+			return err
 		}
+		perms.Role = groups.Role(r)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if groupID == "" {
+		perms.AllowedOperations = append(perms.AllowedOperations, groups.SiteOperation_INITIALIZE_SERVER)
+	}
+
+	// If force push is not allowed (default) then only members who can edit the group content can push. Everyone otherwise.
+	switch {
+	case ws.allowPush || hyper.GroupRoleAllows(perms.Role, hyper.GroupCapEditContent):
+		perms.AllowedOperations = append(perms.AllowedOperations, groups.SiteOperation_PUBLISH_BLOBS)
+	case groupID == "":
+		perms.PublishDeniedReason = "Site is not initialized yet."
+	default:
+		perms.PublishDeniedReason = fmt.Sprintf("Caller %q does not have enough permissions to publish to this site. Only owners, admins and editors of the group %q can publish.", callerAccount.String(), groupID)
+	}
+
+	return perms, nil
+}
+
+// PublishBlobs publishes blobs to the website.
+func (ws *Website) PublishBlobs(ctx context.Context, in *groups.PublishBlobsRequest) (*groups.PublishBlobsResponse, error) {
+	n, ok := ws.node.Get()
+	if !ok {
+		return nil, errNodeNotReadyYet
+	}
+
+	perms, err := ws.callerPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(perms.AllowedOperations, groups.SiteOperation_PUBLISH_BLOBS) {
+		return nil, status.Error(codes.PermissionDenied, perms.PublishDeniedReason)
+	}
+
+	if len(in.Blobs) > maxBlobsPerRequest {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many blobs in one request: %d > %d", len(in.Blobs), maxBlobsPerRequest)
 	}
+
 	blobs, err := ws.blobs.Await(ctx)
 	if err != nil {
 		return nil, err
@@ -332,6 +405,10 @@ func (ws *Website) PublishBlobs(ctx context.Context, in *groups.PublishBlobsRequ
 				return nil, fmt.Errorf("could not get block %s: %w", c.String(), err)
 			}
 
+			if len(blk.RawData()) > maxBlobSize {
+				return nil, status.Errorf(codes.ResourceExhausted, "blob %s is too large: %d > %d bytes", c.String(), len(blk.RawData()), maxBlobSize)
+			}
+
 			if err := bs.Put(ctx, blk); err != nil {
 				return nil, fmt.Errorf("could not store block %s: %w", c.String(), err)
 			}
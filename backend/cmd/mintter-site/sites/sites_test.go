@@ -8,11 +8,15 @@ import (
 	"mintter/backend/daemon"
 	"mintter/backend/daemon/storage"
 	accounts "mintter/backend/genproto/accounts/v1alpha"
+	daemonpb "mintter/backend/genproto/daemon/v1alpha"
+	documents "mintter/backend/genproto/documents/v1alpha"
 	groups "mintter/backend/genproto/groups/v1alpha"
 	"mintter/backend/ipfs"
 	"mintter/backend/pkg/libp2px"
 	"mintter/backend/pkg/must"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
@@ -92,10 +96,20 @@ func TestSiteSync(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	_, err = alice.RPC.Groups.SyncGroupSite(ctx, &groups.SyncGroupSiteRequest{GroupId: group.Id})
+	syncResp, err := alice.RPC.Groups.SyncGroupSite(ctx, &groups.SyncGroupSiteRequest{GroupId: group.Id})
 	require.NoError(t, err, "alice must be able to sync with the site as an owner")
+	require.Equal(t, "", syncResp.PushDeniedReason, "owner must be allowed to push")
 	_ = david
 
+	jobs, err := alice.RPC.Daemon.ListSyncJobs(ctx, &daemonpb.ListSyncJobsRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, jobs.RecentJobs)
+	siteJob := jobs.RecentJobs[0]
+	require.Equal(t, daemonpb.SyncJobKind_SITE, siteJob.Kind, "site sync must run as a sync job")
+	require.Equal(t, daemonpb.SyncJobState_SUCCEEDED, siteJob.State)
+	require.Equal(t, site.Address.String(), siteJob.Target)
+	require.Greater(t, siteJob.PushedBlobs, int64(0), "site job must report the pushed blobs")
+
 	info, err := site.Website.GetSiteInfo(ctx, &groups.GetSiteInfoRequest{})
 	require.NoError(t, err)
 	require.Equal(t, group.Id, info.GroupId, "site must serve the correct group ID")
@@ -116,6 +130,64 @@ func TestSiteSync(t *testing.T) {
 	require.NotEqual(t, "", bobOnSite.Profile.Alias, "site must have bob's account because he's a member of the group")
 }
 
+func TestSiteCallerPermissions(t *testing.T) {
+	t.Parallel()
+
+	site := makeTestSite(t, "carol")
+	alice := daemon.MakeTestApp(t, "alice", daemon.MakeTestConfig(t), true)
+	bob := daemon.MakeTestApp(t, "bob", daemon.MakeTestConfig(t), true)
+	ctx := context.Background()
+
+	require.NoError(t, alice.Net.MustGet().Connect(ctx, bob.Net.MustGet().AddrInfo()), "alice must connect to bob")
+	require.NoError(t, alice.Syncing.MustGet().SyncWithPeer(ctx, bob.Storage.Device().PeerID()), "alice must have synced with bob")
+
+	group, err := alice.RPC.Groups.CreateGroup(ctx, &groups.CreateGroupRequest{
+		Title:        "My test group",
+		SiteSetupUrl: site.Website.GetSetupURL(ctx),
+	})
+	require.NoError(t, err)
+
+	_, err = alice.RPC.Groups.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groups.Role{
+			bob.Storage.Identity().MustGet().Account().Principal().String(): groups.Role_EDITOR,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = alice.RPC.Groups.SyncGroupSite(ctx, &groups.SyncGroupSiteRequest{GroupId: group.Id})
+	require.NoError(t, err)
+
+	siteID := site.Storage.Device().PeerID()
+
+	tests := []struct {
+		name string
+		app  *daemon.App
+		role groups.Role
+	}{
+		{"alice", alice, groups.Role_OWNER},
+		{"bob", bob, groups.Role_EDITOR},
+	}
+
+	for _, tt := range tests {
+		n := tt.app.Net.MustGet()
+		require.NoError(t, n.Connect(ctx, site.Net.MustGet().AddrInfo()))
+
+		sc, err := n.SiteClient(ctx, siteID)
+		require.NoError(t, err)
+
+		perms, err := sc.GetCallerPermissions(ctx, &groups.GetCallerPermissionsRequest{})
+		require.NoError(t, err)
+		require.Equal(t, tt.app.Storage.Identity().MustGet().Account().Principal().String(), perms.AccountId, "%s must be identified by the account", tt.name)
+		require.Equal(t, tt.role, perms.Role, "%s must have the correct role", tt.name)
+		require.Equal(t, []groups.SiteOperation{groups.SiteOperation_PUBLISH_BLOBS}, perms.AllowedOperations, "%s must be allowed to publish", tt.name)
+		require.Equal(t, "", perms.PublishDeniedReason)
+		require.Equal(t, int64(maxBlobsPerRequest), perms.StorageLimits.MaxBlobsPerRequest)
+		require.Equal(t, int64(maxBlobSize), perms.StorageLimits.MaxBlobSize)
+		require.Contains(t, perms.Features, "caller-permissions")
+	}
+}
+
 func makeTestSite(t *testing.T, name string) *App {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -160,3 +232,82 @@ func freePort(t *testing.T) int {
 	defer l.Close()
 	return l.Addr().(*net.TCPAddr).Port
 }
+
+func TestSiteRendering(t *testing.T) {
+	t.Parallel()
+
+	site := makeTestSite(t, "carol")
+	alice := daemon.MakeTestApp(t, "alice", daemon.MakeTestConfig(t), true)
+	ctx := context.Background()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		site.Website.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	require.Equal(t, http.StatusNotFound, get("/").Code, "uninitialized site must not have any content")
+
+	draft, err := alice.RPC.Documents.ImportMarkdown(ctx, &documents.ImportMarkdownRequest{
+		Markdown: "# Welcome\n\nHello **world** & friends.\n\n- One\n- Two\n",
+	})
+	require.NoError(t, err)
+	pub, err := alice.RPC.Documents.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	group, err := alice.RPC.Groups.CreateGroup(ctx, &groups.CreateGroupRequest{
+		Title:        "My test group",
+		SiteSetupUrl: site.Website.GetSetupURL(ctx),
+	})
+	require.NoError(t, err)
+
+	_, err = alice.RPC.Groups.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedContent: map[string]string{
+			"/":      pub.Document.Id + "?v=" + pub.Version,
+			"/about": "hm://d/missing-document",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = alice.RPC.Groups.SyncGroupSite(ctx, &groups.SyncGroupSiteRequest{GroupId: group.Id})
+	require.NoError(t, err)
+
+	{
+		w := get("/")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		require.Contains(t, body, "<title>Welcome | My test group</title>")
+		require.Contains(t, body, "<h1>Welcome</h1>")
+		require.Contains(t, body, "Hello <strong>world</strong> &amp; friends.")
+		require.Contains(t, body, "<ul><li>")
+	}
+
+	require.Equal(t, http.StatusNotFound, get("/about").Code, "content without published document must not be found")
+	require.Equal(t, http.StatusNotFound, get("/missing").Code)
+
+	{
+		w := get("/feed.xml")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		body := w.Body.String()
+		require.Contains(t, body, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+		require.Contains(t, body, "<title>Welcome</title>")
+		require.Contains(t, body, "<id>"+site.Address.String()+"/</id>")
+		require.Contains(t, body, "<summary>Hello world &amp; friends.</summary>")
+	}
+
+	{
+		w := get("/sitemap.xml")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		body := w.Body.String()
+		require.Contains(t, body, "<loc>"+site.Address.String()+"/</loc>")
+		require.NotContains(t, body, "/about", "sitemap must only list available documents")
+	}
+
+	// Pages must be served by the HTTP server of the site.
+	resp, err := http.Get(site.Address.String() + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"mintter/backend/config"
+	entities "mintter/backend/genproto/entities/v1alpha"
+)
+
+func exportCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("mintterd export", flag.ExitOnError)
+	port := fs.Int("grpc.port", config.Default().GRPC.Port, "Port of the gRPC server of the running daemon")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mintterd export [flags] <entity-id> <file.car>")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("must specify entity ID and output file")
+	}
+
+	conn, err := dialDaemon(ctx, *port)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := entities.NewEntitiesClient(conn)
+
+	resp, err := client.ExportEntity(ctx, &entities.ExportEntityRequest{Id: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(fs.Arg(1), resp.Car, 0o600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d blobs of %s into %s\n", resp.BlobCount, fs.Arg(0), fs.Arg(1))
+
+	return nil
+}
+
+func importCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("mintterd import", flag.ExitOnError)
+	port := fs.Int("grpc.port", config.Default().GRPC.Port, "Port of the gRPC server of the running daemon")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mintterd import [flags] <file.car>")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("must specify input file")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	conn, err := dialDaemon(ctx, *port)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := entities.NewEntitiesClient(conn)
+
+	resp, err := client.ImportEntity(ctx, &entities.ImportEntityRequest{Car: data})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d blobs from %s\n", resp.BlobCount, fs.Arg(0))
+	for _, id := range resp.Ids {
+		fmt.Println(id)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// maxArchiveSize is the maximum size of the CAR archives we can transfer over gRPC
+// when exporting and importing entities.
+const maxArchiveSize = 256 << 20 // 256MiB.
+
+// subcommands are executed as clients of the running daemon.
+var subcommands = map[string]func(ctx context.Context, args []string) error{
+	"export":   exportCmd,
+	"import":   importCmd,
+	"markdown": markdownCmd,
+}
+
+func dialDaemon(ctx context.Context, port int) (*grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(ctx, "localhost:"+strconv.Itoa(port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxArchiveSize),
+			grpc.MaxCallSendMsgSize(maxArchiveSize),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the daemon: %w", err)
+	}
+
+	return conn, nil
+}
@@ -29,6 +29,12 @@ func main() {
 	mainutil.Run(func() error {
 		ctx := mainutil.TrapSignals()
 
+		if len(os.Args) > 1 {
+			if cmd, ok := subcommands[os.Args[1]]; ok {
+				return cmd(ctx, os.Args[2:])
+			}
+		}
+
 		fs := flag.NewFlagSet("mintterd", flag.ExitOnError)
 
 		cfg := config.Default()
@@ -72,6 +78,10 @@ func main() {
 		if err != nil {
 			return err
 		}
+
+		if err := daemon.WaitUnlock(ctx, cfg, dir); err != nil {
+			return err
+		}
 		app, err := daemon.Load(ctx, cfg, dir, cfg.LogLevel,
 			grpc.ChainUnaryInterceptor(
 				otelgrpc.UnaryServerInterceptor(),
@@ -80,6 +90,7 @@ func main() {
 			grpc.ChainStreamInterceptor(
 				otelgrpc.StreamServerInterceptor(),
 			),
+			grpc.MaxRecvMsgSize(maxArchiveSize),
 		)
 		if err != nil {
 			return err
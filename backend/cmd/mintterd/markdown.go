@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"mintter/backend/config"
+	documents "mintter/backend/genproto/documents/v1alpha"
+)
+
+func markdownCmd(ctx context.Context, args []string) error {
+	const usage = "Usage: mintterd markdown <export|import> [flags] <args>"
+
+	if len(args) == 0 {
+		return fmt.Errorf(usage)
+	}
+
+	switch args[0] {
+	case "export":
+		return markdownExportCmd(ctx, args[1:])
+	case "import":
+		return markdownImportCmd(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown markdown command %q\n%s", args[0], usage)
+	}
+}
+
+func markdownExportCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("mintterd markdown export", flag.ExitOnError)
+	port := fs.Int("grpc.port", config.Default().GRPC.Port, "Port of the gRPC server of the running daemon")
+	draft := fs.Bool("draft", false, "Export the current draft instead of the publication")
+	version := fs.String("version", "", "Version of the publication to export, latest if empty")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mintterd markdown export [flags] <document-id> [file.md]")
+		fmt.Fprintln(fs.Output(), "Writes to stdout if the file is not specified.")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return fmt.Errorf("must specify document ID")
+	}
+
+	conn, err := dialDaemon(ctx, *port)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := documents.NewDraftsClient(conn).ExportMarkdown(ctx, &documents.ExportMarkdownRequest{
+		DocumentId: fs.Arg(0),
+		Version:    *version,
+		Draft:      *draft,
+	})
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() == 1 {
+		_, err := os.Stdout.WriteString(resp.Markdown)
+		return err
+	}
+
+	return os.WriteFile(fs.Arg(1), []byte(resp.Markdown), 0o600)
+}
+
+func markdownImportCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("mintterd markdown import", flag.ExitOnError)
+	port := fs.Int("grpc.port", config.Default().GRPC.Port, "Port of the gRPC server of the running daemon")
+	docID := fs.String("document", "", "ID of the existing document to update, a new document is created if empty")
+	publish := fs.Bool("publish", false, "Publish the draft after importing")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mintterd markdown import [flags] <file.md>")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("must specify input file")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	conn, err := dialDaemon(ctx, *port)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := documents.NewDraftsClient(conn)
+
+	draft, err := client.ImportMarkdown(ctx, &documents.ImportMarkdownRequest{
+		DocumentId: *docID,
+		Markdown:   string(data),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !*publish {
+		fmt.Printf("Imported %s into draft %s\n", fs.Arg(0), draft.Id)
+		return nil
+	}
+
+	pub, err := client.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %s and published %s?v=%s\n", fs.Arg(0), draft.Id, pub.Version)
+
+	return nil
+}
@@ -48,6 +48,7 @@ type Config struct {
 	Lndhub  Lndhub
 	P2P     P2P
 	Syncing Syncing
+	GC      GC
 }
 
 // BindFlags configures the given FlagSet with the existing values from the given Config
@@ -63,6 +64,7 @@ func (c *Config) BindFlags(fs *flag.FlagSet) {
 	c.Lndhub.BindFlags(fs)
 	c.P2P.BindFlags(fs)
 	c.Syncing.BindFlags(fs)
+	c.GC.BindFlags(fs)
 }
 
 // Default creates a new default config.
@@ -82,15 +84,21 @@ func Default() Config {
 			Mainnet: false,
 		},
 		P2P: P2P{
-			BootstrapPeers: ipfs.DefaultBootstrapPeers(),
-			Port:           55000,
-			RelayBackoff:   time.Minute * 3,
+			BootstrapPeers:    ipfs.DefaultBootstrapPeers(),
+			Port:              55000,
+			RelayBackoff:      time.Minute * 3,
+			ProvidingStrategy: ProvideAll,
 		},
 		Syncing: Syncing{
 			WarmupDuration:  time.Second * 20,
 			Interval:        time.Minute,
 			TimeoutPerPeer:  time.Minute * 5,
 			RefreshInterval: time.Second * 50,
+			MaxJobs:         16,
+		},
+		GC: GC{
+			Interval:    time.Hour,
+			GracePeriod: time.Hour,
 		},
 	}
 }
@@ -138,6 +146,26 @@ func newAddrsFlag(val []multiaddr.Multiaddr, p *[]multiaddr.Multiaddr) flag.Valu
 	return (*addrsFlag)(p)
 }
 
+type stringsFlag []string
+
+func (sl *stringsFlag) String() string {
+	if sl == nil {
+		return ""
+	}
+
+	return strings.Join(*sl, ",")
+}
+
+func (sl *stringsFlag) Set(s string) error {
+	*sl = strings.Split(s, ",")
+	return nil
+}
+
+func newStringsFlag(val []string, p *[]string) flag.Value {
+	*p = val
+	return (*stringsFlag)(p)
+}
+
 // HTTP configuration.
 type HTTP struct {
 	Port int
@@ -174,6 +202,7 @@ type Syncing struct {
 	Interval        time.Duration
 	TimeoutPerPeer  time.Duration
 	RefreshInterval time.Duration
+	MaxJobs         int
 	NoPull          bool
 	NoDiscovery     bool
 	AllowPush       bool
@@ -183,13 +212,28 @@ type Syncing struct {
 func (c *Syncing) BindFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&c.WarmupDuration, "syncing.warmup-duration", c.WarmupDuration, "Time to wait before the first sync loop iteration")
 	fs.DurationVar(&c.Interval, "syncing.interval", c.Interval, "Periodic interval at which sync loop is triggered")
-	fs.DurationVar(&c.TimeoutPerPeer, "syncing.timeout-per-peer", c.TimeoutPerPeer, "Maximum duration for syncing with a single peer")
+	fs.DurationVar(&c.TimeoutPerPeer, "syncing.timeout-per-peer", c.TimeoutPerPeer, "Maximum duration for a single sync job, e.g. syncing with a peer or a site")
+	fs.IntVar(&c.MaxJobs, "syncing.max-jobs", c.MaxJobs, "Maximum number of sync jobs running concurrently")
 	fs.DurationVar(&c.RefreshInterval, "syncing.refresh-interval", c.RefreshInterval, "Periodic interval at which list of peers to sync is refreshed from the database")
 	fs.BoolVar(&c.AllowPush, "syncing.allow-push", c.AllowPush, "Allows direct content push. Anyone could force push content.")
 	fs.BoolVar(&c.NoPull, "syncing.no-pull", c.NoPull, "Disables periodic content pulling")
 	fs.BoolVar(&c.NoDiscovery, "syncing.no-discovery", c.NoDiscovery, "Disables the ability to discover content from other peers")
 }
 
+// GC is the configuration for the garbage collection of blobs.
+type GC struct {
+	Interval    time.Duration
+	GracePeriod time.Duration
+	QuotaBytes  int64
+}
+
+// BindFlags binds the flags to the given FlagSet.
+func (c *GC) BindFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&c.Interval, "gc.interval", c.Interval, "Periodic interval at which unreachable blobs are deleted. Zero disables periodic garbage collection")
+	fs.DurationVar(&c.GracePeriod, "gc.grace-period", c.GracePeriod, "Minimum age of unreachable blobs before they can be deleted")
+	fs.Int64Var(&c.QuotaBytes, "gc.quota-bytes", c.QuotaBytes, "Maximum size of the stored blobs. When exceeded, content from other accounts is evicted, least recently updated first. Zero means no quota")
+}
+
 // P2P networking configuration.
 type P2P struct {
 	TestnetName             string
@@ -202,8 +246,21 @@ type P2P struct {
 	NoPrivateIps            bool
 	NoMetrics               bool
 	RelayBackoff            time.Duration
+	LocalDiscovery          bool
+	ProvidingStrategy       string
+	ProvidingPins           []string
 }
 
+// Providing strategies define which entities we announce on the DHT.
+// Entities listed in the pins are always announced in addition to the ones selected by the strategy.
+const (
+	ProvideAll        = "all"        // All the entities we have.
+	ProvideOwn        = "own"        // Entities owned by our accounts.
+	ProvideTrusted    = "trusted"    // Entities owned by our accounts and the accounts we trust.
+	ProvideSubscribed = "subscribed" // Entities we are subscribed to, including the content of the subscribed groups.
+	ProvidePinned     = "pinned"     // Only the pinned entities.
+)
+
 // BindFlags binds the flags to the given FlagSet.
 func (p2p *P2P) BindFlags(fs *flag.FlagSet) {
 	fs.StringVar(&p2p.TestnetName, "p2p.testnet-name", p2p.TestnetName, "Name of the testnet to use (empty for mainnet)")
@@ -216,6 +273,9 @@ func (p2p *P2P) BindFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&p2p.NoPrivateIps, "p2p.no-private-ips", p2p.NoPrivateIps, "Avoid announcing private IP addresses (ignored when using -p2p.announce-addrs)")
 	fs.BoolVar(&p2p.NoMetrics, "p2p.no-metrics", p2p.NoMetrics, "Disable Prometheus metrics collection")
 	fs.DurationVar(&p2p.RelayBackoff, "p2p.relay-backoff", p2p.RelayBackoff, "The time the autorelay waits to reconnect after failing to obtain a reservation with a candidate")
+	fs.BoolVar(&p2p.LocalDiscovery, "p2p.local-discovery", p2p.LocalDiscovery, "Discover other peers on the local network using mDNS")
+	fs.StringVar(&p2p.ProvidingStrategy, "p2p.providing-strategy", p2p.ProvidingStrategy, "Which entities to announce on the DHT periodically: all | own | trusted | subscribed | pinned")
+	fs.Var(newStringsFlag(p2p.ProvidingPins, &p2p.ProvidingPins), "p2p.providing-pins", "Entity IDs to always announce on the DHT (comma separated)")
 }
 
 // NoBootstrap indicates whether bootstrap nodes are configured.
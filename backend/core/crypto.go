@@ -5,14 +5,17 @@ import (
 	"crypto/cipher"
 	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/sha512"
 	"encoding"
 	"errors"
 	"fmt"
+	"math/big"
 	"unsafe"
 
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/crypto/nacl/box"
 )
 
 const (
@@ -177,6 +180,22 @@ func (pk PublicKey) Wrapped() crypto.PubKey {
 	return pk.k
 }
 
+// SealAnonymous encrypts the message in a way that only the owner of the corresponding private key can open it.
+// The sender remains anonymous. The Ed25519 key is converted into its X25519 form to be used with NaCl box.
+func (pk PublicKey) SealAnonymous(msg []byte) ([]byte, error) {
+	raw, err := pk.k.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := x25519PublicKey(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return box.SealAnonymous(nil, msg, recipient, rand.Reader)
+}
+
 // KeyPair is a wrapper libp2p crypto package, to provide some convenience function for
 // encoding/decoding and other things, like having the public key pre-generated and cached
 // instead of generating it with every method call like libp2p does. Also, libp2p cryptographic
@@ -287,3 +306,86 @@ func (kp KeyPair) Decrypt(ciphertext []byte) ([]byte, error) {
 func (kp KeyPair) Wrapped() crypto.PrivKey {
 	return kp.k
 }
+
+// OpenAnonymous decrypts the message sealed with SealAnonymous for the public key of this key pair.
+func (kp KeyPair) OpenAnonymous(sealed []byte) ([]byte, error) {
+	raw, err := kp.k.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := kp.PublicKey.k.Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := x25519PublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey := x25519PrivateKey(raw[:ed25519.SeedSize])
+
+	out, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to open sealed message")
+	}
+
+	return out, nil
+}
+
+// curve25519P is the prime order of the field of Curve25519: 2^255 - 19.
+var curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// x25519PublicKey converts the Ed25519 public key into the X25519 public key
+// using the birational map between Edwards and Montgomery forms of the curve: u = (1 + y) / (1 - y).
+func x25519PublicKey(edPub []byte) (*[32]byte, error) {
+	if len(edPub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key size %d", len(edPub))
+	}
+
+	// The key is the little-endian encoding of y, with the highest bit being the sign of x.
+	var be [32]byte
+	for i := range edPub {
+		be[31-i] = edPub[i]
+	}
+	be[0] &= 0x7f
+
+	y := new(big.Int).SetBytes(be[:])
+	if y.Cmp(curve25519P) >= 0 {
+		return nil, fmt.Errorf("invalid ed25519 public key: y is out of range")
+	}
+
+	den := new(big.Int).Sub(big.NewInt(1), y)
+	den.Mod(den, curve25519P)
+	if den.Sign() == 0 {
+		return nil, fmt.Errorf("invalid ed25519 public key: can't be converted to x25519")
+	}
+	den.ModInverse(den, curve25519P)
+
+	u := new(big.Int).Add(big.NewInt(1), y)
+	u.Mul(u, den)
+	u.Mod(u, curve25519P)
+
+	var out [32]byte
+	ub := u.FillBytes(make([]byte, 32))
+	for i := range ub {
+		out[31-i] = ub[i]
+	}
+
+	return &out, nil
+}
+
+// x25519PrivateKey converts the Ed25519 private key seed into the X25519 private key,
+// which is the clamped first half of the SHA-512 hash of the seed, same as the Ed25519 scalar.
+func x25519PrivateKey(seed []byte) *[32]byte {
+	h := sha512.Sum512(seed)
+
+	var out [32]byte
+	copy(out[:], h[:32])
+	out[0] &= 248
+	out[31] &= 127
+	out[31] |= 64
+
+	return &out
+}
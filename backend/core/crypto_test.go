@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestX25519Conversion(t *testing.T) {
+	kp, err := NewKeyPairRandom()
+	require.NoError(t, err)
+
+	raw, err := kp.k.Raw()
+	require.NoError(t, err)
+
+	pub, err := kp.PublicKey.k.Raw()
+	require.NoError(t, err)
+
+	want, err := curve25519.X25519(x25519PrivateKey(raw[:32])[:], curve25519.Basepoint)
+	require.NoError(t, err)
+
+	got, err := x25519PublicKey(pub)
+	require.NoError(t, err)
+	require.Equal(t, want, got[:], "converted public key must correspond to the converted private key")
+}
+
+func TestSealAnonymous(t *testing.T) {
+	alice, err := NewKeyPairRandom()
+	require.NoError(t, err)
+
+	bob, err := NewKeyPairRandom()
+	require.NoError(t, err)
+
+	msg := []byte("Hello Alice")
+
+	sealed, err := alice.PublicKey.SealAnonymous(msg)
+	require.NoError(t, err)
+
+	opened, err := alice.OpenAnonymous(sealed)
+	require.NoError(t, err)
+	require.Equal(t, msg, opened)
+
+	_, err = bob.OpenAnonymous(sealed)
+	require.Error(t, err, "only the recipient must be able to open the message")
+}
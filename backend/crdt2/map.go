@@ -22,10 +22,18 @@ func NewMap() *Map {
 }
 
 func (m *Map) Set(time int64, origin string, path []string, value any) {
-	m.setNode(time, origin, path, mapValuePrimitive, value)
+	m.setNode(time, origin, "", path, mapValuePrimitive, value)
 }
 
 func (m *Map) ApplyPatch(time int64, origin string, patch map[string]any) (ok bool) {
+	return m.ApplyPatchWithID(time, origin, "", patch)
+}
+
+// ApplyPatchWithID is like ApplyPatch, but also records the unique ID of the change the patch comes from.
+// The short origin is enough to break ties between the values written at the same time,
+// but the characters of RGAs are referenced by their origin, which must never be ambiguous,
+// so the RGA chunks use the ID as their origin instead.
+func (m *Map) ApplyPatchWithID(time int64, origin, id string, patch map[string]any) (ok bool) {
 	if patch == nil {
 		return false
 	}
@@ -58,26 +66,27 @@ func (m *Map) ApplyPatch(time int64, origin string, patch map[string]any) (ok bo
 					v = vv
 					vt = mapValueAtomicMap
 				} else if vv, ok := vm["#rga"]; ok && len(vm) == 1 {
-					_ = vv
-					panic("TODO: implement RGA")
+					v = vv
+					vt = mapValueRGAChunk
 				} else {
 					queue = append(queue, item{m: vm, path: append(cur.path, k)})
 				}
 			}
-			m.setNode(time, origin, append(cur.path, k), vt, v)
+			m.setNode(time, origin, id, append(cur.path, k), vt, v)
 		}
 	}
 
 	return true
 }
 
-func (m *Map) setNode(time int64, origin string, path []string, vt mapValueType, value any) {
+func (m *Map) setNode(time int64, origin, id string, path []string, vt mapValueType, value any) {
 	mn := mapNode{
 		key: mapKey{
 			path:   path,
 			time:   time,
 			origin: origin,
 		},
+		id:        id,
 		valueType: vt,
 		value:     value,
 	}
@@ -105,6 +114,8 @@ func (m *Map) Get(path ...string) (value any, ok bool) {
 	return n.value, true
 }
 
+// GetWithOrigin is like Get, but also returns the origin of the value,
+// which is the ID of the change if it was applied with one.
 func (m *Map) GetWithOrigin(path ...string) (value any, origin string, ok bool) {
 	pivot := newPivot(path, true)
 
@@ -122,6 +133,10 @@ func (m *Map) GetWithOrigin(path ...string) (value any, origin string, ok bool)
 		return nil, "", false
 	}
 
+	if n.id != "" {
+		return n.value, n.id, true
+	}
+
 	return n.value, n.key.origin, true
 }
 
@@ -194,6 +209,49 @@ func (m *Map) ForEachListChunk(path []string, fn func(time int64, origin string,
 	})
 }
 
+// ForEachRGAChunk iterates over the RGA chunks stored at path in causal order.
+// The origin of the chunk is the ID of the change if it was applied with one.
+func (m *Map) ForEachRGAChunk(path []string, fn func(time int64, origin string, chunk map[string]any) (ok bool)) {
+	pivot := newPivot(path, false)
+
+	m.state.Ascend(pivot, func(item mapNode) bool {
+		if item.valueType != mapValueRGAChunk {
+			return false
+		}
+		if !samePath(path, item.key.path) {
+			return false
+		}
+
+		chunk, ok := item.value.(map[string]any)
+		if !ok {
+			return true
+		}
+
+		origin := item.key.origin
+		if item.id != "" {
+			origin = item.id
+		}
+
+		return fn(item.key.time, origin, chunk)
+	})
+}
+
+// RGA builds the RGA stored at path from all of its chunks.
+// Returns false if there're no chunks at path.
+func (m *Map) RGA(path ...string) (rga *RGA, ok bool, err error) {
+	rga = NewRGA()
+	m.ForEachRGAChunk(path, func(time int64, origin string, chunk map[string]any) bool {
+		ok = true
+		err = rga.Apply(time, origin, chunk)
+		return err == nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rga, ok, nil
+}
+
 func (m *Map) ForgetState(time int64, origin string) {
 	var toDelete []mapNode
 	m.state.Scan(func(item mapNode) bool {
@@ -223,6 +281,7 @@ func newPivot(path []string, reverse bool) mapNode {
 
 type mapNode struct {
 	key       mapKey
+	id        string
 	valueType mapValueType
 	value     any
 }
@@ -0,0 +1,371 @@
+package crdt2
+
+import (
+	"fmt"
+	"mintter/backend/pkg/lcs"
+	"strconv"
+	"strings"
+)
+
+// RGA is a Replicated Growable Array for collaborative text editing.
+// Every character gets a unique ID made of the time and origin of the change that inserted it,
+// plus the index of the character within that change. Deleted characters are kept as tombstones,
+// so that concurrent changes can still refer to them.
+//
+// Changes are stored as chunks with the following format:
+//
+//	{
+//		"#ins": [{"r": "<ref>", "v": "<text>"}, ...],
+//		"#del": [{"r": "<ref>", "n": <count>}, ...]
+//	}
+//
+// Each insert adds a run of characters after the referenced one, and characters are numbered
+// sequentially across all the runs of the chunk. Each delete removes n characters of the same origin
+// with sequential indices starting from the referenced one.
+//
+// References have the form "<idx>@<origin>". An empty origin refers to the characters of the same chunk,
+// and an empty reference means the beginning of the text. Because references don't include the time,
+// origins must be unique for each change.
+type RGA struct {
+	root  rgaNode
+	nodes map[rgaRef]*rgaNode
+
+	// Positions of characters in the visible text.
+	// Lazily computed and reset on every change.
+	index map[*rgaNode]int
+}
+
+// NewRGA creates a new empty RGA.
+func NewRGA() *RGA {
+	return &RGA{
+		nodes: make(map[rgaRef]*rgaNode),
+	}
+}
+
+type rgaRef struct {
+	origin string
+	idx    int
+}
+
+func (r rgaRef) String() string {
+	return strconv.Itoa(r.idx) + "@" + r.origin
+}
+
+func parseRGARef(s, origin string) (ref rgaRef, err error) {
+	idx, o, ok := strings.Cut(s, "@")
+	if !ok {
+		return ref, fmt.Errorf("malformed RGA reference '%s'", s)
+	}
+
+	ref.idx, err = strconv.Atoi(idx)
+	if err != nil {
+		return ref, fmt.Errorf("malformed RGA reference '%s': %w", s, err)
+	}
+
+	if o == "" {
+		o = origin
+	}
+	ref.origin = o
+
+	return ref, nil
+}
+
+type rgaID struct {
+	time int64
+	rgaRef
+}
+
+func (id rgaID) Less(o rgaID) bool {
+	if id.time != o.time {
+		return id.time < o.time
+	}
+
+	if id.origin != o.origin {
+		return id.origin < o.origin
+	}
+
+	return id.idx < o.idx
+}
+
+type rgaNode struct {
+	id      rgaID
+	value   rune
+	deleted bool
+	// Origin of the chunk that deleted the character.
+	deletedBy string
+	next      *rgaNode
+}
+
+// Apply integrates a chunk of operations created by a change with the given time and origin.
+// Chunks must be applied in causal order.
+func (r *RGA) Apply(time int64, origin string, chunk map[string]any) error {
+	r.index = nil
+
+	var idx int
+
+	if v, ok := chunk["#ins"]; ok {
+		ins, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("RGA inserts must be a list, got %T", v)
+		}
+
+		for _, op := range ins {
+			m, ok := op.(map[string]any)
+			if !ok {
+				return fmt.Errorf("RGA insert must be a map, got %T", op)
+			}
+
+			refStr, _ := m["r"].(string)
+			text, ok := m["v"].(string)
+			if !ok {
+				return fmt.Errorf("RGA insert must have text value")
+			}
+
+			left := &r.root
+			if refStr != "" {
+				ref, err := parseRGARef(refStr, origin)
+				if err != nil {
+					return err
+				}
+
+				left = r.nodes[ref]
+				if left == nil {
+					return fmt.Errorf("RGA insert refers to unknown character %s", ref)
+				}
+			}
+
+			for _, c := range text {
+				n := &rgaNode{
+					id:    rgaID{time: time, rgaRef: rgaRef{origin: origin, idx: idx}},
+					value: c,
+				}
+				idx++
+
+				if _, ok := r.nodes[n.id.rgaRef]; ok {
+					return fmt.Errorf("duplicate RGA character %s", n.id.rgaRef)
+				}
+
+				r.integrate(left, n)
+				left = n
+			}
+		}
+	}
+
+	if v, ok := chunk["#del"]; ok {
+		dels, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("RGA deletes must be a list, got %T", v)
+		}
+
+		for _, op := range dels {
+			m, ok := op.(map[string]any)
+			if !ok {
+				return fmt.Errorf("RGA delete must be a map, got %T", op)
+			}
+
+			refStr, _ := m["r"].(string)
+			ref, err := parseRGARef(refStr, origin)
+			if err != nil {
+				return err
+			}
+
+			n, err := toInt(m["n"])
+			if err != nil {
+				return fmt.Errorf("RGA delete has invalid count: %w", err)
+			}
+
+			for i := 0; i < n; i++ {
+				node := r.nodes[rgaRef{origin: ref.origin, idx: ref.idx + i}]
+				if node == nil {
+					return fmt.Errorf("RGA delete refers to unknown character %d@%s", ref.idx+i, ref.origin)
+				}
+				if !node.deleted {
+					node.deleted = true
+					node.deletedBy = origin
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// integrate inserts the node after the left one, skipping over the concurrent inserts
+// with greater IDs, and their causal children, which always have greater IDs as well.
+func (r *RGA) integrate(left, n *rgaNode) {
+	for left.next != nil && n.id.Less(left.next.id) {
+		left = left.next
+	}
+
+	n.next = left.next
+	left.next = n
+	r.nodes[n.id.rgaRef] = n
+}
+
+// String returns the visible text.
+func (r *RGA) String() string {
+	var sb strings.Builder
+	for n := r.root.next; n != nil; n = n.next {
+		if !n.deleted {
+			sb.WriteRune(n.value)
+		}
+	}
+	return sb.String()
+}
+
+// Refs returns the references of the characters of the visible text.
+func (r *RGA) Refs() []string {
+	var out []string
+	for n := r.root.next; n != nil; n = n.next {
+		if !n.deleted {
+			out = append(out, n.id.rgaRef.String())
+		}
+	}
+	return out
+}
+
+// DeletedBy returns the origin of the chunk that deleted the character with the given reference.
+// Returns false if the character is unknown or not deleted.
+func (r *RGA) DeletedBy(ref string) (origin string, ok bool) {
+	rr, err := parseRGARef(ref, "")
+	if err != nil {
+		return "", false
+	}
+
+	n := r.nodes[rr]
+	if n == nil || !n.deleted {
+		return "", false
+	}
+
+	return n.deletedBy, true
+}
+
+// Find returns the position in the visible text of the character with the given reference.
+// References without origin are resolved using the provided origin.
+// For deleted characters the position of the next visible character is returned.
+func (r *RGA) Find(ref, origin string) (pos int, deleted bool, ok bool) {
+	rr, err := parseRGARef(ref, origin)
+	if err != nil {
+		return 0, false, false
+	}
+
+	n := r.nodes[rr]
+	if n == nil {
+		return 0, false, false
+	}
+
+	if r.index == nil {
+		r.index = make(map[*rgaNode]int, len(r.nodes))
+		var i int
+		for n := r.root.next; n != nil; n = n.next {
+			r.index[n] = i
+			if !n.deleted {
+				i++
+			}
+		}
+	}
+
+	return r.index[n], n.deleted, true
+}
+
+// Diff returns the chunk of operations that transforms the current visible text into the given one.
+// The returned refs hold the reference for each character of the new text,
+// with the new characters referring to the returned chunk.
+// The chunk is nil if the texts are equal.
+//
+// The RGA must not contain characters without origin, which would be ambiguous with the new ones.
+func (r *RGA) Diff(text string) (chunk map[string]any, refs []string) {
+	var old []*rgaNode
+	for n := r.root.next; n != nil; n = n.next {
+		if !n.deleted {
+			old = append(old, n)
+		}
+	}
+
+	runes := []rune(text)
+	refs = make([]string, len(runes))
+
+	keep := lcs.Match(old, runes, func(n *rgaNode, r rune) bool { return n.value == r })
+
+	var (
+		ins  []any
+		dels []any
+		idx  int
+		// Index of the last kept old character, to know where to insert new characters.
+		left = -1
+		// Index of the old character to match with the next kept one.
+		oi int
+	)
+
+	addDelete := func(n *rgaNode) {
+		if len(dels) > 0 {
+			last := dels[len(dels)-1].(map[string]any)
+			ref := last["r"].(string)
+			count := last["n"].(int)
+			if ref == (rgaRef{origin: n.id.origin, idx: n.id.idx - count}).String() {
+				last["n"] = count + 1
+				return
+			}
+		}
+		dels = append(dels, map[string]any{"r": n.id.rgaRef.String(), "n": 1})
+	}
+
+	for i := 0; i < len(runes); {
+		if keep[i] >= 0 {
+			for ; oi < keep[i]; oi++ {
+				addDelete(old[oi])
+			}
+			oi++
+			left = keep[i]
+			refs[i] = old[keep[i]].id.rgaRef.String()
+			i++
+			continue
+		}
+
+		// Collecting the run of new characters.
+		start := i
+		for ; i < len(runes) && keep[i] < 0; i++ {
+			refs[i] = rgaRef{idx: idx}.String()
+			idx++
+		}
+
+		var ref string
+		if left >= 0 {
+			ref = old[left].id.rgaRef.String()
+		}
+		ins = append(ins, map[string]any{"r": ref, "v": string(runes[start:i])})
+	}
+
+	for ; oi < len(old); oi++ {
+		addDelete(old[oi])
+	}
+
+	if ins == nil && dels == nil {
+		return nil, refs
+	}
+
+	chunk = make(map[string]any, 2)
+	if ins != nil {
+		chunk["#ins"] = ins
+	}
+	if dels != nil {
+		chunk["#del"] = dels
+	}
+
+	return chunk, refs
+}
+
+func toInt(v any) (int, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case uint64:
+		return int(n), nil
+	case float64:
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("expected a number, got %T", v)
+	}
+}
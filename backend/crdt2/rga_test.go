@@ -0,0 +1,240 @@
+package crdt2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRGADiff(t *testing.T) {
+	tests := []struct {
+		Old string
+		New string
+	}{
+		{"", ""},
+		{"", "Hello"},
+		{"Hello", ""},
+		{"Hello", "Hello World"},
+		{"Hello World", "World"},
+		{"Hello World", "Hello brave new World"},
+		{"abcdef", "axcyef"},
+		{"Привет, мир!", "Привет, 🌍!"},
+	}
+
+	for _, tt := range tests {
+		rga := NewRGA()
+		if tt.Old != "" {
+			require.NoError(t, rga.Apply(1, "alice", map[string]any{
+				"#ins": []any{map[string]any{"r": "", "v": tt.Old}},
+			}))
+		}
+		require.Equal(t, tt.Old, rga.String())
+
+		chunk, refs := rga.Diff(tt.New)
+		require.Len(t, refs, len([]rune(tt.New)))
+		if tt.Old == tt.New {
+			require.Nil(t, chunk, "equal texts must produce no changes")
+			continue
+		}
+
+		require.NoError(t, rga.Apply(2, "bob", chunk))
+		require.Equal(t, tt.New, rga.String(), "applying diff %q → %q must produce the new text", tt.Old, tt.New)
+
+		for i, ref := range refs {
+			pos, deleted, ok := rga.Find(ref, "bob")
+			require.True(t, ok, "ref %s must be found", ref)
+			require.False(t, deleted)
+			require.Equal(t, i, pos)
+		}
+	}
+}
+
+func TestRGAMinimalDiff(t *testing.T) {
+	rga := NewRGA()
+	require.NoError(t, rga.Apply(1, "alice", map[string]any{
+		"#ins": []any{map[string]any{"r": "", "v": "abcdef"}},
+	}))
+
+	chunk, refs := rga.Diff("axcyef")
+	require.Equal(t, map[string]any{
+		"#ins": []any{
+			map[string]any{"r": "0@alice", "v": "x"},
+			map[string]any{"r": "2@alice", "v": "y"},
+		},
+		"#del": []any{
+			map[string]any{"r": "1@alice", "n": 1},
+			map[string]any{"r": "3@alice", "n": 1},
+		},
+	}, chunk)
+	require.Equal(t, []string{"0@alice", "0@", "2@alice", "1@", "4@alice", "5@alice"}, refs)
+}
+
+func TestRGAConvergence(t *testing.T) {
+	base := map[string]any{
+		"#ins": []any{map[string]any{"r": "", "v": "The quick fox"}},
+	}
+
+	newReplica := func() *RGA {
+		rga := NewRGA()
+		require.NoError(t, rga.Apply(1, "base", base))
+		return rga
+	}
+
+	// Alice, Bob and Carol edit the same text concurrently.
+	alice, _ := newReplica().Diff("The quick brown fox")
+	bob, _ := newReplica().Diff("The very quick fox jumps")
+	carol, _ := newReplica().Diff("A quick fox")
+
+	type change struct {
+		origin string
+		chunk  map[string]any
+	}
+
+	orders := [][]change{
+		{{"alice", alice}, {"bob", bob}, {"carol", carol}},
+		{{"bob", bob}, {"carol", carol}, {"alice", alice}},
+		{{"carol", carol}, {"alice", alice}, {"bob", bob}},
+	}
+
+	var want string
+	for _, order := range orders {
+		rga := newReplica()
+		for _, c := range order {
+			require.NoError(t, rga.Apply(2, c.origin, c.chunk))
+		}
+
+		if want == "" {
+			want = rga.String()
+			continue
+		}
+		require.Equal(t, want, rga.String(), "replicas must converge regardless of the order of concurrent changes")
+	}
+
+	require.Equal(t, "A very quick brown fox jumps", want, "all concurrent edits must be preserved")
+}
+
+func TestRGAConcurrentInsertsAtSamePosition(t *testing.T) {
+	base := map[string]any{
+		"#ins": []any{map[string]any{"r": "", "v": "ac"}},
+	}
+
+	a := NewRGA()
+	require.NoError(t, a.Apply(1, "base", base))
+	b := NewRGA()
+	require.NoError(t, b.Apply(1, "base", base))
+
+	c1, _ := a.Diff("aXXc")
+	c2, _ := b.Diff("aYYc")
+
+	require.NoError(t, a.Apply(2, "alice", c1))
+	require.NoError(t, a.Apply(2, "bob", c2))
+
+	require.NoError(t, b.Apply(2, "bob", c2))
+	require.NoError(t, b.Apply(2, "alice", c1))
+
+	require.Equal(t, a.String(), b.String())
+	require.Equal(t, "aYYXXc", a.String(), "concurrent runs must not interleave")
+}
+
+func TestRGAFindDeleted(t *testing.T) {
+	rga := NewRGA()
+	require.NoError(t, rga.Apply(1, "alice", map[string]any{
+		"#ins": []any{map[string]any{"r": "", "v": "Hello World"}},
+	}))
+	require.NoError(t, rga.Apply(2, "bob", map[string]any{
+		"#del": []any{map[string]any{"r": "5@alice", "n": 6}},
+	}))
+	require.Equal(t, "Hello", rga.String())
+
+	pos, deleted, ok := rga.Find("6@alice", "")
+	require.True(t, ok)
+	require.True(t, deleted)
+	require.Equal(t, 5, pos, "deleted characters must point to the next visible position")
+
+	_, _, ok = rga.Find("100@alice", "")
+	require.False(t, ok)
+
+	require.Equal(t, []string{"0@alice", "1@alice", "2@alice", "3@alice", "4@alice"}, rga.Refs())
+
+	origin, ok := rga.DeletedBy("6@alice")
+	require.True(t, ok)
+	require.Equal(t, "bob", origin, "deleted characters must know the origin of the deletion")
+
+	_, ok = rga.DeletedBy("0@alice")
+	require.False(t, ok, "visible characters are not deleted")
+}
+
+func TestMapRGAValues(t *testing.T) {
+	m := NewMap()
+
+	ok := m.ApplyPatch(1, "alice", map[string]any{
+		"text": map[string]any{
+			"b1": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "", "v": "Hello"}},
+				},
+			},
+		},
+	})
+	require.True(t, ok)
+
+	ok = m.ApplyPatch(2, "bob", map[string]any{
+		"text": map[string]any{
+			"b1": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "4@alice", "v": " World"}},
+				},
+			},
+		},
+	})
+	require.True(t, ok)
+
+	rga, ok, err := m.RGA("text", "b1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "Hello World", rga.String())
+
+	_, ok, err = m.RGA("text", "b2")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.Equal(t, []string{"b1"}, m.Keys("text"))
+
+	m.ForgetState(2, "bob")
+	rga, ok, err = m.RGA("text", "b1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "Hello", rga.String())
+}
+
+func TestMapRGAValuesWithSameOrigin(t *testing.T) {
+	m := NewMap()
+
+	// Short origins of different changes can collide, so the characters must be identified by the change ID.
+	ok := m.ApplyPatchWithID(1, "alice", "change-1", map[string]any{
+		"text": map[string]any{
+			"b1": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "", "v": "Hello"}},
+				},
+			},
+		},
+	})
+	require.True(t, ok)
+
+	ok = m.ApplyPatchWithID(2, "alice", "change-2", map[string]any{
+		"text": map[string]any{
+			"b1": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "4@change-1", "v": " World"}},
+				},
+			},
+		},
+	})
+	require.True(t, ok)
+
+	rga, ok, err := m.RGA("text", "b1")
+	require.NoError(t, err, "characters with the same short origin must not be duplicates")
+	require.True(t, ok)
+	require.Equal(t, "Hello World", rga.String())
+}
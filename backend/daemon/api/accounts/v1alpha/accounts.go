@@ -6,17 +6,22 @@ import (
 	"context"
 	"fmt"
 	"mintter/backend/core"
+	"mintter/backend/daemon/apiutil"
 	accounts "mintter/backend/genproto/accounts/v1alpha"
 	"mintter/backend/hyper"
 	"mintter/backend/hyper/hypersql"
 	"mintter/backend/pkg/future"
 	"regexp"
 	"strings"
+	"time"
 
 	"crawshaw.io/sqlite"
 	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Profile is exposed for convenience.
@@ -42,23 +47,16 @@ func (srv *Server) GetAccount(ctx context.Context, in *accounts.GetAccountReques
 		return nil, status.Errorf(codes.FailedPrecondition, "account is not initialized yet")
 	}
 
-	var aid core.Principal
-	if in.Id == "" {
-		me, err := srv.getMe()
-		if err != nil {
-			return nil, err
-		}
-		aid = me.Account().Principal()
-	} else {
-		p, err := core.DecodePrincipal(in.Id)
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "can't decode Account ID: %v", err)
-		}
-		aid = p
+	aid, err := srv.accountOrMe(ctx, in.Id)
+	if err != nil {
+		return nil, err
 	}
 
 	aids := aid.String()
 
+	// Trust is only known when one of our accounts is selected.
+	me, meErr := srv.getMe(ctx)
+
 	acc := &accounts.Account{
 		Id:      aids,
 		Profile: &accounts.Profile{},
@@ -67,23 +65,18 @@ func (srv *Server) GetAccount(ctx context.Context, in *accounts.GetAccountReques
 
 	// Load devices for this account.
 	if err := srv.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-		list, err := hypersql.KeyDelegationsList(conn, aid)
+		devices, err := listDevices(conn, aid, time.Now())
 		if err != nil {
 			return err
 		}
 
-		for _, res := range list {
-			del := core.Principal(res.KeyDelegationsViewDelegate)
-			pid, err := del.PeerID()
-			if err != nil {
-				return err
-			}
-			pids := pid.String()
-			acc.Devices[pids] = &accounts.Device{
-				DeviceId: pids,
-			}
+		for _, d := range devices {
+			acc.Devices[d.DeviceId] = d
+		}
+		if meErr != nil {
+			return nil
 		}
-		istrusted, err := hypersql.IsTrustedAccount(conn, aid)
+		istrusted, err := hypersql.IsTrustedAccount(conn, me.Account().Principal(), aid)
 		if err != nil {
 			return err
 		}
@@ -164,7 +157,7 @@ func getDelegation(ctx context.Context, me core.Identity, blobs *hyper.Storage)
 
 // UpdateProfile implements the corresponding gRPC method.
 func (srv *Server) UpdateProfile(ctx context.Context, in *accounts.Profile) (*accounts.Account, error) {
-	me, err := srv.getMe()
+	me, err := srv.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -261,17 +254,17 @@ func (srv *Server) SetAccountTrust(ctx context.Context, in *accounts.SetAccountT
 	if err != nil {
 		return nil, err
 	}
+	me, err := srv.getMe(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if in.IsTrusted {
-		err = srv.blobs.SetAccountTrust(ctx, acc)
+		err = srv.blobs.SetAccountTrust(ctx, me.Account().Principal(), acc)
 	} else {
-		me, ok := srv.me.Get()
-		if !ok {
-			return nil, fmt.Errorf("account not initialized yet")
-		}
 		if acc.String() == me.Account().Principal().String() {
 			return nil, fmt.Errorf("cannot untrust self")
 		}
-		err = srv.blobs.UnsetAccountTrust(ctx, acc)
+		err = srv.blobs.UnsetAccountTrust(ctx, me.Account().Principal(), acc)
 	}
 
 	if err != nil {
@@ -315,10 +308,183 @@ func (srv *Server) ListAccounts(ctx context.Context, in *accounts.ListAccountsRe
 	return resp, nil
 }
 
-func (srv *Server) getMe() (core.Identity, error) {
-	me, ok := srv.me.Get()
-	if !ok {
-		return core.Identity{}, status.Errorf(codes.FailedPrecondition, "account is not initialized yet")
+// ListDevices implements the corresponding gRPC method.
+func (srv *Server) ListDevices(ctx context.Context, in *accounts.ListDevicesRequest) (*accounts.ListDevicesResponse, error) {
+	aid, err := srv.accountOrMe(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &accounts.ListDevicesResponse{}
+	if err := srv.blobs.Query(ctx, func(conn *sqlite.Conn) error {
+		resp.Devices, err = listDevices(conn, aid, time.Now())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Devices) == 0 {
+		return nil, status.Errorf(codes.NotFound, "account %s not found", aid)
+	}
+
+	return resp, nil
+}
+
+// RevokeDevice implements the corresponding gRPC method.
+func (srv *Server) RevokeDevice(ctx context.Context, in *accounts.RevokeDeviceRequest) (*accounts.Device, error) {
+	me, err := srv.getMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.DeviceId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "must specify device ID")
+	}
+
+	pid, err := peer.Decode(in.DeviceId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "can't decode device ID: %v", err)
+	}
+
+	if pid == me.DeviceKey().PeerID() {
+		return nil, status.Errorf(codes.FailedPrecondition, "can't revoke the device of this node")
+	}
+
+	pub, err := pid.ExtractPublicKey()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "can't extract public key from device ID: %v", err)
+	}
+	device := core.PrincipalFromPubKey(pub)
+
+	acc, err := core.AccountFromMnemonic(in.Mnemonic, in.Passphrase)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to derive account from mnemonic: %v", err)
+	}
+
+	if !bytes.Equal(acc.Principal(), me.Account().Principal()) {
+		return nil, status.Errorf(codes.PermissionDenied, "mnemonic doesn't correspond to our own account")
+	}
+
+	find := func() (*accounts.Device, error) {
+		var devices []*accounts.Device
+		if err := srv.blobs.Query(ctx, func(conn *sqlite.Conn) error {
+			devices, err = listDevices(conn, acc.Principal(), time.Now())
+			return err
+		}); err != nil {
+			return nil, err
+		}
+
+		idx := slices.IndexFunc(devices, func(d *accounts.Device) bool { return d.DeviceId == in.DeviceId })
+		if idx == -1 {
+			return nil, status.Errorf(codes.NotFound, "device %s is not a device of our account", in.DeviceId)
+		}
+
+		return devices[idx], nil
+	}
+
+	d, err := find()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Status == accounts.DeviceStatus_REVOKED {
+		return d, nil
+	}
+
+	kr, err := hyper.NewKeyRevocation(acc, device, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.blobs.SaveBlob(ctx, kr.Blob()); err != nil {
+		return nil, fmt.Errorf("failed to save key revocation: %w", err)
+	}
+
+	return find()
+}
+
+// listDevices returns all the devices of the account along with their status at the given time, sorted by ID.
+func listDevices(conn *sqlite.Conn, aid core.Principal, now time.Time) ([]*accounts.Device, error) {
+	list, err := hypersql.KeyDelegationsList(conn, aid)
+	if err != nil {
+		return nil, err
+	}
+
+	// There can be multiple delegations for the same device. The one that expires later wins.
+	notAfter := make(map[string]int64, len(list))
+	for _, res := range list {
+		del := core.Principal(res.KeyDelegationsViewDelegate)
+		v, ok := notAfter[del.UnsafeString()]
+		switch {
+		case !ok:
+			notAfter[del.UnsafeString()] = res.KeyDelegationsViewNotAfter
+		case v == 0 || res.KeyDelegationsViewNotAfter == 0:
+			notAfter[del.UnsafeString()] = 0
+		case res.KeyDelegationsViewNotAfter > v:
+			notAfter[del.UnsafeString()] = res.KeyDelegationsViewNotAfter
+		}
+	}
+
+	revoked := make(map[string]int64)
+	if err := hypersql.KeyRevocationsList(conn, aid, func(delegate []byte, revokeTime int64) error {
+		revoked[core.Principal(delegate).UnsafeString()] = revokeTime
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	out := make([]*accounts.Device, 0, len(notAfter))
+	for k, exp := range notAfter {
+		pid, err := core.Principal(k).PeerID()
+		if err != nil {
+			return nil, err
+		}
+
+		d := &accounts.Device{
+			DeviceId: pid.String(),
+			Status:   accounts.DeviceStatus_ACTIVE,
+		}
+
+		if exp != 0 {
+			d.ExpireTime = timestamppb.New(time.Unix(exp, 0))
+			if now.After(d.ExpireTime.AsTime()) {
+				d.Status = accounts.DeviceStatus_EXPIRED
+			}
+		}
+
+		if rt, ok := revoked[k]; ok {
+			d.RevokeTime = timestamppb.New(time.Unix(rt, 0))
+			if !now.Before(d.RevokeTime.AsTime()) {
+				d.Status = accounts.DeviceStatus_REVOKED
+			}
+		}
+
+		out = append(out, d)
+	}
+
+	slices.SortFunc(out, func(a, b *accounts.Device) int { return strings.Compare(a.DeviceId, b.DeviceId) })
+
+	return out, nil
+}
+
+// accountOrMe decodes the account ID, or returns our own account if the ID is empty.
+func (srv *Server) accountOrMe(ctx context.Context, id string) (core.Principal, error) {
+	if id == "" {
+		me, err := srv.getMe(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return me.Account().Principal(), nil
+	}
+
+	aid, err := core.DecodePrincipal(id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "can't decode Account ID: %v", err)
 	}
-	return me, nil
+
+	return aid, nil
+}
+
+func (srv *Server) getMe(ctx context.Context) (core.Identity, error) {
+	return apiutil.Identity(ctx, srv.me)
 }
@@ -15,6 +15,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestGetAccount_Own(t *testing.T) {
@@ -27,6 +29,7 @@ func TestGetAccount_Own(t *testing.T) {
 		Devices: map[string]*accounts.Device{
 			"12D3KooWFMTJanyH3XttUC2AmS9fZnbeYsxbAjSEvyCeHVbHBX3C": {
 				DeviceId: "12D3KooWFMTJanyH3XttUC2AmS9fZnbeYsxbAjSEvyCeHVbHBX3C",
+				Status:   accounts.DeviceStatus_ACTIVE,
 			},
 		},
 		IsTrusted: true,
@@ -65,6 +68,7 @@ func TestAPIUpdateProfile(t *testing.T) {
 		Devices: map[string]*accounts.Device{
 			"12D3KooWFMTJanyH3XttUC2AmS9fZnbeYsxbAjSEvyCeHVbHBX3C": {
 				DeviceId: "12D3KooWFMTJanyH3XttUC2AmS9fZnbeYsxbAjSEvyCeHVbHBX3C",
+				Status:   accounts.DeviceStatus_ACTIVE,
 			},
 		},
 		IsTrusted: true,
@@ -88,6 +92,7 @@ func TestAPIUpdateProfile(t *testing.T) {
 			Devices: map[string]*accounts.Device{
 				"12D3KooWFMTJanyH3XttUC2AmS9fZnbeYsxbAjSEvyCeHVbHBX3C": {
 					DeviceId: "12D3KooWFMTJanyH3XttUC2AmS9fZnbeYsxbAjSEvyCeHVbHBX3C",
+					Status:   accounts.DeviceStatus_ACTIVE,
 				},
 			},
 			IsTrusted: true,
@@ -118,20 +123,75 @@ func TestTrustOwnAccount(t *testing.T) {
 	require.True(t, acc.IsTrusted)
 }
 
+func TestRevokeDevice(t *testing.T) {
+	ctx := context.Background()
+
+	mnemonic, err := core.NewBIP39Mnemonic(12)
+	require.NoError(t, err)
+	acc, err := core.AccountFromMnemonic(mnemonic, "")
+	require.NoError(t, err)
+
+	alice := coretest.NewTester("alice")
+	lost := coretest.NewTester("alice-2")
+	srv := newTestServerWithAccount(t, acc, alice.Device)
+
+	_, err = daemon.Register(ctx, srv.blobs, acc, lost.Device.PublicKey, time.Now().UTC())
+	require.NoError(t, err)
+
+	list, err := srv.ListDevices(ctx, &accounts.ListDevicesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Devices, 2)
+	for _, d := range list.Devices {
+		require.Equal(t, accounts.DeviceStatus_ACTIVE, d.Status)
+	}
+
+	lostID := lost.Device.PeerID().String()
+
+	_, err = srv.RevokeDevice(ctx, &accounts.RevokeDeviceRequest{DeviceId: alice.Device.PeerID().String(), Mnemonic: mnemonic})
+	require.Error(t, err, "must not revoke own device")
+
+	other, err := core.NewBIP39Mnemonic(12)
+	require.NoError(t, err)
+	_, err = srv.RevokeDevice(ctx, &accounts.RevokeDeviceRequest{DeviceId: lostID, Mnemonic: other})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "must not revoke with a mnemonic of a different account")
+
+	_, err = srv.RevokeDevice(ctx, &accounts.RevokeDeviceRequest{DeviceId: coretest.NewTester("bob").Device.PeerID().String(), Mnemonic: mnemonic})
+	require.Equal(t, codes.NotFound, status.Code(err), "must not revoke unknown devices")
+
+	dev, err := srv.RevokeDevice(ctx, &accounts.RevokeDeviceRequest{DeviceId: lostID, Mnemonic: mnemonic})
+	require.NoError(t, err)
+	require.Equal(t, lostID, dev.DeviceId)
+	require.Equal(t, accounts.DeviceStatus_REVOKED, dev.Status)
+	require.NotNil(t, dev.RevokeTime)
+
+	got, err := srv.GetAccount(ctx, &accounts.GetAccountRequest{})
+	require.NoError(t, err)
+	testutil.ProtoEqual(t, dev, got.Devices[lostID], "account must report the revoked device")
+	require.Equal(t, accounts.DeviceStatus_ACTIVE, got.Devices[alice.Device.PeerID().String()].Status)
+
+	again, err := srv.RevokeDevice(ctx, &accounts.RevokeDeviceRequest{DeviceId: lostID, Mnemonic: mnemonic})
+	require.NoError(t, err, "revoking twice must be a no-op")
+	testutil.ProtoEqual(t, dev, again, "revoking twice must not change the revoke time")
+}
+
 // TODO: update profile idempotent no change
 
 func newTestServer(t *testing.T, name string) *Server {
 	u := coretest.NewTester(name)
 
+	return newTestServerWithAccount(t, u.Account, u.Device)
+}
+
+func newTestServerWithAccount(t *testing.T, account, device core.KeyPair) *Server {
 	pool := storage.MakeTestDB(t)
 	ctx := context.Background()
 	blobs := hyper.NewStorage(pool, logging.New("mintter/hyper", "debug"))
 
-	_, err := daemon.Register(ctx, blobs, u.Account, u.Device.PublicKey, time.Now().UTC().Add(-1*time.Hour))
+	_, err := daemon.Register(ctx, blobs, account, device.PublicKey, time.Now().UTC().Add(-1*time.Hour))
 	require.NoError(t, err)
 
 	fut := future.New[core.Identity]()
-	require.NoError(t, fut.Resolve(u.Identity))
+	require.NoError(t, fut.Resolve(core.NewIdentity(account.PublicKey, device)))
 
 	return NewServer(fut.ReadOnly, blobs)
 }
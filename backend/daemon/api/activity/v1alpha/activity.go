@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"math"
 	"mintter/backend/core"
+	"mintter/backend/daemon/apiutil"
 	"mintter/backend/daemon/storage"
 	activity "mintter/backend/genproto/activity/v1alpha"
+	"mintter/backend/hyper"
 	"mintter/backend/pkg/dqb"
 	"mintter/backend/pkg/future"
 	"regexp"
@@ -37,13 +39,15 @@ type Repo interface {
 type Server struct {
 	me        *future.ReadOnly[core.Identity]
 	db        *sqlitex.Pool
+	blobs     *hyper.Storage
 	startTime time.Time
 }
 
 // NewServer creates a new Server.
-func NewServer(id *future.ReadOnly[core.Identity], db *sqlitex.Pool) *Server {
+func NewServer(id *future.ReadOnly[core.Identity], db *sqlitex.Pool, blobs *hyper.Storage) *Server {
 	return &Server{
 		db:        db,
+		blobs:     blobs,
 		startTime: time.Now(),
 		me:        id,
 	}
@@ -51,61 +55,104 @@ func NewServer(id *future.ReadOnly[core.Identity], db *sqlitex.Pool) *Server {
 
 // ListEvents list all the events seen locally.
 func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsRequest) (*activity.ListEventsResponse, error) {
-	me, ok := srv.me.Get()
-	if !ok {
-		return nil, fmt.Errorf("account is not initialized yet")
+	me, err := apiutil.Identity(ctx, srv.me)
+	if err != nil {
+		return nil, err
 	}
 	var cursorBlobID int64 = math.MaxInt32
-	var err error
 	if req.PageToken != "" {
-		pageTokenBytes, err := base64.StdEncoding.DecodeString(req.PageToken)
-		if err != nil {
-			return nil, fmt.Errorf("Token encoding not valid: %w", err)
-		}
-		clearPageToken, err := me.DeviceKey().Decrypt(pageTokenBytes)
+		cursorBlobID, err = decodePageToken(me, req.PageToken)
 		if err != nil {
-			return nil, fmt.Errorf("Token not valid: %w", err)
+			return nil, err
 		}
-		pageToken, err := strconv.ParseUint(string(clearPageToken), 10, 32)
-		if err != nil {
-			return nil, fmt.Errorf("Token not valid: %w", err)
-		}
-		cursorBlobID = int64(pageToken)
 	}
+
+	getEventsStr, err := eventsQuery(eventsFilter{
+		TrustedOnly:     req.TrustedOnly,
+		TrustingAccount: me.Account().Principal(),
+		Users:           req.FilterUsers,
+		EventTypes:      req.FilterEventType,
+		Resources:       req.FilterResource,
+		LinkedResources: req.AddLinkedResource,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
 	conn, cancel, err := srv.db.Conn(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer cancel()
 
-	var events []*activity.Event
+	events, ids, err := queryEvents(conn, dqb.Str(getEventsStr)(), cursorBlobID, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("Problem collecting activity feed, Probably no feed or token out of range: %w", err)
+	}
+	var lastBlobID int64
+	if len(ids) > 0 {
+		lastBlobID = ids[len(ids)-1]
+	}
+	var PageTokenStr string
+
+	pageToken, err := encodePageToken(me, lastBlobID-1)
+	if err != nil {
+		return nil, err
+	}
+	if lastBlobID != 0 && req.PageSize == int32(len(events)) {
+		PageTokenStr = pageToken
+	}
+	return &activity.ListEventsResponse{
+		Events:        events,
+		NextPageToken: PageTokenStr,
+	}, err
+}
 
+// eventsFilter are the filters supported by the activity feed.
+type eventsFilter struct {
+	TrustedOnly bool
+	// Account whose trusted accounts are used with TrustedOnly.
+	TrustingAccount core.Principal
+	Users           []string
+	EventTypes      []string
+	Resources       []string
+	LinkedResources []string
+}
+
+// eventsQuery builds the query for the events matching the filter.
+// The query expects the cursor and the page size as arguments.
+// By default the cursor is the blob ID, and the query returns events before the cursor (inclusive), newest first.
+// With after the cursor is the position in the sequence of indexed blobs,
+// and the query returns events indexed after the cursor, in the order they were indexed.
+// Blob IDs can't be used for that, because blobs can be indexed long after we receive them.
+func eventsQuery(f eventsFilter, after bool) (string, error) {
 	var trustedStr string
-	if req.TrustedOnly {
-		trustedStr = "JOIN " + storage.TrustedAccounts.String() + " ON " + storage.TrustedAccountsID.String() + "=" + storage.PublicKeysID.String()
+	if f.TrustedOnly {
+		trustedStr = "JOIN " + storage.TrustedAccounts.String() + " ON " + storage.TrustedAccountsID.String() + "=" + storage.PublicKeysID.String() +
+			" AND " + storage.TrustedAccountsAccount.String() + " = (SELECT id FROM public_keys WHERE principal = unhex('" + strings.ToUpper(hex.EncodeToString(f.TrustingAccount)) + "'))"
 	}
 	var filtersStr string
-	if len(req.FilterUsers) > 0 {
+	if len(f.Users) > 0 {
 		filtersStr = storage.PublicKeysPrincipal.String() + " in ("
-		for i, user := range req.FilterUsers {
+		for i, user := range f.Users {
 			if i > 0 {
 				filtersStr += ", "
 			}
 			principal, err := core.DecodePrincipal(user)
 			if err != nil {
-				return nil, fmt.Errorf("Invalid user filter [%s]: %w", user, err)
+				return "", fmt.Errorf("Invalid user filter [%s]: %w", user, err)
 			}
 			filtersStr += "unhex('" + strings.ToUpper(hex.EncodeToString(principal)) + "')"
 		}
 		filtersStr += ") AND "
 	}
 
-	if len(req.FilterEventType) > 0 {
+	if len(f.EventTypes) > 0 {
 		filtersStr += "lower(" + storage.StructuralBlobsType.String() + ") in ("
-		for i, eventType := range req.FilterEventType {
+		for i, eventType := range f.EventTypes {
 			// Hardcode this to prevent injection attacks
 			if strings.ToLower(eventType) != "keydelegation" && strings.ToLower(eventType) != "change" && strings.ToLower(eventType) != "comment" && strings.ToLower(eventType) != "dagpb" {
-				return nil, fmt.Errorf("Invalid event type filter [%s]: Only KeyDelegation | Change | Comment | DagPB aresupported at the moment", eventType)
+				return "", fmt.Errorf("Invalid event type filter [%s]: Only KeyDelegation | Change | Comment | DagPB aresupported at the moment", eventType)
 			}
 			if i > 0 {
 				filtersStr += ", "
@@ -114,11 +161,11 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		}
 		filtersStr += ") AND "
 	}
-	if len(req.FilterResource) > 0 {
+	if len(f.Resources) > 0 {
 		filtersStr += storage.ResourcesIRI.String() + " in ("
-		for i, resource := range req.FilterResource {
+		for i, resource := range f.Resources {
 			if !resourcePattern.MatchString(resource) {
-				return nil, fmt.Errorf("Invalid resource format [%s]", resource)
+				return "", fmt.Errorf("Invalid resource format [%s]", resource)
 			}
 			if i > 0 {
 				filtersStr += ", "
@@ -128,15 +175,15 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		filtersStr += ") AND "
 	}
 	var linksStr string
-	if len(req.AddLinkedResource) > 0 {
-		if len(req.FilterResource) > 0 || len(req.FilterEventType) > 0 {
+	if len(f.LinkedResources) > 0 {
+		if len(f.Resources) > 0 || len(f.EventTypes) > 0 {
 			linksStr += " OR "
 		}
 		linksStr += "(" + storage.StructuralBlobsType.String() + " in ('Change', 'Comment') AND " + storage.ResourceLinksTarget.String() + " IN (" +
 			"select " + storage.ResourcesID.String() + " FROM " + storage.T_Resources + " where " + storage.ResourcesIRI.String() + " in ("
-		for i, resource := range req.AddLinkedResource {
+		for i, resource := range f.LinkedResources {
 			if !resourcePattern.MatchString(resource) {
-				return nil, fmt.Errorf("Invalid link resource format [%s]", resource)
+				return "", fmt.Errorf("Invalid link resource format [%s]", resource)
 			}
 			if i > 0 {
 				linksStr += ", "
@@ -145,18 +192,29 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		}
 		linksStr += "))) AND "
 	}
+
+	cursorColumn, joinSeqStr := storage.BlobsID, ""
+	cursorStr, orderStr := " <= :idx", " desc"
+	if after {
+		cursorColumn = storage.IndexedBlobsSeq
+		joinSeqStr = "JOIN " + storage.IndexedBlobs.String() + " ON " + storage.IndexedBlobsID.String() + "=" + storage.StructuralBlobsID.String()
+		cursorStr, orderStr = " > :idx", " asc"
+	}
+
 	var (
-		selectStr            = "SELECT distinct " + storage.BlobsID + ", " + storage.StructuralBlobsType + ", " + storage.PublicKeysPrincipal + ", " + storage.ResourcesIRI + ", " + storage.StructuralBlobsTs + ", " + storage.BlobsInsertTime + ", " + storage.BlobsMultihash + ", " + storage.BlobsCodec
+		selectStr            = "SELECT distinct " + cursorColumn + ", " + storage.StructuralBlobsType + ", " + storage.PublicKeysPrincipal + ", " + storage.ResourcesIRI + ", " + storage.StructuralBlobsTs + ", " + storage.BlobsInsertTime + ", " + storage.BlobsMultihash + ", " + storage.BlobsCodec
 		tableStr             = "FROM " + storage.T_StructuralBlobs
 		joinIDStr            = "JOIN " + storage.Blobs.String() + " ON " + storage.BlobsID.String() + "=" + storage.StructuralBlobsID.String()
 		joinpkStr            = "JOIN " + storage.PublicKeys.String() + " ON " + storage.StructuralBlobsAuthor.String() + "=" + storage.PublicKeysID.String()
 		joinLinksStr         = "LEFT JOIN " + storage.ResourceLinks.String() + " ON " + storage.StructuralBlobsID.String() + "=" + storage.ResourceLinksSource.String()
 		leftjoinResourcesStr = "LEFT JOIN " + storage.Resources.String() + " ON " + storage.StructuralBlobsResource.String() + "=" + storage.ResourcesID.String()
 
-		pageTokenStr = storage.BlobsID.String() + " <= :idx AND (" + storage.ResourcesIRI.String() + " NOT IN (SELECT " + storage.DraftsViewResource.String() + " from " + storage.DraftsView.String() + ") OR " + storage.ResourcesIRI.String() + " IS NULL) AND " + storage.BlobsSize.String() + ">0 ORDER BY " + storage.BlobsID.String() + " desc limit :page_size"
+		pageTokenStr = cursorColumn.String() + cursorStr + " AND (" + storage.ResourcesIRI.String() + " NOT IN (SELECT " + storage.DraftsViewResource.String() + " from " + storage.DraftsView.String() + ") OR " + storage.ResourcesIRI.String() + " IS NULL) AND " + storage.BlobsSize.String() + ">0 ORDER BY " + cursorColumn.String() + orderStr + " limit :page_size"
 	)
 
-	var getEventsStr = fmt.Sprintf(`
+	// Trailing whitespace after the semicolon would make the statement invalid.
+	return strings.TrimSpace(fmt.Sprintf(`
+		%s
 		%s
 		%s
 		%s
@@ -165,10 +223,14 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		%s
 		%s
 		WHERE %s %s %s;
-	`, selectStr, tableStr, joinIDStr, joinpkStr, joinLinksStr, leftjoinResourcesStr, trustedStr, filtersStr, linksStr, pageTokenStr)
-	var lastBlobID int64
-	err = sqlitex.Exec(conn, dqb.Str(getEventsStr)(), func(stmt *sqlite.Stmt) error {
-		lastBlobID = stmt.ColumnInt64(0)
+	`, selectStr, tableStr, joinIDStr, joinSeqStr, joinpkStr, joinLinksStr, leftjoinResourcesStr, trustedStr, filtersStr, linksStr, pageTokenStr)), nil
+}
+
+// queryEvents executes the query built by eventsQuery,
+// and returns the events along with the cursor of each event.
+func queryEvents(conn *sqlite.Conn, query string, cursor int64, pageSize int32) (events []*activity.Event, ids []int64, err error) {
+	err = sqlitex.Exec(conn, query, func(stmt *sqlite.Stmt) error {
+		ids = append(ids, stmt.ColumnInt64(0))
 		eventType := stmt.ColumnText(1)
 		author := stmt.ColumnBytes(2)
 		resource := stmt.ColumnText(3)
@@ -194,21 +256,36 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		}
 		events = append(events, &event)
 		return nil
-	}, cursorBlobID, req.PageSize)
+	}, cursor, pageSize)
 	if err != nil {
-		return nil, fmt.Errorf("Problem collecting activity feed, Probably no feed or token out of range: %w", err)
+		return nil, nil, err
 	}
-	var PageTokenStr string
 
-	pageToken, err := me.DeviceKey().Encrypt([]byte(strconv.Itoa(int(lastBlobID - 1))))
+	return events, ids, nil
+}
+
+// encodePageToken encrypts the blob ID cursor, so clients can't tamper with it.
+func encodePageToken(me core.Identity, blobID int64) (string, error) {
+	pageToken, err := me.DeviceKey().Encrypt([]byte(strconv.Itoa(int(blobID))))
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	if lastBlobID != 0 && req.PageSize == int32(len(events)) {
-		PageTokenStr = base64.StdEncoding.EncodeToString(pageToken)
+
+	return base64.StdEncoding.EncodeToString(pageToken), nil
+}
+
+func decodePageToken(me core.Identity, token string) (int64, error) {
+	pageTokenBytes, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("Token encoding not valid: %w", err)
 	}
-	return &activity.ListEventsResponse{
-		Events:        events,
-		NextPageToken: PageTokenStr,
-	}, err
+	clearPageToken, err := me.DeviceKey().Decrypt(pageTokenBytes)
+	if err != nil {
+		return 0, fmt.Errorf("Token not valid: %w", err)
+	}
+	pageToken, err := strconv.ParseUint(string(clearPageToken), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Token not valid: %w", err)
+	}
+	return int64(pageToken), nil
 }
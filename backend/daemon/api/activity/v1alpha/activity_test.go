@@ -1,15 +1,25 @@
 package activity
 
 import (
+	"bufio"
 	context "context"
 	"mintter/backend/core"
 	"mintter/backend/core/coretest"
 	"mintter/backend/daemon/storage"
 	activity "mintter/backend/genproto/activity/v1alpha"
+	"mintter/backend/hyper"
+	"mintter/backend/logging"
 	"mintter/backend/pkg/future"
+	"mintter/backend/pkg/must"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func TestListEvents(t *testing.T) {
@@ -26,15 +36,180 @@ func TestListEvents(t *testing.T) {
 	require.Len(t, events.Events, 0)
 }
 
+func TestSubscribeEvents(t *testing.T) {
+	alice := newTestServer(t, "alice")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Events existing before subscribing must not be sent without a resume token.
+	saveDelegation(t, alice, "alice", "bob")
+
+	subscribe := func(req *activity.SubscribeEventsRequest) <-chan *activity.SubscribeEventsResponse {
+		out := make(chan *activity.SubscribeEventsResponse, 10)
+		go func() {
+			_ = alice.subscribeEvents(ctx, req, func(resp *activity.SubscribeEventsResponse) error {
+				out <- resp
+				return nil
+			})
+		}()
+		// Give the subscription some time to start.
+		time.Sleep(100 * time.Millisecond)
+		return out
+	}
+
+	all := subscribe(&activity.SubscribeEventsRequest{})
+	bobs := subscribe(&activity.SubscribeEventsRequest{FilterUsers: []string{coretest.NewTester("bob").Account.Principal().String()}})
+
+	c1 := saveDelegation(t, alice, "alice", "carol")
+	c2 := saveDelegation(t, alice, "bob", "carol")
+
+	first := recv(t, all)
+	require.Equal(t, c1.String(), first.Event.GetNewBlob().Cid)
+	require.Equal(t, "KeyDelegation", first.Event.GetNewBlob().BlobType)
+	require.Equal(t, c2.String(), recv(t, all).Event.GetNewBlob().Cid)
+
+	require.Equal(t, c2.String(), recv(t, bobs).Event.GetNewBlob().Cid, "filtered subscription must only get bob's events")
+
+	// Resuming after the first event must send the missed events.
+	resumed := subscribe(&activity.SubscribeEventsRequest{ResumeToken: first.ResumeToken})
+	require.Equal(t, c2.String(), recv(t, resumed).Event.GetNewBlob().Cid)
+
+	select {
+	case resp := <-all:
+		t.Fatalf("unexpected event %v", resp)
+	case resp := <-bobs:
+		t.Fatalf("unexpected event %v", resp)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscribeEventsPendingChanges(t *testing.T) {
+	alice := newTestServer(t, "alice")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	del := saveDelegation(t, alice, "alice", "alice")
+
+	out := make(chan *activity.SubscribeEventsResponse, 10)
+	go func() {
+		_ = alice.subscribeEvents(ctx, &activity.SubscribeEventsRequest{}, func(resp *activity.SubscribeEventsResponse) error {
+			out <- resp
+			return nil
+		})
+	}()
+	// Give the subscription some time to start.
+	time.Sleep(100 * time.Millisecond)
+
+	device := coretest.NewTester("alice").Device
+	e := hyper.NewEntity("alice-test-id")
+	c1, err := e.CreateChange(e.NextTimestamp(), device, del, map[string]any{"title": "Foo"})
+	require.NoError(t, err)
+	c2, err := e.CreateChange(e.NextTimestamp(), device, del, map[string]any{"title": "Bar"})
+	require.NoError(t, err)
+
+	// The second change is received first, so it stays pending until its dep arrives.
+	// Other blobs received in the meantime move the subscription past its blob ID.
+	require.NoError(t, alice.blobs.SaveBlob(ctx, c2))
+	other := saveDelegation(t, alice, "alice", "bob")
+	require.Equal(t, other.String(), recv(t, out).Event.GetNewBlob().Cid, "pending changes must not be sent")
+
+	require.NoError(t, alice.blobs.SaveBlob(ctx, c1))
+	require.Equal(t, c1.CID.String(), recv(t, out).Event.GetNewBlob().Cid)
+	resp := recv(t, out)
+	require.Equal(t, c2.CID.String(), resp.Event.GetNewBlob().Cid, "changes must be sent once they are indexed, even if they were received before")
+
+	// Resuming must not send the same events again.
+	resumed := make(chan *activity.SubscribeEventsResponse, 10)
+	go func() {
+		_ = alice.subscribeEvents(ctx, &activity.SubscribeEventsRequest{ResumeToken: resp.ResumeToken}, func(resp *activity.SubscribeEventsResponse) error {
+			resumed <- resp
+			return nil
+		})
+	}()
+
+	select {
+	case resp := <-resumed:
+		t.Fatalf("unexpected event %v", resp)
+	case resp := <-out:
+		t.Fatalf("unexpected event %v", resp)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestSubscribeEventsHTTP(t *testing.T) {
+	alice := newTestServer(t, "alice")
+	srv := httptest.NewServer(alice)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?filter_event_type=Comment&filter_event_type=Bad")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Give the subscription some time to start.
+	time.Sleep(100 * time.Millisecond)
+	c := saveDelegation(t, alice, "alice", "bob")
+
+	lines := make(chan string)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+		close(lines)
+	}()
+
+	var id, data string
+	for line := range lines {
+		if line == "" {
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+
+	require.NotEqual(t, "", id, "events must have IDs to resume the stream")
+	var e activity.Event
+	require.NoError(t, protojson.Unmarshal([]byte(data), &e))
+	require.Equal(t, c.String(), e.GetNewBlob().Cid)
+}
+
+func recv(t *testing.T, c <-chan *activity.SubscribeEventsResponse) *activity.SubscribeEventsResponse {
+	t.Helper()
+	select {
+	case resp := <-c:
+		return resp
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func saveDelegation(t *testing.T, srv *Server, issuer, delegate string) cid.Cid {
+	kd := must.Do2(hyper.NewKeyDelegation(coretest.NewTester(issuer).Account, coretest.NewTester(delegate).Device.PublicKey, time.Now())).Blob()
+	require.NoError(t, srv.blobs.SaveBlob(context.Background(), kd))
+	return kd.CID
+}
+
 // TODO: update profile idempotent no change
 
 func newTestServer(t *testing.T, name string) *Server {
 	u := coretest.NewTester(name)
 	//repo := daemontest.MakeTestRepo(t, u)
 	db := storage.MakeTestDB(t)
-	//blobs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
+	blobs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
 	fut := future.New[core.Identity]()
 	require.NoError(t, fut.Resolve(u.Identity))
 
-	return NewServer(fut.ReadOnly, db)
+	return NewServer(fut.ReadOnly, db, blobs)
 }
@@ -0,0 +1,217 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"mintter/backend/daemon/apiutil"
+	activity "mintter/backend/genproto/activity/v1alpha"
+	"mintter/backend/pkg/dqb"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"crawshaw.io/sqlite/sqlitex"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// subscriptionBatchSize is the max number of events loaded at once for subscriptions.
+const subscriptionBatchSize = 100
+
+// SubscribeEvents implements the corresponding gRPC method.
+func (srv *Server) SubscribeEvents(req *activity.SubscribeEventsRequest, stream activity.ActivityFeed_SubscribeEventsServer) error {
+	return srv.subscribeEvents(stream.Context(), req, stream.Send)
+}
+
+// subscribeEvents sends the events matching the request as they appear,
+// until the context is canceled or sending fails.
+func (srv *Server) subscribeEvents(ctx context.Context, req *activity.SubscribeEventsRequest, send func(*activity.SubscribeEventsResponse) error) error {
+	me, err := apiutil.Identity(ctx, srv.me)
+	if err != nil {
+		return err
+	}
+
+	q, err := eventsQuery(eventsFilter{
+		TrustedOnly:     req.TrustedOnly,
+		TrustingAccount: me.Account().Principal(),
+		Users:           req.FilterUsers,
+		EventTypes:      req.FilterEventType,
+		Resources:       req.FilterResource,
+		LinkedResources: req.AddLinkedResource,
+	}, true)
+	if err != nil {
+		return err
+	}
+
+	// Without the resume token only the events newer than the ones we already have are sent.
+	// The cursor is the position in the sequence of indexed blobs, so the blobs indexed
+	// later than we've received them, e.g. changes waiting for their deps, are not missed.
+	var cursor int64
+	if req.ResumeToken != "" {
+		cursor, err = decodePageToken(me, req.ResumeToken)
+	} else {
+		cursor, err = srv.lastIndexedSeq(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Getting the updates channel before querying the events to not miss the updates in between.
+		updates := srv.blobs.IndexUpdates()
+
+		for {
+			events, ids, err := srv.loadEventsAfter(ctx, q, cursor)
+			if err != nil {
+				return err
+			}
+
+			for i, e := range events {
+				token, err := encodePageToken(me, ids[i])
+				if err != nil {
+					return err
+				}
+
+				if err := send(&activity.SubscribeEventsResponse{
+					Event:       e,
+					ResumeToken: token,
+				}); err != nil {
+					return err
+				}
+
+				cursor = ids[i]
+			}
+
+			if len(events) < subscriptionBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updates:
+		}
+	}
+}
+
+func (srv *Server) loadEventsAfter(ctx context.Context, q string, cursor int64) ([]*activity.Event, []int64, error) {
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	events, ids, err := queryEvents(conn, q, cursor, subscriptionBatchSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query new events: %w", err)
+	}
+
+	return events, ids, nil
+}
+
+var qLastIndexedSeq = dqb.Str(`
+	SELECT coalesce(max(indexed_blobs.seq), 0)
+	FROM indexed_blobs;
+`)
+
+// lastIndexedSeq returns the position of the last blob in the sequence of indexed blobs.
+func (srv *Server) lastIndexedSeq(ctx context.Context) (seq int64, err error) {
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	seq, err = sqlitex.ResultInt64(conn.Prep(qLastIndexedSeq()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last indexed blob: %w", err)
+	}
+
+	return seq, nil
+}
+
+// ServeHTTP streams the activity events as Server-Sent Events.
+// It supports the same filters as SubscribeEvents as URL query parameters.
+// The resume token of each event is used as the event ID, so browsers
+// automatically resume the stream with the Last-Event-ID header after reconnecting.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	me, ok := srv.me.Get()
+	if !ok {
+		http.Error(w, "Account is not initialized yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+
+	req := &activity.SubscribeEventsRequest{
+		ResumeToken:       r.Header.Get("Last-Event-ID"),
+		FilterUsers:       query["filter_users"],
+		FilterEventType:   query["filter_event_type"],
+		FilterResource:    query["filter_resource"],
+		AddLinkedResource: query["add_linked_resource"],
+	}
+	if req.ResumeToken == "" {
+		req.ResumeToken = query.Get("resume_token")
+	}
+	if v := query.Get("trusted_only"); v != "" {
+		trusted, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "Invalid trusted_only parameter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.TrustedOnly = trusted
+	}
+
+	// Validating the request before sending the headers, to be able to respond with an error.
+	if _, err := eventsQuery(eventsFilter{
+		Users:           req.FilterUsers,
+		EventTypes:      req.FilterEventType,
+		Resources:       req.FilterResource,
+		LinkedResources: req.AddLinkedResource,
+	}, true); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.ResumeToken != "" {
+		if _, err := decodePageToken(me, req.ResumeToken); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err := srv.subscribeEvents(r.Context(), req, func(resp *activity.SubscribeEventsResponse) error {
+		data, err := protojson.Marshal(resp.Event)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "id: %s\nevent: event\ndata: %s\n\n", resp.ResumeToken, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err != nil && r.Context().Err() == nil {
+		// Headers are already sent, so we can only report the error as a stream event.
+		fmt.Fprintf(w, "event: error\ndata: %s\n\n", strings.ReplaceAll(err.Error(), "\n", " "))
+		flusher.Flush()
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"mintter/backend/config"
 	accounts "mintter/backend/daemon/api/accounts/v1alpha"
 	activity "mintter/backend/daemon/api/activity/v1alpha"
 	daemon "mintter/backend/daemon/api/daemon/v1alpha"
@@ -43,32 +44,18 @@ func New(
 	node *future.ReadOnly[*mttnet.Node],
 	sync *future.ReadOnly[*syncing.Service],
 	wallet *wallet.Service,
+	gc config.GC,
 	LogLevel string,
 ) Server {
-	doSync := func() error {
-		s, ok := sync.Get()
-		if !ok {
-			return fmt.Errorf("account is not initialized yet")
-		}
-
-		go func() {
-			if err := s.SyncAllAndLog(ctx); err != nil {
-				panic("bug or fatal error during sync " + err.Error())
-			}
-		}()
-
-		return nil
-	}
-
-	documentsSrv := documents.NewServer(repo.Identity(), db, &lazyDiscoverer{sync: sync, net: node}, &lazyGwClient{net: node}, LogLevel)
+	documentsSrv := documents.NewServer(repo.Identity(), db, blobs, &lazyDiscoverer{sync: sync, net: node}, &lazyGwClient{net: node})
 	return Server{
 		Accounts:   accounts.NewServer(repo.Identity(), blobs),
-		Activity:   activity.NewServer(repo.Identity(), db),
-		Daemon:     daemon.NewServer(repo, blobs, wallet, doSync),
+		Activity:   activity.NewServer(repo.Identity(), db, blobs),
+		Daemon:     daemon.NewServer(repo, blobs, wallet, &lazySyncer{ctx: ctx, sync: sync}, hyper.GCOptions{QuotaBytes: gc.QuotaBytes, GracePeriod: gc.GracePeriod}),
 		Documents:  documentsSrv,
 		Networking: networking.NewServer(blobs, node),
 		Entities:   entities.NewServer(blobs, &lazyDiscoverer{sync: sync}),
-		Groups:     groups.NewServer(repo.Identity(), logging.New("mintter/groups", LogLevel), groups.NewSQLiteDB(db), blobs, node),
+		Groups:     groups.NewServer(repo.Identity(), logging.New("mintter/groups", LogLevel), groups.NewSQLiteDB(db), blobs, node, sync),
 	}
 }
 
@@ -3,9 +3,13 @@
 package daemon
 
 import (
+	"bytes"
 	context "context"
+	"errors"
 	"fmt"
 	"mintter/backend/core"
+	"mintter/backend/daemon/apiutil"
+	"mintter/backend/daemon/storage"
 	daemon "mintter/backend/genproto/daemon/v1alpha"
 	"mintter/backend/hyper"
 	"mintter/backend/pkg/future"
@@ -24,6 +28,12 @@ type Repo interface {
 	Device() core.KeyPair
 	Identity() *future.ReadOnly[core.Identity]
 	CommitAccount(core.PublicKey) error
+	Accounts() []core.PublicKey
+	Account(core.Principal) (core.PublicKey, bool)
+	ActiveAccount() (core.PublicKey, bool)
+	SwitchAccount(core.Principal) error
+	KeyEncrypted() (bool, error)
+	ChangeKeyPassphrase(oldPassphrase, newPassphrase string) error
 }
 
 // Wallet is a subset of the wallet service used by this server.
@@ -31,26 +41,40 @@ type Wallet interface {
 	ConfigureMintterLNDHub(context.Context, core.KeyPair) error
 }
 
+// Syncer is a subset of the syncing service used by this server.
+// The syncing service lives outside of this package, and can be unavailable until the account is initialized.
+type Syncer interface {
+	// ForceSync starts syncing with all the peers in the background.
+	ForceSync() error
+	// SyncWithProgress syncs with all the peers, and calls send with the progress until it's finished.
+	SyncWithProgress(ctx context.Context, send func(*daemon.SyncProgress) error) error
+	ListSyncJobs() (active, recent []*daemon.SyncJob, err error)
+	SyncStatus(ctx context.Context, kind daemon.SyncJobKind, target string) ([]*daemon.SyncTargetStatus, error)
+	// SyncHistory returns up to limit attempts with ID lower than beforeID, most recent first.
+	SyncHistory(ctx context.Context, kind daemon.SyncJobKind, target string, beforeID int64, limit int) ([]*daemon.SyncAttempt, error)
+}
+
 // Server implements the Daemon gRPC API.
 type Server struct {
 	blobs     *hyper.Storage
 	repo      Repo
 	startTime time.Time
 	wallet    Wallet
-
-	forceSyncFunc func() error
+	syncer    Syncer
+	gcOpts    hyper.GCOptions
 
 	mu sync.Mutex // we only want one register request at a time.
 }
 
 // NewServer creates a new Server.
-func NewServer(r Repo, blobs *hyper.Storage, w Wallet, syncFunc func() error) *Server {
+func NewServer(r Repo, blobs *hyper.Storage, w Wallet, syncer Syncer, gcOpts hyper.GCOptions) *Server {
 	return &Server{
-		blobs:         blobs,
-		repo:          r,
-		startTime:     time.Now(),
-		wallet:        w,
-		forceSyncFunc: syncFunc,
+		blobs:     blobs,
+		repo:      r,
+		startTime: time.Now(),
+		wallet:    w,
+		syncer:    syncer,
+		gcOpts:    gcOpts,
 	}
 }
 
@@ -69,18 +93,18 @@ func (srv *Server) Register(ctx context.Context, req *daemon.RegisterRequest) (*
 	srv.mu.Lock()
 	defer srv.mu.Unlock()
 
+	acc, err := core.AccountFromMnemonic(req.Mnemonic, req.Passphrase)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create account: %v", err)
+	}
+
 	// Check if account already exist
 	{
-		if _, ok := srv.repo.Identity().Get(); ok {
+		if _, ok := srv.repo.Account(acc.Principal()); ok {
 			return nil, status.Errorf(codes.AlreadyExists, "account is already registered")
 		}
 	}
 
-	acc, err := core.AccountFromMnemonic(req.Mnemonic, req.Passphrase)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "failed to create account: %v", err)
-	}
-
 	if err := srv.RegisterAccount(ctx, acc); err != nil {
 		return nil, err
 	}
@@ -91,12 +115,23 @@ func (srv *Server) Register(ctx context.Context, req *daemon.RegisterRequest) (*
 }
 
 // RegisterAccount performs registration given an existing account key pair.
+// Only the first account registered on the device configures the wallet.
 func (srv *Server) RegisterAccount(ctx context.Context, acc core.KeyPair) error {
+	_, isFirst := srv.repo.ActiveAccount()
+	isFirst = !isFirst
+
 	_, err := RegisterWithRepo(ctx, srv.repo, srv.blobs, acc, srv.repo.Device().PublicKey, time.Now().UTC())
 	if err != nil {
+		if errors.Is(err, storage.ErrAccountExists) {
+			return status.Errorf(codes.AlreadyExists, "account is already registered")
+		}
 		return err
 	}
 
+	if !isFirst {
+		return nil
+	}
+
 	if err := srv.wallet.ConfigureMintterLNDHub(ctx, acc); err != nil {
 		return fmt.Errorf("failed to configure wallet when registering: %w", err)
 	}
@@ -126,35 +161,207 @@ func Register(ctx context.Context, bs *hyper.Storage, account core.KeyPair, devi
 		return cid.Undef, err
 	}
 
-	if err = bs.SetAccountTrust(ctx, account.Principal()); err != nil {
+	if err = bs.SetAccountTrust(ctx, account.Principal(), account.Principal()); err != nil {
 		return blob.CID, fmt.Errorf("could not set own account to trusted: " + err.Error())
 	}
 	return blob.CID, nil
 }
 
 // GetInfo implements the corresponding gRPC method.
-func (srv *Server) GetInfo(context.Context, *daemon.GetInfoRequest) (*daemon.Info, error) {
-	me, ok := srv.repo.Identity().Get()
-	if !ok {
-		return nil, status.Error(codes.FailedPrecondition, "account is not initialized yet")
+func (srv *Server) GetInfo(ctx context.Context, _ *daemon.GetInfoRequest) (*daemon.Info, error) {
+	me, err := apiutil.Identity(ctx, srv.repo.Identity())
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := srv.repo.KeyEncrypted()
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &daemon.Info{
-		AccountId: me.Account().Principal().String(),
-		DeviceId:  srv.repo.Device().PeerID().String(),
-		StartTime: timestamppb.New(srv.startTime),
+		AccountId:          me.Account().Principal().String(),
+		DeviceId:           srv.repo.Device().PeerID().String(),
+		StartTime:          timestamppb.New(srv.startTime),
+		DeviceKeyEncrypted: encrypted,
 	}
 
 	return resp, nil
 }
 
+// Unlock implements the corresponding gRPC method.
+// The daemon is only started after the device key is unlocked, so here it's always unlocked already.
+func (srv *Server) Unlock(context.Context, *daemon.UnlockRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.FailedPrecondition, "device key is already unlocked")
+}
+
+// ChangeKeyPassphrase implements the corresponding gRPC method.
+func (srv *Server) ChangeKeyPassphrase(_ context.Context, in *daemon.ChangeKeyPassphraseRequest) (*emptypb.Empty, error) {
+	if err := srv.repo.ChangeKeyPassphrase(in.OldPassphrase, in.NewPassphrase); err != nil {
+		if errors.Is(err, storage.ErrWrongPassphrase) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 // ForceSync implements the corresponding gRPC method.
 func (srv *Server) ForceSync(context.Context, *daemon.ForceSyncRequest) (*emptypb.Empty, error) {
-	if srv.forceSyncFunc == nil {
-		return &emptypb.Empty{}, status.Error(codes.FailedPrecondition, "force sync function is not set")
+	if srv.syncer == nil {
+		return &emptypb.Empty{}, status.Error(codes.FailedPrecondition, "syncer is not set")
+	}
+
+	if err := srv.syncer.ForceSync(); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ForceSyncWithProgress implements the corresponding gRPC method.
+func (srv *Server) ForceSyncWithProgress(_ *daemon.ForceSyncRequest, stream daemon.Daemon_ForceSyncWithProgressServer) error {
+	if srv.syncer == nil {
+		return status.Error(codes.FailedPrecondition, "syncer is not set")
 	}
 
-	if err := srv.forceSyncFunc(); err != nil {
+	return srv.syncer.SyncWithProgress(stream.Context(), stream.Send)
+}
+
+// ListSyncJobs implements the corresponding gRPC method.
+func (srv *Server) ListSyncJobs(context.Context, *daemon.ListSyncJobsRequest) (*daemon.ListSyncJobsResponse, error) {
+	if srv.syncer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "syncer is not set")
+	}
+
+	active, recent, err := srv.syncer.ListSyncJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	return &daemon.ListSyncJobsResponse{
+		ActiveJobs: active,
+		RecentJobs: recent,
+	}, nil
+}
+
+// GetSyncStatus implements the corresponding gRPC method.
+func (srv *Server) GetSyncStatus(ctx context.Context, in *daemon.GetSyncStatusRequest) (*daemon.SyncStatus, error) {
+	if srv.syncer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "syncer is not set")
+	}
+
+	targets, err := srv.syncer.SyncStatus(ctx, in.Kind, in.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &daemon.SyncStatus{Targets: targets}, nil
+}
+
+// ListSyncHistory implements the corresponding gRPC method.
+func (srv *Server) ListSyncHistory(ctx context.Context, in *daemon.ListSyncHistoryRequest) (*daemon.ListSyncHistoryResponse, error) {
+	if srv.syncer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "syncer is not set")
+	}
+
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	var cursor struct {
+		BeforeID int64 `json:"b"`
+	}
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	attempts, err := srv.syncer.SyncHistory(ctx, in.Kind, in.Target, cursor.BeforeID, int(in.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &daemon.ListSyncHistoryResponse{
+		Attempts: attempts,
+	}
+
+	if len(attempts) == int(in.PageSize) {
+		cursor.BeforeID = attempts[len(attempts)-1].Id
+		resp.NextPageToken, err = apiutil.EncodePageToken(cursor, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// CollectGarbage implements the corresponding gRPC method.
+func (srv *Server) CollectGarbage(ctx context.Context, in *daemon.CollectGarbageRequest) (*daemon.GarbageCollectionReport, error) {
+	me, ok := srv.repo.Identity().Get()
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "account is not initialized yet")
+	}
+
+	opts := srv.gcOpts
+	opts.DryRun = in.DryRun
+
+	report, err := srv.blobs.CollectGarbage(ctx, me.Account().Principal(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &daemon.GarbageCollectionReport{
+		DryRun:           report.DryRun,
+		TotalBlobs:       report.TotalBlobs,
+		TotalBytes:       report.TotalBytes,
+		UnreachableBlobs: report.UnreachableBlobs,
+		ReclaimableBytes: report.ReclaimableBytes,
+		EvictedEntities:  make([]string, len(report.EvictedEntities)),
+	}
+	for i, eid := range report.EvictedEntities {
+		resp.EvictedEntities[i] = eid.String()
+	}
+
+	return resp, nil
+}
+
+// ListAccounts implements the corresponding gRPC method.
+func (srv *Server) ListAccounts(context.Context, *daemon.ListAccountsRequest) (*daemon.ListAccountsResponse, error) {
+	active, hasActive := srv.repo.ActiveAccount()
+
+	accs := srv.repo.Accounts()
+	resp := &daemon.ListAccountsResponse{
+		Accounts: make([]*daemon.LocalAccount, len(accs)),
+	}
+	for i, acc := range accs {
+		resp.Accounts[i] = &daemon.LocalAccount{
+			AccountId: acc.String(),
+			IsActive:  hasActive && bytes.Equal(acc.Principal(), active.Principal()),
+		}
+	}
+
+	return resp, nil
+}
+
+// SwitchAccount implements the corresponding gRPC method.
+func (srv *Server) SwitchAccount(_ context.Context, in *daemon.SwitchAccountRequest) (*emptypb.Empty, error) {
+	if in.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "must specify account ID")
+	}
+
+	aid, err := core.DecodePrincipal(in.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode account ID: %v", err)
+	}
+
+	if err := srv.repo.SwitchAccount(aid); err != nil {
+		if errors.Is(err, storage.ErrAccountNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account %s is not registered on this device", in.AccountId)
+		}
 		return nil, err
 	}
 
@@ -4,6 +4,7 @@ import (
 	context "context"
 	"mintter/backend/core"
 	"mintter/backend/core/coretest"
+	"mintter/backend/daemon/apiutil"
 	"mintter/backend/daemon/daemontest"
 	"mintter/backend/daemon/storage"
 	daemon "mintter/backend/genproto/daemon/v1alpha"
@@ -41,13 +42,66 @@ func TestRegister(t *testing.T) {
 	require.Equal(t, "z6MkrGJF5qWkmaD1XsXpxwnX7uhjfR5bAWURvrSPsF12eCAH", resp.AccountId)
 
 	_, err = srv.Register(ctx, &daemon.RegisterRequest{
-		Mnemonic: testMnemonic,
+		Mnemonic:   testMnemonic,
+		Passphrase: testPassphrase,
 	})
-	require.Error(t, err, "calling Register more than once must fail")
+	require.Error(t, err, "registering the same account more than once must fail")
 
 	stat, ok := status.FromError(err)
 	require.True(t, ok)
 	require.Equal(t, codes.AlreadyExists, stat.Code())
+
+	// Different passphrase is a different account.
+	resp, err = srv.Register(ctx, &daemon.RegisterRequest{
+		Mnemonic: testMnemonic,
+	})
+	require.NoError(t, err, "registering another account must succeed")
+	require.NotEqual(t, "z6MkrGJF5qWkmaD1XsXpxwnX7uhjfR5bAWURvrSPsF12eCAH", resp.AccountId)
+	require.Equal(t, 1, srv.wallet.(*mockedWallet).configured, "only the first account must configure the wallet")
+}
+
+func TestListAndSwitchAccounts(t *testing.T) {
+	srv := newTestServer(t, "alice")
+	ctx := context.Background()
+
+	list, err := srv.ListAccounts(ctx, &daemon.ListAccountsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Accounts, 0)
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		seed, err := srv.GenMnemonic(ctx, &daemon.GenMnemonicRequest{MnemonicsLength: 12})
+		require.NoError(t, err)
+		reg, err := srv.Register(ctx, &daemon.RegisterRequest{Mnemonic: seed.Mnemonic})
+		require.NoError(t, err)
+		ids = append(ids, reg.AccountId)
+	}
+
+	list, err = srv.ListAccounts(ctx, &daemon.ListAccountsRequest{})
+	require.NoError(t, err)
+	testutil.ProtoEqual(t, &daemon.ListAccountsResponse{
+		Accounts: []*daemon.LocalAccount{
+			{AccountId: ids[0], IsActive: true},
+			{AccountId: ids[1]},
+		},
+	}, list, "first account must be active")
+
+	_, err = srv.SwitchAccount(ctx, &daemon.SwitchAccountRequest{AccountId: coretest.NewTester("bob").Account.Principal().String()})
+	require.Equal(t, codes.NotFound, status.Code(err), "must not switch to unknown accounts")
+
+	_, err = srv.SwitchAccount(ctx, &daemon.SwitchAccountRequest{AccountId: ids[1]})
+	require.NoError(t, err)
+
+	list, err = srv.ListAccounts(ctx, &daemon.ListAccountsRequest{})
+	require.NoError(t, err)
+	require.False(t, list.Accounts[0].IsActive)
+	require.True(t, list.Accounts[1].IsActive)
+
+	second, ok := srv.repo.ActiveAccount()
+	require.True(t, ok)
+	info, err := srv.GetInfo(apiutil.ContextWithAccount(ctx, second), &daemon.GetInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, ids[1], info.AccountId, "info must report the selected account")
 }
 
 func TestGetInfo_NonReady(t *testing.T) {
@@ -94,12 +148,14 @@ func newTestServer(t *testing.T, name string) *Server {
 	wallet := new(mockedWallet)
 	blobs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
 
-	return NewServer(repo, blobs, wallet, nil)
+	return NewServer(repo, blobs, wallet, nil, hyper.GCOptions{})
 }
 
 type mockedWallet struct {
+	configured int
 }
 
 func (w *mockedWallet) ConfigureMintterLNDHub(context.Context, core.KeyPair) error {
+	w.configured++
 	return nil
 }
@@ -3,6 +3,7 @@ package documents
 import (
 	"context"
 	"fmt"
+	"mintter/backend/daemon/apiutil"
 	documents "mintter/backend/genproto/documents/v1alpha"
 	"mintter/backend/hyper"
 	"mintter/backend/pkg/errutil"
@@ -39,13 +40,35 @@ func (api *Server) ListChanges(ctx context.Context, in *documents.ListChangesReq
 		return nil, status.Errorf(codes.InvalidArgument, "must provide document id")
 	}
 
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	opts := hyper.ListOptions{
+		// We query for pageSize + 1 items to know if there's more items on the next page.
+		Limit:   int(in.PageSize) + 1,
+		Reverse: in.ReverseOrder,
+	}
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &opts.Cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
 	eid := hyper.EntityID(in.DocumentId)
 
 	out := &documents.ListChangesResponse{}
 
 	dels := map[cid.Cid]hyper.KeyDelegation{}
 
-	if err := api.blobs.ForEachChange(ctx, eid, func(c cid.Cid, ch hyper.Change) error {
+	var lastCursor hyper.BlobCursor
+	if err := api.blobs.ListChanges(ctx, eid, opts, func(cur hyper.BlobCursor, c cid.Cid, ch hyper.Change) error {
+		if len(out.Changes) == int(in.PageSize) {
+			var err error
+			out.NextPageToken, err = apiutil.EncodePageToken(lastCursor, nil)
+			return err
+		}
+
 		kd, ok := dels[ch.Delegation]
 		if !ok {
 			if err := api.blobs.LoadBlob(ctx, ch.Delegation, &kd); err != nil {
@@ -55,6 +78,7 @@ func (api *Server) ListChanges(ctx context.Context, in *documents.ListChangesReq
 		}
 
 		out.Changes = append(out.Changes, changeToProto(c, ch, kd))
+		lastCursor = cur
 
 		return nil
 	}); err != nil {
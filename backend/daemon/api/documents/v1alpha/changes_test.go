@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"fmt"
 	documents "mintter/backend/genproto/documents/v1alpha"
 	"mintter/backend/testutil"
 	"testing"
@@ -143,3 +144,57 @@ func TestChangesFields(t *testing.T) {
 		testutil.ProtoEqual(t, c, cc, "get change must return same data as list changes")
 	}
 }
+
+func TestListChangesPagination(t *testing.T) {
+	t.Parallel()
+
+	api := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	draft, err := api.CreateDraft(ctx, &documents.CreateDraftRequest{})
+	require.NoError(t, err)
+	draft = updateDraft(ctx, t, api, draft.Id, []*documents.DocumentChange{
+		{Op: &documents.DocumentChange_SetTitle{SetTitle: "Title 0"}},
+	})
+	pub, err := api.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	for i := 1; i < 5; i++ {
+		draft, err := api.CreateDraft(ctx, &documents.CreateDraftRequest{ExistingDocumentId: pub.Document.Id})
+		require.NoError(t, err)
+		updateDraft(ctx, t, api, draft.Id, []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetTitle{SetTitle: fmt.Sprintf("Title %d", i)}},
+		})
+		pub, err = api.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+		require.NoError(t, err)
+	}
+
+	all, err := api.ListChanges(ctx, &documents.ListChangesRequest{DocumentId: pub.Document.Id})
+	require.NoError(t, err)
+	require.Len(t, all.Changes, 5)
+	require.Equal(t, "", all.NextPageToken, "must not return page token for the last page")
+
+	listAll := func(req *documents.ListChangesRequest) (out []*documents.ChangeInfo) {
+		req.DocumentId = pub.Document.Id
+		req.PageSize = 2
+		for {
+			list, err := api.ListChanges(ctx, req)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(list.Changes), 2, "page must not exceed page size")
+			out = append(out, list.Changes...)
+			if list.NextPageToken == "" {
+				return out
+			}
+			req.PageToken = list.NextPageToken
+		}
+	}
+
+	testutil.ProtoEqual(t, all, &documents.ListChangesResponse{Changes: listAll(&documents.ListChangesRequest{})}, "paginated changes must match")
+
+	reversed := listAll(&documents.ListChangesRequest{ReverseOrder: true})
+	require.Len(t, reversed, len(all.Changes))
+	for i := range reversed {
+		testutil.ProtoEqual(t, all.Changes[len(all.Changes)-1-i], reversed[i], "reversed changes must match")
+	}
+	require.Equal(t, pub.Version, reversed[0].Id, "newest change must be first in reverse order")
+}
@@ -3,6 +3,7 @@ package documents
 import (
 	"context"
 	"fmt"
+	"mintter/backend/daemon/apiutil"
 	documents "mintter/backend/genproto/documents/v1alpha"
 	"mintter/backend/hlc"
 	"mintter/backend/hyper"
@@ -10,7 +11,6 @@ import (
 	"net/url"
 	"strings"
 
-	"crawshaw.io/sqlite"
 	"github.com/ipfs/go-cid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -40,7 +40,7 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 		return nil, status.Errorf(codes.InvalidArgument, "target must use versioned URLs, got %s", in.Target)
 	}
 
-	me, err := srv.getMe()
+	me, err := srv.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +91,17 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 		}
 	}
 
-	hb, err := hyper.NewComment(in.Target, threadRoot, repliedComment, clock.MustNow(), me.DeviceKey(), del, commentContentFromProto(in.Content))
+	// Comments on documents in private groups are sealed with the content key of the group.
+	var opts []hyper.CommentOption
+	key, err := srv.contentKey(ctx, hyper.EntityID("hm://d/"+strings.TrimPrefix(u.Path, "/")))
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		opts = append(opts, hyper.WithCommentContentKey(*key))
+	}
+
+	hb, err := hyper.NewComment(in.Target, threadRoot, repliedComment, clock.MustNow(), me.DeviceKey(), del, commentContentFromProto(in.Content), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +110,7 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 		return nil, fmt.Errorf("failed to save comment: %w", err)
 	}
 
-	return commentToProto(ctx, srv.blobs, hb.CID, hb.Decoded.(hyper.Comment))
+	return srv.openCommentToProto(ctx, hb.CID, hb.Decoded.(hyper.Comment))
 }
 
 // GetComment gets a comment by ID.
@@ -124,7 +134,16 @@ func (srv *Server) GetComment(ctx context.Context, in *documents.GetCommentReque
 		return nil, err
 	}
 
-	return commentToProto(ctx, srv.blobs, hb.CID, hb.Decoded.(hyper.Comment))
+	return srv.openCommentToProto(ctx, hb.CID, hb.Decoded.(hyper.Comment))
+}
+
+// openCommentToProto decrypts the comment if it's sealed and converts it to proto.
+func (srv *Server) openCommentToProto(ctx context.Context, c cid.Cid, cmt hyper.Comment) (*documents.Comment, error) {
+	if err := srv.blobs.OpenComment(ctx, &cmt); err != nil {
+		return nil, contentKeyError(err)
+	}
+
+	return commentToProto(ctx, srv.blobs, c, cmt)
 }
 
 // ListComments lists comments and replies for a given target.
@@ -133,16 +152,54 @@ func (srv *Server) ListComments(ctx context.Context, in *documents.ListCommentsR
 		return nil, errutil.MissingArgument("target")
 	}
 
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	var threadRoot cid.Cid
+	if in.ThreadRoot != "" {
+		if !strings.HasPrefix(in.ThreadRoot, "hm://c/") {
+			return nil, status.Errorf(codes.InvalidArgument, "thread root must be a comment ID starting with hm://c/, got '%s'", in.ThreadRoot)
+		}
+
+		c, err := cid.Decode(strings.TrimPrefix(in.ThreadRoot, "hm://c/"))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse thread root CID from %s: %v", in.ThreadRoot, err)
+		}
+		threadRoot = c
+	}
+
+	opts := hyper.ListOptions{
+		// We query for pageSize + 1 items to know if there's more items on the next page,
+		// because if not we don't need to return the page token in the response.
+		Limit:   int(in.PageSize) + 1,
+		Reverse: in.ReverseOrder,
+	}
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &opts.Cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
 	resp := &documents.ListCommentsResponse{}
-	if err := srv.blobs.ForEachComment(ctx, in.Target, func(c cid.Cid, cmt hyper.Comment, _ *sqlite.Conn) error {
+
+	var lastCursor hyper.BlobCursor
+	if err := srv.blobs.ListComments(ctx, in.Target, threadRoot, opts, func(cur hyper.BlobCursor, c cid.Cid, cmt hyper.Comment) error {
+		if len(resp.Comments) == int(in.PageSize) {
+			var err error
+			resp.NextPageToken, err = apiutil.EncodePageToken(lastCursor, nil)
+			return err
+		}
+
 		pb, err := commentToProto(ctx, srv.blobs, c, cmt)
 		if err != nil {
 			return fmt.Errorf("failed to convert comment %s to proto", c.String())
 		}
 		resp.Comments = append(resp.Comments, pb)
+		lastCursor = cur
 		return nil
 	}); err != nil {
-		return nil, err
+		return nil, contentKeyError(err)
 	}
 
 	return resp, nil
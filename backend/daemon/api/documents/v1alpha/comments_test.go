@@ -105,3 +105,82 @@ func TestCommentsSmoke(t *testing.T) {
 	}
 	testutil.ProtoEqual(t, want, list, "list must match")
 }
+
+func TestListCommentsPagination(t *testing.T) {
+	t.Parallel()
+
+	api := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	draft, err := api.CreateDraft(ctx, &CreateDraftRequest{})
+	require.NoError(t, err)
+
+	pub, err := api.PublishDraft(ctx, &PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	target := pub.Document.Id + "?v=" + pub.Version
+
+	newComment := func(replied string) *Comment {
+		cmt, err := api.CreateComment(ctx, &CreateCommentRequest{
+			Target:         target,
+			RepliedComment: replied,
+			Content: []*BlockNode{{
+				Block: &Block{
+					Id:   "b1",
+					Type: "paragraph",
+					Text: "Hello",
+				},
+			}},
+		})
+		require.NoError(t, err)
+		return cmt
+	}
+
+	var (
+		all    []*Comment
+		thread []*Comment
+	)
+	for i := 0; i < 5; i++ {
+		cmt := newComment("")
+		all = append(all, cmt)
+		if i == 1 {
+			thread = append(thread, cmt)
+			for j := 0; j < 3; j++ {
+				reply := newComment(thread[len(thread)-1].Id)
+				all = append(all, reply)
+				thread = append(thread, reply)
+			}
+		}
+	}
+
+	listAll := func(req *ListCommentsRequest) (out []*Comment) {
+		req.Target = pub.Document.Id
+		req.PageSize = 2
+		for {
+			list, err := api.ListComments(ctx, req)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(list.Comments), 2, "page must not exceed page size")
+			out = append(out, list.Comments...)
+			if list.NextPageToken == "" {
+				return out
+			}
+			req.PageToken = list.NextPageToken
+		}
+	}
+
+	reversed := func(in []*Comment) []*Comment {
+		out := make([]*Comment, len(in))
+		for i := range in {
+			out[len(in)-1-i] = in[i]
+		}
+		return out
+	}
+
+	testutil.ProtoEqual(t, &ListCommentsResponse{Comments: all}, &ListCommentsResponse{Comments: listAll(&ListCommentsRequest{})}, "paginated comments must match")
+	testutil.ProtoEqual(t, &ListCommentsResponse{Comments: reversed(all)}, &ListCommentsResponse{Comments: listAll(&ListCommentsRequest{ReverseOrder: true})}, "reversed comments must match")
+	testutil.ProtoEqual(t, &ListCommentsResponse{Comments: thread}, &ListCommentsResponse{Comments: listAll(&ListCommentsRequest{ThreadRoot: thread[0].Id})}, "thread comments must match")
+	testutil.ProtoEqual(t, &ListCommentsResponse{Comments: reversed(thread)}, &ListCommentsResponse{Comments: listAll(&ListCommentsRequest{ThreadRoot: thread[0].Id, ReverseOrder: true})}, "reversed thread comments must match")
+
+	_, err = api.ListComments(ctx, &ListCommentsRequest{Target: pub.Document.Id, ThreadRoot: "foo"})
+	require.Error(t, err, "thread root must be a comment ID")
+}
@@ -0,0 +1,524 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"mintter/backend/daemon/api/documents/v1alpha/docmodel"
+	documents "mintter/backend/genproto/documents/v1alpha"
+	"mintter/backend/hyper"
+	"mintter/backend/pkg/lcs"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/exp/maps"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DiffVersions implements the corresponding gRPC method.
+func (api *Server) DiffVersions(ctx context.Context, in *documents.DiffVersionsRequest) (*documents.DiffVersionsResponse, error) {
+	if in.DocumentId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "must specify document ID")
+	}
+
+	if in.BaseVersion == "" || in.TargetVersion == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "must specify both base and target versions")
+	}
+
+	eid := hyper.EntityID(in.DocumentId)
+
+	base, err := api.loadDocument(ctx, eid, hyper.Version(in.BaseVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := api.loadDocument(ctx, eid, hyper.Version(in.TargetVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	return api.diffDocuments(ctx, base, target)
+}
+
+func (api *Server) diffDocuments(ctx context.Context, base, target *docmodel.Document) (*documents.DiffVersionsResponse, error) {
+	basepb, err := base.Hydrate(ctx, api.blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	targetpb, err := target.Hydrate(ctx, api.blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &documents.DiffVersionsResponse{}
+
+	// Changes that are only in one of the versions are the ones responsible for the differences.
+	var changes []hyper.ParsedBlob[hyper.Change]
+	{
+		baseChanges := changeSet(base.Entity())
+		targetChanges := changeSet(target.Entity())
+
+		for _, c := range target.Entity().AppliedChanges() {
+			if _, ok := baseChanges[c.CID]; !ok {
+				out.AddedChanges = append(out.AddedChanges, c.CID.String())
+				changes = append(changes, c)
+			}
+		}
+
+		for _, c := range base.Entity().AppliedChanges() {
+			if _, ok := targetChanges[c.CID]; !ok {
+				out.RemovedChanges = append(out.RemovedChanges, c.CID.String())
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	idx, err := api.indexDiffChanges(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if basepb.Title != targetpb.Title {
+		out.Metadata = append(out.Metadata, &documents.MetadataDiff{
+			Field:    "title",
+			OldValue: basepb.Title,
+			NewValue: targetpb.Title,
+			Authors:  idx.authors("title"),
+		})
+	}
+
+	out.Blocks = diffBlocks(basepb.Children, targetpb.Children, idx.moves)
+	for _, b := range out.Blocks {
+		b.Authors = idx.authors("blocks", b.BlockId)
+		if err := idx.attributeText(base.Entity(), target.Entity(), b); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func changeSet(e *hyper.Entity) map[cid.Cid]struct{} {
+	out := make(map[cid.Cid]struct{}, len(e.AppliedChanges()))
+	for _, c := range e.AppliedChanges() {
+		out[c.CID] = struct{}{}
+	}
+	return out
+}
+
+// diffChangeIndex holds what the changes between two versions did to the document.
+type diffChangeIndex struct {
+	// Parts of the document mapped to the accounts who authored the changes that touched them.
+	touched map[string]map[string]struct{}
+	// Blocks that were explicitly moved by the changes, excluding deletions.
+	moves map[string]struct{}
+	// IDs of the changes mapped to their authors.
+	changeAuthors map[string]string
+}
+
+func (idx *diffChangeIndex) touch(author string, path ...string) {
+	key := strings.Join(path, "/")
+	if idx.touched[key] == nil {
+		idx.touched[key] = make(map[string]struct{})
+	}
+	idx.touched[key][author] = struct{}{}
+}
+
+func (idx *diffChangeIndex) authors(path ...string) []string {
+	out := maps.Keys(idx.touched[strings.Join(path, "/")])
+	sort.Strings(out)
+	return out
+}
+
+// indexDiffChanges finds which parts of the document were touched by the given changes and by whom.
+// Block content, text, and moves of the block are all attributed to the block.
+func (api *Server) indexDiffChanges(ctx context.Context, changes []hyper.ParsedBlob[hyper.Change]) (*diffChangeIndex, error) {
+	idx := &diffChangeIndex{
+		touched:       make(map[string]map[string]struct{}),
+		moves:         make(map[string]struct{}),
+		changeAuthors: make(map[string]string, len(changes)),
+	}
+	issuers := map[cid.Cid]string{}
+
+	for _, c := range changes {
+		ch := c.Data
+
+		author, ok := issuers[ch.Delegation]
+		if !ok {
+			var kd hyper.KeyDelegation
+			if err := api.blobs.LoadBlob(ctx, ch.Delegation, &kd); err != nil {
+				return nil, fmt.Errorf("failed to load key delegation of change %s: %w", c.CID, err)
+			}
+			author = kd.Issuer.String()
+			issuers[ch.Delegation] = author
+		}
+		idx.changeAuthors[c.CID.String()] = author
+
+		if _, ok := ch.Patch["title"]; ok {
+			idx.touch(author, "title")
+		}
+
+		for _, field := range []string{"blocks", "text"} {
+			blocks, _ := ch.Patch[field].(map[string]any)
+			for id := range blocks {
+				idx.touch(author, "blocks", id)
+			}
+		}
+
+		moves, _ := ch.Patch["moves"].(map[string]any)
+		list, _ := moves["#list"].(map[string]any)
+		ops, _ := list["#ins"].([]any)
+		for _, op := range ops {
+			mm, _ := op.(map[string]any)
+			id, ok := mm["b"].(string)
+			if !ok {
+				continue
+			}
+
+			idx.touch(author, "blocks", id)
+			if mm["p"] != docmodel.TrashNodeID {
+				idx.moves[id] = struct{}{}
+			}
+		}
+	}
+
+	return idx, nil
+}
+
+// attributeText sets the authors of the inserted and deleted text of the block,
+// using the origins of the characters in the text RGA of both versions.
+// Inserted text is attributed to the changes that inserted the characters, or deleted them in the base version,
+// and deleted text to the changes that deleted the characters, or inserted them in the base version.
+// Blocks with the text stored inline, from before the text was stored as RGA, are only attributed as a whole.
+func (idx *diffChangeIndex) attributeText(base, target *hyper.Entity, bd *documents.BlockDiff) error {
+	if len(bd.TextDiff) == 0 {
+		return nil
+	}
+
+	oldText, ok, err := base.State().RGA("text", bd.BlockId)
+	if err != nil || !ok {
+		return err
+	}
+
+	newText, ok, err := target.State().RGA("text", bd.BlockId)
+	if err != nil || !ok {
+		return err
+	}
+
+	// Hunks are computed from the text of the blocks, which must be the text of the RGA.
+	if oldText.String() != bd.OldBlock.Text || newText.String() != bd.NewBlock.Text {
+		return nil
+	}
+
+	oldRefs := oldText.Refs()
+	newRefs := newText.Refs()
+
+	// Character references have the form <idx>@<origin>, and origins are the IDs of the changes.
+	origin := func(ref string) string {
+		_, o, _ := strings.Cut(ref, "@")
+		return o
+	}
+
+	var i, j int
+	for _, h := range bd.TextDiff {
+		n := utf8.RuneCountInString(h.Text)
+
+		authors := make(map[string]struct{})
+		addAuthor := func(change string) {
+			if author, ok := idx.changeAuthors[change]; ok {
+				authors[author] = struct{}{}
+			}
+		}
+
+		switch h.Op {
+		case documents.DiffOp_EQUAL:
+			i += n
+			j += n
+			continue
+		case documents.DiffOp_INSERT:
+			for _, ref := range newRefs[j : j+n] {
+				if o, ok := oldText.DeletedBy(ref); ok {
+					addAuthor(o)
+				} else {
+					addAuthor(origin(ref))
+				}
+			}
+			j += n
+		case documents.DiffOp_DELETE:
+			for _, ref := range oldRefs[i : i+n] {
+				if o, ok := newText.DeletedBy(ref); ok {
+					addAuthor(o)
+				} else {
+					addAuthor(origin(ref))
+				}
+			}
+			i += n
+		}
+
+		if len(authors) > 0 {
+			h.Authors = maps.Keys(authors)
+			sort.Strings(h.Authors)
+		}
+	}
+
+	return nil
+}
+
+// flatBlock is a block with its position in the document.
+type flatBlock struct {
+	block  *documents.Block
+	parent string
+	left   string
+}
+
+// flattenBlocks returns the blocks of the document tree in the document order.
+func flattenBlocks(nodes []*documents.BlockNode) (order []string, blocks map[string]flatBlock) {
+	blocks = make(map[string]flatBlock)
+
+	var walk func(parent string, nodes []*documents.BlockNode)
+	walk = func(parent string, nodes []*documents.BlockNode) {
+		var left string
+		for _, n := range nodes {
+			id := n.Block.Id
+			order = append(order, id)
+			blocks[id] = flatBlock{block: n.Block, parent: parent, left: left}
+			walk(id, n.Children)
+			left = id
+		}
+	}
+	walk("", nodes)
+
+	return order, blocks
+}
+
+func (fb flatBlock) position() *documents.BlockPosition {
+	return &documents.BlockPosition{
+		Parent:      fb.parent,
+		LeftSibling: fb.left,
+	}
+}
+
+// diffBlocks compares two block trees. Changed blocks of the new tree are returned first,
+// followed by the removed blocks in the order of the old tree.
+// Only the blocks that were explicitly moved by the changes between the trees can be reported as moved.
+func diffBlocks(oldTree, newTree []*documents.BlockNode, moves map[string]struct{}) []*documents.BlockDiff {
+	oldOrder, oldBlocks := flattenBlocks(oldTree)
+	newOrder, newBlocks := flattenBlocks(newTree)
+
+	moved := movedBlocks(oldOrder, oldBlocks, newOrder, newBlocks, moves)
+
+	var out []*documents.BlockDiff
+	for _, id := range newOrder {
+		nb := newBlocks[id]
+		ob, ok := oldBlocks[id]
+		if !ok {
+			out = append(out, &documents.BlockDiff{
+				BlockId:     id,
+				NewBlock:    nb.block,
+				NewPosition: nb.position(),
+			})
+			continue
+		}
+
+		bd := diffBlock(ob.block, nb.block)
+		_, bd.Moved = moved[id]
+		if !bd.Moved && bd.ChangedFields == nil {
+			continue
+		}
+
+		bd.OldPosition = ob.position()
+		bd.NewPosition = nb.position()
+		out = append(out, bd)
+	}
+
+	for _, id := range oldOrder {
+		if _, ok := newBlocks[id]; ok {
+			continue
+		}
+
+		ob := oldBlocks[id]
+		out = append(out, &documents.BlockDiff{
+			BlockId:     id,
+			OldBlock:    ob.block,
+			OldPosition: ob.position(),
+		})
+	}
+
+	return out
+}
+
+// movedBlocks finds the moved blocks which ended up under a different parent,
+// or in a different order relative to the siblings they had in both versions.
+// Blocks that only shifted because other blocks were added, removed, or moved around them are not considered moved.
+func movedBlocks(oldOrder []string, oldBlocks map[string]flatBlock, newOrder []string, newBlocks map[string]flatBlock, moves map[string]struct{}) map[string]struct{} {
+	// Finds the nearest left sibling which is under the same parent in both versions.
+	commonLeft := func(order []string, blocks, other map[string]flatBlock) map[string]string {
+		out := make(map[string]string, len(order))
+		last := map[string]string{}
+		for _, id := range order {
+			b := blocks[id]
+			if ob, ok := other[id]; !ok || ob.parent != b.parent {
+				continue
+			}
+			out[id] = last[b.parent]
+			last[b.parent] = id
+		}
+		return out
+	}
+
+	oldLeft := commonLeft(oldOrder, oldBlocks, newBlocks)
+	newLeft := commonLeft(newOrder, newBlocks, oldBlocks)
+
+	moved := make(map[string]struct{})
+	for id := range moves {
+		ob, ok := oldBlocks[id]
+		if !ok {
+			continue
+		}
+
+		nb, ok := newBlocks[id]
+		if !ok {
+			continue
+		}
+
+		if ob.parent != nb.parent || oldLeft[id] != newLeft[id] {
+			moved[id] = struct{}{}
+		}
+	}
+
+	return moved
+}
+
+// diffBlock compares two versions of the same block.
+func diffBlock(oldBlk, newBlk *documents.Block) *documents.BlockDiff {
+	bd := &documents.BlockDiff{
+		BlockId:  newBlk.Id,
+		OldBlock: oldBlk,
+		NewBlock: newBlk,
+	}
+
+	if oldBlk.Type != newBlk.Type {
+		bd.ChangedFields = append(bd.ChangedFields, "type")
+	}
+
+	if oldBlk.Text != newBlk.Text {
+		bd.ChangedFields = append(bd.ChangedFields, "text")
+		bd.TextDiff = diffText(oldBlk.Text, newBlk.Text)
+	}
+
+	if oldBlk.Ref != newBlk.Ref {
+		bd.ChangedFields = append(bd.ChangedFields, "ref")
+	}
+
+	if !maps.Equal(oldBlk.Attributes, newBlk.Attributes) {
+		bd.ChangedFields = append(bd.ChangedFields, "attributes")
+	}
+
+	bd.AnnotationDiffs = diffAnnotations(oldBlk.Annotations, newBlk.Annotations)
+	if bd.AnnotationDiffs != nil {
+		bd.ChangedFields = append(bd.ChangedFields, "annotations")
+	}
+
+	return bd
+}
+
+// diffText returns the character-level difference between two texts,
+// as runs of kept, inserted, and deleted text.
+func diffText(oldText, newText string) []*documents.TextHunk {
+	a := []rune(oldText)
+	b := []rune(newText)
+
+	var out []*documents.TextHunk
+	add := func(op documents.DiffOp, text []rune) {
+		if len(text) == 0 {
+			return
+		}
+		if len(out) > 0 && out[len(out)-1].Op == op {
+			out[len(out)-1].Text += string(text)
+			return
+		}
+		out = append(out, &documents.TextHunk{Op: op, Text: string(text)})
+	}
+
+	// Deleted text goes before the inserted text that replaces it.
+	var (
+		i   int
+		ins []rune
+	)
+	for j, k := range lcs.Match(a, b, lcs.Equal[rune]) {
+		if k < 0 {
+			ins = append(ins, b[j])
+			continue
+		}
+
+		add(documents.DiffOp_DELETE, a[i:k])
+		add(documents.DiffOp_INSERT, ins)
+		add(documents.DiffOp_EQUAL, b[j:j+1])
+		i = k + 1
+		ins = ins[:0]
+	}
+	add(documents.DiffOp_DELETE, a[i:])
+	add(documents.DiffOp_INSERT, ins)
+
+	return out
+}
+
+// diffAnnotations compares the annotations of two versions of a block.
+// Annotations are matched by their identity, i.e. type, ref, and attributes,
+// and the matched ones are compared by their spans.
+func diffAnnotations(oldAnns, newAnns []*documents.Annotation) []*documents.AnnotationDiff {
+	annotationKey := func(a *documents.Annotation) string {
+		attrs := maps.Keys(a.Attributes)
+		sort.Strings(attrs)
+
+		var sb strings.Builder
+		sb.WriteString(a.Type)
+		sb.WriteByte(0)
+		sb.WriteString(a.Ref)
+		for _, k := range attrs {
+			sb.WriteByte(0)
+			sb.WriteString(k)
+			sb.WriteByte('=')
+			sb.WriteString(a.Attributes[k])
+		}
+		return sb.String()
+	}
+
+	// Annotations with the same identity are matched in order.
+	unmatched := map[string][]*documents.Annotation{}
+	for _, a := range oldAnns {
+		k := annotationKey(a)
+		unmatched[k] = append(unmatched[k], a)
+	}
+
+	var out []*documents.AnnotationDiff
+	for _, a := range newAnns {
+		k := annotationKey(a)
+		old := unmatched[k]
+		if len(old) == 0 {
+			out = append(out, &documents.AnnotationDiff{NewAnnotation: a})
+			continue
+		}
+		unmatched[k] = old[1:]
+
+		if !proto.Equal(old[0], a) {
+			out = append(out, &documents.AnnotationDiff{OldAnnotation: old[0], NewAnnotation: a})
+		}
+	}
+
+	for _, a := range oldAnns {
+		k := annotationKey(a)
+		for _, u := range unmatched[k] {
+			if u == a {
+				out = append(out, &documents.AnnotationDiff{OldAnnotation: a})
+				break
+			}
+		}
+	}
+
+	return out
+}
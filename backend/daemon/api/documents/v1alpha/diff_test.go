@@ -0,0 +1,226 @@
+package documents
+
+import (
+	"context"
+	"mintter/backend/core/coretest"
+	"mintter/backend/crdt2"
+	documents "mintter/backend/genproto/documents/v1alpha"
+	"mintter/backend/hyper"
+	"mintter/backend/pkg/must"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffVersions(t *testing.T) {
+	t.Parallel()
+
+	api := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	me, err := api.getMe(ctx)
+	require.NoError(t, err)
+	alice := me.Account().Principal().String()
+
+	draft, err := api.CreateDraft(ctx, &documents.CreateDraftRequest{})
+	require.NoError(t, err)
+	updateDraft(ctx, t, api, draft.Id, []*documents.DocumentChange{
+		{Op: &documents.DocumentChange_SetTitle{SetTitle: "Old title"}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1"}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b1", Type: "statement", Text: "Hello world!"}}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b2", LeftSibling: "b1"}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b2", Type: "statement", Text: "Second block"}}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b3", LeftSibling: "b2"}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b3", Type: "statement", Text: "Third block"}}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b4", LeftSibling: "b3"}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b4", Type: "statement", Text: "Untouched block"}}},
+	})
+	v1, err := api.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	_, err = api.CreateDraft(ctx, &documents.CreateDraftRequest{ExistingDocumentId: draft.Id})
+	require.NoError(t, err)
+	updateDraft(ctx, t, api, draft.Id, []*documents.DocumentChange{
+		{Op: &documents.DocumentChange_SetTitle{SetTitle: "New title"}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{
+			Id:   "b1",
+			Type: "statement",
+			Text: "Hello brave world!",
+			Annotations: []*documents.Annotation{
+				{Type: "strong", Starts: []int32{6}, Ends: []int32{11}},
+			},
+		}}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b3"}}},
+		{Op: &documents.DocumentChange_DeleteBlock{DeleteBlock: "b2"}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b5", LeftSibling: "b4"}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b5", Type: "statement", Text: "New block"}}},
+	})
+	v2, err := api.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	diff, err := api.DiffVersions(ctx, &documents.DiffVersionsRequest{
+		DocumentId:    draft.Id,
+		BaseVersion:   v1.Version,
+		TargetVersion: v2.Version,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{v2.Version}, diff.AddedChanges)
+	require.Nil(t, diff.RemovedChanges)
+
+	require.Len(t, diff.Metadata, 1)
+	require.Equal(t, "title", diff.Metadata[0].Field)
+	require.Equal(t, "Old title", diff.Metadata[0].OldValue)
+	require.Equal(t, "New title", diff.Metadata[0].NewValue)
+	require.Equal(t, []string{alice}, diff.Metadata[0].Authors)
+
+	// Target order: b3, b1, b4, b5, and then removed b2.
+	require.Len(t, diff.Blocks, 3+1, "b4 must not be in the diff")
+
+	moved := diff.Blocks[0]
+	require.Equal(t, "b3", moved.BlockId)
+	require.True(t, moved.Moved)
+	require.Nil(t, moved.ChangedFields)
+	require.Equal(t, "b2", moved.OldPosition.LeftSibling)
+	require.Equal(t, "", moved.NewPosition.LeftSibling)
+
+	modified := diff.Blocks[1]
+	require.Equal(t, "b1", modified.BlockId)
+	require.False(t, modified.Moved, "b1 must not be moved, only shifted by the move of b3")
+	require.Equal(t, []string{"text", "annotations"}, modified.ChangedFields)
+	require.Equal(t, []*documents.TextHunk{
+		{Op: documents.DiffOp_EQUAL, Text: "Hello "},
+		{Op: documents.DiffOp_INSERT, Text: "brave ", Authors: []string{alice}},
+		{Op: documents.DiffOp_EQUAL, Text: "world!"},
+	}, modified.TextDiff)
+	require.Len(t, modified.AnnotationDiffs, 1)
+	require.Nil(t, modified.AnnotationDiffs[0].OldAnnotation)
+	require.Equal(t, "strong", modified.AnnotationDiffs[0].NewAnnotation.Type)
+	require.Equal(t, []string{alice}, modified.Authors)
+
+	added := diff.Blocks[2]
+	require.Equal(t, "b5", added.BlockId)
+	require.Nil(t, added.OldBlock)
+	require.Equal(t, "New block", added.NewBlock.Text)
+	require.Equal(t, "b4", added.NewPosition.LeftSibling)
+
+	removed := diff.Blocks[3]
+	require.Equal(t, "b2", removed.BlockId)
+	require.Nil(t, removed.NewBlock)
+	require.Equal(t, "Second block", removed.OldBlock.Text)
+	require.Equal(t, []string{alice}, removed.Authors)
+
+	// Diffing in the opposite direction must swap the sides.
+	back, err := api.DiffVersions(ctx, &documents.DiffVersionsRequest{
+		DocumentId:    draft.Id,
+		BaseVersion:   v2.Version,
+		TargetVersion: v1.Version,
+	})
+	require.NoError(t, err)
+	require.Nil(t, back.AddedChanges)
+	require.Equal(t, []string{v2.Version}, back.RemovedChanges)
+	require.Equal(t, "Old title", back.Metadata[0].NewValue)
+	require.Equal(t, "b1", back.Blocks[0].BlockId)
+	require.Equal(t, []*documents.TextHunk{
+		{Op: documents.DiffOp_EQUAL, Text: "Hello "},
+		{Op: documents.DiffOp_DELETE, Text: "brave ", Authors: []string{alice}},
+		{Op: documents.DiffOp_EQUAL, Text: "world!"},
+	}, back.Blocks[0].TextDiff, "text inserted by the removed changes must be attributed to their authors")
+}
+
+func TestDiffTextAuthors(t *testing.T) {
+	t.Parallel()
+
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	del := must.Do2(hyper.NewKeyDelegation(alice.Account, alice.Device.PublicKey, time.Now())).Blob().CID
+
+	e := hyper.NewEntity("hm://d/foo")
+	edit := func(signer coretest.Tester, text string) hyper.Blob {
+		var chunk map[string]any
+		if rga, ok, err := e.State().RGA("text", "b1"); ok {
+			require.NoError(t, err)
+			chunk, _ = rga.Diff(text)
+		} else {
+			chunk, _ = crdt2.NewRGA().Diff(text)
+		}
+
+		hb, err := e.CreateChange(e.NextTimestamp(), signer.Device, del, map[string]any{
+			"text": map[string]any{"b1": map[string]any{"#rga": chunk}},
+		})
+		require.NoError(t, err)
+		return hb
+	}
+
+	c1 := edit(alice, "aaa ccc")
+	base := hyper.NewEntity(e.ID())
+	require.NoError(t, base.ApplyChange(c1.CID, c1.Decoded.(hyper.Change)))
+
+	c2 := edit(alice, "aaa bbb ccc")
+	c3 := edit(bob, "bbb ccc ddd")
+
+	idx := &diffChangeIndex{changeAuthors: map[string]string{
+		c2.CID.String(): alice.Account.Principal().String(),
+		c3.CID.String(): bob.Account.Principal().String(),
+	}}
+
+	bd := &documents.BlockDiff{
+		BlockId:  "b1",
+		OldBlock: &documents.Block{Id: "b1", Text: "aaa ccc"},
+		NewBlock: &documents.Block{Id: "b1", Text: "bbb ccc ddd"},
+	}
+	bd.TextDiff = diffText(bd.OldBlock.Text, bd.NewBlock.Text)
+	require.NoError(t, idx.attributeText(base, e, bd))
+
+	require.Equal(t, []*documents.TextHunk{
+		{Op: documents.DiffOp_DELETE, Text: "aaa", Authors: []string{bob.Account.Principal().String()}},
+		{Op: documents.DiffOp_INSERT, Text: "bbb", Authors: []string{alice.Account.Principal().String()}},
+		{Op: documents.DiffOp_EQUAL, Text: " ccc"},
+		{Op: documents.DiffOp_INSERT, Text: " ddd", Authors: []string{bob.Account.Principal().String()}},
+	}, bd.TextDiff)
+}
+
+func TestDiffText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Old  string
+		New  string
+		Want []*documents.TextHunk
+	}{
+		{
+			Old:  "",
+			New:  "Hello",
+			Want: []*documents.TextHunk{{Op: documents.DiffOp_INSERT, Text: "Hello"}},
+		},
+		{
+			Old:  "Hello",
+			New:  "",
+			Want: []*documents.TextHunk{{Op: documents.DiffOp_DELETE, Text: "Hello"}},
+		},
+		{
+			Old: "The quick fox",
+			New: "The slow fox",
+			Want: []*documents.TextHunk{
+				{Op: documents.DiffOp_EQUAL, Text: "The "},
+				{Op: documents.DiffOp_DELETE, Text: "quick"},
+				{Op: documents.DiffOp_INSERT, Text: "slow"},
+				{Op: documents.DiffOp_EQUAL, Text: " fox"},
+			},
+		},
+		{
+			Old: "Привет, мир",
+			New: "Привет, 世界",
+			Want: []*documents.TextHunk{
+				{Op: documents.DiffOp_EQUAL, Text: "Привет, "},
+				{Op: documents.DiffOp_DELETE, Text: "мир"},
+				{Op: documents.DiffOp_INSERT, Text: "世界"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.Want, diffText(tt.Old, tt.New), "%q -> %q", tt.Old, tt.New)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"mintter/backend/core"
+	"mintter/backend/crdt2"
 	documents "mintter/backend/genproto/documents/v1alpha"
 	"mintter/backend/hlc"
 	"mintter/backend/hyper"
@@ -13,8 +14,10 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ipfs/go-cid"
+	"golang.org/x/exp/maps"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -34,6 +37,7 @@ type Document struct {
 	oldChange  hyper.Change
 	done       bool
 	nextHLC    hlc.Timestamp
+	contentKey *hyper.ContentKey  // key to seal the changes of documents in private groups.
 	origins    map[string]cid.Cid // map of abbreviated origin hashes to actual cids; workaround, should not be necessary.
 	// Index for blocks that we've created in this change.
 	createdBlocks map[string]struct{}
@@ -82,9 +86,10 @@ func New(e *hyper.Entity, signer core.KeyPair, delegation cid.Cid) (*Document, e
 		deletedBlocks: make(map[string]struct{}),
 	}
 
+	// Text chunks use the whole CID as their origin, the rest of the state uses the short one.
 	for _, c := range e.AppliedChanges() {
-		o := hyper.OriginFromCID(c.CID)
-		dm.origins[o] = c.CID
+		dm.origins[hyper.OriginFromCID(c.CID)] = c.CID
+		dm.origins[c.CID.String()] = c.CID
 	}
 
 	if err := dm.replayMoves(); err != nil {
@@ -201,6 +206,24 @@ func (dm *Document) SetAuthor(author core.Principal) error {
 	return nil
 }
 
+// SetPrivateGroup makes a new document private to the group of the content key.
+// Privacy of the document can't be changed after it's created.
+func (dm *Document) SetPrivateGroup(key hyper.ContentKey) error {
+	if _, ok := dm.e.Get("createTime"); ok {
+		return fmt.Errorf("only new documents can be made private")
+	}
+
+	dm.patch["privateGroup"] = string(key.Group)
+	dm.contentKey = &key
+
+	return nil
+}
+
+// SetContentKey sets the key to seal the changes of the document in a private group.
+func (dm *Document) SetContentKey(key hyper.ContentKey) {
+	dm.contentKey = &key
+}
+
 // SetTitle sets the title of the document.
 func (dm *Document) SetTitle(title string) error {
 	v, ok := dm.e.Get("title")
@@ -228,18 +251,33 @@ func (dm *Document) DeleteBlock(block string) error {
 }
 
 // ReplaceBlock replaces a block.
+// Block text is stored separately as RGA, so only the difference with the current text is recorded,
+// and concurrent edits of the same block are merged at character level.
 func (dm *Document) ReplaceBlock(blk *documents.Block) error {
 	if blk.Id == "" {
 		return fmt.Errorf("blocks must have ID")
 	}
 
-	blockMap, err := blockToMap(blk)
+	text, err := dm.baseText(blk.Id)
 	if err != nil {
 		return err
 	}
 
-	oldBlock, ok := dm.e.Get("blocks", blk.Id)
-	if ok && reflect.DeepEqual(oldBlock, blockMap) {
+	chunk, refs := text.Diff(blk.Text)
+	if chunk != nil {
+		colx.ObjectSet(dm.patch, []string{"text", blk.Id, "#rga"}, chunk)
+	} else {
+		// We could have changed the text in the draft before.
+		colx.ObjectDelete(dm.patch, []string{"text", blk.Id})
+	}
+
+	blockMap, err := blockToMap(blk, refs)
+	if err != nil {
+		return err
+	}
+
+	oldBlock, origin, ok := dm.e.State().GetWithOrigin("blocks", blk.Id)
+	if ok && reflect.DeepEqual(qualifyAnchors(oldBlock.(map[string]any), origin), blockMap) {
 		return nil
 	}
 
@@ -248,6 +286,28 @@ func (dm *Document) ReplaceBlock(blk *documents.Block) error {
 	return nil
 }
 
+// baseText builds the RGA of the block text from the committed changes,
+// ignoring the restored draft, because the draft patch is always computed from scratch.
+func (dm *Document) baseText(block string) (*crdt2.RGA, error) {
+	rga := crdt2.NewRGA()
+
+	var err error
+	dm.e.State().ForEachRGAChunk([]string{"text", block}, func(time int64, origin string, chunk map[string]any) bool {
+		// Restored draft is applied without origin.
+		if origin == "" {
+			return true
+		}
+
+		err = rga.Apply(time, origin, chunk)
+		return err == nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load text of block %s: %w", block, err)
+	}
+
+	return rga, nil
+}
+
 // MoveBlock moves a block.
 func (dm *Document) MoveBlock(block, parent, left string) error {
 	if parent == TrashNodeID {
@@ -295,6 +355,12 @@ func (dm *Document) Change() (hb hyper.Blob, err error) {
 
 	dm.cleanupPatch()
 
+	// Restored draft state has to be forgotten, because it will be applied again with the real origin,
+	// and the RGA can't have the same characters inserted twice.
+	if dm.oldDraft.Defined() {
+		dm.e.State().ForgetState(int64(dm.nextHLC), "")
+	}
+
 	action := dm.oldChange.Action
 	if action == "" {
 		action = "Create"
@@ -309,21 +375,26 @@ func (dm *Document) Change() (hb hyper.Blob, err error) {
 		delete(dm.patch, "isDraft")
 	}
 
-	return dm.e.CreateChange(dm.nextHLC, dm.signer, dm.delegation, dm.patch, hyper.WithAction(action))
+	opts := []hyper.ChangeOption{hyper.WithAction(action)}
+	if dm.contentKey != nil {
+		opts = append(opts, hyper.WithContentKey(*dm.contentKey))
+	}
+
+	return dm.e.CreateChange(dm.nextHLC, dm.signer, dm.delegation, dm.patch, opts...)
 }
 
-// Commit commits a change.
-func (dm *Document) Commit(ctx context.Context, bs *hyper.Storage) (hb hyper.Blob, err error) {
+// Commit commits a change as the draft of the account.
+func (dm *Document) Commit(ctx context.Context, account core.Principal, bs *hyper.Storage) (hb hyper.Blob, err error) {
 	hb, err = dm.Change()
 	if err != nil {
 		return hb, err
 	}
 
 	if dm.oldDraft.Defined() {
-		return hb, bs.ReplaceDraftBlob(ctx, dm.e.ID(), dm.oldDraft, hb)
+		return hb, bs.ReplaceDraftBlob(ctx, account, dm.e.ID(), dm.oldDraft, hb)
 	}
 
-	return hb, bs.SaveDraftBlob(ctx, dm.e.ID(), hb)
+	return hb, bs.SaveDraftBlob(ctx, account, dm.e.ID(), hb)
 }
 
 func (dm *Document) cleanupPatch() {
@@ -359,14 +430,15 @@ func (dm *Document) cleanupPatch() {
 	for blk := range dm.deletedBlocks {
 		if _, mustIgnore := dm.createdBlocks[blk]; mustIgnore {
 			colx.ObjectDelete(dm.patch, []string{"blocks", blk})
+			colx.ObjectDelete(dm.patch, []string{"text", blk})
 			continue
 		}
 	}
 
-	// Remove the blocks key from the patch if we end up with no blocks after cleanup.
-	if blocks, ok := dm.patch["blocks"].(map[string]any); ok {
-		if len(blocks) == 0 {
-			delete(dm.patch, "blocks")
+	// Remove the blocks and text keys from the patch if we end up with no blocks after cleanup.
+	for _, k := range [...]string{"blocks", "text"} {
+		if v, ok := dm.patch[k].(map[string]any); ok && len(v) == 0 {
+			delete(dm.patch, k)
 		}
 	}
 }
@@ -457,6 +529,13 @@ func (dm *Document) Hydrate(ctx context.Context, blobs *hyper.Storage) (*documen
 		blk.Children = append(blk.Children, child)
 	}
 
+	// Times of the changes, to know which one modified the block last.
+	times := make(map[string]int64, 2*len(e.AppliedChanges()))
+	for _, c := range e.AppliedChanges() {
+		times[hyper.OriginFromCID(c.CID)] = int64(c.Data.HLCTime)
+		times[c.CID.String()] = int64(c.Data.HLCTime)
+	}
+
 	var err error
 	dm.tree.mutate().walkDFT(func(m *move) bool {
 		// TODO(burdiyan): block revision would change only if block itself was changed.
@@ -467,14 +546,35 @@ func (dm *Document) Hydrate(ctx context.Context, blobs *hyper.Storage) (*documen
 			// we just skip them, we don't want to blow up here.
 			return true
 		}
+		v := mm.(map[string]any)
+
+		// Blocks created before we started to store the text as RGA have it inline.
+		var text *crdt2.RGA
+		revision := origin
+		if _, ok := v["text"]; !ok {
+			text = crdt2.NewRGA()
+			dm.e.State().ForEachRGAChunk([]string{"text", m.Block}, func(time int64, o string, chunk map[string]any) bool {
+				if err = text.Apply(time, o, chunk); err != nil {
+					return false
+				}
+				if time > times[revision] {
+					revision = o
+				}
+				return true
+			})
+			if err != nil {
+				err = fmt.Errorf("failed to load text of block %s: %w", m.Block, err)
+				return false
+			}
+		}
 
-		oo := dm.origins[origin]
+		oo := dm.origins[revision]
 		if !oo.Defined() {
 			oo = dm.oldDraft
 		}
 
 		var blk *documents.Block
-		blk, err = blockFromMap(m.Block, oo.String(), mm.(map[string]any))
+		blk, err = blockFromMap(m.Block, oo.String(), v, text, origin)
 		if err != nil {
 			return false
 		}
@@ -492,7 +592,10 @@ func (dm *Document) Hydrate(ctx context.Context, blobs *hyper.Storage) (*documen
 	return docpb, nil
 }
 
-func blockToMap(blk *documents.Block) (map[string]any, error) {
+// blockToMap converts the block into a map to store in the patch.
+// Text is stored separately, and annotation spans are anchored to the characters
+// with the corresponding text refs, so that they follow the concurrent text edits.
+func blockToMap(blk *documents.Block, refs []string) (map[string]any, error) {
 	// This is a very bad way to convert something into a map,
 	// but mapstructure package could have problems here,
 	// because protobuf have peculiar encoding of oneof fields into JSON,
@@ -511,11 +614,143 @@ func blockToMap(blk *documents.Block) (map[string]any, error) {
 	// We don't want those fields, because they can be inferred.
 	delete(v, "revision")
 	delete(v, "id")
+	delete(v, "text")
+
+	if anns, ok := v["annotations"].([]any); ok {
+		runes := utf16ToRunes(blk.Text)
+		for i, ann := range blk.Annotations {
+			if len(ann.Starts) != len(ann.Ends) {
+				return nil, fmt.Errorf("annotation %s in block %s must have the same number of starts and ends", ann.Type, blk.Id)
+			}
+
+			am := anns[i].(map[string]any)
+			delete(am, "starts")
+			delete(am, "ends")
+
+			var starts, ends []any
+			for j := range ann.Starts {
+				start, end := runes(ann.Starts[j]), runes(ann.Ends[j])
+				if start >= end {
+					continue
+				}
+				starts = append(starts, refs[start])
+				ends = append(ends, refs[end-1])
+			}
+
+			if starts != nil {
+				am["startAnchors"] = starts
+				am["endAnchors"] = ends
+			}
+		}
+	}
 
 	return v, nil
 }
 
-func blockFromMap(id, revision string, v map[string]any) (*documents.Block, error) {
+// qualifyAnchors makes annotation anchors of the block map from the given origin
+// comparable with the anchors produced by blockToMap.
+func qualifyAnchors(v map[string]any, origin string) map[string]any {
+	anns, ok := v["annotations"].([]any)
+	if !ok || origin == "" {
+		return v
+	}
+
+	qualify := func(in []any) []any {
+		out := make([]any, len(in))
+		for i, x := range in {
+			ref, _ := x.(string)
+			if strings.HasSuffix(ref, "@") {
+				ref += origin
+			}
+			out[i] = ref
+		}
+		return out
+	}
+
+	v = maps.Clone(v)
+	qanns := make([]any, len(anns))
+	for i, ann := range anns {
+		am, ok := ann.(map[string]any)
+		if !ok {
+			qanns[i] = ann
+			continue
+		}
+		am = maps.Clone(am)
+		if starts, ok := am["startAnchors"].([]any); ok {
+			am["startAnchors"] = qualify(starts)
+		}
+		if ends, ok := am["endAnchors"].([]any); ok {
+			am["endAnchors"] = qualify(ends)
+		}
+		qanns[i] = am
+	}
+	v["annotations"] = qanns
+
+	return v
+}
+
+// blockFromMap converts the block map back into a block.
+// Text must be nil for blocks with inline text, otherwise annotation anchors
+// are resolved against it, using origin of the block map for the anchors without origin.
+func blockFromMap(id, revision string, v map[string]any, text *crdt2.RGA, origin string) (*documents.Block, error) {
+	if text != nil {
+		v = maps.Clone(v)
+		s := text.String()
+		v["text"] = s
+
+		if anns, ok := v["annotations"].([]any); ok {
+			offsets := runesToUTF16(s)
+			out := make([]any, len(anns))
+			for i, ann := range anns {
+				am, ok := ann.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("annotation in block %s must be a map, got %T", id, ann)
+				}
+				am = maps.Clone(am)
+				startAnchors, _ := am["startAnchors"].([]any)
+				endAnchors, _ := am["endAnchors"].([]any)
+				delete(am, "startAnchors")
+				delete(am, "endAnchors")
+
+				var starts, ends []int
+				for j := range startAnchors {
+					if j >= len(endAnchors) {
+						break
+					}
+					sref, _ := startAnchors[j].(string)
+					eref, _ := endAnchors[j].(string)
+
+					start, _, ok := text.Find(sref, origin)
+					if !ok {
+						continue
+					}
+					end, deleted, ok := text.Find(eref, origin)
+					if !ok {
+						continue
+					}
+					// End anchor is the last character of the span.
+					if !deleted {
+						end++
+					}
+					// The whole span could have been deleted.
+					if start >= end {
+						continue
+					}
+
+					starts = append(starts, offsets[start])
+					ends = append(ends, offsets[end])
+				}
+
+				if starts != nil {
+					am["starts"] = starts
+					am["ends"] = ends
+				}
+				out[i] = am
+			}
+			v["annotations"] = out
+		}
+	}
+
 	data, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
@@ -530,3 +765,41 @@ func blockFromMap(id, revision string, v map[string]any) (*documents.Block, erro
 
 	return pb, nil
 }
+
+// utf16ToRunes returns a function that converts UTF-16 offsets of the text into rune offsets.
+// Offsets out of range are clamped to the text length.
+func utf16ToRunes(text string) func(int32) int {
+	var offsets []int
+	for i, r := range []rune(text) {
+		offsets = append(offsets, i)
+		if r >= 0x10000 {
+			// Surrogate pairs take two code units.
+			offsets = append(offsets, i)
+		}
+	}
+	total := utf8.RuneCountInString(text)
+
+	return func(o int32) int {
+		if o < 0 {
+			return 0
+		}
+		if int(o) >= len(offsets) {
+			return total
+		}
+		return offsets[o]
+	}
+}
+
+// runesToUTF16 returns UTF-16 offsets for each rune offset of the text, including the end of the text.
+func runesToUTF16(text string) []int {
+	offsets := make([]int, 0, len(text)+1)
+	var n int
+	for _, r := range text {
+		offsets = append(offsets, n)
+		n++
+		if r >= 0x10000 {
+			n++
+		}
+	}
+	return append(offsets, n)
+}
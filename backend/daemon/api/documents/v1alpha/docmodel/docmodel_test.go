@@ -11,6 +11,7 @@ import (
 	"mintter/backend/hyper"
 	"mintter/backend/logging"
 	"mintter/backend/pkg/must"
+	"mintter/backend/testutil"
 	"testing"
 	"time"
 
@@ -62,14 +63,14 @@ func TestDocument_LoadingDrafts(t *testing.T) {
 	ctx := context.Background()
 
 	dm := newTestDocModel(t, blobs, alice.Account, alice.Device)
-	_, err := dm.Commit(ctx, blobs)
+	_, err := dm.Commit(ctx, alice.Account.Principal(), blobs)
 	require.NoError(t, err)
 
 	entity, err := blobs.LoadEntity(ctx, dm.e.ID())
 	require.NoError(t, err)
 	require.Nil(t, entity)
 
-	entity, err = blobs.LoadDraftEntity(ctx, dm.e.ID())
+	entity, err = blobs.LoadDraftEntity(ctx, alice.Account.Principal(), dm.e.ID())
 	require.NoError(t, err)
 	require.NotNil(t, entity)
 }
@@ -100,11 +101,17 @@ func TestDocument_DeleteTurnaround(t *testing.T) {
 		"blocks": map[string]any{
 			"b2": map[string]any{
 				"#map": map[string]any{
-					"text": "Hi there!",
 					"type": "statement",
 				},
 			},
 		},
+		"text": map[string]any{
+			"b2": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "", "v": "Hi there!"}},
+				},
+			},
+		},
 		"moves": map[string]any{
 			"#list": map[string]any{
 				"#ins": []any{
@@ -120,6 +127,7 @@ func TestDocument_DeleteTurnaround(t *testing.T) {
 
 	require.Equal(t, want["moves"], dm.patch["moves"])
 	require.Equal(t, want["blocks"], dm.patch["blocks"])
+	require.Equal(t, want["text"], dm.patch["text"])
 }
 
 func TestDocument_Cleanup(t *testing.T) {
@@ -160,23 +168,37 @@ func TestDocument_Cleanup(t *testing.T) {
 		"blocks": map[string]any{
 			"b2": map[string]any{
 				"#map": map[string]any{
-					"text": "Hi there!",
 					"type": "statement",
 				},
 			},
 			"b3": map[string]any{
 				"#map": map[string]any{
-					"text": "New Front",
 					"type": "statement",
 				},
 			},
 			"b4": map[string]any{
 				"#map": map[string]any{
-					"text": "New Front 2",
 					"type": "statement",
 				},
 			},
 		},
+		"text": map[string]any{
+			"b2": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "", "v": "Hi there!"}},
+				},
+			},
+			"b3": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "", "v": "New Front"}},
+				},
+			},
+			"b4": map[string]any{
+				"#rga": map[string]any{
+					"#ins": []any{map[string]any{"r": "", "v": "New Front 2"}},
+				},
+			},
+		},
 		"moves": map[string]any{
 			"#list": map[string]any{
 				"#ins": []any{
@@ -202,6 +224,7 @@ func TestDocument_Cleanup(t *testing.T) {
 
 	require.Equal(t, want["moves"], dm.patch["moves"])
 	require.Equal(t, want["blocks"], dm.patch["blocks"])
+	require.Equal(t, want["text"], dm.patch["text"])
 }
 
 func TestDocumentUpdatePublished(t *testing.T) {
@@ -213,11 +236,17 @@ func TestDocumentUpdatePublished(t *testing.T) {
 
 	require.NoError(t, dm.SetTitle("My document"))
 
-	hb, err := dm.Commit(ctx, blobs)
+	hb, err := dm.Commit(ctx, alice.Account.Principal(), blobs)
 	require.NoError(t, err)
 
-	_, err = blobs.PublishDraft(ctx, dm.e.ID())
+	updates := blobs.IndexUpdates()
+	_, err = blobs.PublishDraft(ctx, alice.Account.Principal(), dm.e.ID())
 	require.NoError(t, err)
+	select {
+	case <-updates:
+	default:
+		t.Fatal("publishing must notify the index listeners")
+	}
 
 	entity, err := blobs.LoadEntity(ctx, dm.e.ID())
 	require.NoError(t, err)
@@ -237,7 +266,7 @@ func TestDocumentUpdatePublished(t *testing.T) {
 	// require.NoError(t, err)
 
 	// require.NoError(t, dm.SetTitle("My changed title"))
-	// hb2, err := dm.Commit(ctx, blobs)
+	// hb2, err := dm.Commit(ctx, alice.Account.Principal(), blobs)
 	// require.NoError(t, err)
 	// require.Equal(t, []cid.Cid{hb.CID}, hb2.Decoded.(hyper.Change).Deps, "new change must have old one in deps")
 }
@@ -438,6 +467,187 @@ func TestBug_UndeletableBlock(t *testing.T) {
 	require.Equal(t, want, c2.Decoded.(hyper.Change).Patch, "c2 must delete a block")
 }
 
+func TestDocument_ConcurrentTextEdits(t *testing.T) {
+	t.Parallel()
+
+	alice := coretest.NewTester("alice")
+	db := storage.MakeTestDB(t)
+	blobs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
+	ctx := context.Background()
+
+	bold := func(start, end int32) []*documents.Annotation {
+		return []*documents.Annotation{{Type: "strong", Starts: []int32{start}, Ends: []int32{end}}}
+	}
+
+	// Alice creates a block with some formatted text.
+	var c1 hyper.Blob
+	{
+		model := newTestDocModel(t, blobs, alice.Account, alice.Device)
+		must.Do(model.MoveBlock("b1", "", ""))
+		must.Do(model.ReplaceBlock(&documents.Block{
+			Id:          "b1",
+			Type:        "paragraph",
+			Text:        "Hello World",
+			Annotations: bold(6, 11),
+		}))
+		c1 = must.Do2(model.Change())
+	}
+
+	edit := func(blk *documents.Block) hyper.Blob {
+		entity := hyper.NewEntity(c1.Decoded.(hyper.Change).Entity)
+		must.Do(entity.ApplyChange(c1.CID, c1.Decoded.(hyper.Change)))
+		model := must.Do2(New(entity, alice.Device, c1.Decoded.(hyper.Change).Delegation))
+		model.nextHLC = entity.NextTimestamp()
+		must.Do(model.ReplaceBlock(blk))
+		return must.Do2(model.Change())
+	}
+
+	// Two replicas edit the same block concurrently.
+	c2 := edit(&documents.Block{
+		Id:          "b1",
+		Type:        "paragraph",
+		Text:        "Hello brave World",
+		Annotations: bold(12, 17),
+	})
+	c3 := edit(&documents.Block{
+		Id:          "b1",
+		Type:        "paragraph",
+		Text:        "Hello World!",
+		Annotations: bold(6, 11),
+	})
+
+	_, ok := c3.Decoded.(hyper.Change).Patch["blocks"]
+	require.False(t, ok, "changing only text must not replace the block attributes")
+
+	entity := hyper.NewEntity(c1.Decoded.(hyper.Change).Entity)
+	must.Do(entity.ApplyChange(c1.CID, c1.Decoded.(hyper.Change)))
+	must.Do(entity.ApplyChange(c2.CID, c2.Decoded.(hyper.Change)))
+	must.Do(entity.ApplyChange(c3.CID, c3.Decoded.(hyper.Change)))
+
+	model := must.Do2(New(entity, alice.Device, c1.Decoded.(hyper.Change).Delegation))
+	doc, err := model.Hydrate(ctx, blobs)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Children, 1)
+	blk := doc.Children[0].Block
+	require.Equal(t, "Hello brave World!", blk.Text, "concurrent text edits must be merged")
+	testutil.ProtoEqual(t, &documents.Block{Annotations: bold(12, 17)}, &documents.Block{Annotations: blk.Annotations}, "annotations must follow the merged text")
+	require.Equal(t, c3.CID.String(), blk.Revision, "block revision must point to the last text change")
+}
+
+func TestDocument_ConcurrentDeleteAndInsert(t *testing.T) {
+	t.Parallel()
+
+	alice := coretest.NewTester("alice")
+	db := storage.MakeTestDB(t)
+	blobs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
+	ctx := context.Background()
+
+	var c1 hyper.Blob
+	{
+		model := newTestDocModel(t, blobs, alice.Account, alice.Device)
+		must.Do(model.MoveBlock("b1", "", ""))
+		must.Do(model.ReplaceBlock(&documents.Block{
+			Id:   "b1",
+			Type: "paragraph",
+			Text: "The quick brown fox",
+			Annotations: []*documents.Annotation{
+				{Type: "emphasis", Starts: []int32{4}, Ends: []int32{9}},
+				{Type: "strong", Starts: []int32{10}, Ends: []int32{15}},
+			},
+		}))
+		c1 = must.Do2(model.Change())
+	}
+
+	edit := func(text string, anns ...*documents.Annotation) hyper.Blob {
+		entity := hyper.NewEntity(c1.Decoded.(hyper.Change).Entity)
+		must.Do(entity.ApplyChange(c1.CID, c1.Decoded.(hyper.Change)))
+		model := must.Do2(New(entity, alice.Device, c1.Decoded.(hyper.Change).Delegation))
+		model.nextHLC = entity.NextTimestamp()
+		must.Do(model.ReplaceBlock(&documents.Block{Id: "b1", Type: "paragraph", Text: text, Annotations: anns}))
+		return must.Do2(model.Change())
+	}
+
+	// One replica removes the first word with its formatting,
+	// while the other one inserts some text in the middle of it.
+	c2 := edit("The brown fox", &documents.Annotation{Type: "strong", Starts: []int32{4}, Ends: []int32{9}})
+	c3 := edit("The qu!!ick brown fox",
+		&documents.Annotation{Type: "emphasis", Starts: []int32{4}, Ends: []int32{11}},
+		&documents.Annotation{Type: "strong", Starts: []int32{12}, Ends: []int32{17}},
+	)
+
+	entity := hyper.NewEntity(c1.Decoded.(hyper.Change).Entity)
+	must.Do(entity.ApplyChange(c1.CID, c1.Decoded.(hyper.Change)))
+	must.Do(entity.ApplyChange(c2.CID, c2.Decoded.(hyper.Change)))
+	must.Do(entity.ApplyChange(c3.CID, c3.Decoded.(hyper.Change)))
+
+	model := must.Do2(New(entity, alice.Device, c1.Decoded.(hyper.Change).Delegation))
+	doc, err := model.Hydrate(ctx, blobs)
+	require.NoError(t, err)
+
+	blk := doc.Children[0].Block
+	require.Equal(t, "The !!brown fox", blk.Text, "only the concurrently inserted text must survive the deletion")
+	testutil.ProtoEqual(t, &documents.Block{
+		Annotations: []*documents.Annotation{{Type: "strong", Starts: []int32{6}, Ends: []int32{11}}},
+	}, &documents.Block{Annotations: blk.Annotations}, "annotations must follow the merged text")
+}
+
+func TestDocument_LegacyInlineText(t *testing.T) {
+	t.Parallel()
+
+	alice := coretest.NewTester("alice")
+	db := storage.MakeTestDB(t)
+	blobs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
+	ctx := context.Background()
+
+	// Blocks used to be stored with the text inline.
+	model := newTestDocModel(t, blobs, alice.Account, alice.Device)
+	must.Do(model.MoveBlock("b1", "", ""))
+	model.patch["blocks"] = map[string]any{
+		"b1": map[string]any{
+			"#map": map[string]any{
+				"type": "paragraph",
+				"text": "Hello World",
+				"annotations": []any{
+					map[string]any{"type": "strong", "starts": []any{6}, "ends": []any{11}},
+				},
+			},
+		},
+	}
+	c1 := must.Do2(model.Change())
+
+	entity := hyper.NewEntity(c1.Decoded.(hyper.Change).Entity)
+	must.Do(entity.ApplyChange(c1.CID, c1.Decoded.(hyper.Change)))
+	model = must.Do2(New(entity, alice.Device, c1.Decoded.(hyper.Change).Delegation))
+	model.nextHLC = entity.NextTimestamp()
+	must.Do(model.ReplaceBlock(&documents.Block{
+		Id:          "b1",
+		Type:        "paragraph",
+		Text:        "Hello World!",
+		Annotations: []*documents.Annotation{{Type: "strong", Starts: []int32{6}, Ends: []int32{11}}},
+	}))
+	c2 := must.Do2(model.Change())
+
+	for _, changes := range [][]hyper.Blob{{c1}, {c1, c2}} {
+		entity := hyper.NewEntity(c1.Decoded.(hyper.Change).Entity)
+		for _, c := range changes {
+			must.Do(entity.ApplyChange(c.CID, c.Decoded.(hyper.Change)))
+		}
+		model := must.Do2(New(entity, alice.Device, c1.Decoded.(hyper.Change).Delegation))
+		doc, err := model.Hydrate(ctx, blobs)
+		require.NoError(t, err)
+
+		want := "Hello World"
+		if len(changes) > 1 {
+			want = "Hello World!"
+		}
+		require.Equal(t, want, doc.Children[0].Block.Text)
+		testutil.ProtoEqual(t, &documents.Block{
+			Annotations: []*documents.Annotation{{Type: "strong", Starts: []int32{6}, Ends: []int32{11}}},
+		}, &documents.Block{Annotations: doc.Children[0].Block.Annotations}, "annotations must match")
+	}
+}
+
 func newTestDocModel(t *testing.T, blobs *hyper.Storage, account, device core.KeyPair) *Document {
 	clock := hlc.NewClock()
 	ts := clock.MustNow()
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"mintter/backend/core"
@@ -20,7 +21,6 @@ import (
 
 	"mintter/backend/hyper"
 	"mintter/backend/hyper/hypersql"
-	"mintter/backend/logging"
 	"mintter/backend/pkg/colx"
 	"mintter/backend/pkg/dqb"
 	"mintter/backend/pkg/future"
@@ -61,12 +61,12 @@ type Server struct {
 }
 
 // NewServer creates a new RPC handler.
-func NewServer(me *future.ReadOnly[core.Identity], db *sqlitex.Pool, disc Discoverer, gwClient GatewayClient, LogLevel string) *Server {
+func NewServer(me *future.ReadOnly[core.Identity], db *sqlitex.Pool, blobs *hyper.Storage, disc Discoverer, gwClient GatewayClient) *Server {
 	srv := &Server{
 		db:       db,
 		me:       me,
 		disc:     disc,
-		blobs:    hyper.NewStorage(db, logging.New("mintter/hyper", LogLevel)),
+		blobs:    blobs,
 		gwClient: gwClient,
 	}
 
@@ -75,15 +75,19 @@ func NewServer(me *future.ReadOnly[core.Identity], db *sqlitex.Pool, disc Discov
 
 // CreateDraft implements the corresponding gRPC method.
 func (api *Server) CreateDraft(ctx context.Context, in *documents.CreateDraftRequest) (out *documents.Document, err error) {
-	me, err := api.getMe()
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if in.ExistingDocumentId != "" && in.PrivateGroupId != "" {
+		return nil, status.Errorf(codes.InvalidArgument, "existing documents can't be made private")
+	}
+
 	if in.ExistingDocumentId != "" {
 		eid := hyper.EntityID(in.ExistingDocumentId)
 
-		_, err := api.blobs.FindDraft(ctx, eid)
+		_, err := api.blobs.FindDraft(ctx, me.Account().Principal(), eid)
 		if err == nil {
 			return nil, status.Errorf(codes.FailedPrecondition, "draft for %s already exists", in.ExistingDocumentId)
 		}
@@ -111,16 +115,25 @@ func (api *Server) CreateDraft(ctx context.Context, in *documents.CreateDraftReq
 			return nil, err
 		}
 
+		opts := []hyper.ChangeOption{hyper.WithAction("Update")}
+		key, err := api.contentKey(ctx, eid)
+		if err != nil {
+			return nil, err
+		}
+		if key != nil {
+			opts = append(opts, hyper.WithContentKey(*key))
+		}
+
 		hb, err := entity.CreateChange(entity.NextTimestamp(), me.DeviceKey(), del, map[string]any{
 			// Using the dummy field which will be cleared in future updates,
 			// because all changes must have patches.
 			"isDraft": true,
-		}, hyper.WithAction("Update"))
+		}, opts...)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := api.blobs.SaveDraftBlob(ctx, eid, hb); err != nil {
+		if err := api.blobs.SaveDraftBlob(ctx, me.Account().Principal(), eid, hb); err != nil {
 			return nil, err
 		}
 
@@ -137,7 +150,18 @@ func (api *Server) CreateDraft(ctx context.Context, in *documents.CreateDraftReq
 		return nil, err
 	}
 
-	_, err = dm.Commit(ctx, api.blobs)
+	if in.PrivateGroupId != "" {
+		key, err := api.blobs.CurrentContentKey(ctx, hyper.EntityID(in.PrivateGroupId))
+		if err != nil {
+			return nil, contentKeyError(err)
+		}
+
+		if err := dm.SetPrivateGroup(key); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = dm.Commit(ctx, me.Account().Principal(), api.blobs)
 	if err != nil {
 		return nil, err
 	}
@@ -157,14 +181,14 @@ func (api *Server) UpdateDraft(ctx context.Context, in *documents.UpdateDraftReq
 		return nil, status.Errorf(codes.InvalidArgument, "must send some changes to apply to the document")
 	}
 
-	me, err := api.me.Await(ctx)
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	eid := hyper.EntityID(in.DocumentId)
 
-	draft, err := api.blobs.LoadDraft(ctx, eid)
+	draft, err := api.blobs.LoadDraft(ctx, me.Account().Principal(), eid)
 	if err != nil {
 		return nil, err
 	}
@@ -186,6 +210,14 @@ func (api *Server) UpdateDraft(ctx context.Context, in *documents.UpdateDraftReq
 		return nil, fmt.Errorf("failed to restore draft: %w", err)
 	}
 
+	key, err := api.contentKey(ctx, eid)
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		mut.SetContentKey(*key)
+	}
+
 	for _, op := range in.Changes {
 		switch o := op.Op.(type) {
 		case *documents.DocumentChange_SetTitle:
@@ -209,7 +241,7 @@ func (api *Server) UpdateDraft(ctx context.Context, in *documents.UpdateDraftReq
 		}
 	}
 
-	blob, err := mut.Commit(ctx, api.blobs)
+	blob, err := mut.Commit(ctx, me.Account().Principal(), api.blobs)
 	if err != nil {
 		return nil, err
 	}
@@ -231,16 +263,16 @@ func (api *Server) GetDraft(ctx context.Context, in *documents.GetDraftRequest)
 		return nil, status.Errorf(codes.InvalidArgument, "must specify document ID to get the draft")
 	}
 
-	me, err := api.getMe()
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	eid := hyper.EntityID(in.DocumentId)
 
-	entity, err := api.blobs.LoadDraftEntity(ctx, eid)
+	entity, err := api.blobs.LoadDraftEntity(ctx, me.Account().Principal(), eid)
 	if err != nil {
-		return nil, err
+		return nil, contentKeyError(err)
 	}
 	if entity == nil {
 		return nil, status.Errorf(codes.NotFound, "not found draft for entity %s", eid)
@@ -261,7 +293,7 @@ func (api *Server) GetDraft(ctx context.Context, in *documents.GetDraftRequest)
 
 // ListDrafts implements the corresponding gRPC method.
 func (api *Server) ListDrafts(ctx context.Context, req *documents.ListDraftsRequest) (*documents.ListDraftsResponse, error) {
-	me, err := api.getMe()
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -277,8 +309,11 @@ func (api *Server) ListDrafts(ctx context.Context, req *documents.ListDraftsRequ
 
 	var cursor Cursor
 	if req.PageToken == "" {
-		cursor.Ts = math.MaxInt64
-		cursor.Resource = math.MaxInt64
+		// Without this querying in the default descending order wouldn't ever return any results.
+		if !req.ReverseOrder {
+			cursor.Ts = math.MaxInt64
+			cursor.Resource = math.MaxInt64
+		}
 	} else {
 		if err := apiutil.DecodePageToken(req.PageToken, &cursor, me.DeviceKey()); err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
@@ -292,7 +327,7 @@ func (api *Server) ListDrafts(ctx context.Context, req *documents.ListDraftsRequ
 			count      int32
 			lastCursor Cursor
 		)
-		return sqlitex.Exec(conn, qListAllDrafts(), func(stmt *sqlite.Stmt) error {
+		return sqlitex.Exec(conn, qListAllDrafts(req.ReverseOrder), func(stmt *sqlite.Stmt) error {
 			// This is necessary to always return empty page token when we reach the last result.
 			if count == req.PageSize {
 				var err error
@@ -335,7 +370,7 @@ func (api *Server) ListDrafts(ctx context.Context, req *documents.ListDraftsRequ
 			resp.Documents = append(resp.Documents, doc)
 
 			return nil
-		}, cursor.Ts, cursor.Resource, req.PageSize)
+		}, cursor.Ts, cursor.Resource, me.Account().Principal(), req.PageSize)
 	}); err != nil {
 		return nil, err
 	}
@@ -343,7 +378,23 @@ func (api *Server) ListDrafts(ctx context.Context, req *documents.ListDraftsRequ
 	return resp, nil
 }
 
-var qListAllDrafts = dqb.Str(`
+func qListAllDrafts(reverse bool) string {
+	if reverse {
+		return qListAllDraftsAsc()
+	}
+
+	return qListAllDraftsDesc()
+}
+
+var qListAllDraftsAsc = dqb.Q(func() string {
+	return fmt.Sprintf(qListAllDraftsTpl, ">", "ASC", "ASC", "ASC", "ASC")
+})
+
+var qListAllDraftsDesc = dqb.Q(func() string {
+	return fmt.Sprintf(qListAllDraftsTpl, "<", "DESC", "DESC", "DESC", "DESC")
+})
+
+const qListAllDraftsTpl = `
 	WITH RECURSIVE
 	-- Finding the drafts we want, sorted as desired in the output,
 	-- and filtered out to find the requested page.
@@ -351,8 +402,9 @@ var qListAllDrafts = dqb.Str(`
 		SELECT structural_blobs.*
 		FROM drafts
 		JOIN structural_blobs ON structural_blobs.id = drafts.blob
-		WHERE structural_blobs.ts < :cursor_ts AND drafts.resource < :cursor_resource
-		ORDER BY structural_blobs.ts DESC, structural_blobs.resource DESC
+		WHERE (structural_blobs.ts, drafts.resource) %s (:cursor_ts, :cursor_resource)
+		AND drafts.account = (SELECT id FROM public_keys WHERE principal = :account)
+		ORDER BY structural_blobs.ts %s, drafts.resource %s
 		LIMIT :page_size + 1
 	),
 	-- Resolving the DAG of changes for each document
@@ -381,14 +433,19 @@ var qListAllDrafts = dqb.Str(`
 	JOIN resources ON resources.id = cset.resource
 	JOIN public_keys authors ON authors.id = resources.owner
 	GROUP BY resource
-	ORDER BY update_time DESC, resources.id DESC;
-`)
+	ORDER BY update_time %s, resources.id %s;
+`
 
 func (api *Server) ListDocumentDrafts(ctx context.Context, in *documents.ListDocumentDraftsRequest) (*documents.ListDocumentDraftsResponse, error) {
 	if in.DocumentId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "must specify document ID to get the draft")
 	}
 
+	me, err := api.getMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := &documents.ListDocumentDraftsResponse{}
 
 	if err := api.db.WithSave(ctx, func(conn *sqlite.Conn) error {
@@ -429,7 +486,7 @@ func (api *Server) ListDocumentDrafts(ctx context.Context, in *documents.ListDoc
 			resp.Drafts = append(resp.Drafts, doc)
 
 			return err
-		}, edb.ResourcesID)
+		}, edb.ResourcesID, me.Account().Principal())
 	}); err != nil {
 		return nil, err
 	}
@@ -443,6 +500,7 @@ var qListDocumentDrafts = dqb.Str(`
 	cset (id) AS (
 		SELECT blob FROM drafts
 		WHERE resource = :resource
+		AND account = (SELECT id FROM public_keys WHERE principal = :account)
 		UNION
 		SELECT blob_links.target
 		FROM blob_links
@@ -477,7 +535,12 @@ func (api *Server) PublishDraft(ctx context.Context, in *documents.PublishDraftR
 		return nil, fmt.Errorf("failed to convert document to CID: %w", err)
 	}
 
-	ch, err := api.blobs.GetDraft(ctx, eid)
+	me, err := api.getMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := api.blobs.GetDraft(ctx, me.Account().Principal(), eid)
 	if err != nil {
 		return nil, err
 	}
@@ -493,7 +556,7 @@ func (api *Server) PublishDraft(ctx context.Context, in *documents.PublishDraftR
 	// so instead we act as if we published something, but return the previous version instead,
 	// while deleting the current draft.
 	if len(ch.Patch) <= 1 && isDraft {
-		if err := api.blobs.DeleteDraft(ctx, eid); err != nil {
+		if err := api.blobs.DeleteDraft(ctx, me.Account().Principal(), eid); err != nil {
 			return nil, fmt.Errorf("failed to delete empty draft when publishing: %w", err)
 		}
 		prev := hyper.NewVersion(ch.Deps...)
@@ -504,7 +567,7 @@ func (api *Server) PublishDraft(ctx context.Context, in *documents.PublishDraftR
 		})
 	}
 
-	c, err := api.blobs.PublishDraft(ctx, eid)
+	c, err := api.blobs.PublishDraft(ctx, me.Account().Principal(), eid)
 	if err != nil {
 		return nil, err
 	}
@@ -528,9 +591,14 @@ func (api *Server) DeleteDraft(ctx context.Context, in *documents.DeleteDraftReq
 		return nil, status.Errorf(codes.InvalidArgument, "must specify draft ID to delete")
 	}
 
+	me, err := api.getMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	eid := hyper.EntityID(in.DocumentId)
 
-	if err := api.blobs.DeleteDraft(ctx, eid); err != nil {
+	if err := api.blobs.DeleteDraft(ctx, me.Account().Principal(), eid); err != nil {
 		return nil, err
 	}
 
@@ -582,7 +650,30 @@ func (api *Server) GetPublication(ctx context.Context, in *documents.GetPublicat
 }
 
 func (api *Server) loadPublication(ctx context.Context, docid hyper.EntityID, version hyper.Version) (docpb *documents.Publication, err error) {
-	var entity *hyper.Entity
+	mut, err := api.loadDocument(ctx, docid, version)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := mut.Hydrate(ctx, api.blobs)
+	if err != nil {
+		return nil, err
+	}
+	doc.PublishTime = doc.UpdateTime
+
+	return &documents.Publication{
+		Document: doc,
+		Version:  doc.Version,
+	}, nil
+}
+
+// loadDocument loads the published document at the given version,
+// or at the latest known version if version is empty.
+func (api *Server) loadDocument(ctx context.Context, docid hyper.EntityID, version hyper.Version) (*docmodel.Document, error) {
+	var (
+		entity *hyper.Entity
+		err    error
+	)
 	if version != "" {
 		heads, err := hyper.Version(version).Parse()
 		if err != nil {
@@ -591,19 +682,19 @@ func (api *Server) loadPublication(ctx context.Context, docid hyper.EntityID, ve
 
 		entity, err = api.blobs.LoadEntityFromHeads(ctx, docid, heads...)
 		if err != nil {
-			return nil, err
+			return nil, contentKeyError(err)
 		}
 	} else {
 		entity, err = api.blobs.LoadEntity(ctx, docid)
 		if err != nil {
-			return nil, err
+			return nil, contentKeyError(err)
 		}
 	}
 	if entity == nil {
 		return nil, status.Errorf(codes.NotFound, "no published changes for entity %s", docid)
 	}
 
-	me, err := api.getMe()
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -613,21 +704,7 @@ func (api *Server) loadPublication(ctx context.Context, docid hyper.EntityID, ve
 		return nil, err
 	}
 
-	mut, err := docmodel.New(entity, me.DeviceKey(), del)
-	if err != nil {
-		return nil, err
-	}
-
-	doc, err := mut.Hydrate(ctx, api.blobs)
-	if err != nil {
-		return nil, err
-	}
-	doc.PublishTime = doc.UpdateTime
-
-	return &documents.Publication{
-		Document: doc,
-		Version:  doc.Version,
-	}, nil
+	return docmodel.New(entity, me.DeviceKey(), del)
 }
 
 // PushPublication implements the corresponding gRPC method.
@@ -768,6 +845,7 @@ var qListTrustedPublications = dqb.Str(`
 			resources.owner
 		FROM resources
 		JOIN trusted_accounts ON trusted_accounts.id = resources.owner
+			AND trusted_accounts.account = (SELECT id FROM public_keys WHERE principal = :account)
 		JOIN structural_blobs sb ON sb.resource = resources.id AND resources.owner = sb.author
 		LEFT JOIN drafts ON drafts.blob = sb.id
 		WHERE resources.iri GLOB 'hm://d/*'
@@ -806,9 +884,9 @@ var qListTrustedPublications = dqb.Str(`
 
 // ListPublications implements the corresponding gRPC method.
 func (api *Server) ListPublications(ctx context.Context, in *documents.ListPublicationsRequest) (*documents.ListPublicationsResponse, error) {
-	me, ok := api.me.Get()
-	if !ok {
-		return nil, fmt.Errorf("account is not initialized yet")
+	me, err := api.getMe(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
@@ -838,9 +916,12 @@ func (api *Server) ListPublications(ctx context.Context, in *documents.ListPubli
 			lastCursor Cursor
 		)
 
+		args := []any{cursor.UpdateTime, cursor.IRI, in.PageSize}
+
 		q := qListAllPublications
 		if in.TrustedOnly {
 			q = qListTrustedPublications
+			args = append([]any{me.Account().Principal()}, args...)
 		}
 
 		return sqlitex.Exec(conn, q(), func(stmt *sqlite.Stmt) error {
@@ -886,7 +967,7 @@ func (api *Server) ListPublications(ctx context.Context, in *documents.ListPubli
 			resp.Publications = append(resp.Publications, pub)
 
 			return nil
-		}, cursor.UpdateTime, cursor.IRI, in.PageSize)
+		}, args...)
 	}); err != nil {
 		return nil, err
 	}
@@ -949,7 +1030,7 @@ func (api *Server) ListAccountPublications(ctx context.Context, in *documents.Li
 
 // MergeChanges implements the corresponding gRPC method. It merges changes and publishes them.
 func (api *Server) MergeChanges(ctx context.Context, in *documents.MergeChangesRequest) (*documents.Publication, error) {
-	me, err := api.getMe()
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -984,8 +1065,17 @@ func (api *Server) MergeChanges(ctx context.Context, in *documents.MergeChangesR
 	if err != nil {
 		return nil, err
 	}
-	hb, err := entity.CreateChange(entity.NextTimestamp(), me.DeviceKey(), del, nil, hyper.WithAction(hyper.ActionUpdate))
 
+	opts := []hyper.ChangeOption{hyper.WithAction(hyper.ActionUpdate)}
+	key, err := api.contentKey(ctx, entity.ID())
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		opts = append(opts, hyper.WithContentKey(*key))
+	}
+
+	hb, err := entity.CreateChange(entity.NextTimestamp(), me.DeviceKey(), del, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1014,7 +1104,7 @@ func (api *Server) MergeChanges(ctx context.Context, in *documents.MergeChangesR
 
 // RebaseChanges implements the corresponding gRPC method.
 func (api *Server) RebaseChanges(ctx context.Context, in *documents.RebaseChangesRequest) (*documents.Document, error) {
-	me, err := api.getMe()
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1025,7 +1115,7 @@ func (api *Server) RebaseChanges(ctx context.Context, in *documents.RebaseChange
 	}
 
 	allHeads := []cid.Cid{}
-	draft, err := api.blobs.LoadDraft(ctx, hyper.EntityID(in.BaseDraftId))
+	draft, err := api.blobs.LoadDraft(ctx, me.Account().Principal(), hyper.EntityID(in.BaseDraftId))
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Could not load draft with provided ID [%s]: %v", in.BaseDraftId, err)
 	}
@@ -1058,16 +1148,40 @@ func (api *Server) RebaseChanges(ctx context.Context, in *documents.RebaseChange
 	return mut.Hydrate(ctx, api.blobs)
 }
 
-func (api *Server) getMe() (core.Identity, error) {
-	me, ok := api.me.Get()
-	if !ok {
-		return core.Identity{}, status.Errorf(codes.FailedPrecondition, "account is not initialized yet")
+// contentKey returns the current content key of the private group the document belongs to,
+// or nil if the document is public.
+func (api *Server) contentKey(ctx context.Context, eid hyper.EntityID) (*hyper.ContentKey, error) {
+	group, err := api.blobs.EntityPrivateGroup(ctx, eid)
+	if err != nil {
+		return nil, err
+	}
+	if group == "" {
+		return nil, nil
+	}
+
+	key, err := api.blobs.CurrentContentKey(ctx, group)
+	if err != nil {
+		return nil, contentKeyError(err)
 	}
-	return me, nil
+
+	return &key, nil
+}
+
+// contentKeyError converts errors about missing content keys into permission errors,
+// because it means we are not a member of the private group.
+func contentKeyError(err error) error {
+	if errors.Is(err, hyper.ErrNoContentKey) {
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+	return err
+}
+
+func (api *Server) getMe(ctx context.Context) (core.Identity, error) {
+	return apiutil.Identity(ctx, api.me)
 }
 
 func (api *Server) getDelegation(ctx context.Context) (cid.Cid, error) {
-	me, err := api.getMe()
+	me, err := api.getMe(ctx)
 	if err != nil {
 		return cid.Undef, err
 	}
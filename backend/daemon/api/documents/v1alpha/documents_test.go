@@ -2,21 +2,30 @@ package documents
 
 import (
 	"context"
+	"fmt"
 	"mintter/backend/core"
 	"mintter/backend/core/coretest"
 	daemon "mintter/backend/daemon/api/daemon/v1alpha"
+	groups "mintter/backend/daemon/api/groups/v1alpha"
+	"mintter/backend/daemon/apiutil"
 	"mintter/backend/daemon/storage"
 	documents "mintter/backend/genproto/documents/v1alpha"
+	groupspb "mintter/backend/genproto/groups/v1alpha"
 	"mintter/backend/hyper"
 	"mintter/backend/logging"
+	"mintter/backend/mttnet"
 	"mintter/backend/pkg/future"
+	"mintter/backend/syncing"
 	"mintter/backend/testutil"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -482,6 +491,46 @@ func TestListDrafts(t *testing.T) {
 	testutil.ProtoEqual(t, updated, list.Documents[0], "must have draft in the list")
 }
 
+func TestListDraftsPagination(t *testing.T) {
+	t.Parallel()
+
+	api := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	var want []string
+	for i := 0; i < 5; i++ {
+		draft, err := api.CreateDraft(ctx, &documents.CreateDraftRequest{})
+		require.NoError(t, err)
+		updateDraft(ctx, t, api, draft.Id, []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetTitle{SetTitle: fmt.Sprintf("Draft %d", i)}},
+		})
+		want = append(want, draft.Id)
+	}
+
+	listAll := func(reverse bool) (out []string) {
+		req := &documents.ListDraftsRequest{PageSize: 2, ReverseOrder: reverse}
+		for {
+			list, err := api.ListDrafts(ctx, req)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(list.Documents), 2, "page must not exceed page size")
+			for _, doc := range list.Documents {
+				out = append(out, doc.Id)
+			}
+			if list.NextPageToken == "" {
+				return out
+			}
+			req.PageToken = list.NextPageToken
+		}
+	}
+
+	require.Equal(t, want, listAll(true), "reverse order must list oldest drafts first")
+
+	newest := listAll(false)
+	for i := range newest {
+		require.Equal(t, want[len(want)-1-i], newest[i], "default order must list newest drafts first")
+	}
+}
+
 func TestAPIPublishDraft_E2E(t *testing.T) {
 	t.Parallel()
 
@@ -942,6 +991,210 @@ func TestGetPreviousVersions(t *testing.T) {
 	testutil.ProtoEqual(t, p, pub1, "latest publication must match getting by version string")
 }
 
+func TestDraftsAndTrustPerAccount(t *testing.T) {
+	t.Parallel()
+
+	api := newTestDocsAPI(t, "alice")
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	bobCtx := apiutil.ContextWithAccount(ctx, bob.Account.PublicKey)
+
+	// Bob's account is registered on the same device.
+	_, err := daemon.Register(ctx, api.blobs, bob.Account, alice.Device.PublicKey, time.Now())
+	require.NoError(t, err)
+
+	draft, err := api.CreateDraft(ctx, &documents.CreateDraftRequest{})
+	require.NoError(t, err)
+
+	list, err := api.ListDrafts(bobCtx, &documents.ListDraftsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Documents, 0, "bob must not see alice's drafts")
+
+	_, err = api.GetDraft(bobCtx, &documents.GetDraftRequest{DocumentId: draft.Id})
+	require.Error(t, err, "bob must not get alice's draft")
+
+	bobDraft, err := api.CreateDraft(bobCtx, &documents.CreateDraftRequest{})
+	require.NoError(t, err)
+	require.Equal(t, bob.Account.Principal().String(), bobDraft.Author)
+
+	list, err = api.ListDrafts(ctx, &documents.ListDraftsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Documents, 1)
+	require.Equal(t, draft.Id, list.Documents[0].Id, "alice must only see own drafts")
+
+	updateDraft(ctx, t, api, draft.Id, []*documents.DocumentChange{
+		{Op: &documents.DocumentChange_SetTitle{SetTitle: "Alice's document"}},
+	})
+	_, err = api.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	trusted, err := api.ListPublications(ctx, &documents.ListPublicationsRequest{TrustedOnly: true})
+	require.NoError(t, err)
+	require.Len(t, trusted.Publications, 1, "alice must trust herself")
+
+	trusted, err = api.ListPublications(bobCtx, &documents.ListPublicationsRequest{TrustedOnly: true})
+	require.NoError(t, err)
+	require.Len(t, trusted.Publications, 0, "bob doesn't trust alice yet")
+
+	require.NoError(t, api.blobs.SetAccountTrust(ctx, bob.Account.Principal(), alice.Account.Principal()))
+
+	trusted, err = api.ListPublications(bobCtx, &documents.ListPublicationsRequest{TrustedOnly: true})
+	require.NoError(t, err)
+	require.Len(t, trusted.Publications, 1, "bob must see publications of trusted accounts")
+}
+
+func TestPrivateGroupDocuments(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := newTestDocsAPI(t, "bob")
+	carol := newTestDocsAPI(t, "carol")
+	ctx := context.Background()
+
+	aliceGroups := groups.NewServer(alice.me, logging.New("mintter/groups", "debug"), groups.NewSQLiteDB(alice.db), alice.blobs, future.New[*mttnet.Node]().ReadOnly, future.New[*syncing.Service]().ReadOnly)
+
+	syncStorage(t, bob, alice)
+	syncStorage(t, carol, alice)
+
+	group, err := aliceGroups.CreateGroup(ctx, &groupspb.CreateGroupRequest{
+		Title:   "Secret Group",
+		Private: true,
+	})
+	require.NoError(t, err)
+
+	_, err = aliceGroups.UpdateGroup(ctx, &groupspb.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groupspb.Role{
+			bob.me.MustGet().Account().Principal().String():   groupspb.Role_EDITOR,
+			carol.me.MustGet().Account().Principal().String(): groupspb.Role_EDITOR,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = alice.CreateDraft(ctx, &documents.CreateDraftRequest{ExistingDocumentId: group.Id, PrivateGroupId: group.Id})
+	require.Error(t, err, "existing documents can't be made private")
+
+	draft, err := alice.CreateDraft(ctx, &documents.CreateDraftRequest{PrivateGroupId: group.Id})
+	require.NoError(t, err)
+
+	updateDraft(ctx, t, alice, draft.Id, []*documents.DocumentChange{
+		{Op: &documents.DocumentChange_SetTitle{SetTitle: "Secret plans"}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1"}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{
+			Id:   "b1",
+			Type: "statement",
+			Text: "Top secret content",
+		}}},
+	})
+
+	pub, err := alice.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	// Content must not be stored in plain text.
+	{
+		c, err := cid.Decode(pub.Version)
+		require.NoError(t, err)
+
+		blk, err := alice.blobs.IPFSBlockstore().Get(ctx, c)
+		require.NoError(t, err)
+		require.NotContains(t, string(blk.RawData()), "Secret plans")
+		require.NotContains(t, string(blk.RawData()), "Top secret content")
+
+		ok, err := alice.blobs.IsBlobAllowedForDevice(ctx, c, bob.me.MustGet().DeviceKey().Principal())
+		require.NoError(t, err)
+		require.True(t, ok, "private blobs must be served to members")
+
+		david := coretest.NewTester("david")
+		ok, err = alice.blobs.IsBlobAllowedForDevice(ctx, c, david.Device.Principal())
+		require.NoError(t, err)
+		require.False(t, ok, "private blobs must not be served to non-members")
+	}
+
+	syncStorage(t, alice, bob)
+	syncStorage(t, alice, carol)
+
+	for _, api := range []*Server{bob, carol} {
+		doc, err := api.GetPublication(ctx, &documents.GetPublicationRequest{DocumentId: draft.Id, LocalOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, "Secret plans", doc.Document.Title, "members must be able to read private documents")
+		require.Equal(t, "Top secret content", doc.Document.Children[0].Block.Text)
+	}
+
+	cmt, err := bob.CreateComment(ctx, &documents.CreateCommentRequest{
+		Target: draft.Id + "?v=" + pub.Version,
+		Content: []*documents.BlockNode{{
+			Block: &documents.Block{
+				Id:   "c1",
+				Type: "paragraph",
+				Text: "Secret comment",
+			},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Secret comment", cmt.Content[0].Block.Text)
+
+	syncStorage(t, bob, alice)
+
+	got, err := alice.GetComment(ctx, &documents.GetCommentRequest{Id: cmt.Id})
+	require.NoError(t, err)
+	testutil.ProtoEqual(t, cmt, got, "members must be able to read comments on private documents")
+
+	// After carol is removed she can't read the new content anymore.
+	_, err = aliceGroups.UpdateGroup(ctx, &groupspb.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groupspb.Role{
+			carol.me.MustGet().Account().Principal().String(): groupspb.Role_ROLE_UNSPECIFIED,
+		},
+	})
+	require.NoError(t, err)
+
+	draft, err = alice.CreateDraft(ctx, &documents.CreateDraftRequest{ExistingDocumentId: draft.Id})
+	require.NoError(t, err)
+
+	updateDraft(ctx, t, alice, draft.Id, []*documents.DocumentChange{
+		{Op: &documents.DocumentChange_SetTitle{SetTitle: "New secret plans"}},
+	})
+
+	pub, err = alice.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	syncStorage(t, alice, bob)
+	syncStorage(t, alice, carol)
+
+	doc, err := bob.GetPublication(ctx, &documents.GetPublicationRequest{DocumentId: draft.Id, LocalOnly: true})
+	require.NoError(t, err)
+	require.Equal(t, "New secret plans", doc.Document.Title)
+
+	_, err = carol.GetPublication(ctx, &documents.GetPublicationRequest{DocumentId: draft.Id, LocalOnly: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "removed members must not read new content")
+
+	{
+		c, err := cid.Decode(pub.Version)
+		require.NoError(t, err)
+
+		ok, err := alice.blobs.IsBlobAllowedForDevice(ctx, c, carol.me.MustGet().DeviceKey().Principal())
+		require.NoError(t, err)
+		require.False(t, ok, "private blobs must not be served to removed members")
+	}
+}
+
+// syncStorage copies all the blobs from one server to another.
+func syncStorage(t *testing.T, src, dst *Server) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	keys, err := src.blobs.IPFSBlockstore().AllKeysChan(ctx)
+	require.NoError(t, err)
+
+	for c := range keys {
+		blk, err := src.blobs.IPFSBlockstore().Get(ctx, c)
+		require.NoError(t, err)
+		require.NoError(t, dst.blobs.IPFSBlockstore().Put(ctx, blk))
+	}
+}
+
 func updateDraft(ctx context.Context, t *testing.T, api *Server, id string, updates []*documents.DocumentChange) *documents.Document {
 	_, err := api.UpdateDraft(ctx, &documents.UpdateDraftRequest{
 		DocumentId: id,
@@ -956,15 +1209,16 @@ func updateDraft(ctx context.Context, t *testing.T, api *Server, id string, upda
 }
 
 func newTestDocsAPI(t *testing.T, name string) *Server {
-	u := coretest.NewTester("alice")
+	u := coretest.NewTester(name)
 
 	db := storage.MakeTestDB(t)
 
 	fut := future.New[core.Identity]()
 	require.NoError(t, fut.Resolve(u.Identity))
 
-	srv := NewServer(fut.ReadOnly, db, nil, nil, "debug")
 	bs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
+	bs.SetDeviceKey(u.Device)
+	srv := NewServer(fut.ReadOnly, db, bs, nil, nil)
 	_, err := daemon.Register(context.Background(), bs, u.Account, u.Device.PublicKey, time.Now())
 	require.NoError(t, err)
 
@@ -0,0 +1,99 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"mintter/backend/daemon/api/documents/v1alpha/mdconv"
+	documents "mintter/backend/genproto/documents/v1alpha"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImportMarkdown implements the corresponding gRPC method.
+func (api *Server) ImportMarkdown(ctx context.Context, in *documents.ImportMarkdownRequest) (*documents.Document, error) {
+	if in.Markdown == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "must specify markdown to import")
+	}
+
+	var (
+		draft   *documents.Document
+		created bool
+		err     error
+	)
+	if in.DocumentId == "" {
+		draft, err = api.CreateDraft(ctx, &documents.CreateDraftRequest{})
+		created = true
+	} else {
+		draft, err = api.CreateDraft(ctx, &documents.CreateDraftRequest{ExistingDocumentId: in.DocumentId})
+		created = err == nil
+		// Reusing the existing draft if there's one already.
+		if status.Code(err) == codes.FailedPrecondition {
+			draft, err = api.GetDraft(ctx, &documents.GetDraftRequest{DocumentId: in.DocumentId})
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The imported content replaces the whole content of the draft.
+	var changes []*documents.DocumentChange
+	for _, n := range draft.Children {
+		changes = append(changes, &documents.DocumentChange{
+			Op: &documents.DocumentChange_DeleteBlock{DeleteBlock: n.Block.Id},
+		})
+	}
+	changes = append(changes, mdconv.ChangesFromMarkdown([]byte(in.Markdown))...)
+
+	if len(changes) == 0 {
+		return draft, nil
+	}
+
+	resp, err := api.UpdateDraft(ctx, &documents.UpdateDraftRequest{
+		DocumentId: draft.Id,
+		Changes:    changes,
+	})
+	if err != nil {
+		if created {
+			if _, derr := api.DeleteDraft(ctx, &documents.DeleteDraftRequest{DocumentId: draft.Id}); derr != nil {
+				return nil, fmt.Errorf("failed to delete draft after failed import: %w (import error: %v)", derr, err)
+			}
+		}
+		return nil, fmt.Errorf("failed to import markdown: %w", err)
+	}
+
+	return resp.UpdatedDocument, nil
+}
+
+// ExportMarkdown implements the corresponding gRPC method.
+func (api *Server) ExportMarkdown(ctx context.Context, in *documents.ExportMarkdownRequest) (*documents.ExportMarkdownResponse, error) {
+	if in.DocumentId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "must specify document ID to export")
+	}
+
+	var doc *documents.Document
+	if in.Draft {
+		if in.Version != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "version can't be specified when exporting a draft")
+		}
+
+		draft, err := api.GetDraft(ctx, &documents.GetDraftRequest{DocumentId: in.DocumentId})
+		if err != nil {
+			return nil, err
+		}
+		doc = draft
+	} else {
+		pub, err := api.GetPublication(ctx, &documents.GetPublicationRequest{
+			DocumentId: in.DocumentId,
+			Version:    in.Version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		doc = pub.Document
+	}
+
+	return &documents.ExportMarkdownResponse{
+		Markdown: mdconv.ToMarkdown(doc),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package documents
+
+import (
+	"context"
+	documents "mintter/backend/genproto/documents/v1alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownImportExport(t *testing.T) {
+	api := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	const md = `# My Document
+
+Some **bold** text.
+
+## Section
+
+- One
+- Two
+`
+
+	draft, err := api.ImportMarkdown(ctx, &documents.ImportMarkdownRequest{Markdown: md})
+	require.NoError(t, err)
+	require.Equal(t, "My Document", draft.Title)
+	require.Len(t, draft.Children, 2)
+	require.Equal(t, "Section", draft.Children[1].Block.Text)
+
+	exported, err := api.ExportMarkdown(ctx, &documents.ExportMarkdownRequest{DocumentId: draft.Id, Draft: true})
+	require.NoError(t, err)
+	require.Equal(t, md, exported.Markdown)
+
+	pub, err := api.PublishDraft(ctx, &documents.PublishDraftRequest{DocumentId: draft.Id})
+	require.NoError(t, err)
+
+	// Importing into a published document replaces its content in a new draft.
+	const updated = `# My Document
+
+Replaced content.
+`
+	draft, err = api.ImportMarkdown(ctx, &documents.ImportMarkdownRequest{DocumentId: draft.Id, Markdown: updated})
+	require.NoError(t, err)
+	require.Len(t, draft.Children, 1)
+	require.Equal(t, "Replaced content.", draft.Children[0].Block.Text)
+
+	exported, err = api.ExportMarkdown(ctx, &documents.ExportMarkdownRequest{DocumentId: draft.Id, Draft: true})
+	require.NoError(t, err)
+	require.Equal(t, updated, exported.Markdown)
+
+	exported, err = api.ExportMarkdown(ctx, &documents.ExportMarkdownRequest{DocumentId: draft.Id, Version: pub.Version})
+	require.NoError(t, err)
+	require.Equal(t, md, exported.Markdown, "published version must not be affected by the import")
+}
@@ -0,0 +1,547 @@
+// Package mdconv converts documents to and from CommonMark.
+//
+// The mapping between documents and Markdown is the following:
+//
+//   - Title is rendered as the top-level heading (# Title).
+//   - Headings are rendered according to their nesting level (## for top-level headings, ### for their child headings, etc.),
+//     and the content nested under headings follows them.
+//   - Blocks with ul/ol children types are rendered as lists, and blocks with blockquote children type as quotes.
+//   - Code blocks are fenced and keep their language. Math blocks are fenced code blocks with "math" language.
+//   - Images are rendered as ![name](ipfs://...).
+//   - Embeds are rendered as autolinks <hm://...>, and inline embeds (mentions) as inline autolinks.
+//   - Files and videos are rendered as links with the block type in the title: [name](ipfs://... "video").
+//   - Bold, italic, strikethrough, code, and link annotations are rendered with the corresponding inline syntax.
+//     Other annotations (e.g. underline) don't have Markdown counterparts and are dropped.
+//
+// Strikethrough uses the GFM syntax, and import also supports GFM tables.
+// Tables have no block counterpart, so each table row is imported as a paragraph with the cells separated by " | ".
+//
+// Annotation offsets are expressed in UTF-16 code units, same as the frontend does.
+package mdconv
+
+import (
+	documents "mintter/backend/genproto/documents/v1alpha"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Children types of blocks.
+const (
+	childrenTypeGroup      = "group"
+	childrenTypeOrdered    = "ol"
+	childrenTypeUnordered  = "ul"
+	childrenTypeBlockquote = "blockquote"
+)
+
+// Block types with special Markdown representation.
+const (
+	blockTypeParagraph = "paragraph"
+	blockTypeHeading   = "heading"
+	blockTypeCode      = "codeBlock"
+	blockTypeMath      = "math"
+	blockTypeImage     = "image"
+	blockTypeEmbed     = "embed"
+	blockTypeFile      = "file"
+	blockTypeVideo     = "video"
+	blockTypeWebEmbed  = "web-embed"
+)
+
+// Annotation types with Markdown representation.
+const (
+	annotationStrong      = "strong"
+	annotationEmphasis    = "emphasis"
+	annotationStrike      = "strike"
+	annotationCode        = "code"
+	annotationLink        = "link"
+	annotationInlineEmbed = "inline-embed"
+)
+
+// mathLanguage is the info string of fenced code blocks representing math blocks.
+const mathLanguage = "math"
+
+// linkBlockTypes are the block types represented as links with the block type in the title.
+var linkBlockTypes = map[string]bool{
+	blockTypeFile:     true,
+	blockTypeVideo:    true,
+	blockTypeWebEmbed: true,
+}
+
+// ToMarkdown renders the document as CommonMark.
+func ToMarkdown(doc *documents.Document) string {
+	var lines []string
+	if doc.Title != "" {
+		lines = append(lines, "# "+escapeText(strings.ReplaceAll(doc.Title, "\n", " ")))
+	}
+
+	if content := renderBlocks(doc.Children, childrenTypeGroup, 0); len(content) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, content...)
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// renderBlocks renders sibling blocks according to the children type of their parent.
+// Depth is the number of headings the blocks are nested in.
+func renderBlocks(nodes []*documents.BlockNode, childrenType string, depth int) (out []string) {
+	// Lists are tight unless some items have nested content.
+	tight := childrenType == childrenTypeOrdered || childrenType == childrenTypeUnordered
+	for _, n := range nodes {
+		if len(n.Children) > 0 {
+			tight = false
+		}
+	}
+
+	for i, n := range nodes {
+		lines := renderNode(n, depth)
+		if len(lines) == 0 {
+			continue
+		}
+
+		switch childrenType {
+		case childrenTypeUnordered:
+			lines = indentItem(lines, "- ")
+		case childrenTypeOrdered:
+			lines = indentItem(lines, strconv.Itoa(i+1)+". ")
+		}
+
+		if len(out) > 0 && !tight {
+			out = append(out, "")
+		}
+		out = append(out, lines...)
+	}
+
+	if childrenType == childrenTypeBlockquote {
+		for i, l := range out {
+			if l == "" {
+				out[i] = ">"
+			} else {
+				out[i] = "> " + l
+			}
+		}
+	}
+
+	return out
+}
+
+func indentItem(lines []string, marker string) []string {
+	pad := strings.Repeat(" ", len(marker))
+	for i, l := range lines {
+		switch {
+		case i == 0:
+			lines[i] = marker + l
+		case l != "":
+			lines[i] = pad + l
+		}
+	}
+	return lines
+}
+
+func renderNode(n *documents.BlockNode, depth int) []string {
+	lines := renderBlock(n.Block, depth)
+	if len(n.Children) == 0 {
+		return lines
+	}
+
+	if n.Block.GetType() == blockTypeHeading {
+		depth++
+	}
+
+	children := renderBlocks(n.Children, n.Block.GetAttributes()["childrenType"], depth)
+	if len(children) == 0 {
+		return lines
+	}
+
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+
+	return append(lines, children...)
+}
+
+func renderBlock(blk *documents.Block, depth int) []string {
+	if blk == nil {
+		return nil
+	}
+
+	switch blk.Type {
+	case blockTypeHeading:
+		level := depth + 2
+		if level > 6 {
+			level = 6
+		}
+		text := strings.ReplaceAll(renderInline(blk), "\n", " ")
+		return []string{strings.Repeat("#", level) + " " + text}
+	case blockTypeCode, blockTypeMath, "equation":
+		lang := blk.Attributes["language"]
+		if blk.Type != blockTypeCode {
+			lang = mathLanguage
+		}
+		fence := strings.Repeat("`", 3)
+		if n := longestRun(blk.Text, '`'); n >= 3 {
+			fence = strings.Repeat("`", n+1)
+		}
+		lines := []string{fence + lang}
+		if blk.Text != "" {
+			lines = append(lines, strings.Split(strings.TrimSuffix(blk.Text, "\n"), "\n")...)
+		}
+		return append(lines, fence)
+	case blockTypeImage:
+		return []string{"![" + escapeText(blk.Attributes["name"]) + "](" + escapeDestination(blk.Ref) + ")"}
+	case blockTypeEmbed:
+		return []string{"<" + blk.Ref + ">"}
+	}
+
+	if linkBlockTypes[blk.Type] {
+		label := blk.Attributes["name"]
+		if label == "" {
+			label = blk.Ref
+		}
+		return []string{"[" + escapeText(label) + "](" + escapeDestination(blk.Ref) + " \"" + blk.Type + "\")"}
+	}
+
+	// Paragraphs and everything else we don't know about.
+	text := renderInline(blk)
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = escapeLine(lines[i])
+		// Line breaks within blocks are hard breaks.
+		if i < len(lines)-1 {
+			lines[i] += "\\"
+		}
+	}
+
+	return lines
+}
+
+// mark is an annotation that can be rendered as inline Markdown.
+type mark struct {
+	idx      int // Index of the annotation in the block, to make marks of different annotations distinct.
+	priority int // Marks with higher priority are nested inside marks with lower priority.
+	ann      *documents.Annotation
+}
+
+var markPriority = map[string]int{
+	annotationLink:     0,
+	annotationStrong:   1,
+	annotationEmphasis: 2,
+	annotationStrike:   3,
+	annotationCode:     4,
+}
+
+// segment is a piece of block text that has the same set of marks applied.
+type segment struct {
+	start, end int
+	marks      []mark
+	embed      *documents.Annotation
+}
+
+// renderInline renders the text of the block with its annotations.
+func renderInline(blk *documents.Block) string {
+	text := utf16.Encode([]rune(blk.Text))
+	segments := splitSegments(blk, len(text))
+
+	codeFence := strings.Repeat("`", longestRun(blk.Text, '`')+1)
+
+	var (
+		out   []byte
+		stack []mark
+	)
+
+	openMark := func(m mark) {
+		switch m.ann.Type {
+		case annotationStrong:
+			out = append(out, "**"...)
+		case annotationEmphasis:
+			out = append(out, '_')
+		case annotationStrike:
+			out = append(out, "~~"...)
+		case annotationCode:
+			out = append(out, codeFence...)
+			if len(codeFence) > 1 {
+				out = append(out, ' ')
+			}
+		case annotationLink:
+			// Exclamation mark before a link would turn it into an image.
+			if len(out) > 0 && out[len(out)-1] == '!' {
+				out = append(out[:len(out)-1], `\!`...)
+			}
+			out = append(out, '[')
+		}
+	}
+
+	closeMark := func(m mark) {
+		switch m.ann.Type {
+		case annotationStrong:
+			out = append(out, "**"...)
+		case annotationEmphasis:
+			out = append(out, '_')
+		case annotationStrike:
+			out = append(out, "~~"...)
+		case annotationCode:
+			if len(codeFence) > 1 {
+				out = append(out, ' ')
+			}
+			out = append(out, codeFence...)
+		case annotationLink:
+			out = append(out, "]("+escapeDestination(m.ann.Ref)+")"...)
+		}
+	}
+
+	for i, seg := range segments {
+		// Marks are sorted, so the marks shared with the previous segment are at the bottom of the stack.
+		common := 0
+		for common < len(stack) && common < len(seg.marks) && stack[common].idx == seg.marks[common].idx {
+			common++
+		}
+
+		for j := len(stack) - 1; j >= common; j-- {
+			closeMark(stack[j])
+		}
+		stack = stack[:common]
+
+		if seg.embed != nil {
+			out = append(out, "<"+seg.embed.Ref+">"...)
+			continue
+		}
+
+		chunk := string(utf16.Decode(text[seg.start:seg.end]))
+		inCode := len(seg.marks) > 0 && seg.marks[len(seg.marks)-1].ann.Type == annotationCode
+
+		// Emphasis delimiters must not be surrounded by whitespace on the inside,
+		// so we move the whitespace to the outside of the delimiters.
+		if common < len(seg.marks) && !inCode {
+			trimmed := strings.TrimLeft(chunk, " \t")
+			out = append(out, chunk[:len(chunk)-len(trimmed)]...)
+			chunk = trimmed
+
+			// There's nothing to apply the marks to.
+			if chunk == "" {
+				continue
+			}
+		}
+
+		var trailing string
+		if len(seg.marks) > 0 && !inCode && closesMarks(seg, segments, i) {
+			trimmed := strings.TrimRight(chunk, " \t")
+			trailing = chunk[len(trimmed):]
+			chunk = trimmed
+		}
+
+		for _, m := range seg.marks[common:] {
+			openMark(m)
+			stack = append(stack, m)
+		}
+
+		if inCode {
+			out = append(out, chunk...)
+		} else {
+			out = append(out, escapeText(chunk)...)
+		}
+
+		if trailing != "" {
+			for j := len(stack) - 1; j >= 0; j-- {
+				closeMark(stack[j])
+			}
+			stack = stack[:0]
+			out = append(out, trailing...)
+		}
+	}
+
+	for j := len(stack) - 1; j >= 0; j-- {
+		closeMark(stack[j])
+	}
+
+	return string(out)
+}
+
+// closesMarks checks whether the segment after the i-th one doesn't continue all of its marks.
+func closesMarks(seg segment, segments []segment, i int) bool {
+	if i+1 >= len(segments) {
+		return true
+	}
+
+	next := segments[i+1]
+	if next.embed != nil || len(next.marks) < len(seg.marks) {
+		return true
+	}
+
+	for j := range seg.marks {
+		if next.marks[j].idx != seg.marks[j].idx {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitSegments splits the text of the block at the boundaries of the annotations.
+func splitSegments(blk *documents.Block, size int) []segment {
+	bounds := map[int]struct{}{0: {}, size: {}}
+	for _, a := range blk.Annotations {
+		for i := range a.Starts {
+			if i >= len(a.Ends) {
+				break
+			}
+			bounds[clamp(int(a.Starts[i]), size)] = struct{}{}
+			bounds[clamp(int(a.Ends[i]), size)] = struct{}{}
+		}
+	}
+
+	points := make([]int, 0, len(bounds))
+	for p := range bounds {
+		points = append(points, p)
+	}
+	sort.Ints(points)
+
+	segments := make([]segment, 0, len(points))
+	for i := 0; i+1 < len(points); i++ {
+		seg := segment{start: points[i], end: points[i+1]}
+
+		for idx, a := range blk.Annotations {
+			if !covers(a, seg.start, seg.end) {
+				continue
+			}
+
+			if a.Type == annotationInlineEmbed {
+				seg.embed = a
+				break
+			}
+
+			prio, ok := markPriority[a.Type]
+			if !ok {
+				continue
+			}
+			seg.marks = append(seg.marks, mark{idx: idx, priority: prio, ann: a})
+		}
+
+		sort.Slice(seg.marks, func(i, j int) bool {
+			if seg.marks[i].priority != seg.marks[j].priority {
+				return seg.marks[i].priority < seg.marks[j].priority
+			}
+			return seg.marks[i].idx < seg.marks[j].idx
+		})
+
+		// Links can't be nested in Markdown, so we only keep the first one.
+		for j := 1; j < len(seg.marks); j++ {
+			if seg.marks[j].ann.Type == annotationLink {
+				seg.marks = append(seg.marks[:j], seg.marks[j+1:]...)
+				j--
+			}
+		}
+
+		// Code spans can't contain other marks.
+		for j, m := range seg.marks {
+			if m.ann.Type == annotationCode && j < len(seg.marks)-1 {
+				seg.marks = seg.marks[:j+1]
+				break
+			}
+		}
+
+		// Inline embeds replace their whole span.
+		if seg.embed != nil {
+			seg.marks = nil
+			if len(segments) > 0 && segments[len(segments)-1].embed == seg.embed {
+				continue
+			}
+		}
+
+		segments = append(segments, seg)
+	}
+
+	return segments
+}
+
+func covers(a *documents.Annotation, start, end int) bool {
+	for i := range a.Starts {
+		if i >= len(a.Ends) {
+			break
+		}
+		if int(a.Starts[i]) <= start && end <= int(a.Ends[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func clamp(v, size int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > size {
+		return size
+	}
+	return v
+}
+
+func longestRun(s string, c rune) (longest int) {
+	var cur int
+	for _, r := range s {
+		if r != c {
+			cur = 0
+			continue
+		}
+		cur++
+		if cur > longest {
+			longest = cur
+		}
+	}
+	return longest
+}
+
+// escapeText escapes the characters that have special meaning in inline Markdown.
+func escapeText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch r {
+		case '\\', '`', '*', '_', '[', ']', '<', '>', '~', '&':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// escapeLine escapes the beginning of a line that would otherwise be parsed as a block.
+func escapeLine(s string) string {
+	trimmed := strings.TrimLeft(s, " ")
+	if trimmed == "" {
+		return s
+	}
+
+	switch trimmed[0] {
+	case '#', '-', '+', '=', '|':
+		return "\\" + trimmed
+	}
+
+	// Ordered list markers.
+	digits := 0
+	for digits < len(trimmed) && digits < 10 && trimmed[digits] >= '0' && trimmed[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(trimmed) && (trimmed[digits] == '.' || trimmed[digits] == ')') {
+		return trimmed[:digits] + "\\" + trimmed[digits:]
+	}
+
+	return trimmed
+}
+
+func escapeDestination(s string) string {
+	if strings.ContainsAny(s, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(s) + ">"
+	}
+	return s
+}
@@ -0,0 +1,547 @@
+package mdconv
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"html"
+	documents "mintter/backend/genproto/documents/v1alpha"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// markdown parses CommonMark with the GFM tables and strikethrough extensions.
+var markdown = goldmark.New(goldmark.WithExtensions(extension.Table, extension.Strikethrough))
+
+// FromMarkdown parses Markdown into a document with title and content.
+// The leading top-level heading becomes the title of the document.
+// Blocks get new random IDs.
+func FromMarkdown(src []byte) *documents.Document {
+	root := markdown.Parser().Parse(text.NewReader(src))
+
+	doc := &documents.Document{}
+	p := &parser{src: src}
+
+	n := root.FirstChild()
+	if h, ok := n.(*ast.Heading); ok && h.Level == 1 {
+		doc.Title = p.inline(n).Text
+		n = n.NextSibling()
+	}
+
+	for ; n != nil; n = n.NextSibling() {
+		p.topLevel(n)
+	}
+
+	doc.Children = p.root
+	normalize(doc.Children)
+
+	return doc
+}
+
+// ChangesFromMarkdown parses Markdown and returns the changes to apply to an empty draft
+// to make it contain the parsed document.
+func ChangesFromMarkdown(src []byte) []*documents.DocumentChange {
+	return DocumentChanges(FromMarkdown(src))
+}
+
+// DocumentChanges returns the changes that create the given document from scratch.
+func DocumentChanges(doc *documents.Document) []*documents.DocumentChange {
+	var out []*documents.DocumentChange
+	if doc.Title != "" {
+		out = append(out, &documents.DocumentChange{
+			Op: &documents.DocumentChange_SetTitle{SetTitle: doc.Title},
+		})
+	}
+
+	var walk func(parent string, nodes []*documents.BlockNode)
+	walk = func(parent string, nodes []*documents.BlockNode) {
+		var left string
+		for _, n := range nodes {
+			out = append(out,
+				&documents.DocumentChange{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{
+					BlockId:     n.Block.Id,
+					Parent:      parent,
+					LeftSibling: left,
+				}}},
+				&documents.DocumentChange{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: n.Block}},
+			)
+			walk(n.Block.Id, n.Children)
+			left = n.Block.Id
+		}
+	}
+	walk("", doc.Children)
+
+	return out
+}
+
+type section struct {
+	level int
+	node  *documents.BlockNode
+}
+
+// parser converts the top-level Markdown nodes into the block tree,
+// nesting the content under the preceding headings.
+type parser struct {
+	src      []byte
+	root     []*documents.BlockNode
+	sections []section
+}
+
+func (p *parser) topLevel(n ast.Node) {
+	h, ok := n.(*ast.Heading)
+	if !ok {
+		p.appendTo(p.container(), n)
+		return
+	}
+
+	for len(p.sections) > 0 && p.sections[len(p.sections)-1].level >= h.Level {
+		p.sections = p.sections[:len(p.sections)-1]
+	}
+
+	blk := newBlock(blockTypeHeading)
+	p.fillInline(blk, n)
+	node := &documents.BlockNode{Block: blk}
+
+	container := p.container()
+	*container = append(*container, node)
+	p.sections = append(p.sections, section{level: h.Level, node: node})
+}
+
+// container returns the list of blocks where the top-level content is added,
+// i.e. children of the current heading or the root of the document.
+func (p *parser) container() *[]*documents.BlockNode {
+	if len(p.sections) == 0 {
+		return &p.root
+	}
+	return &p.sections[len(p.sections)-1].node.Children
+}
+
+// appendTo converts a Markdown node into blocks, and appends them to the container.
+func (p *parser) appendTo(container *[]*documents.BlockNode, n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Paragraph, *ast.TextBlock:
+		*container = append(*container, &documents.BlockNode{Block: p.paragraphBlock(n)})
+	case *ast.Heading:
+		blk := newBlock(blockTypeHeading)
+		p.fillInline(blk, n)
+		*container = append(*container, &documents.BlockNode{Block: blk})
+	case *ast.FencedCodeBlock:
+		*container = append(*container, &documents.BlockNode{Block: codeBlock(string(n.Language(p.src)), p.lines(n))})
+	case *ast.CodeBlock:
+		*container = append(*container, &documents.BlockNode{Block: codeBlock("", p.lines(n))})
+	case *ast.HTMLBlock:
+		raw := p.lines(n)
+		if n.HasClosure() {
+			raw += string(n.ClosureLine.Value(p.src))
+		}
+		blk := newBlock(blockTypeParagraph)
+		blk.Text = strings.TrimRight(raw, "\n")
+		*container = append(*container, &documents.BlockNode{Block: blk})
+	case *ast.List:
+		childrenType := childrenTypeUnordered
+		if n.IsOrdered() {
+			childrenType = childrenTypeOrdered
+		}
+
+		var items []*documents.BlockNode
+		for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+			items = append(items, p.listItem(item))
+		}
+		attachChildren(container, childrenType, items)
+	case *ast.Blockquote:
+		var children []*documents.BlockNode
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			p.appendTo(&children, c)
+		}
+		attachChildren(container, childrenTypeBlockquote, children)
+	case *east.Table:
+		// Header and body rows have the same structure, and the cells are direct children of both.
+		for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+			b := newInlineBuilder(p.src)
+			for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+				if cell != row.FirstChild() {
+					b.write(" | ")
+				}
+				b.children(cell)
+			}
+			blk := newBlock(blockTypeParagraph)
+			res := b.result()
+			blk.Text = res.Text
+			blk.Annotations = res.Annotations
+			*container = append(*container, &documents.BlockNode{Block: blk})
+		}
+	}
+}
+
+// listItem converts a list item into a block. The first paragraph of the item becomes the block itself,
+// and the rest of the item content becomes the children of the block.
+func (p *parser) listItem(item ast.Node) *documents.BlockNode {
+	c := item.FirstChild()
+
+	var node *documents.BlockNode
+	if c != nil && (c.Kind() == ast.KindParagraph || c.Kind() == ast.KindTextBlock) {
+		node = &documents.BlockNode{Block: p.paragraphBlock(c)}
+		c = c.NextSibling()
+	} else {
+		node = &documents.BlockNode{Block: newBlock(blockTypeParagraph)}
+	}
+
+	for ; c != nil; c = c.NextSibling() {
+		p.appendTo(&node.Children, c)
+	}
+
+	return node
+}
+
+// attachChildren attaches the children to the last block of the container,
+// if it doesn't have children yet, otherwise it creates an empty paragraph to hold them.
+// This is needed because lists and quotes in the documents are represented as children of some block.
+func attachChildren(container *[]*documents.BlockNode, childrenType string, children []*documents.BlockNode) {
+	if len(children) == 0 {
+		return
+	}
+
+	var parent *documents.BlockNode
+	if l := len(*container); l > 0 && len((*container)[l-1].Children) == 0 && (*container)[l-1].Block.Type == blockTypeParagraph {
+		parent = (*container)[l-1]
+	} else {
+		parent = &documents.BlockNode{Block: newBlock(blockTypeParagraph)}
+		*container = append(*container, parent)
+	}
+
+	parent.Block.Attributes["childrenType"] = childrenType
+	parent.Children = children
+}
+
+// normalize hoists the children of empty paragraphs created to hold lists and quotes,
+// when they are the only child of their parent, e.g. nested lists in list items, or lists right after a heading.
+func normalize(nodes []*documents.BlockNode) {
+	for _, n := range nodes {
+		if len(n.Children) == 1 {
+			only := n.Children[0]
+			if only.Block.Type == blockTypeParagraph && only.Block.Text == "" && len(only.Children) > 0 && n.Block.Attributes["childrenType"] == "" {
+				n.Block.Attributes["childrenType"] = only.Block.Attributes["childrenType"]
+				n.Children = only.Children
+			}
+		}
+		normalize(n.Children)
+	}
+}
+
+func (p *parser) paragraphBlock(n ast.Node) *documents.Block {
+	// Paragraphs with a single image or link can be represented as special blocks.
+	switch only := p.singleInline(n).(type) {
+	case *ast.Image:
+		blk := newBlock(blockTypeImage)
+		blk.Ref = string(only.Destination)
+		if label := p.inline(only).Text; label != "" {
+			blk.Attributes["name"] = label
+		}
+		return blk
+	case *ast.Link:
+		dest := string(only.Destination)
+		if linkBlockTypes[string(only.Title)] {
+			blk := newBlock(string(only.Title))
+			blk.Ref = dest
+			if label := p.inline(only).Text; label != "" && label != dest {
+				blk.Attributes["name"] = label
+			}
+			return blk
+		}
+		if isEmbedLink(p.src, only) {
+			blk := newBlock(blockTypeEmbed)
+			blk.Ref = dest
+			return blk
+		}
+	case *ast.AutoLink:
+		if isEmbedLink(p.src, only) {
+			blk := newBlock(blockTypeEmbed)
+			blk.Ref = string(only.URL(p.src))
+			return blk
+		}
+	}
+
+	blk := newBlock(blockTypeParagraph)
+	p.fillInline(blk, n)
+	return blk
+}
+
+// singleInline returns the only image or link inside the paragraph, ignoring empty text nodes.
+func (p *parser) singleInline(n ast.Node) (out ast.Node) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if t, ok := c.(*ast.Text); ok && len(bytes.TrimSpace(t.Value(p.src))) == 0 {
+			continue
+		}
+		if out != nil {
+			return nil
+		}
+		switch c.Kind() {
+		case ast.KindImage, ast.KindLink, ast.KindAutoLink:
+			out = c
+		default:
+			return nil
+		}
+	}
+	return out
+}
+
+// isEmbedLink checks whether the link is a hypermedia autolink, like <hm://d/foo>,
+// or a link to a hypermedia URL with the same URL as its text.
+func isEmbedLink(src []byte, n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AutoLink:
+		return n.AutoLinkType == ast.AutoLinkURL && bytes.HasPrefix(n.URL(src), []byte("hm://"))
+	case *ast.Link:
+		dest := string(n.Destination)
+		if !strings.HasPrefix(dest, "hm://") || len(n.Title) > 0 {
+			return false
+		}
+
+		c, ok := n.FirstChild().(*ast.Text)
+		return ok && c.NextSibling() == nil && string(c.Value(src)) == dest
+	default:
+		return false
+	}
+}
+
+func codeBlock(info, code string) *documents.Block {
+	lang, _, _ := strings.Cut(strings.TrimSpace(info), " ")
+
+	var blk *documents.Block
+	if lang == mathLanguage {
+		blk = newBlock(blockTypeMath)
+	} else {
+		blk = newBlock(blockTypeCode)
+		if lang != "" {
+			blk.Attributes["language"] = lang
+		}
+	}
+
+	blk.Text = strings.TrimSuffix(code, "\n")
+	return blk
+}
+
+// lines returns the raw content of a block node.
+func (p *parser) lines(n ast.Node) string {
+	var sb strings.Builder
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		sb.Write(seg.Value(p.src))
+	}
+	return sb.String()
+}
+
+func (p *parser) inline(n ast.Node) *documents.Block {
+	return newInlineBuilder(p.src).build(n)
+}
+
+func (p *parser) fillInline(blk *documents.Block, n ast.Node) {
+	res := p.inline(n)
+	blk.Text = res.Text
+	blk.Annotations = res.Annotations
+}
+
+// inlineBuilder accumulates text and annotations from inline Markdown nodes.
+type inlineBuilder struct {
+	src    []byte
+	text   strings.Builder
+	offset int32 // Current offset in UTF-16 code units.
+	marks  []*documents.Annotation
+	index  map[annotationKey]*documents.Annotation
+	order  []*documents.Annotation
+}
+
+type annotationKey struct {
+	Type string
+	Ref  string
+}
+
+func newInlineBuilder(src []byte) *inlineBuilder {
+	return &inlineBuilder{src: src, index: map[annotationKey]*documents.Annotation{}}
+}
+
+func (b *inlineBuilder) build(n ast.Node) *documents.Block {
+	b.children(n)
+	return b.result()
+}
+
+func (b *inlineBuilder) result() *documents.Block {
+	return &documents.Block{
+		Text:        b.text.String(),
+		Annotations: b.order,
+	}
+}
+
+func (b *inlineBuilder) node(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Text:
+		if n.IsRaw() {
+			b.write(string(n.Value(b.src)))
+		} else {
+			b.write(unescape(n.Value(b.src)))
+		}
+		if n.HardLineBreak() || n.SoftLineBreak() {
+			b.write("\n")
+		}
+	case *ast.String:
+		if n.IsRaw() {
+			b.write(string(n.Value))
+		} else {
+			b.write(unescape(n.Value))
+		}
+	case *ast.RawHTML:
+		for i := 0; i < n.Segments.Len(); i++ {
+			seg := n.Segments.At(i)
+			b.write(string(seg.Value(b.src)))
+		}
+	case *ast.CodeSpan:
+		b.withMark(annotationKey{Type: annotationCode}, func() {
+			// Line endings inside code spans are converted to spaces.
+			for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+				if t, ok := c.(*ast.Text); ok {
+					b.write(strings.ReplaceAll(string(t.Value(b.src)), "\n", " "))
+				}
+			}
+		})
+	case *ast.Emphasis:
+		key := annotationKey{Type: annotationEmphasis}
+		if n.Level >= 2 {
+			key.Type = annotationStrong
+		}
+		b.withMark(key, func() { b.children(n) })
+	case *east.Strikethrough:
+		b.withMark(annotationKey{Type: annotationStrike}, func() { b.children(n) })
+	case *ast.Link:
+		dest := string(n.Destination)
+		if isEmbedLink(b.src, n) {
+			b.inlineEmbed(dest)
+			return
+		}
+		b.withMark(annotationKey{Type: annotationLink, Ref: dest}, func() { b.children(n) })
+	case *ast.Image:
+		b.withMark(annotationKey{Type: annotationLink, Ref: string(n.Destination)}, func() { b.children(n) })
+	case *ast.AutoLink:
+		dest := string(n.URL(b.src))
+		if isEmbedLink(b.src, n) {
+			b.inlineEmbed(dest)
+			return
+		}
+		if n.AutoLinkType == ast.AutoLinkEmail {
+			dest = "mailto:" + dest
+		}
+		b.withMark(annotationKey{Type: annotationLink, Ref: dest}, func() {
+			b.write(string(n.Label(b.src)))
+		})
+	default:
+		b.children(n)
+	}
+}
+
+// inlineEmbed writes an inline embed, which occupies a single character of text.
+func (b *inlineBuilder) inlineEmbed(ref string) {
+	b.withMark(annotationKey{Type: annotationInlineEmbed, Ref: ref}, func() {
+		b.write(" ")
+	})
+}
+
+func (b *inlineBuilder) children(n ast.Node) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		b.node(c)
+	}
+}
+
+func (b *inlineBuilder) withMark(key annotationKey, fn func()) {
+	a, ok := b.index[key]
+	if !ok {
+		a = &documents.Annotation{Type: key.Type, Ref: key.Ref}
+		if key.Type == annotationInlineEmbed {
+			a.Attributes = map[string]string{}
+		}
+		b.index[key] = a
+		b.order = append(b.order, a)
+	}
+
+	b.marks = append(b.marks, a)
+	fn()
+	b.marks = b.marks[:len(b.marks)-1]
+}
+
+func (b *inlineBuilder) write(s string) {
+	if s == "" {
+		return
+	}
+
+	b.text.WriteString(s)
+
+	var size int32
+	for _, r := range s {
+		// Runes outside of the basic multilingual plane take two UTF-16 code units.
+		if r >= 0x10000 {
+			size += 2
+		} else {
+			size++
+		}
+	}
+
+	start, end := b.offset, b.offset+size
+	b.offset = end
+
+	for _, a := range b.marks {
+		// Merge with the previous span if it's adjacent.
+		// The same annotation can appear more than once when the marks are nested, e.g. **a **b** c**.
+		if l := len(a.Ends); l > 0 && (a.Ends[l-1] == start || a.Ends[l-1] == end) {
+			a.Ends[l-1] = end
+			continue
+		}
+		a.Starts = append(a.Starts, start)
+		a.Ends = append(a.Ends, end)
+	}
+}
+
+// unescape resolves backslash escapes and character references in the text.
+// It reuses the HTML writer of goldmark to handle them in the same pass, the way CommonMark requires,
+// and then decodes the HTML back to plain text.
+func unescape(v []byte) string {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	gmhtml.DefaultWriter.Write(w, v)
+	if err := w.Flush(); err != nil {
+		panic(err)
+	}
+	return html.UnescapeString(buf.String())
+}
+
+// newBlock creates a new block with a random ID.
+func newBlock(blockType string) *documents.Block {
+	return &documents.Block{
+		Id:         newBlockID(),
+		Type:       blockType,
+		Attributes: map[string]string{},
+	}
+}
+
+// newBlockID generates IDs similar to the ones the editor creates.
+func newBlockID() string {
+	const (
+		alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"
+		size     = 8
+	)
+
+	var buf [size]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(err)
+	}
+
+	for i, b := range buf {
+		buf[i] = alphabet[int(b)%len(alphabet)]
+	}
+
+	return string(buf[:])
+}
@@ -0,0 +1,161 @@
+package mdconv
+
+import (
+	documents "mintter/backend/genproto/documents/v1alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	const md = `# Hello World
+
+Intro with **bold**, _italic_, ~~strike~~, ` + "`code`" + `, and a [link](https://example.com).
+
+## First Section
+
+Mention <hm://a/alice> inline.
+
+- Item one
+- Item **two**
+- Item three
+
+### Nested Section
+
+1. First
+
+   - Sub item
+
+2. Second
+
+` + "```go" + `
+func main() {}
+` + "```" + `
+
+![Cat picture](ipfs://bafkreicat)
+
+<hm://d/other-doc?v=bafy123#block1>
+
+[Movie](ipfs://bafkreimovie "video")
+
+## Second Section
+
+> Quoted text
+>
+> More quoted text
+`
+
+	doc := FromMarkdown([]byte(md))
+	require.Equal(t, "Hello World", doc.Title)
+	require.Equal(t, md, ToMarkdown(doc), "markdown must survive the round trip")
+
+	require.Len(t, doc.Children, 3, "intro paragraph and two sections must be at the top level")
+
+	section := doc.Children[1]
+	require.Equal(t, "heading", section.Block.Type)
+	require.Equal(t, "First Section", section.Block.Text)
+	require.Len(t, section.Children, 2, "section must contain the paragraph with the list, and the nested section")
+
+	list := section.Children[0]
+	require.Equal(t, "ul", list.Block.Attributes["childrenType"], "list must be attached to the preceding paragraph")
+	require.Len(t, list.Children, 3)
+	require.Equal(t, "Item two", list.Children[1].Block.Text)
+
+	nested := section.Children[1]
+	require.Equal(t, "heading", nested.Block.Type)
+	require.Equal(t, "ol", nested.Children[0].Block.Attributes["childrenType"])
+	require.Equal(t, "ul", nested.Children[0].Children[0].Block.Attributes["childrenType"], "nested list must be hoisted into the list item")
+
+	var types []string
+	for _, c := range nested.Children[1:] {
+		types = append(types, c.Block.Type)
+	}
+	require.Equal(t, []string{"codeBlock", "image", "embed", "video"}, types)
+	require.Equal(t, "go", nested.Children[1].Block.Attributes["language"])
+	require.Equal(t, "ipfs://bafkreicat", nested.Children[2].Block.Ref)
+	require.Equal(t, "Cat picture", nested.Children[2].Block.Attributes["name"])
+	require.Equal(t, "hm://d/other-doc?v=bafy123#block1", nested.Children[3].Block.Ref)
+
+	mention := section.Children[0].Block
+	require.Equal(t, "Mention   inline.", mention.Text)
+	require.Equal(t, []*documents.Annotation{
+		{Type: "inline-embed", Ref: "hm://a/alice", Attributes: map[string]string{}, Starts: []int32{8}, Ends: []int32{9}},
+	}, mention.Annotations)
+
+	quote := doc.Children[2]
+	require.Equal(t, "blockquote", quote.Block.Attributes["childrenType"], "quote must be hoisted into the heading")
+	require.Len(t, quote.Children, 2)
+}
+
+func TestInlineAnnotations(t *testing.T) {
+	blk := &documents.Block{
+		Id:   "b1",
+		Type: "paragraph",
+		// Emoji takes two UTF-16 code units.
+		Text: "😀 bold italic plain link_x",
+		Annotations: []*documents.Annotation{
+			{Type: "strong", Starts: []int32{3}, Ends: []int32{14}},
+			{Type: "emphasis", Starts: []int32{8}, Ends: []int32{20}},
+			{Type: "underline", Starts: []int32{0}, Ends: []int32{2}},
+			{Type: "link", Ref: "https://example.com/a b", Starts: []int32{21}, Ends: []int32{27}},
+		},
+	}
+
+	md := ToMarkdown(&documents.Document{Children: []*documents.BlockNode{{Block: blk}}})
+	require.Equal(t, "😀 **bold _italic_** _plain_ [link\\_x](<https://example.com/a b>)\n", md)
+
+	doc := FromMarkdown([]byte(md))
+	require.Len(t, doc.Children, 1)
+	got := doc.Children[0].Block
+	require.Equal(t, blk.Text, got.Text)
+	require.Equal(t, []*documents.Annotation{
+		{Type: "strong", Starts: []int32{3}, Ends: []int32{14}},
+		{Type: "emphasis", Starts: []int32{8, 15}, Ends: []int32{14, 20}},
+		{Type: "link", Ref: "https://example.com/a b", Starts: []int32{21}, Ends: []int32{27}},
+	}, got.Annotations, "whitespace is moved outside of the marks, and underline is dropped")
+}
+
+func TestDocumentChanges(t *testing.T) {
+	doc := FromMarkdown([]byte("# Title\n\nParent\n\n- Child\n"))
+	changes := DocumentChanges(doc)
+
+	parent := doc.Children[0].Block
+	child := doc.Children[0].Children[0].Block
+
+	require.Equal(t, []*documents.DocumentChange{
+		{Op: &documents.DocumentChange_SetTitle{SetTitle: "Title"}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: parent.Id}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: parent}},
+		{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: child.Id, Parent: parent.Id}}},
+		{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: child}},
+	}, changes)
+}
+
+func TestImportTable(t *testing.T) {
+	doc := FromMarkdown([]byte("| Name | Value |\n| --- | --- |\n| a | **1** |\n| b | 2 |\n"))
+
+	var rows []string
+	for _, n := range doc.Children {
+		require.Equal(t, "paragraph", n.Block.Type)
+		rows = append(rows, n.Block.Text)
+	}
+	require.Equal(t, []string{"Name | Value", "a | 1", "b | 2"}, rows, "table rows must be flattened into paragraphs")
+	require.Equal(t, []*documents.Annotation{
+		{Type: "strong", Starts: []int32{4}, Ends: []int32{5}},
+	}, doc.Children[1].Block.Annotations, "cells must keep their inline annotations")
+}
+
+func TestImportCommonMark(t *testing.T) {
+	doc := FromMarkdown([]byte("1) First\n2) Second\n\nEscaped \\*stars\\* &amp; &#42;refs&#42;\n"))
+	require.Len(t, doc.Children, 2)
+
+	list := doc.Children[0]
+	require.Equal(t, "ol", list.Block.Attributes["childrenType"], "lists with parenthesis delimiters must be ordered")
+	require.Len(t, list.Children, 2)
+	require.Equal(t, "First", list.Children[0].Block.Text)
+	require.Equal(t, "Second", list.Children[1].Block.Text)
+
+	p := doc.Children[1].Block
+	require.Equal(t, "Escaped *stars* & *refs*", p.Text)
+	require.Empty(t, p.Annotations, "escaped characters and references must not produce marks")
+}
@@ -2,13 +2,16 @@
 package entities
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"math"
 	"mintter/backend/core"
+	"mintter/backend/daemon/apiutil"
 	entities "mintter/backend/genproto/entities/v1alpha"
 	"mintter/backend/hlc"
 	"mintter/backend/hyper"
@@ -16,12 +19,9 @@ import (
 	"mintter/backend/pkg/colx"
 	"mintter/backend/pkg/dqb"
 	"mintter/backend/pkg/errutil"
-	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/lithammer/fuzzysearch/fuzzy"
-
 	"crawshaw.io/sqlite"
 	"crawshaw.io/sqlite/sqlitex"
 	"github.com/ipfs/go-cid"
@@ -32,6 +32,25 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// trustingAccount returns the account selected for the request,
+// which is the one whose trust we report. Nil means any of our accounts.
+func trustingAccount(ctx context.Context) core.Principal {
+	acc, ok := apiutil.AccountFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return acc.Principal()
+}
+
+// trustingAccountArg is the same as trustingAccount, but suitable for binding as a query argument,
+// where a missing account must be NULL.
+func trustingAccountArg(ctx context.Context) any {
+	if acc := trustingAccount(ctx); acc != nil {
+		return []byte(acc)
+	}
+	return nil
+}
+
 // Discoverer is an interface for discovering objects.
 type Discoverer interface {
 	DiscoverObject(context.Context, hyper.EntityID, hyper.Version) error
@@ -71,7 +90,7 @@ func (api *Server) GetChange(ctx context.Context, in *entities.GetChangeRequest)
 			return errutil.NotFound("no such change %s", c)
 		}
 
-		out, err = getChange(conn, c, size.BlobsID)
+		out, err = getChange(conn, c, size.BlobsID, trustingAccount(ctx))
 		if err != nil {
 			return err
 		}
@@ -83,13 +102,25 @@ func (api *Server) GetChange(ctx context.Context, in *entities.GetChangeRequest)
 	return out, nil
 }
 
-func getChange(conn *sqlite.Conn, c cid.Cid, id int64) (*entities.Change, error) {
+// getChange loads the change. Trust is checked for the given account,
+// or for any of our accounts if it's nil.
+func getChange(conn *sqlite.Conn, c cid.Cid, id int64, account core.Principal) (*entities.Change, error) {
 	var out *entities.Change
 	info, err := hypersql.ChangesGetInfo(conn, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if account != nil && info.IsTrusted > 0 {
+		trusted, err := hypersql.IsTrustedAccount(conn, account, info.PublicKeysPrincipal)
+		if err != nil {
+			return nil, err
+		}
+		if trusted.TrustedAccountsID == 0 {
+			info.IsTrusted = 0
+		}
+	}
+
 	out = &entities.Change{
 		Id:         c.String(),
 		Author:     core.Principal(info.PublicKeysPrincipal).String(),
@@ -228,7 +259,7 @@ func (api *Server) GetEntityTimeline(ctx context.Context, in *entities.GetEntity
 
 			authorHeads.Put(idLong)
 			return nil
-		}, edb.ResourcesID, in.IncludeDrafts); err != nil {
+		}, trustingAccountArg(ctx), edb.ResourcesID, in.IncludeDrafts); err != nil {
 			return err
 		}
 
@@ -271,7 +302,11 @@ var qGetEntityTimeline = dqb.Str(`
 		blobs.codec,
 		blobs.multihash,
 		structural_blobs.ts,
-		trusted_accounts.id > 0 AS is_trusted,
+		EXISTS (
+			SELECT 1 FROM trusted_accounts
+			WHERE trusted_accounts.id = structural_blobs.author
+			AND (:account IS NULL OR trusted_accounts.account = (SELECT id FROM public_keys WHERE principal = :account))
+		) AS is_trusted,
 		public_keys.id AS author_id,
 		public_keys.principal AS author,
 		group_concat(change_deps.parent, ' ') AS deps,
@@ -281,7 +316,6 @@ var qGetEntityTimeline = dqb.Str(`
 	JOIN public_keys ON public_keys.id = structural_blobs.author
 	LEFT JOIN change_deps ON change_deps.child = structural_blobs.id
 	LEFT JOIN drafts ON (drafts.resource, drafts.blob) = (structural_blobs.resource, structural_blobs.id)
-	LEFT JOIN trusted_accounts ON trusted_accounts.id = structural_blobs.author
 	WHERE structural_blobs.resource IS NOT NULL
 		AND structural_blobs.type = 'Change'
 		AND structural_blobs.resource = :resource
@@ -367,40 +401,206 @@ func (api *Server) DiscoverEntity(ctx context.Context, in *entities.DiscoverEnti
 	return &entities.DiscoverEntityResponse{}, nil
 }
 
-// SearchEntities implements the Fuzzy search of entities.
+// SearchEntities implements the corresponding gRPC method.
 func (api *Server) SearchEntities(ctx context.Context, in *entities.SearchEntitiesRequest) (*entities.SearchEntitiesResponse, error) {
-	var titles []string
-	var iris []string
-	var owners []string
-	const limit = 30
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	var cursor searchCursor
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	for _, t := range in.EntityTypes {
+		switch t {
+		case "document", "group", "account", "comment":
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown entity type '%s': must be one of document, group, account, comment", t)
+		}
+	}
+
+	var author core.Principal
+	if in.Author != "" {
+		var err error
+		author, err = core.DecodePrincipal(in.Author)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode author %s: %v", in.Author, err)
+		}
+	}
+
+	resp := &entities.SearchEntitiesResponse{
+		Entities: []*entities.Entity{},
+	}
+
+	query := ftsQuery(in.Query)
+	if query == "" {
+		return resp, nil
+	}
+
+	types := "[]"
+	if len(in.EntityTypes) > 0 {
+		data, err := json.Marshal(in.EntityTypes)
+		if err != nil {
+			return nil, err
+		}
+		types = string(data)
+	}
+
 	if err := api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-		return sqlitex.Exec(conn, qGetEntityTitles(), func(stmt *sqlite.Stmt) error {
-			titles = append(titles, stmt.ColumnText(0))
-			iris = append(iris, stmt.ColumnText(1))
-			ownerID := core.Principal(stmt.ColumnBytes(2)).String()
-			owners = append(owners, ownerID)
+		var count int32
+		return sqlitex.Exec(conn, qSearchEntities(), func(stmt *sqlite.Stmt) error {
+			// We query for pageSize + 1 items to know if there's more items on the next page.
+			if count == in.PageSize {
+				var err error
+				resp.NextPageToken, err = apiutil.EncodePageToken(searchCursor{Offset: cursor.Offset + count}, nil)
+				return err
+			}
+			count++
+
+			var (
+				contentType = stmt.ColumnText(0)
+				blockID     = stmt.ColumnText(1)
+				snippet     = snippetHTML(stmt.ColumnText(2))
+				iri         = stmt.ColumnText(3)
+				title       = stmt.ColumnText(4)
+				owner       = core.Principal(stmt.ColumnBytes(5))
+				author      = core.Principal(stmt.ColumnBytes(6))
+				version     = cid.NewCidV1(uint64(stmt.ColumnInt64(7)), stmt.ColumnBytesUnsafe(8)).String()
+			)
+
+			e := &entities.Entity{
+				Id:          iri,
+				Title:       title,
+				ContentType: contentType,
+				Snippet:     snippet,
+				BlockId:     blockID,
+				Version:     version,
+				Author:      author.String(),
+			}
+
+			if contentType == "comment" {
+				e.Id = "hm://c/" + version
+				e.Title = ""
+				e.Owner = e.Author
+			} else if owner != nil {
+				e.Owner = owner.String()
+			}
+
+			resp.Entities = append(resp.Entities, e)
 			return nil
-		})
+		}, query, types, []byte(author), in.GroupId, in.PageSize, cursor.Offset)
 	}); err != nil {
 		return nil, err
 	}
-	ranks := fuzzy.RankFindNormalizedFold(in.Query, titles)
-	sort.Slice(ranks, func(i, j int) bool {
-		return ranks[i].Distance < ranks[j].Distance
-	})
-	matchingEntities := []*entities.Entity{}
-	for i, rank := range ranks {
-		if i >= limit {
-			break
-		}
-		matchingEntities = append(matchingEntities, &entities.Entity{
-			Id:    iris[rank.OriginalIndex],
-			Title: rank.Target,
-			Owner: owners[rank.OriginalIndex]})
+
+	return resp, nil
+}
+
+// snippetHTML turns the snippet of the matched content into HTML.
+// The matches are marked with control characters in the query, because the content itself must be escaped.
+func snippetHTML(s string) string {
+	return snippetReplacer.Replace(html.EscapeString(s))
+}
+
+var snippetReplacer = strings.NewReplacer("\x02", "<b>", "\x03", "</b>")
+
+type searchCursor struct {
+	Offset int32 `json:"o"`
+}
+
+// ftsQuery converts a user-provided search query into an FTS5 query,
+// where each word must be matched as a prefix.
+func ftsQuery(in string) string {
+	words := strings.Fields(in)
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"*`
 	}
-	return &entities.SearchEntitiesResponse{Entities: matchingEntities}, nil
+	return strings.Join(words, " ")
 }
 
+// We only want the most recent content for each block of each resource,
+// except for comments, which are immutable, and can have blocks with the same IDs.
+// Each entity is returned only once, with its most relevant match.
+var qSearchEntities = dqb.Str(`
+	WITH hits AS MATERIALIZED (
+		SELECT
+			fts_index.blob_id,
+			fts_index.type,
+			fts_index.block_id,
+			fts_index.resource,
+			fts.rank,
+			snippet(fts, 0, char(2), char(3), '…', 12) AS snippet
+		FROM fts
+		JOIN fts_index ON fts_index.id = fts.rowid
+		WHERE fts MATCH :query
+	),
+	matches AS (
+		SELECT
+			hits.*,
+			resources.iri,
+			CASE
+				WHEN hits.type = 'comment' THEN 'comment'
+				WHEN resources.iri GLOB 'hm://d/*' THEN 'document'
+				WHEN resources.iri GLOB 'hm://g/*' THEN 'group'
+				WHEN resources.iri GLOB 'hm://a/*' THEN 'account'
+			END AS entity_type
+		FROM hits
+		JOIN resources ON resources.id = hits.resource
+		WHERE hits.type = 'comment'
+		OR hits.blob_id = (
+			SELECT latest.blob_id
+			FROM fts_index latest
+			WHERE latest.resource = hits.resource
+			AND latest.type = hits.type
+			AND latest.block_id = hits.block_id
+			ORDER BY latest.ts DESC
+			LIMIT 1
+		)
+	)
+	SELECT
+		matches.type,
+		matches.block_id,
+		matches.snippet,
+		matches.iri,
+		(
+			SELECT meta
+			FROM structural_blobs
+			WHERE structural_blobs.resource = matches.resource
+			AND structural_blobs.type = 'Change'
+			AND structural_blobs.meta IS NOT NULL
+			ORDER BY structural_blobs.ts DESC
+			LIMIT 1
+		) AS title,
+		owners.principal AS owner,
+		authors.principal AS author,
+		blobs.codec,
+		blobs.multihash,
+		MIN(matches.rank) AS best_rank
+	FROM matches
+	JOIN structural_blobs ON structural_blobs.id = matches.blob_id
+	JOIN blobs INDEXED BY blobs_metadata ON blobs.id = matches.blob_id
+	JOIN public_keys authors ON authors.id = structural_blobs.author
+	JOIN resources ON resources.id = matches.resource
+	LEFT JOIN public_keys owners ON owners.id = resources.owner
+	WHERE (:types = '[]' OR matches.entity_type IN (SELECT value FROM json_each(:types)))
+	AND (:author IS NULL OR authors.principal = :author)
+	AND (:group = '' OR matches.iri = :group OR matches.resource IN (
+		SELECT resource_links.target
+		FROM resource_links
+		JOIN structural_blobs group_changes ON group_changes.id = resource_links.source
+		JOIN resources groups ON groups.id = group_changes.resource
+		WHERE groups.iri = :group
+		AND resource_links.type = 'group/content'
+	))
+	GROUP BY CASE WHEN matches.type = 'comment' THEN -matches.blob_id ELSE matches.resource END
+	ORDER BY best_rank, matches.blob_id
+	LIMIT :page_size + 1
+	OFFSET :offset;
+`)
+
 // DeleteEntity implements the corresponding gRPC method.
 func (api *Server) DeleteEntity(ctx context.Context, in *entities.DeleteEntityRequest) (*emptypb.Empty, error) {
 	var meta string
@@ -521,9 +721,94 @@ func (api *Server) ListDeletedEntities(ctx context.Context, _ *entities.ListDele
 	return resp, err
 }
 
-var qGetEntityTitles = dqb.Str(`
-	SELECT meta, iri, principal
-	FROM meta_view;`)
+// Subscribe implements the corresponding gRPC method.
+func (api *Server) Subscribe(ctx context.Context, in *entities.SubscribeRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	if err := api.blobs.Subscribe(ctx, hyper.EntityID(in.Id)); err != nil {
+		if errors.Is(err, hyper.ErrInvalidSubscription) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Unsubscribe implements the corresponding gRPC method.
+func (api *Server) Unsubscribe(ctx context.Context, in *entities.UnsubscribeRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	return &emptypb.Empty{}, api.blobs.Unsubscribe(ctx, hyper.EntityID(in.Id))
+}
+
+// ListSubscriptions implements the corresponding gRPC method.
+func (api *Server) ListSubscriptions(ctx context.Context, _ *entities.ListSubscriptionsRequest) (*entities.ListSubscriptionsResponse, error) {
+	list, err := api.blobs.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &entities.ListSubscriptionsResponse{
+		Subscriptions: make([]*entities.Subscription, len(list)),
+	}
+
+	for i, sub := range list {
+		resp.Subscriptions[i] = &entities.Subscription{
+			Id:         sub.ID.String(),
+			CreateTime: timestamppb.New(sub.CreateTime),
+		}
+	}
+
+	return resp, nil
+}
+
+// ExportEntity implements the corresponding gRPC method.
+func (api *Server) ExportEntity(ctx context.Context, in *entities.ExportEntityRequest) (*entities.ExportEntityResponse, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	var buf bytes.Buffer
+	count, err := api.blobs.ExportEntity(ctx, &buf, hyper.EntityID(in.Id))
+	if err != nil {
+		if errors.Is(err, hyper.ErrEntityNotFound) {
+			return nil, status.Errorf(codes.NotFound, "entity %s not found", in.Id)
+		}
+		return nil, err
+	}
+
+	return &entities.ExportEntityResponse{
+		Car:       buf.Bytes(),
+		BlobCount: int32(count),
+	}, nil
+}
+
+// ImportEntity implements the corresponding gRPC method.
+func (api *Server) ImportEntity(ctx context.Context, in *entities.ImportEntityRequest) (*entities.ImportEntityResponse, error) {
+	if len(in.Car) == 0 {
+		return nil, errutil.MissingArgument("car")
+	}
+
+	report, err := api.blobs.ImportEntities(ctx, bytes.NewReader(in.Car))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to import archive: %v", err)
+	}
+
+	resp := &entities.ImportEntityResponse{
+		Ids:       make([]string, len(report.Entities)),
+		BlobCount: int32(report.Blobs),
+	}
+	for i, eid := range report.Entities {
+		resp.Ids[i] = eid.String()
+	}
+
+	return resp, nil
+}
 
 // ListEntityMentions implements listing mentions of an entity in other resources.
 func (api *Server) ListEntityMentions(ctx context.Context, in *entities.ListEntityMentionsRequest) (*entities.ListEntityMentionsResponse, error) {
@@ -7,7 +7,9 @@ import (
 	daemon "mintter/backend/daemon/api/daemon/v1alpha"
 	"mintter/backend/daemon/storage"
 	entities "mintter/backend/genproto/entities/v1alpha"
+	"mintter/backend/hlc"
 	"mintter/backend/hyper"
+	"mintter/backend/hyper/hypertest"
 	"mintter/backend/pkg/must"
 	"mintter/backend/testutil"
 	"strings"
@@ -18,6 +20,8 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -113,7 +117,7 @@ func TestEntityTimeline(t *testing.T) {
 		"lastName": "Liddell",
 	})
 	require.NoError(t, err)
-	require.NoError(t, blobs.SaveDraftBlob(ctx, e.ID(), c4))
+	require.NoError(t, blobs.SaveDraftBlob(ctx, alice.Account.Principal(), e.ID(), c4))
 	require.NoError(t, e.ApplyChange(c4.CID, c4.Decoded.(hyper.Change)))
 
 	want := &entities.EntityTimeline{
@@ -225,3 +229,153 @@ func TestEntityTimeline(t *testing.T) {
 		testutil.ProtoEqual(t, want, timeline, "timeline without drafts must match")
 	}
 }
+
+func TestSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	db := storage.MakeTestDB(t)
+	blobs := hyper.NewStorage(db, zap.NewNop())
+	api := NewServer(blobs, nil)
+	ctx := context.Background()
+
+	_, err := api.Subscribe(ctx, &entities.SubscribeRequest{Id: "hm://x/foo"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "must not subscribe to unknown entity types")
+
+	_, err = api.Subscribe(ctx, &entities.SubscribeRequest{Id: "hm://d/foo?v=bafy"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "must not subscribe to versions")
+
+	for _, id := range []string{"hm://d/foo", "hm://g/bar", "hm://d/foo"} {
+		_, err = api.Subscribe(ctx, &entities.SubscribeRequest{Id: id})
+		require.NoError(t, err)
+	}
+
+	list, err := api.ListSubscriptions(ctx, &entities.ListSubscriptionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Subscriptions, 2, "duplicate subscriptions must be ignored")
+
+	_, err = api.Unsubscribe(ctx, &entities.UnsubscribeRequest{Id: "hm://d/foo"})
+	require.NoError(t, err)
+
+	list, err = api.ListSubscriptions(ctx, &entities.ListSubscriptionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Subscriptions, 1)
+	require.Equal(t, "hm://g/bar", list.Subscriptions[0].Id)
+}
+
+func TestSearchEntities(t *testing.T) {
+	t.Parallel()
+
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+
+	db := storage.MakeTestDB(t)
+	blobs := hyper.NewStorage(db, zap.NewNop())
+	api := NewServer(blobs, nil)
+	ctx := context.Background()
+	del := must.Do2(daemon.Register(ctx, blobs, alice.Account, alice.Device.PublicKey, time.Now()))
+	must.Do2(daemon.Register(ctx, blobs, bob.Account, bob.Device.PublicKey, time.Now()))
+
+	create := func(prefix string, patch map[string]any) *hyper.Entity {
+		e, blob := hypertest.NewEntity(t, alice.Identity, del, prefix, patch)
+		require.NoError(t, blobs.SaveBlob(ctx, blob))
+		return e
+	}
+
+	doc := create("hm://d/", map[string]any{
+		"title": "Fox Story",
+		"blocks": map[string]any{
+			"b1": map[string]any{"#map": map[string]any{"type": "paragraph", "text": "The quick brown animal"}},
+		},
+	})
+
+	// Only the most recent content of the block must be found.
+	{
+		blob, err := doc.CreateChange(doc.NextTimestamp(), alice.Device, del, map[string]any{
+			"blocks": map[string]any{
+				"b1": map[string]any{"#map": map[string]any{"type": "paragraph", "text": "The lazy brown animal"}},
+			},
+		}, hyper.WithAction("Update"))
+		require.NoError(t, err)
+		require.NoError(t, blobs.SaveBlob(ctx, blob))
+	}
+
+	group := create("hm://g/", map[string]any{
+		"title":       "Animals",
+		"description": "Stories about the fox and other animals",
+		"content": map[string]any{
+			"/fox": string(doc.ID()),
+		},
+	})
+
+	comment, err := hyper.NewComment(string(doc.ID()), cid.Undef, cid.Undef, hlc.NewClock().MustNow(), alice.Device, del, []hyper.CommentBlock{
+		{Block: hyper.Block{ID: "c1", Type: "paragraph", Text: "What an interesting animal"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, comment))
+
+	search := func(in *entities.SearchEntitiesRequest) *entities.SearchEntitiesResponse {
+		t.Helper()
+		resp, err := api.SearchEntities(ctx, in)
+		require.NoError(t, err)
+		return resp
+	}
+
+	ids := func(resp *entities.SearchEntitiesResponse) []string {
+		var out []string
+		for _, e := range resp.Entities {
+			out = append(out, e.Id)
+		}
+		return out
+	}
+
+	require.Len(t, search(&entities.SearchEntitiesRequest{Query: "quick"}).Entities, 0, "outdated content must not be found")
+
+	resp := search(&entities.SearchEntitiesRequest{Query: "laz"})
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, string(doc.ID()), resp.Entities[0].Id)
+	require.Equal(t, "Fox Story", resp.Entities[0].Title)
+	require.Equal(t, "document", resp.Entities[0].ContentType)
+	require.Equal(t, "b1", resp.Entities[0].BlockId)
+	require.Equal(t, alice.Account.Principal().String(), resp.Entities[0].Author)
+	require.Contains(t, resp.Entities[0].Snippet, "<b>lazy</b>")
+
+	resp = search(&entities.SearchEntitiesRequest{Query: "interesting animal"})
+	require.Equal(t, []string{"hm://c/" + comment.CID.String()}, ids(resp))
+	require.Equal(t, "comment", resp.Entities[0].ContentType)
+
+	markup, err := hyper.NewComment(string(doc.ID()), cid.Undef, cid.Undef, hlc.NewClock().MustNow(), alice.Device, del, []hyper.CommentBlock{
+		{Block: hyper.Block{ID: "c1", Type: "paragraph", Text: "<img src=x onerror=alert(1)> marmot"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, markup))
+
+	resp = search(&entities.SearchEntitiesRequest{Query: "marmot"})
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "&lt;img src=x onerror=alert(1)&gt; <b>marmot</b>", resp.Entities[0].Snippet, "content of the snippet must be escaped")
+
+	require.ElementsMatch(t, []string{string(doc.ID()), string(group.ID())}, ids(search(&entities.SearchEntitiesRequest{Query: "fox"})))
+	require.Equal(t, []string{string(group.ID())}, ids(search(&entities.SearchEntitiesRequest{Query: "fox", EntityTypes: []string{"group"}})))
+	require.Len(t, search(&entities.SearchEntitiesRequest{Query: "fox", Author: bob.Account.Principal().String()}).Entities, 0)
+	require.ElementsMatch(t,
+		[]string{string(doc.ID()), string(group.ID()), "hm://c/" + comment.CID.String()},
+		ids(search(&entities.SearchEntitiesRequest{Query: "animal", GroupId: string(group.ID())})),
+	)
+
+	// Paginating through all the results.
+	var (
+		all   []string
+		token string
+	)
+	for {
+		resp := search(&entities.SearchEntitiesRequest{Query: "animal", PageSize: 1, PageToken: token})
+		all = append(all, ids(resp)...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		token = resp.NextPageToken
+	}
+	require.ElementsMatch(t, []string{string(doc.ID()), string(group.ID()), "hm://c/" + comment.CID.String()}, all)
+
+	_, err = api.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "fox", EntityTypes: []string{"foo"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
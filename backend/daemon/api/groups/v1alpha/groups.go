@@ -10,8 +10,8 @@ import (
 	"io"
 	"math/rand"
 	"mintter/backend/core"
+	"mintter/backend/daemon/apiutil"
 	groups "mintter/backend/genproto/groups/v1alpha"
-	p2p "mintter/backend/genproto/p2p/v1alpha"
 	"mintter/backend/hlc"
 	"mintter/backend/hyper"
 	"mintter/backend/hyper/hypersql"
@@ -33,6 +33,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -46,16 +47,18 @@ type Server struct {
 	blobs *hyper.Storage
 	db    *DB
 	node  *future.ReadOnly[*mttnet.Node]
+	sync  *future.ReadOnly[*syncing.Service]
 }
 
 // NewServer creates a new groups server.
-func NewServer(me *future.ReadOnly[core.Identity], log *zap.Logger, db *DB, blobs *hyper.Storage, node *future.ReadOnly[*mttnet.Node]) *Server {
+func NewServer(me *future.ReadOnly[core.Identity], log *zap.Logger, db *DB, blobs *hyper.Storage, node *future.ReadOnly[*mttnet.Node], sync *future.ReadOnly[*syncing.Service]) *Server {
 	return &Server{
 		me:    me,
 		log:   log,
 		db:    db,
 		blobs: blobs,
 		node:  node,
+		sync:  sync,
 	}
 }
 
@@ -157,8 +160,8 @@ func (srv *Server) scheduleSiteWorkers(ctx context.Context,
 
 					// We want to log error message if sync round failed.
 					logFunc := log.Debug
-					err := srv.syncGroupSite(ctx, group, interval)
-					if err != nil {
+					_, err := srv.syncGroupSite(ctx, group, interval, syncing.PriorityBackground)
+					if err != nil && !errors.Is(err, syncing.ErrJobBackoff) {
 						logFunc = log.Warn
 					}
 					logFunc("SiteSyncRoundFinished",
@@ -184,7 +187,8 @@ func (srv *Server) SyncGroupSite(ctx context.Context, in *groups.SyncGroupSiteRe
 		return nil, errutil.MissingArgument("groupId")
 	}
 
-	if err := srv.syncGroupSite(ctx, in.GroupId, 0); err != nil {
+	perms, err := srv.syncGroupSite(ctx, in.GroupId, 0, syncing.PriorityInteractive)
+	if err != nil {
 		return nil, err
 	}
 
@@ -202,16 +206,19 @@ func (srv *Server) SyncGroupSite(ctx context.Context, in *groups.SyncGroupSiteRe
 	}
 
 	return &groups.SyncGroupSiteResponse{
-		SiteInfo: info,
+		SiteInfo:         info,
+		PushDeniedReason: perms.GetPublishDeniedReason(),
 	}, nil
 }
 
-// syncSite syncs one site and blocks until finished,
+// syncGroupSite syncs one site and blocks until finished,
 // unless the last time we've synced was within the specified interval.
-func (srv *Server) syncGroupSite(ctx context.Context, group string, interval time.Duration) (err error) {
+// The sync runs as a job of the syncing service, sharing its concurrency limits and backoff.
+// It returns our permissions on the site, if the site reported them.
+func (srv *Server) syncGroupSite(ctx context.Context, group string, interval time.Duration, priority syncing.JobPriority) (perms *groups.CallerPermissions, err error) {
 	sr, err := srv.db.GetGroupSite(ctx, group)
 	if err != nil {
-		return fmt.Errorf("failed to get site record for group %s: %w", group, err)
+		return nil, fmt.Errorf("failed to get site record for group %s: %w", group, err)
 	}
 
 	now := time.Now()
@@ -219,145 +226,98 @@ func (srv *Server) syncGroupSite(ctx context.Context, group string, interval tim
 
 	// Check if we actually need to sync. Check the time of the last sync.
 	if now.Sub(lastSync) < interval {
-		return nil
+		return nil, nil
+	}
+
+	syncer, err := srv.sync.Await(ctx)
+	if err != nil {
+		return nil, err
 	}
 
+	return srv.syncSite(ctx, syncer, sr, priority)
+}
+
+// siteInfoTimeout is the timeout for fetching the site info, which happens before the sync job starts.
+const siteInfoTimeout = 30 * time.Second
+
+// syncSite resolves the peer serving the site, and syncs with it as a job of the syncing service.
+// The job is keyed by the peer, so it never runs at the same time as other syncs with the same peer,
+// even if the site is known by different URLs.
+func (srv *Server) syncSite(ctx context.Context, syncer *syncing.Service, sr groupSite, priority syncing.JobPriority) (perms *groups.CallerPermissions, err error) {
 	var info *groups.PublicSiteInfo
 	// We want to record the timestamp of the last sync attempt, even if it fails.
+	// Jobs that didn't run because of the backoff are not attempts.
 	defer func() {
-		err = errors.Join(err, srv.db.RecordGroupSiteSync(ctx, group, time.Now(), err, info))
+		if errors.Is(err, syncing.ErrJobBackoff) {
+			return
+		}
+		err = errors.Join(err, srv.db.RecordGroupSiteSync(ctx, sr.GroupID, time.Now(), err, info))
 	}()
 
 	// We make remote call on every sync, because we want to make sure the site is actually serving the group we are syncing.
-	info, err = GetSiteInfoHTTP(ctx, nil, sr.URL)
+	{
+		ctx, cancel := context.WithTimeout(ctx, siteInfoTimeout)
+		info, err = GetSiteInfoHTTP(ctx, nil, sr.URL)
+		cancel()
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get site info: %w", err)
+		return nil, fmt.Errorf("failed to get site info: %w", err)
 	}
 
 	ai, err := addrInfoFromProto(info.PeerInfo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if info.GroupId != sr.GroupID {
-		return fmt.Errorf("group ID mismatch: remote %q != local %q", info.GroupId, sr.GroupID)
+		return nil, fmt.Errorf("group ID mismatch: remote %q != local %q", info.GroupId, sr.GroupID)
 	}
 
-	n, err := srv.node.Await(ctx)
-	if err != nil {
+	err = syncer.RunJob(ctx, syncing.JobKindSite, ai.ID.String(), priority, func(ctx context.Context, p *syncing.Progress) (err error) {
+		perms, err = srv.syncSitePeer(ctx, syncer, sr, ai, p)
 		return err
-	}
-
-	if err := n.Connect(ctx, ai); err != nil {
-		return err
-	}
+	})
 
-	client, err := n.Client(ctx, ai.ID)
-	if err != nil {
-		return err
-	}
-
-	pubKey, err := ai.ID.ExtractPublicKey()
-	if err != nil {
-		return fmt.Errorf("failed to extract public key from peer ID %s: %w", ai.ID, err)
-	}
-
-	remotePrincipal := core.PrincipalFromPubKey(pubKey)
-
-	cursor, err := syncing.GetCursor(ctx, srv.db.db, remotePrincipal)
-	if err != nil {
-		return err
-	}
+	return perms, err
+}
 
-	stream, err := client.ListBlobs(ctx, &p2p.ListBlobsRequest{Cursor: cursor})
+// syncSitePeer pulls the content from the site's peer and pushes our content to it, if we are allowed to.
+func (srv *Server) syncSitePeer(ctx context.Context, syncer *syncing.Service, sr groupSite, ai peer.AddrInfo, p *syncing.Progress) (perms *groups.CallerPermissions, err error) {
+	n, err := srv.node.Await(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	bs := srv.blobs.IPFSBlockstore()
-
-	type wantBlob struct {
-		ID     cid.Cid
-		Cursor string
+	if err := n.Connect(ctx, ai); err != nil {
+		return nil, err
 	}
 
-	// Pull from the site.
-	var want []wantBlob
+	// Pull from the site, remembering what the site has.
 	onSite := map[cid.Cid]struct{}{}
-	for {
-		blob, err := stream.Recv()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return err
-		}
-
-		c, err := cid.Cast(blob.Cid)
-		if err != nil {
-			return fmt.Errorf("failed to parse CID of a blob: %w", err)
-		}
-
+	if err := syncer.PullFromPeer(ctx, ai.ID, p, func(c cid.Cid) {
 		onSite[c] = struct{}{}
-
-		ok, err := bs.Has(ctx, c)
-		if err != nil {
-			return fmt.Errorf("failed to check if we have blob %s: %w", c, err)
-		}
-		if ok {
-			continue
-		}
-
-		want = append(want, wantBlob{ID: c, Cursor: blob.Cursor})
-	}
-
-	// Pulling those blobs we want from site.
-	if len(want) > 0 {
-		syncing.MSyncingWantedBlobs.WithLabelValues("groups").Add(float64(len(want)))
-		defer syncing.MSyncingWantedBlobs.WithLabelValues("groups").Sub(float64(len(want)))
-
-		sess := n.Bitswap().NewSession(ctx)
-		var lastSavedCursor string
-		for i, c := range want {
-			blk, err := sess.GetBlock(ctx, c.ID)
-			if err != nil {
-				return fmt.Errorf("failed to get blob %s from site: %w", c, err)
-			}
-
-			if err := bs.Put(ctx, blk); err != nil {
-				return fmt.Errorf("failed to put blob %s from site: %w", c, err)
-			}
-
-			if i%50 == 0 {
-				if err := syncing.SaveCursor(ctx, srv.db.db, remotePrincipal, c.Cursor); err != nil {
-					return err
-				}
-				lastSavedCursor = c.Cursor
-			}
-		}
-
-		lastCursor := want[len(want)-1].Cursor
-		if lastSavedCursor != lastCursor {
-			if err := syncing.SaveCursor(ctx, srv.db.db, remotePrincipal, lastCursor); err != nil {
-				return err
-			}
-		}
+	}); err != nil {
+		return nil, fmt.Errorf("failed to pull from the site: %w", err)
 	}
 
 	// Pushing to site if we can.
 	{
 		sc, err := n.SiteClient(ctx, ai.ID)
 		if err != nil {
-			return fmt.Errorf("failed to get site client: %w", err)
+			return nil, fmt.Errorf("failed to get site client: %w", err)
 		}
 
-		// This is a nasty way to check if we are allowed to push to the site.
-		if _, err := sc.PublishBlobs(ctx, &groups.PublishBlobsRequest{}); status.Code(err) == codes.PermissionDenied {
-			return nil
+		perms, err = sc.GetCallerPermissions(ctx, &groups.GetCallerPermissionsRequest{})
+		if err != nil && status.Code(err) != codes.Unimplemented {
+			return nil, fmt.Errorf("failed to get permissions on the site: %w", err)
+		}
+
+		// Older sites don't report permissions, so we just try to push and see if it's denied.
+		if perms != nil && !slices.Contains(perms.AllowedOperations, groups.SiteOperation_PUBLISH_BLOBS) {
+			return perms, nil
 		}
 
-		// Reusing the same slice to reduce allocations.
-		missingOnSite := want[:0]
+		var missingOnSite []cid.Cid
 
 		// Collect relevant blobs locally
 		if err := srv.db.ForEachRelatedBlob(ctx, hyper.EntityID(sr.GroupID), func(c cid.Cid) error {
@@ -365,27 +325,40 @@ func (srv *Server) syncGroupSite(ctx context.Context, group string, interval tim
 				return nil
 			}
 
-			missingOnSite = append(missingOnSite, wantBlob{ID: c}) // no cursor here.
+			missingOnSite = append(missingOnSite, c)
 
 			return nil
 		}); err != nil {
-			return err
+			return perms, err
 		}
 
-		if len(missingOnSite) == 0 {
-			return nil
+		// Pushing in batches to respect the limits of the site.
+		batchSize := int(perms.GetStorageLimits().GetMaxBlobsPerRequest())
+		if batchSize <= 0 {
+			batchSize = len(missingOnSite)
 		}
 
-		if _, err := sc.PublishBlobs(ctx, &groups.PublishBlobsRequest{
-			Blobs: colx.SliceMap(missingOnSite, func(w wantBlob) string {
-				return w.ID.String()
-			}),
-		}); err != nil {
-			return fmt.Errorf("failed to push blobs to the site: %w", err)
+		for len(missingOnSite) > 0 {
+			size := len(missingOnSite)
+			if size > batchSize {
+				size = batchSize
+			}
+			batch := missingOnSite[:size]
+			missingOnSite = missingOnSite[size:]
+
+			if _, err := sc.PublishBlobs(ctx, &groups.PublishBlobsRequest{
+				Blobs: colx.SliceMap(batch, cid.Cid.String),
+			}); err != nil {
+				if perms == nil && status.Code(err) == codes.PermissionDenied {
+					return &groups.CallerPermissions{PublishDeniedReason: status.Convert(err).Message()}, nil
+				}
+				return perms, fmt.Errorf("failed to push blobs to the site: %w", err)
+			}
+			p.AddPushed(len(batch))
 		}
 	}
 
-	return nil
+	return perms, nil
 }
 
 // CreateGroup creates a new group.
@@ -394,7 +367,7 @@ func (srv *Server) CreateGroup(ctx context.Context, in *groups.CreateGroupReques
 		return nil, errutil.MissingArgument("title")
 	}
 
-	me, err := srv.getMe()
+	me, err := srv.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -417,6 +390,10 @@ func (srv *Server) CreateGroup(ctx context.Context, in *groups.CreateGroupReques
 		patch["description"] = in.Description
 	}
 
+	if in.Private {
+		patch["private"] = true
+	}
+
 	if in.Members != nil {
 		return nil, status.Errorf(codes.Unimplemented, "adding members when creating a group is not implemented yet")
 	}
@@ -444,9 +421,53 @@ func (srv *Server) CreateGroup(ctx context.Context, in *groups.CreateGroupReques
 		return nil, err
 	}
 
+	// The first content key of a private group is only distributed to our own devices.
+	if in.Private {
+		if err := srv.distributeContentKeys(ctx, e, me, del, true, false); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv.groupToProto(ctx, e)
 }
 
+// distributeContentKeys creates GroupKey blobs distributing the content keys of the private group
+// to the devices of the current members. When rotate is true a new content key is created,
+// so that removed members can't read the content created after their removal.
+// When history is true all the previous keys are distributed again, so that new members can read the existing content.
+func (srv *Server) distributeContentKeys(ctx context.Context, e *hyper.Entity, me core.Identity, del cid.Cid, rotate, history bool) error {
+	var keys []hyper.ContentKey
+	if history {
+		old, err := srv.blobs.ContentKeys(ctx, e.ID())
+		if err != nil {
+			return err
+		}
+		keys = old
+	}
+
+	// The new key must be distributed last to become the current one.
+	if rotate {
+		key, err := hyper.NewContentKey(e.ID())
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		hb, err := srv.blobs.CreateGroupKey(ctx, e, key, me.DeviceKey(), del)
+		if err != nil {
+			return err
+		}
+
+		if err := srv.blobs.SaveBlob(ctx, hb); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (srv *Server) initSiteServer(ctx context.Context, setupURL string, groupID hyper.EntityID) (baseURL string, err error) {
 	n, err := srv.node.Await(ctx)
 	if err != nil {
@@ -552,7 +573,7 @@ func (srv *Server) UpdateGroup(ctx context.Context, in *groups.UpdateGroupReques
 		return nil, errutil.MissingArgument("id")
 	}
 
-	me, err := srv.getMe()
+	me, err := srv.getMe(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -590,13 +611,34 @@ func (srv *Server) UpdateGroup(ctx context.Context, in *groups.UpdateGroupReques
 		}
 	}
 
+	// Removed members must not be able to read the content created after their removal,
+	// so private groups get a new content key when members are removed.
+	var (
+		membersAdded   bool
+		membersRemoved bool
+	)
 	for k, v := range in.UpdatedMembers {
 		if v == groups.Role_ROLE_UNSPECIFIED {
-			return nil, status.Errorf(codes.Unimplemented, "removing members is not implemented yet")
+			membersRemoved = true
+		} else {
+			membersAdded = true
 		}
 		colx.ObjectSet(patch, []string{"members", k}, int64(v))
 	}
 
+	// Checking the permissions before setting up the site,
+	// because changes without enough permissions would be excluded from the group anyway.
+	if in.SiteSetupUrl != "" {
+		patch["siteURL"] = in.SiteSetupUrl
+	}
+
+	if err := hyper.AuthorizeGroupChange(e, me.Account().Principal(), patch); err != nil {
+		if errors.Is(err, hyper.ErrGroupPermissionDenied) {
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return nil, err
+	}
+
 	del, err := srv.getDelegation(ctx)
 	if err != nil {
 		return nil, err
@@ -620,6 +662,12 @@ func (srv *Server) UpdateGroup(ctx context.Context, in *groups.UpdateGroupReques
 		return nil, err
 	}
 
+	if private, _ := e.Get("private"); private == true && (membersAdded || membersRemoved) {
+		if err := srv.distributeContentKeys(ctx, e, me, del, membersRemoved, membersAdded); err != nil {
+			return nil, err
+		}
+	}
+
 	grouppb, err := srv.groupToProto(ctx, e)
 	if err != nil {
 		return nil, err
@@ -631,7 +679,7 @@ func (srv *Server) UpdateGroup(ctx context.Context, in *groups.UpdateGroupReques
 			go func() {
 				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 				defer cancel()
-				if err := srv.syncGroupSite(ctx, in.Id, 0); err != nil {
+				if _, err := srv.syncGroupSite(ctx, in.Id, 0, syncing.PriorityInteractive); err != nil {
 					srv.log.Error("PushGroupToSiteError", zap.String("groupID", in.Id), zap.String("siteURL", vv), zap.Error(err))
 				}
 			}()
@@ -748,7 +796,7 @@ func (srv *Server) ListMembers(ctx context.Context, in *groups.ListMembersReques
 
 		resp.OwnerAccountId = core.Principal(ownerPub.PublicKeysPrincipal).String()
 
-		return hypersql.GroupListMembers(conn, edb.ResourcesID, owner, func(principal []byte, role int64) error {
+		return hypersql.GroupListMembers(conn, edb.ResourcesID, func(principal []byte, role int64) error {
 			if resp.Members == nil {
 				resp.Members = make(map[string]groups.Role)
 			}
@@ -910,9 +958,9 @@ func (srv *Server) ListAccountGroups(ctx context.Context, in *groups.ListAccount
 }
 
 // This query assumes that we've indexed only valid changes,
-// i.e. group members are only mutated by the owner.
-// TODO(burdiyan): support member removals and make sure to query
-// only valid changes.
+// i.e. group members are only mutated by the owner or admins.
+// Members whose latest role is unspecified were removed from the group.
+// TODO(burdiyan): make sure to query only valid changes.
 var qListAccountGroups = dqb.Str(`
 	SELECT
 		resources.iri AS entity,
@@ -924,6 +972,7 @@ var qListAccountGroups = dqb.Str(`
 	WHERE resource_links.type = 'group/member'
 	AND resource_links.target = :member
 	GROUP BY structural_blobs.resource
+	HAVING role > 0
 `)
 
 func (srv *Server) groupToProto(ctx context.Context, e *hyper.Entity) (*groups.Group, error) {
@@ -979,6 +1028,10 @@ func (srv *Server) groupToProto(ctx context.Context, e *hyper.Entity) (*groups.G
 		}
 	}
 
+	if v, ok := e.Get("private"); ok {
+		gpb.Private, _ = v.(bool)
+	}
+
 	return gpb, nil
 }
 
@@ -989,16 +1042,12 @@ func maybeTimeToProto(t time.Time) *timestamppb.Timestamp {
 	return timestamppb.New(t)
 }
 
-func (srv *Server) getMe() (core.Identity, error) {
-	me, ok := srv.me.Get()
-	if !ok {
-		return core.Identity{}, status.Errorf(codes.FailedPrecondition, "account is not initialized yet")
-	}
-	return me, nil
+func (srv *Server) getMe(ctx context.Context) (core.Identity, error) {
+	return apiutil.Identity(ctx, srv.me)
 }
 
 func (srv *Server) getDelegation(ctx context.Context) (cid.Cid, error) {
-	me, err := srv.getMe()
+	me, err := srv.getMe(ctx)
 	if err != nil {
 		return cid.Undef, err
 	}
@@ -11,11 +11,14 @@ import (
 	"mintter/backend/logging"
 	"mintter/backend/mttnet"
 	"mintter/backend/pkg/future"
+	"mintter/backend/syncing"
 	"mintter/backend/testutil"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -566,6 +569,462 @@ func TestBug_MustConsiderMemberChangesToo(t *testing.T) {
 	testutil.ProtoEqual(t, group, gotGroup, "get group response must match in Alice")
 }
 
+func TestGroupRoles(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestSrv(t, "alice")
+	bob := newTestSrv(t, "bob")
+	carol := newTestSrv(t, "carol")
+	david := coretest.NewTester("david")
+	ctx := context.Background()
+
+	var (
+		aliceID = alice.me.MustGet().Account().Principal().String()
+		bobID   = bob.me.MustGet().Account().Principal().String()
+		carolID = carol.me.MustGet().Account().Principal().String()
+		davidID = david.Account.Principal().String()
+	)
+
+	group, err := alice.CreateGroup(ctx, &groups.CreateGroupRequest{
+		Title: "My Group",
+	})
+	require.NoError(t, err)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groups.Role{
+			bobID:   groups.Role_ADMIN,
+			carolID: groups.Role_EDITOR,
+		},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+
+	// Admins can manage members which are not admins.
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groups.Role{
+			carolID: groups.Role_VIEWER,
+			davidID: groups.Role_EDITOR,
+		},
+	})
+	require.NoError(t, err, "admin must be able to manage members")
+
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{davidID: groups.Role_ADMIN},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "only owner can grant admin role")
+
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{aliceID: groups.Role_EDITOR},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "role of the owner can't be changed")
+
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:    group.Id,
+		Title: "Bob's Group",
+	})
+	require.NoError(t, err, "admin must be able to edit the group")
+
+	syncBlobs(t, bob, carol)
+
+	// Viewers can't change the group.
+	_, err = carol.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:    group.Id,
+		Title: "Carol's Group",
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "viewer must not be able to edit the group")
+
+	want := &groups.ListMembersResponse{
+		OwnerAccountId: aliceID,
+		Members: map[string]groups.Role{
+			aliceID: groups.Role_OWNER,
+			bobID:   groups.Role_ADMIN,
+			carolID: groups.Role_VIEWER,
+			davidID: groups.Role_EDITOR,
+		},
+	}
+
+	syncBlobs(t, bob, alice)
+	for _, srv := range []*Server{alice, bob, carol} {
+		members, err := srv.ListMembers(ctx, &groups.ListMembersRequest{Id: group.Id})
+		require.NoError(t, err)
+		testutil.ProtoEqual(t, want, members, "list members response must match")
+		requireGroupRoles(t, srv, group.Id, want.Members)
+	}
+}
+
+func TestGroupDemotionRace(t *testing.T) {
+	// Alice demotes Bob from admin, while Bob concurrently adds Carol and edits the group.
+	// Bob's concurrent changes must be accepted, because Bob was an admin when making them,
+	// but no changes Bob makes after learning about the demotion.
+
+	t.Parallel()
+
+	alice := newTestSrv(t, "alice")
+	bob := newTestSrv(t, "bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+
+	var (
+		aliceID = alice.me.MustGet().Account().Principal().String()
+		bobID   = bob.me.MustGet().Account().Principal().String()
+		carolID = carol.Account.Principal().String()
+	)
+
+	group, err := alice.CreateGroup(ctx, &groups.CreateGroupRequest{
+		Title: "My Group",
+	})
+	require.NoError(t, err)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{bobID: groups.Role_ADMIN},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{bobID: groups.Role_VIEWER},
+	})
+	require.NoError(t, err)
+
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		Title:          "Bob's Group",
+		UpdatedMembers: map[string]groups.Role{carolID: groups.Role_EDITOR},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+	syncBlobs(t, bob, alice)
+
+	wantMembers := map[string]groups.Role{
+		aliceID: groups.Role_OWNER,
+		bobID:   groups.Role_VIEWER,
+		carolID: groups.Role_EDITOR,
+	}
+
+	for _, srv := range []*Server{alice, bob} {
+		g, err := srv.GetGroup(ctx, &groups.GetGroupRequest{Id: group.Id})
+		require.NoError(t, err)
+		require.Equal(t, "Bob's Group", g.Title, "concurrent change of the demoted admin must be applied")
+		requireGroupRoles(t, srv, group.Id, wantMembers)
+	}
+
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:    group.Id,
+		Title: "Bob's Group Again",
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "demoted admin must not be able to edit the group")
+
+	// Changes made bypassing the permission checks must be stored, but excluded from the group state.
+	forceUpdateGroup(t, bob, group.Id, map[string]any{
+		"title":   "Forged Group",
+		"members": map[string]any{bobID: int64(groups.Role_ADMIN)},
+	})
+
+	syncBlobs(t, bob, alice)
+
+	for _, srv := range []*Server{alice, bob} {
+		g, err := srv.GetGroup(ctx, &groups.GetGroupRequest{Id: group.Id})
+		require.NoError(t, err)
+		require.Equal(t, "Bob's Group", g.Title, "unauthorized change must be excluded from the group state")
+		requireGroupRoles(t, srv, group.Id, wantMembers)
+	}
+
+	// The group must still be editable on top of the unauthorized change.
+	g, err := alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:    group.Id,
+		Title: "Alice's Group",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Alice's Group", g.Title)
+}
+
+func TestGroupPromotionRace(t *testing.T) {
+	// Alice promotes Bob to admin, while Bob concurrently adds Carol as an editor.
+	// Bob's change must be excluded even after the promotion,
+	// because Bob was not an admin when making it.
+
+	t.Parallel()
+
+	alice := newTestSrv(t, "alice")
+	bob := newTestSrv(t, "bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+
+	var (
+		aliceID = alice.me.MustGet().Account().Principal().String()
+		bobID   = bob.me.MustGet().Account().Principal().String()
+		carolID = carol.Account.Principal().String()
+	)
+
+	group, err := alice.CreateGroup(ctx, &groups.CreateGroupRequest{
+		Title: "My Group",
+	})
+	require.NoError(t, err)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{bobID: groups.Role_EDITOR},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{bobID: groups.Role_ADMIN},
+	})
+	require.NoError(t, err)
+
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{carolID: groups.Role_EDITOR},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "editor must not be able to manage members")
+
+	forceUpdateGroup(t, bob, group.Id, map[string]any{
+		"members": map[string]any{carolID: int64(groups.Role_EDITOR)},
+	})
+
+	syncBlobs(t, alice, bob)
+	syncBlobs(t, bob, alice)
+
+	wantMembers := map[string]groups.Role{
+		aliceID: groups.Role_OWNER,
+		bobID:   groups.Role_ADMIN,
+	}
+
+	for _, srv := range []*Server{alice, bob} {
+		requireGroupRoles(t, srv, group.Id, wantMembers)
+	}
+
+	// After learning about the promotion Bob can manage members.
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{carolID: groups.Role_EDITOR},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, bob, alice)
+
+	wantMembers[carolID] = groups.Role_EDITOR
+	for _, srv := range []*Server{alice, bob} {
+		requireGroupRoles(t, srv, group.Id, wantMembers)
+	}
+}
+
+func TestGroupConcurrentRoleChanges(t *testing.T) {
+	// Alice and Bob, who is an admin, concurrently change the role of Carol.
+	// Both changes are authorized, and both peers must converge to the same role.
+
+	t.Parallel()
+
+	alice := newTestSrv(t, "alice")
+	bob := newTestSrv(t, "bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+
+	var (
+		aliceID = alice.me.MustGet().Account().Principal().String()
+		bobID   = bob.me.MustGet().Account().Principal().String()
+		carolID = carol.Account.Principal().String()
+	)
+
+	group, err := alice.CreateGroup(ctx, &groups.CreateGroupRequest{
+		Title: "My Group",
+	})
+	require.NoError(t, err)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{bobID: groups.Role_ADMIN},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{carolID: groups.Role_EDITOR},
+	})
+	require.NoError(t, err)
+
+	_, err = bob.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id:             group.Id,
+		UpdatedMembers: map[string]groups.Role{carolID: groups.Role_VIEWER},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+	syncBlobs(t, bob, alice)
+
+	// Bob's change is the latest one.
+	wantMembers := map[string]groups.Role{
+		aliceID: groups.Role_OWNER,
+		bobID:   groups.Role_ADMIN,
+		carolID: groups.Role_VIEWER,
+	}
+
+	for _, srv := range []*Server{alice, bob} {
+		requireGroupRoles(t, srv, group.Id, wantMembers)
+	}
+}
+
+func TestPrivateGroupKeys(t *testing.T) {
+	// Alice creates a private group with bob and carol as members, then removes carol, and adds david.
+	// Carol must not receive the content key created after her removal,
+	// and david must receive all the keys created before joining.
+
+	t.Parallel()
+
+	alice := newTestSrv(t, "alice")
+	bob := newTestSrv(t, "bob")
+	carol := newTestSrv(t, "carol")
+	ctx := context.Background()
+
+	// Alice must know the devices of the members to distribute the keys to them.
+	syncBlobs(t, bob, alice)
+	syncBlobs(t, carol, alice)
+
+	group, err := alice.CreateGroup(ctx, &groups.CreateGroupRequest{
+		Title:   "Secret Group",
+		Private: true,
+	})
+	require.NoError(t, err)
+	require.True(t, group.Private, "group must be private")
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groups.Role{
+			bob.me.MustGet().Account().Principal().String():   groups.Role_EDITOR,
+			carol.me.MustGet().Account().Principal().String(): groups.Role_EDITOR,
+		},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+	syncBlobs(t, alice, carol)
+
+	want, err := alice.blobs.CurrentContentKey(ctx, hyper.EntityID(group.Id))
+	require.NoError(t, err)
+
+	for _, srv := range []*Server{bob, carol} {
+		key, err := srv.blobs.CurrentContentKey(ctx, hyper.EntityID(group.Id))
+		require.NoError(t, err)
+		require.Equal(t, want, key, "members must have the content key of the group")
+	}
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groups.Role{
+			carol.me.MustGet().Account().Principal().String(): groups.Role_ROLE_UNSPECIFIED,
+		},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, bob)
+	syncBlobs(t, alice, carol)
+
+	rotated, err := alice.blobs.CurrentContentKey(ctx, hyper.EntityID(group.Id))
+	require.NoError(t, err)
+	require.NotEqual(t, want.ID, rotated.ID, "content key must be rotated after removing a member")
+
+	key, err := bob.blobs.CurrentContentKey(ctx, hyper.EntityID(group.Id))
+	require.NoError(t, err)
+	require.Equal(t, rotated, key, "bob must receive the new content key")
+
+	_, err = carol.blobs.CurrentContentKey(ctx, hyper.EntityID(group.Id))
+	require.ErrorIs(t, err, hyper.ErrNoContentKey, "carol must not receive the new content key")
+
+	requireGroupRoles(t, carol, group.Id, map[string]groups.Role{
+		alice.me.MustGet().Account().Principal().String(): groups.Role_OWNER,
+		bob.me.MustGet().Account().Principal().String():   groups.Role_EDITOR,
+	})
+
+	carolGroups, err := carol.ListAccountGroups(ctx, &groups.ListAccountGroupsRequest{
+		AccountId: carol.me.MustGet().Account().Principal().String(),
+	})
+	require.NoError(t, err)
+	require.Len(t, carolGroups.Items, 0, "carol must not be a member after removal")
+
+	e, err := alice.blobs.LoadEntityAll(ctx, hyper.EntityID(group.Id))
+	require.NoError(t, err)
+	require.Error(t, hyper.AuthorizeGroupChange(e, alice.me.MustGet().Account().Principal(), map[string]any{"private": false}), "privacy of the group can't be changed")
+
+	// New members must be able to read the content created before they joined,
+	// so they must receive all the previous keys too.
+	david := newTestSrv(t, "david")
+	syncBlobs(t, david, alice)
+
+	_, err = alice.UpdateGroup(ctx, &groups.UpdateGroupRequest{
+		Id: group.Id,
+		UpdatedMembers: map[string]groups.Role{
+			david.me.MustGet().Account().Principal().String(): groups.Role_EDITOR,
+		},
+	})
+	require.NoError(t, err)
+
+	syncBlobs(t, alice, david)
+
+	davidKeys, err := david.blobs.ContentKeys(ctx, hyper.EntityID(group.Id))
+	require.NoError(t, err)
+	require.Equal(t, []hyper.ContentKey{want, rotated}, davidKeys, "new members must receive the whole key history")
+
+	key, err = david.blobs.CurrentContentKey(ctx, hyper.EntityID(group.Id))
+	require.NoError(t, err)
+	require.Equal(t, rotated, key, "redistributing old keys must not change the current key")
+}
+
+// requireGroupRoles checks that both the indexed members of the group
+// and the roles in the group state match the wanted roles.
+func requireGroupRoles(t *testing.T, srv *Server, group string, want map[string]groups.Role) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	members, err := srv.ListMembers(ctx, &groups.ListMembersRequest{Id: group})
+	require.NoError(t, err)
+	require.Equal(t, want, members.Members, "indexed group members must match")
+
+	e, err := srv.blobs.LoadEntityAll(ctx, hyper.EntityID(group))
+	require.NoError(t, err)
+	for acc, wantRole := range want {
+		aid, err := core.DecodePrincipal(acc)
+		require.NoError(t, err)
+
+		role, err := hyper.GroupRole(e, aid)
+		require.NoError(t, err)
+		require.Equal(t, wantRole, role, "role of %s in the group state must match", acc)
+	}
+}
+
+// forceUpdateGroup creates a group change without checking the permissions,
+// like a peer with a different implementation could do.
+func forceUpdateGroup(t *testing.T, srv *Server, group string, patch map[string]any) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	e, err := srv.blobs.LoadEntityAll(ctx, hyper.EntityID(group))
+	require.NoError(t, err)
+
+	del, err := srv.getDelegation(ctx)
+	require.NoError(t, err)
+
+	hb, err := e.CreateChange(e.NextTimestamp(), srv.me.MustGet().DeviceKey(), del, patch, hyper.WithAction("Update"))
+	require.NoError(t, err)
+
+	require.NoError(t, srv.blobs.SaveBlob(ctx, hb))
+}
+
 func syncBlobs(t *testing.T, src, target *Server) {
 	ctx := context.Background()
 	srcKeys, err := src.blobs.IPFSBlockstore().AllKeysChan(ctx)
@@ -591,9 +1050,10 @@ func newTestSrv(t *testing.T, name string) *Server {
 	require.NoError(t, fut.Resolve(u.Identity))
 
 	bs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
+	bs.SetDeviceKey(u.Device)
 
 	node := future.New[*mttnet.Node]()
-	srv := NewServer(fut.ReadOnly, logging.New("mintter/groups", "debug"), NewSQLiteDB(db), bs, node.ReadOnly)
+	srv := NewServer(fut.ReadOnly, logging.New("mintter/groups", "debug"), NewSQLiteDB(db), bs, node.ReadOnly, future.New[*syncing.Service]().ReadOnly)
 
 	_, err := daemon.Register(context.Background(), bs, u.Account, u.Device.PublicKey, time.Now())
 	require.NoError(t, err)
@@ -12,11 +12,14 @@ import (
 	"mintter/backend/pkg/future"
 	"net/netip"
 	"strings"
+	"time"
 
 	"crawshaw.io/sqlite"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Server implements the networking API.
@@ -167,6 +170,106 @@ func (srv *Server) GetPeerInfo(ctx context.Context, in *networking.GetPeerInfoRe
 	return resp, nil
 }
 
+// GetPeerPolicy implements the corresponding gRPC method.
+func (srv *Server) GetPeerPolicy(ctx context.Context, in *networking.GetPeerPolicyRequest) (*networking.PeerPolicy, error) {
+	net, err := srv.getNet()
+	if err != nil {
+		return nil, err
+	}
+
+	policy := net.Policy().Snapshot()
+
+	out := &networking.PeerPolicy{
+		Allowed: policy.Allowed,
+		Denied:  policy.Denied,
+		Bans:    make([]*networking.PeerBan, len(policy.Bans)),
+	}
+
+	for i, ban := range policy.Bans {
+		out.Bans[i] = &networking.PeerBan{
+			DeviceId:   ban.Peer.String(),
+			ExpireTime: timestamppb.New(ban.ExpireTime),
+			Violations: int32(ban.Violations),
+		}
+	}
+
+	return out, nil
+}
+
+// SetPeerAccess implements the corresponding gRPC method.
+func (srv *Server) SetPeerAccess(ctx context.Context, in *networking.SetPeerAccessRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "must specify peer or account id")
+	}
+
+	net, err := srv.getNet()
+	if err != nil {
+		return nil, err
+	}
+
+	// PeerAccess is a 1-to-1 mapping for the policy access levels.
+	if err := net.Policy().SetAccess(ctx, in.Id, mttnet.PeerAccess(in.Access)); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// BanPeer implements the corresponding gRPC method.
+func (srv *Server) BanPeer(ctx context.Context, in *networking.BanPeerRequest) (*emptypb.Empty, error) {
+	pid, err := decodeDeviceID(in.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	var d time.Duration
+	if in.ExpireTime != nil {
+		d = time.Until(in.ExpireTime.AsTime())
+		if d <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "expire time must be in the future")
+		}
+	}
+
+	net, err := srv.getNet()
+	if err != nil {
+		return nil, err
+	}
+
+	net.Policy().Ban(pid, d)
+
+	return &emptypb.Empty{}, nil
+}
+
+// UnbanPeer implements the corresponding gRPC method.
+func (srv *Server) UnbanPeer(ctx context.Context, in *networking.UnbanPeerRequest) (*emptypb.Empty, error) {
+	pid, err := decodeDeviceID(in.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	net, err := srv.getNet()
+	if err != nil {
+		return nil, err
+	}
+
+	net.Policy().Unban(pid)
+
+	return &emptypb.Empty{}, nil
+}
+
+func decodeDeviceID(id string) (peer.ID, error) {
+	if id == "" {
+		return "", status.Error(codes.InvalidArgument, "must specify device id")
+	}
+
+	pid, err := peer.Decode(id)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "failed to parse peer ID %s: %v", id, err)
+	}
+
+	return pid, nil
+}
+
 func (srv *Server) getNet() (*mttnet.Node, error) {
 	net, ok := srv.net.Get()
 	if !ok {
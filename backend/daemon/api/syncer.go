@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"errors"
+	daemonpb "mintter/backend/genproto/daemon/v1alpha"
+	"mintter/backend/pkg/colx"
+	"mintter/backend/pkg/future"
+	"mintter/backend/syncing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// syncProgressInterval is how often we report the progress of a forced sync.
+const syncProgressInterval = time.Second
+
+// lazySyncer implements the syncer for the Daemon API on top of the syncing service,
+// which is only available after the account is initialized.
+type lazySyncer struct {
+	ctx  context.Context
+	sync *future.ReadOnly[*syncing.Service]
+}
+
+func (ls *lazySyncer) service() (*syncing.Service, error) {
+	s, ok := ls.sync.Get()
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "account is not initialized yet")
+	}
+	return s, nil
+}
+
+// ForceSync implements the Syncer interface.
+func (ls *lazySyncer) ForceSync() error {
+	s, err := ls.service()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.SyncAllAndLog(ls.ctx); err != nil {
+			panic("bug or fatal error during sync " + err.Error())
+		}
+	}()
+
+	return nil
+}
+
+// SyncWithProgress implements the Syncer interface.
+func (ls *lazySyncer) SyncWithProgress(ctx context.Context, send func(*daemonpb.SyncProgress) error) error {
+	s, err := ls.service()
+	if err != nil {
+		return err
+	}
+
+	// Jobs finished before the sync are not part of the progress.
+	reported := make(map[int64]struct{})
+	{
+		_, recent := s.Jobs()
+		for _, j := range recent {
+			reported[j.ID] = struct{}{}
+		}
+	}
+
+	progress := func() *daemonpb.SyncProgress {
+		active, recent := s.Jobs()
+		out := &daemonpb.SyncProgress{
+			ActiveJobs: colx.SliceMap(active, syncJobToProto),
+		}
+
+		// Recent jobs are ordered from the most recent, but we want to report them in the order they've finished.
+		for i := len(recent) - 1; i >= 0; i-- {
+			if _, ok := reported[recent[i].ID]; ok {
+				continue
+			}
+			reported[recent[i].ID] = struct{}{}
+			out.FinishedJobs = append(out.FinishedJobs, syncJobToProto(recent[i]))
+		}
+
+		return out
+	}
+
+	type result struct {
+		res syncing.SyncResult
+		err error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		res, err := s.SyncAll(ctx)
+		done <- result{res: res, err: err}
+	}()
+
+	t := time.NewTicker(syncProgressInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			if err := send(progress()); err != nil {
+				return err
+			}
+		case r := <-done:
+			if r.err != nil {
+				if errors.Is(r.err, syncing.ErrSyncAlreadyRunning) {
+					return status.Error(codes.Aborted, r.err.Error())
+				}
+				return r.err
+			}
+
+			out := progress()
+			out.Done = true
+			out.NumSyncOk = r.res.NumSyncOK
+			out.NumSyncFailed = r.res.NumSyncFailed
+			return send(out)
+		}
+	}
+}
+
+// ListSyncJobs implements the Syncer interface.
+func (ls *lazySyncer) ListSyncJobs() (active, recent []*daemonpb.SyncJob, err error) {
+	s, err := ls.service()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, r := s.Jobs()
+
+	return colx.SliceMap(a, syncJobToProto), colx.SliceMap(r, syncJobToProto), nil
+}
+
+// SyncStatus implements the Syncer interface.
+func (ls *lazySyncer) SyncStatus(ctx context.Context, kind daemonpb.SyncJobKind, target string) ([]*daemonpb.SyncTargetStatus, error) {
+	s, err := ls.service()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.SyncStatus(ctx, syncing.JobKind(kind), target)
+	if err != nil {
+		return nil, err
+	}
+
+	return colx.SliceMap(statuses, syncStatusToProto), nil
+}
+
+// SyncHistory implements the Syncer interface.
+func (ls *lazySyncer) SyncHistory(ctx context.Context, kind daemonpb.SyncJobKind, target string, beforeID int64, limit int) ([]*daemonpb.SyncAttempt, error) {
+	s, err := ls.service()
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.SyncHistory(ctx, syncing.JobKind(kind), target, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return colx.SliceMap(attempts, syncAttemptToProto), nil
+}
+
+func syncJobToProto(j syncing.JobInfo) *daemonpb.SyncJob {
+	out := &daemonpb.SyncJob{
+		Id:           j.ID,
+		Kind:         daemonpb.SyncJobKind(j.Kind), // SyncJobKind is a 1-to-1 mapping for the job kind.
+		Target:       j.Target,
+		Priority:     int32(j.Priority),
+		State:        daemonpb.SyncJobState(j.State), // SyncJobState is a 1-to-1 mapping for the job state.
+		CreateTime:   timestamppb.New(j.CreateTime),
+		WantedBlobs:  j.Progress.WantedBlobs,
+		FetchedBlobs: j.Progress.FetchedBlobs,
+		FailedBlobs:  j.Progress.FailedBlobs,
+		PushedBlobs:  j.Progress.PushedBlobs,
+		Attempt:      int32(j.Attempt),
+	}
+
+	if !j.StartTime.IsZero() {
+		out.StartTime = timestamppb.New(j.StartTime)
+	}
+
+	if !j.EndTime.IsZero() {
+		out.EndTime = timestamppb.New(j.EndTime)
+	}
+
+	if j.Err != nil {
+		out.Error = j.Err.Error()
+	}
+
+	return out
+}
+
+func syncAttemptToProto(a syncing.SyncAttempt) *daemonpb.SyncAttempt {
+	return &daemonpb.SyncAttempt{
+		Id:           a.ID,
+		Kind:         daemonpb.SyncJobKind(a.Kind),
+		Target:       a.Target,
+		StartTime:    timestamppb.New(a.StartTime),
+		EndTime:      timestamppb.New(a.EndTime),
+		DurationMs:   a.EndTime.Sub(a.StartTime).Milliseconds(),
+		WantedBlobs:  a.Progress.WantedBlobs,
+		FetchedBlobs: a.Progress.FetchedBlobs,
+		FailedBlobs:  a.Progress.FailedBlobs,
+		PushedBlobs:  a.Progress.PushedBlobs,
+		Error:        a.Error,
+		Cursor:       a.Progress.Cursor,
+	}
+}
+
+func syncStatusToProto(st syncing.SyncStatus) *daemonpb.SyncTargetStatus {
+	out := &daemonpb.SyncTargetStatus{
+		Kind:                daemonpb.SyncJobKind(st.LastAttempt.Kind),
+		Target:              st.LastAttempt.Target,
+		LastAttempt:         syncAttemptToProto(st.LastAttempt),
+		ConsecutiveFailures: int32(st.ConsecutiveFailures),
+	}
+
+	if !st.LastOKTime.IsZero() {
+		out.LastOkTime = timestamppb.New(st.LastOKTime)
+	}
+
+	return out
+}
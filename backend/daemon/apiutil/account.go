@@ -0,0 +1,118 @@
+package apiutil
+
+import (
+	"context"
+	"mintter/backend/core"
+	"mintter/backend/pkg/future"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AccountMetadataKey is the gRPC metadata key for selecting the account
+// on behalf of which the request is made, when multiple accounts are registered on the device.
+const AccountMetadataKey = "x-mintter-account"
+
+// AccountRegistry provides the accounts registered on this device.
+type AccountRegistry interface {
+	Account(core.Principal) (core.PublicKey, bool)
+	ActiveAccount() (core.PublicKey, bool)
+}
+
+type accountCtxKey struct{}
+
+// ContextWithAccount returns a context in which the requests are made on behalf of the account.
+func ContextWithAccount(ctx context.Context, acc core.PublicKey) context.Context {
+	return context.WithValue(ctx, accountCtxKey{}, acc)
+}
+
+// AccountFromContext returns the account selected for the request if there's any.
+func AccountFromContext(ctx context.Context) (core.PublicKey, bool) {
+	acc, ok := ctx.Value(accountCtxKey{}).(core.PublicKey)
+	return acc, ok
+}
+
+// Identity returns the identity on behalf of which the request is made.
+// It's the account selected for the request if there's any,
+// or the default identity otherwise, which must be resolved already.
+func Identity(ctx context.Context, me *future.ReadOnly[core.Identity]) (core.Identity, error) {
+	def, ok := me.Get()
+	if !ok {
+		return core.Identity{}, status.Errorf(codes.FailedPrecondition, "account is not initialized yet")
+	}
+
+	acc, ok := AccountFromContext(ctx)
+	if !ok {
+		return def, nil
+	}
+
+	return core.NewIdentity(acc, def.DeviceKey()), nil
+}
+
+// UnaryAccountInterceptor selects the account for unary requests.
+// See AccountMetadataKey.
+func UnaryAccountInterceptor(accs AccountRegistry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := selectAccount(ctx, accs)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamAccountInterceptor selects the account for streaming requests.
+// See AccountMetadataKey.
+func StreamAccountInterceptor(accs AccountRegistry) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := selectAccount(ss.Context(), accs)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &accountServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// selectAccount puts the account requested in the incoming metadata into the context,
+// falling back to the active account of the device.
+func selectAccount(ctx context.Context, accs AccountRegistry) (context.Context, error) {
+	var requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(AccountMetadataKey); len(v) > 0 {
+			requested = v[0]
+		}
+	}
+
+	if requested == "" {
+		acc, ok := accs.ActiveAccount()
+		if !ok {
+			return ctx, nil
+		}
+		return ContextWithAccount(ctx, acc), nil
+	}
+
+	aid, err := core.DecodePrincipal(requested)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode account %q from metadata: %v", requested, err)
+	}
+
+	acc, ok := accs.Account(aid)
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "account %s is not registered on this device", requested)
+	}
+
+	return ContextWithAccount(ctx, acc), nil
+}
+
+type accountServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *accountServerStream) Context() context.Context {
+	return s.ctx
+}
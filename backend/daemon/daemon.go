@@ -14,6 +14,7 @@ import (
 	"mintter/backend/config"
 	"mintter/backend/core"
 	"mintter/backend/daemon/api"
+	"mintter/backend/daemon/apiutil"
 	"mintter/backend/daemon/storage"
 	"mintter/backend/hyper"
 	"mintter/backend/logging"
@@ -120,6 +121,31 @@ func Load(ctx context.Context, cfg config.Config, r *storage.Dir, extraOpts ...i
 
 	me := a.Storage.Identity()
 
+	// Sealed content of private groups can only be opened once the device key is available.
+	// Same for the wallet credentials that were left in plain text because the key was locked during the migration.
+	a.g.Go(func() error {
+		id, err := me.Await(ctx)
+		if err != nil {
+			return err
+		}
+
+		a.Blobs.SetDeviceKey(id.DeviceKey())
+
+		conn, release, err := a.DB.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		if err := sqlitex.WithTx(conn, func() error {
+			return storage.SealWalletCredentials(conn, id.DeviceKey())
+		}); err != nil {
+			a.log.Warn("FailedToSealWalletCredentials", zap.Error(err))
+		}
+
+		return nil
+	})
+
 	a.Net, err = initNetwork(&a.clean, a.g, me, cfg.P2P, a.DB, a.Blobs, cfg.LogLevel, extraOpts...)
 	if err != nil {
 		return nil, err
@@ -139,7 +165,7 @@ func Load(ctx context.Context, cfg config.Config, r *storage.Dir, extraOpts ...i
 		}
 	}
 
-	a.GRPCServer, a.GRPCListener, a.RPC, err = initGRPC(ctx, cfg.GRPC.Port, &a.clean, a.g, a.Storage, a.DB, a.Blobs, a.Net, a.Syncing, a.Wallet, cfg.LogLevel, extraOpts...)
+	a.GRPCServer, a.GRPCListener, a.RPC, err = initGRPC(ctx, cfg.GRPC.Port, &a.clean, a.g, a.Storage, a.DB, a.Blobs, a.Net, a.Syncing, a.Wallet, cfg.GC, cfg.LogLevel, extraOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,14 +184,14 @@ func Load(ctx context.Context, cfg config.Config, r *storage.Dir, extraOpts ...i
 			e = offline.Exchange(bs)
 		}
 
-		files := mttnet.NewFileManager(logging.New("mintter/file-manager", cfg.LogLevel), bs, e, n.Provider())
+		files := mttnet.NewFileManager(logging.New("mintter/file-manager", cfg.LogLevel), bs, e, n.Provider(), a.Blobs)
 		if err := fm.fm.Resolve(files); err != nil {
 			return err
 		}
 		return nil
 	})
 
-	a.HTTPServer, a.HTTPListener, err = initHTTP(cfg.HTTP.Port, a.GRPCServer, &a.clean, a.g, a.Blobs, a.Wallet, fm, extraHTTPHandlers...)
+	a.HTTPServer, a.HTTPListener, err = initHTTP(cfg.HTTP.Port, a.GRPCServer, &a.clean, a.g, a.Blobs, a.Wallet, fm, a.RPC.Activity, extraHTTPHandlers...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,9 +204,51 @@ func Load(ctx context.Context, cfg config.Config, r *storage.Dir, extraOpts ...i
 		})
 	}
 
+	if cfg.GC.Interval > 0 {
+		a.g.Go(func() error {
+			return a.startPeriodicGC(ctx, cfg.GC)
+		})
+	}
+
 	return
 }
 
+// startPeriodicGC collects garbage in the blob storage every interval,
+// once the account is initialized.
+func (a *App) startPeriodicGC(ctx context.Context, cfg config.GC) error {
+	me, err := a.Storage.Identity().Await(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := hyper.GCOptions{
+		QuotaBytes:  cfg.QuotaBytes,
+		GracePeriod: cfg.GracePeriod,
+	}
+
+	t := time.NewTicker(cfg.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			report, err := a.Blobs.CollectGarbage(ctx, me.Account().Principal(), opts)
+			if err != nil {
+				a.log.Warn("GarbageCollectionFailed", zap.Error(err))
+				continue
+			}
+
+			a.log.Info("GarbageCollectionFinished",
+				zap.Int64("deletedBlobs", report.UnreachableBlobs),
+				zap.Int64("reclaimedBytes", report.ReclaimableBytes),
+				zap.Int("evictedEntities", len(report.EvictedEntities)),
+			)
+		}
+	}
+}
+
 type lazyFileManager struct {
 	fm future.Value[*mttnet.FileManager]
 }
@@ -387,6 +455,7 @@ func initGRPC(
 	node *future.ReadOnly[*mttnet.Node],
 	sync *future.ReadOnly[*syncing.Service],
 	wallet *wallet.Service,
+	gc config.GC,
 	LogLevel string,
 	extras ...interface{},
 ) (srv *grpc.Server, lis net.Listener, rpc api.Server, err error) {
@@ -395,7 +464,10 @@ func initGRPC(
 		return
 	}
 
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(apiutil.UnaryAccountInterceptor(repo)),
+		grpc.ChainStreamInterceptor(apiutil.StreamAccountInterceptor(repo)),
+	}
 	for _, extra := range extras {
 		if opt, ok := extra.(grpc.ServerOption); ok {
 			opts = append(opts, opt)
@@ -403,7 +475,7 @@ func initGRPC(
 	}
 	srv = grpc.NewServer(opts...)
 
-	rpc = api.New(ctx, repo, pool, blobs, node, sync, wallet, LogLevel)
+	rpc = api.New(ctx, repo, pool, blobs, node, sync, wallet, gc, LogLevel)
 	rpc.Register(srv)
 	reflection.Register(srv)
 
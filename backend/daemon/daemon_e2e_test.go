@@ -2,8 +2,12 @@ package daemon
 
 import (
 	"context"
+	"errors"
+	"io"
 	"math/rand"
 	"mintter/backend/core"
+	"mintter/backend/core/coretest"
+	"mintter/backend/daemon/storage"
 	accounts "mintter/backend/genproto/accounts/v1alpha"
 	daemon "mintter/backend/genproto/daemon/v1alpha"
 	documents "mintter/backend/genproto/documents/v1alpha"
@@ -13,6 +17,7 @@ import (
 	"mintter/backend/mttnet"
 	"mintter/backend/pkg/must"
 	"mintter/backend/testutil"
+	"net"
 	"strconv"
 	"testing"
 	"time"
@@ -22,7 +27,9 @@ import (
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -164,8 +171,8 @@ func TestMergeE2E(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal()))
-	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal(), bob.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal(), alice.Storage.Identity().MustGet().Account().Principal()))
 
 	time.Sleep(200 * time.Millisecond)
 
@@ -220,8 +227,8 @@ func TestRebaseE2E(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal()))
-	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal(), bob.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal(), alice.Storage.Identity().MustGet().Account().Principal()))
 
 	time.Sleep(200 * time.Millisecond)
 
@@ -313,8 +320,8 @@ func TestAPIDeleteAndRestoreEntity(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal()))
-	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal(), bob.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal(), alice.Storage.Identity().MustGet().Account().Principal()))
 
 	pub := publishDocument(t, ctx, alice, "", "", "")
 	linkedDoc := publishDocument(t, ctx, alice, pub.Document.Id+"?v="+pub.Version+"#"+pub.Document.Children[0].Block.Id, "", "")
@@ -591,8 +598,8 @@ func TestPeriodicSync(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal()))
-	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, alice.Blobs.SetAccountTrust(ctx, alice.Storage.Identity().MustGet().Account().Principal(), bob.Storage.Identity().MustGet().Account().Principal()))
+	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, bob.Storage.Identity().MustGet().Account().Principal(), alice.Storage.Identity().MustGet().Account().Principal()))
 
 	time.Sleep(200 * time.Millisecond)
 
@@ -609,6 +616,57 @@ func TestPeriodicSync(t *testing.T) {
 
 	checkListAccounts(t, alice, bob, "alice to bob")
 	checkListAccounts(t, bob, alice, "bob to alice")
+
+	jobs, err := alice.RPC.Daemon.ListSyncJobs(ctx, &daemon.ListSyncJobsRequest{})
+	require.NoError(t, err)
+
+	var found bool
+	for _, j := range jobs.RecentJobs {
+		if j.Kind == daemon.SyncJobKind_PEER && j.Target == bob.Storage.Device().PeerID().String() && j.State == daemon.SyncJobState_SUCCEEDED {
+			found = true
+			break
+		}
+	}
+	require.True(t, found, "periodic sync with bob must be reported as a sync job")
+
+	bobPeer := bob.Storage.Device().PeerID().String()
+
+	// Finished jobs are recorded in the history asynchronously.
+	var st *daemon.SyncStatus
+	require.Eventually(t, func() bool {
+		st, err = alice.RPC.Daemon.GetSyncStatus(ctx, &daemon.GetSyncStatusRequest{Kind: daemon.SyncJobKind_PEER, Target: bobPeer})
+		require.NoError(t, err)
+		return len(st.Targets) == 1 && st.Targets[0].LastOkTime != nil
+	}, 5*time.Second, 50*time.Millisecond, "sync status with bob must be recorded")
+	require.Equal(t, bobPeer, st.Targets[0].Target)
+	require.Equal(t, daemon.SyncJobKind_PEER, st.Targets[0].LastAttempt.Kind)
+
+	hist, err := alice.RPC.Daemon.ListSyncHistory(ctx, &daemon.ListSyncHistoryRequest{Target: bobPeer, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, hist.Attempts, 1)
+	require.Equal(t, bobPeer, hist.Attempts[0].Target)
+	require.NotEqual(t, "", hist.NextPageToken, "page token must be returned for full pages")
+
+	conn, err := grpc.Dial(alice.GRPCListener.Addr().String(), grpc.WithBlock(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := daemon.NewDaemonClient(conn).ForceSyncWithProgress(ctx, &daemon.ForceSyncRequest{})
+	require.NoError(t, err)
+
+	var last *daemon.SyncProgress
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		last = msg
+	}
+	require.NotNil(t, last)
+	require.True(t, last.Done, "last progress message must be marked as done")
+	require.Equal(t, int64(1), last.NumSyncOk)
+	require.Equal(t, int64(0), last.NumSyncFailed)
 }
 
 func TestMultiDevice(t *testing.T) {
@@ -753,3 +811,70 @@ func publishDocument(t *testing.T, ctx context.Context, publisher *App, link str
 	require.NoError(t, err)
 	return published
 }
+
+func TestDaemonLocked(t *testing.T) {
+	t.Parallel()
+
+	cfg := makeTestConfig(t)
+	u := coretest.NewTester("alice")
+
+	repo, err := storage.InitRepo(cfg.Base.DataDir, u.Device.Wrapped(), "debug")
+	require.NoError(t, err)
+	require.NoError(t, repo.ChangeKeyPassphrase("", "secret"))
+
+	repo, err = storage.InitRepo(cfg.Base.DataDir, nil, "debug")
+	require.NoError(t, err)
+	require.True(t, repo.Locked())
+
+	grpcLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	httpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- serveLocked(ctx, repo, grpcLis, httpLis)
+	}()
+
+	conn, err := grpc.Dial(grpcLis.Addr().String(), grpc.WithBlock(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	dc := daemon.NewDaemonClient(conn)
+
+	_, err = dc.GetInfo(ctx, &daemon.GetInfoRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "only unlock must be served while locked")
+
+	_, err = accounts.NewAccountsClient(conn).GetAccount(ctx, &accounts.GetAccountRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "other services must not be served while locked")
+
+	_, err = dc.Unlock(ctx, &daemon.UnlockRequest{Passphrase: "wrong"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = dc.Unlock(ctx, &daemon.UnlockRequest{Passphrase: "secret"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), "attempts right after a failure must be rejected")
+	time.Sleep(unlockBackoff(1))
+
+	_, err = dc.Unlock(ctx, &daemon.UnlockRequest{Passphrase: "secret"})
+	require.NoError(t, err)
+
+	require.NoError(t, <-errc, "locked server must stop after unlocking")
+	require.False(t, repo.Locked())
+	require.Equal(t, u.Device.PeerID(), repo.Device().PeerID())
+
+	app, err := Load(ctx, cfg, repo, "debug")
+	require.NoError(t, err)
+	defer func() {
+		cancel()
+		require.Equal(t, context.Canceled, app.Wait())
+	}()
+
+	require.NoError(t, app.RPC.Daemon.RegisterAccount(ctx, u.Account))
+	info, err := app.RPC.Daemon.GetInfo(ctx, &daemon.GetInfoRequest{})
+	require.NoError(t, err)
+	require.True(t, info.DeviceKeyEncrypted)
+	require.Equal(t, u.Device.PeerID().String(), info.DeviceId)
+}
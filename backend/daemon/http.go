@@ -46,7 +46,7 @@ type GenericHandler struct {
 	Path string
 	// HTTP handler.
 	Handler http.Handler
-	// RoutePrefix | RouteNav.
+	// RoutePrefix | RouteNav | RouteFallback.
 	Mode int
 }
 
@@ -122,6 +122,11 @@ func makeBlobDebugHandler(bs blockstore.Blockstore) http.HandlerFunc {
 	}
 }
 
+// setupActivityHandlers sets up the Server-Sent Events stream of the activity feed.
+func setupActivityHandlers(r *Router, h http.Handler) {
+	r.Handle("/activity/events", corsMiddleware(h), 0)
+}
+
 // setupGRPCWebHandler sets up the gRPC-Web handler.
 func setupGRPCWebHandler(r *Router, rpc *grpc.Server) {
 	grpcWebHandler := grpcweb.WrapServer(rpc, grpcweb.WithOriginFunc(func(origin string) bool {
@@ -148,6 +153,7 @@ func initHTTP(
 	blobs *hyper.Storage,
 	wallet *wallet.Service,
 	ipfsHandler IPFSFileHandler,
+	activityHandler http.Handler,
 	extraHandlers ...GenericHandler,
 ) (srv *http.Server, lis net.Listener, err error) {
 	router := &Router{r: mux.NewRouter()}
@@ -160,11 +166,12 @@ func initHTTP(
 	setupDebugHandlers(router, blobs)
 	setupGraphQLHandlers(router, wallet)
 	setupIPFSFileHandlers(router, ipfsHandler)
+	setupActivityHandlers(router, activityHandler)
 	setupGRPCWebHandler(router, rpc)
 	for _, handler := range extraHandlers {
 		router.Handle(handler.Path, handler.Handler, handler.Mode)
 	}
-	router.Handle("/", http.HandlerFunc(router.Index), 0)
+	router.finish()
 
 	srv = &http.Server{
 		Addr:              ":" + strconv.Itoa(port),
@@ -328,16 +335,30 @@ const (
 	RoutePrefix = 1 << 1
 	// RouteNav adds the path to a route nav.
 	RouteNav = 1 << 2
+	// RouteFallback handles all the requests under the path prefix that don't match any other route,
+	// regardless of the order in which the routes are registered. It replaces the index page.
+	RouteFallback = 1 << 3
 )
 
 // Router is a wrapper around mux that can build the navigation menu.
 type Router struct {
 	r   *mux.Router
 	nav []string
+
+	fallbackPath    string
+	fallbackHandler http.Handler
 }
 
 // Handle a route.
 func (r *Router) Handle(path string, h http.Handler, mode int) {
+	if mode&RouteFallback != 0 {
+		if r.fallbackHandler != nil {
+			panic("BUG: fallback route is already registered: " + r.fallbackPath)
+		}
+		r.fallbackPath, r.fallbackHandler = path, h
+		return
+	}
+
 	if mode&RouteNav != 0 {
 		r.r.Name(path).PathPrefix(path).Handler(h)
 	} else {
@@ -349,6 +370,17 @@ func (r *Router) Handle(path string, h http.Handler, mode int) {
 	}
 }
 
+// finish registers the routes that must be matched after all the other ones:
+// the fallback route if any, or the index page otherwise.
+func (r *Router) finish() {
+	if r.fallbackHandler == nil {
+		r.Handle("/", http.HandlerFunc(r.Index), 0)
+		return
+	}
+
+	r.r.Name(r.fallbackPath).PathPrefix(r.fallbackPath).Handler(r.fallbackHandler)
+}
+
 func (r *Router) Index(w http.ResponseWriter, _ *http.Request) {
 	for _, route := range r.nav {
 		fmt.Fprintf(w, `<p><a href="%s">%s</a></p>`, route, route)
@@ -0,0 +1,63 @@
+package daemon
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouterFallback(t *testing.T) {
+	named := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, name)
+		})
+	}
+
+	router := &Router{r: mux.NewRouter()}
+	router.Handle("/debug/version", named("version"), RouteNav)
+	router.Handle("/debug/pprof", named("pprof"), RoutePrefix|RouteNav)
+	router.Handle("/ipfs/{cid}", named("ipfs"), 0)
+	router.Handle("/", named("site"), RouteFallback)
+	// Routes registered after the fallback must still be matched.
+	router.Handle("/activity/events", named("activity"), 0)
+	router.finish()
+
+	tests := []struct {
+		Path string
+		Want string
+	}{
+		{"/debug/version", "version"},
+		{"/debug/pprof/heap", "pprof"},
+		{"/ipfs/bafy", "ipfs"},
+		{"/activity/events", "activity"},
+		{"/", "site"},
+		{"/some/page", "site"},
+		{"/activity/events/other", "site"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.Path, nil))
+		require.Equal(t, http.StatusOK, w.Code, tt.Path)
+		require.Equal(t, tt.Want, w.Body.String(), "path %s must be handled by %s", tt.Path, tt.Want)
+	}
+}
+
+func TestRouterIndex(t *testing.T) {
+	router := &Router{r: mux.NewRouter()}
+	router.Handle("/debug/version", http.NotFoundHandler(), RouteNav)
+	router.finish()
+
+	w := httptest.NewRecorder()
+	router.r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<a href="/debug/version">`, "index must list the nav routes without a fallback")
+
+	w = httptest.NewRecorder()
+	router.r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/some/page", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
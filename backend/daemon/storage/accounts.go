@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mintter/backend/core"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// ErrAccountExists is returned when committing an account which is already registered on this device.
+var ErrAccountExists = errors.New("account is already registered")
+
+// ErrAccountNotFound is returned when the account is not registered on this device.
+var ErrAccountNotFound = errors.New("account is not registered on this device")
+
+// accountsFile is the on-disk registry of the accounts of this device.
+type accountsFile struct {
+	// Account IDs in the order they were registered.
+	Accounts []string `json:"accounts"`
+	// Account used when the caller doesn't specify one.
+	Active string `json:"active,omitempty"`
+}
+
+// Accounts returns all the accounts registered on this device, in the order they were registered.
+func (d *Dir) Accounts() []core.PublicKey {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make([]core.PublicKey, len(d.accounts))
+	copy(out, d.accounts)
+	return out
+}
+
+// Account returns the account with the given ID if it's registered on this device.
+func (d *Dir) Account(id core.Principal) (core.PublicKey, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.findAccount(id)
+}
+
+// ActiveAccount returns the account used when the caller doesn't specify one.
+func (d *Dir) ActiveAccount() (core.PublicKey, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.findAccount(d.active)
+}
+
+// SwitchAccount makes the account with the given ID the active one.
+// The identity of the storage keeps using the account which was active when it was loaded.
+func (d *Dir) SwitchAccount(id core.Principal) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.findAccount(id); !ok {
+		return ErrAccountNotFound
+	}
+
+	prev := d.active
+	d.active = id
+	if err := d.writeAccounts(); err != nil {
+		d.active = prev
+		return fmt.Errorf("failed to write accounts file: %w", err)
+	}
+
+	return nil
+}
+
+// CommitAccount registers the account on this device.
+// The first account becomes the active one, and resolves the identity of the storage.
+func (d *Dir) CommitAccount(acc core.PublicKey) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.findAccount(acc.Principal()); ok {
+		return ErrAccountExists
+	}
+
+	d.accounts = append(d.accounts, acc)
+	if d.active == nil {
+		d.active = acc.Principal()
+	}
+
+	if err := d.writeAccounts(); err != nil {
+		d.accounts = d.accounts[:len(d.accounts)-1]
+		if len(d.accounts) == 0 {
+			d.active = nil
+		}
+		return fmt.Errorf("failed to write accounts file: %w", err)
+	}
+
+	if _, ok := d.me.Get(); !ok {
+		active, _ := d.findAccount(d.active)
+		if err := d.me.Resolve(core.NewIdentity(active, d.device)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Dir) findAccount(id core.Principal) (core.PublicKey, bool) {
+	if id == nil {
+		return core.PublicKey{}, false
+	}
+
+	for _, acc := range d.accounts {
+		if bytes.Equal(acc.Principal(), id) {
+			return acc, true
+		}
+	}
+
+	return core.PublicKey{}, false
+}
+
+// loadAccounts reads the account registry, and resolves the identity with the active account if there's any.
+// Must be called after the device key is loaded.
+func (d *Dir) loadAccounts() error {
+	f, err := readAccountsFile(d.path)
+	if err != nil {
+		return err
+	}
+
+	d.accounts = make([]core.PublicKey, 0, len(f.Accounts))
+	for _, s := range f.Accounts {
+		acc, err := decodeAccount(s)
+		if err != nil {
+			return err
+		}
+		d.accounts = append(d.accounts, acc)
+	}
+
+	if len(d.accounts) == 0 {
+		return nil
+	}
+
+	d.active = d.accounts[0].Principal()
+	if f.Active != "" {
+		active, err := core.DecodePrincipal(f.Active)
+		if err != nil {
+			return fmt.Errorf("failed to decode active account: %w", err)
+		}
+
+		if _, ok := d.findAccount(active); !ok {
+			return fmt.Errorf("active account %s is not in the list of accounts", f.Active)
+		}
+		d.active = active
+	}
+
+	acc, _ := d.findAccount(d.active)
+
+	return d.me.Resolve(core.NewIdentity(acc, d.device))
+}
+
+func (d *Dir) writeAccounts() error {
+	f := accountsFile{
+		Accounts: make([]string, len(d.accounts)),
+	}
+	for i, acc := range d.accounts {
+		f.Accounts[i] = acc.String()
+	}
+	if d.active != nil {
+		f.Active = d.active.String()
+	}
+
+	return writeAccountsFile(d.path, f)
+}
+
+func decodeAccount(s string) (core.PublicKey, error) {
+	p, err := core.DecodePrincipal(s)
+	if err != nil {
+		return core.PublicKey{}, fmt.Errorf("failed to decode account %s: %w", s, err)
+	}
+
+	pub, err := p.Libp2pKey()
+	if err != nil {
+		return core.PublicKey{}, fmt.Errorf("failed to decode account %s: %w", s, err)
+	}
+
+	return core.NewPublicKey(pub)
+}
+
+// readAccountsFile reads the account registry. Missing file means there're no accounts yet.
+func readAccountsFile(dir string) (f accountsFile, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, accountsPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return f, fmt.Errorf("failed to read the file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("failed to decode accounts file: %w", err)
+	}
+
+	return f, nil
+}
+
+// writeAccountsFile replaces the account registry atomically.
+func writeAccountsFile(dir string, f accountsFile) error {
+	data, err := json.MarshalIndent(f, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, accountsPath)
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// migrateLegacyAccountKey moves the single account key file into the account registry.
+// Returns the migrated account, if there's any.
+func migrateLegacyAccountKey(dir string) (acc core.PublicKey, ok bool, err error) {
+	legacyPath := filepath.Join(dir, legacyAccountKeyPath)
+
+	data, err := os.ReadFile(legacyPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return acc, false, err
+		}
+
+		// The registry must have been written already, if the migration was interrupted after that.
+		f, err := readAccountsFile(dir)
+		if err != nil || len(f.Accounts) == 0 {
+			return acc, false, err
+		}
+
+		acc, err = decodeAccount(f.Accounts[0])
+		if err != nil {
+			return acc, false, err
+		}
+
+		return acc, true, nil
+	}
+
+	pub, err := crypto.UnmarshalPublicKey(data)
+	if err != nil {
+		return acc, false, fmt.Errorf("failed to unmarshal account key: %w", err)
+	}
+
+	acc, err = core.NewPublicKey(pub)
+	if err != nil {
+		return acc, false, err
+	}
+
+	if err := writeAccountsFile(dir, accountsFile{
+		Accounts: []string{acc.String()},
+		Active:   acc.String(),
+	}); err != nil {
+		return acc, false, err
+	}
+
+	if err := os.Remove(legacyPath); err != nil {
+		return acc, false, err
+	}
+
+	return acc, true, nil
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"mintter/backend/core"
+	"mintter/backend/core/coretest"
+	"mintter/backend/pkg/must"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAccountRegistry(t *testing.T) {
+	path := t.TempDir()
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+
+	open := func() *Dir {
+		d, err := New(path, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, d.Migrate())
+		return d
+	}
+
+	dir := open()
+	require.Empty(t, dir.Accounts())
+	_, ok := dir.ActiveAccount()
+	require.False(t, ok)
+	_, ok = dir.Identity().Get()
+	require.False(t, ok, "identity must not be resolved without accounts")
+
+	require.NoError(t, dir.CommitAccount(alice.Account.PublicKey))
+	require.ErrorIs(t, dir.CommitAccount(alice.Account.PublicKey), ErrAccountExists)
+	require.NoError(t, dir.CommitAccount(bob.Account.PublicKey))
+
+	me, ok := dir.Identity().Get()
+	require.True(t, ok)
+	require.Equal(t, alice.Account.Principal(), me.Account().Principal(), "first account must resolve the identity")
+
+	active, ok := dir.ActiveAccount()
+	require.True(t, ok)
+	require.Equal(t, alice.Account.Principal(), active.Principal(), "first account must be active")
+
+	require.ErrorIs(t, dir.SwitchAccount(coretest.NewTester("carol").Account.Principal()), ErrAccountNotFound)
+	require.NoError(t, dir.SwitchAccount(bob.Account.Principal()))
+
+	dir = open()
+	accs := dir.Accounts()
+	require.Len(t, accs, 2)
+	require.Equal(t, alice.Account.Principal(), accs[0].Principal())
+	require.Equal(t, bob.Account.Principal(), accs[1].Principal())
+
+	active, ok = dir.ActiveAccount()
+	require.True(t, ok)
+	require.Equal(t, bob.Account.Principal(), active.Principal(), "active account must survive restarts")
+
+	me, ok = dir.Identity().Get()
+	require.True(t, ok)
+	require.Equal(t, bob.Account.Principal(), me.Account().Principal())
+
+	_, ok = dir.Account(alice.Account.Principal())
+	require.True(t, ok)
+}
+
+func TestMigrateLegacyAccountKey(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, copyDir("./testdata/mintter-test-db-snapshot", tmpDir))
+
+	legacyPath := filepath.Join(tmpDir, legacyAccountKeyPath)
+	pub, err := crypto.UnmarshalPublicKey(must.Do2(os.ReadFile(legacyPath)))
+	require.NoError(t, err)
+	want, err := core.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	dir, err := New(tmpDir, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, dir.Migrate())
+
+	_, err = os.Stat(legacyPath)
+	require.ErrorIs(t, err, os.ErrNotExist, "legacy account key file must be removed after migration")
+
+	accs := dir.Accounts()
+	require.Len(t, accs, 1)
+	require.Equal(t, want.Principal(), accs[0].Principal(), "account must be preserved by the migration")
+
+	me, ok := dir.Identity().Get()
+	require.True(t, ok)
+	require.Equal(t, want.Principal(), me.Account().Principal())
+
+	db, err := OpenSQLite(dir.SQLitePath(), 0, 1)
+	require.NoError(t, err)
+	defer db.Close()
+
+	conn, release, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	for _, table := range []string{"trusted_accounts", "drafts"} {
+		var owners []string
+		require.NoError(t, sqlitex.Exec(conn, "SELECT DISTINCT pk.principal FROM "+table+" t JOIN public_keys pk ON pk.id = t.account", func(stmt *sqlite.Stmt) error {
+			owners = append(owners, core.Principal(stmt.ColumnBytes(0)).String())
+			return nil
+		}))
+		for _, o := range owners {
+			require.Equal(t, want.Principal().String(), o, "existing %s must belong to the migrated account", table)
+		}
+	}
+}
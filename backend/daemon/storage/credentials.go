@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mintter/backend/core"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"golang.org/x/crypto/hkdf"
+)
+
+// sealedCredentialPrefix marks the wallet credentials encrypted with the device key,
+// so they can be told apart from the ones stored in plain text by older versions.
+var sealedCredentialPrefix = []byte("sealed/v1:")
+
+// credentialsKeyInfo is the HKDF info string used to derive the wallet credentials key from the device key.
+const credentialsKeyInfo = "mintter/wallet-credentials/v1"
+
+// credentialsCipher derives a dedicated AES-256 GCM cipher for wallet credentials from the device key,
+// so the signing seed itself is never used as a cipher key.
+func credentialsCipher(device core.KeyPair) (cipher.AEAD, error) {
+	raw, err := device.Wrapped().Raw()
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < ed25519.SeedSize {
+		return nil, fmt.Errorf("unsupported device key")
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, raw[:ed25519.SeedSize], nil, []byte(credentialsKeyInfo)), key); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// SealCredential encrypts a wallet credential with a key derived from the device key before storing it in the database.
+// Empty credentials are left empty.
+func SealCredential(device core.KeyPair, plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return plaintext, nil
+	}
+
+	aead, err := credentialsCipher(device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal credential: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to seal credential: %w", err)
+	}
+
+	out := append([]byte{}, sealedCredentialPrefix...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, nil), nil
+}
+
+// OpenCredential decrypts a wallet credential sealed with SealCredential.
+// Credentials that were stored in plain text are returned as is.
+func OpenCredential(device core.KeyPair, data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, sealedCredentialPrefix) {
+		return data, nil
+	}
+
+	aead, err := credentialsCipher(device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credential: %w", err)
+	}
+
+	data = data[len(sealedCredentialPrefix):]
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("failed to open credential: malformed cipher text")
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credential: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// SealWalletCredentials encrypts the wallet credentials that are still stored in plain text.
+// It's safe to call multiple times.
+func SealWalletCredentials(conn *sqlite.Conn, device core.KeyPair) error {
+	type row struct {
+		ID                     string
+		Login, Password, Token []byte
+	}
+
+	var rows []row
+	if err := sqlitex.Exec(conn, qListWalletCredentials, func(stmt *sqlite.Stmt) error {
+		rows = append(rows, row{
+			ID:       stmt.ColumnText(0),
+			Login:    stmt.ColumnBytes(1),
+			Password: stmt.ColumnBytes(2),
+			Token:    stmt.ColumnBytes(3),
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		var changed bool
+		creds := [][]byte{r.Login, r.Password, r.Token}
+		for i, c := range creds {
+			if len(c) == 0 || bytes.HasPrefix(c, sealedCredentialPrefix) {
+				continue
+			}
+
+			sealed, err := SealCredential(device, c)
+			if err != nil {
+				return err
+			}
+			creds[i] = sealed
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := sqlitex.Exec(conn, qUpdateWalletCredentials, nil, creds[0], creds[1], creds[2], r.ID); err != nil {
+			return fmt.Errorf("failed to seal credentials of wallet %s: %w", r.ID, err)
+		}
+	}
+
+	return nil
+}
+
+const qListWalletCredentials = `SELECT id, login, password, token FROM wallets;`
+
+const qUpdateWalletCredentials = `UPDATE wallets SET login = ?, password = ?, token = ? WHERE id = ?;`
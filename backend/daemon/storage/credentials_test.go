@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"mintter/backend/core/coretest"
+	"testing"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealWalletCredentials(t *testing.T) {
+	db := MakeTestDB(t)
+	device := coretest.NewTester("alice").Device
+
+	conn, release, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	// Wallets stored in plain text by older versions.
+	require.NoError(t, sqlitex.ExecScript(conn, `
+		INSERT INTO wallets (id, address, type, login, password, token, name)
+		VALUES ('w1', 'https://ln.example.com', 'lndhub', CAST('login1' AS BLOB), CAST('pass1' AS BLOB), CAST('token1' AS BLOB), 'wallet 1');
+		INSERT INTO wallets (id, address, type, login, password, token, name)
+		VALUES ('w2', 'https://ln.example.com', 'lndhub', CAST('login2' AS BLOB), CAST('pass2' AS BLOB), NULL, 'wallet 2');
+	`))
+
+	type creds struct {
+		Login, Password, Token []byte
+		NullToken              bool
+	}
+
+	get := func(id string) (c creds) {
+		require.NoError(t, sqlitex.Exec(conn, "SELECT login, password, token, token IS NULL FROM wallets WHERE id = ?", func(stmt *sqlite.Stmt) error {
+			c = creds{
+				Login:     stmt.ColumnBytes(0),
+				Password:  stmt.ColumnBytes(1),
+				Token:     stmt.ColumnBytes(2),
+				NullToken: stmt.ColumnInt(3) == 1,
+			}
+			return nil
+		}, id))
+		return c
+	}
+
+	open := func(data []byte) string {
+		out, err := OpenCredential(device, data)
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	require.Equal(t, "login1", open(get("w1").Login), "plain text credentials must be returned as is")
+
+	require.NoError(t, SealWalletCredentials(conn, device))
+
+	w1 := get("w1")
+	require.NotEqual(t, "login1", string(w1.Login), "credentials must be encrypted")
+	require.Equal(t, "login1", open(w1.Login))
+	require.Equal(t, "pass1", open(w1.Password))
+	require.Equal(t, "token1", open(w1.Token))
+
+	w2 := get("w2")
+	require.Equal(t, "login2", open(w2.Login))
+	require.Equal(t, "pass2", open(w2.Password))
+	require.True(t, w2.NullToken, "missing token must stay missing")
+
+	require.NoError(t, SealWalletCredentials(conn, device))
+	require.Equal(t, w1, get("w1"), "sealing must be idempotent")
+
+	_, err = OpenCredential(coretest.NewTester("bob").Device, w1.Login)
+	require.Error(t, err, "other device keys must not open the credentials")
+
+	_, err = device.Decrypt(w1.Login[len(sealedCredentialPrefix):])
+	require.Error(t, err, "credentials must not be encrypted with the raw device key")
+}
@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// ErrKeyLocked is returned when the device key is encrypted and hasn't been unlocked yet.
+var ErrKeyLocked = errors.New("device key is locked")
+
+// ErrWrongPassphrase is returned when the device key can't be decrypted with the given passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase for the device key")
+
+const keystoreVersion = 1
+
+// Argon2id parameters for newly encrypted keys, as recommended by RFC 9106 for memory-constrained environments.
+// The parameters are stored along with the key, so they can be changed without breaking existing keystores.
+const (
+	kdfArgon2id      = "argon2id"
+	argon2idTime     = 3
+	argon2idMemory   = 64 * 1024 // KiB.
+	argon2idThreads  = 4
+	argon2idSaltSize = 16
+)
+
+// keystoreFile is the on-disk format of the device key.
+// The key is stored in plain text unless the KDF parameters are present,
+// in which case it's sealed with XChaCha20-Poly1305 using a key derived from the passphrase.
+type keystoreFile struct {
+	Version int        `json:"version"`
+	KDF     *kdfParams `json:"kdf,omitempty"`
+	Nonce   []byte     `json:"nonce,omitempty"`
+	// Marshaled libp2p private key. Encrypted if KDF is present.
+	Key []byte `json:"key"`
+}
+
+type kdfParams struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+func (p *kdfParams) deriveKey(passphrase string) ([]byte, error) {
+	if p.Name != kdfArgon2id {
+		return nil, fmt.Errorf("unsupported key derivation function '%s'", p.Name)
+	}
+
+	return argon2.IDKey([]byte(passphrase), p.Salt, p.Time, p.Memory, p.Threads, chacha20poly1305.KeySize), nil
+}
+
+// Encrypted reports whether the key requires a passphrase to be opened.
+func (f *keystoreFile) Encrypted() bool {
+	return f.KDF != nil
+}
+
+// Open returns the private key from the keystore, decrypting it with the passphrase if necessary.
+func (f *keystoreFile) Open(passphrase string) (crypto.PrivKey, error) {
+	data := f.Key
+	if f.Encrypted() {
+		key, err := f.KDF.deriveKey(passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err = aead.Open(nil, f.Nonce, f.Key, keystoreAD(f.Version))
+		if err != nil {
+			return nil, ErrWrongPassphrase
+		}
+	}
+
+	pk, err := crypto.UnmarshalPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal private key for device: %w", err)
+	}
+
+	return pk, nil
+}
+
+// sealKeystore creates the keystore for the private key.
+// Empty passphrase leaves the key unencrypted.
+func sealKeystore(pk crypto.PrivKey, passphrase string) (*keystoreFile, error) {
+	data, err := crypto.MarshalPrivateKey(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &keystoreFile{
+		Version: keystoreVersion,
+		Key:     data,
+	}
+
+	if passphrase == "" {
+		return f, nil
+	}
+
+	f.KDF = &kdfParams{
+		Name:    kdfArgon2id,
+		Salt:    make([]byte, argon2idSaltSize),
+		Time:    argon2idTime,
+		Memory:  argon2idMemory,
+		Threads: argon2idThreads,
+	}
+	if _, err := rand.Read(f.KDF.Salt); err != nil {
+		return nil, err
+	}
+
+	key, err := f.KDF.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return nil, err
+	}
+
+	f.Key = aead.Seal(nil, f.Nonce, data, keystoreAD(f.Version))
+
+	return f, nil
+}
+
+// keystoreAD is the additional data for the AEAD, to bind the ciphertext to the format version.
+func keystoreAD(version int) []byte {
+	return []byte(fmt.Sprintf("mintter-device-key-v%d", version))
+}
+
+func readKeystoreFile(dir string) (*keystoreFile, error) {
+	data, err := os.ReadFile(filepath.Join(dir, deviceKeystorePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the file: %w", err)
+	}
+
+	var f keystoreFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode device keystore: %w", err)
+	}
+
+	if f.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported device keystore version %d", f.Version)
+	}
+
+	return &f, nil
+}
+
+// writeKeystoreFile replaces the keystore atomically, so we never end up with a partially written key.
+func writeKeystoreFile(dir string, f *keystoreFile) error {
+	data, err := json.MarshalIndent(f, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, deviceKeystorePath)
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"mintter/backend/pkg/must"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestKeystoreEncryption(t *testing.T) {
+	path := t.TempDir()
+
+	open := func() *Dir {
+		d, err := New(path, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, d.Migrate())
+		return d
+	}
+
+	dir := open()
+	require.False(t, dir.Locked())
+	device := dir.Device()
+
+	require.NoError(t, dir.ChangeKeyPassphrase("", "secret"))
+
+	dir = open()
+	require.True(t, dir.Locked(), "storage with encrypted key must start locked")
+	require.Nil(t, dir.Device().Wrapped(), "device key must not be available while locked")
+	require.ErrorIs(t, dir.ChangeKeyPassphrase("secret", "new-secret"), ErrKeyLocked)
+
+	require.ErrorIs(t, dir.Unlock("wrong"), ErrWrongPassphrase)
+	require.True(t, dir.Locked())
+
+	require.NoError(t, dir.Unlock("secret"))
+	require.False(t, dir.Locked())
+	require.True(t, device.Wrapped().Equals(dir.Device().Wrapped()), "unlocked key must match the original one")
+
+	encrypted, err := dir.KeyEncrypted()
+	require.NoError(t, err)
+	require.True(t, encrypted)
+
+	require.ErrorIs(t, dir.ChangeKeyPassphrase("wrong", "new-secret"), ErrWrongPassphrase)
+	require.NoError(t, dir.ChangeKeyPassphrase("secret", "new-secret"))
+
+	dir = open()
+	require.ErrorIs(t, dir.Unlock("secret"), ErrWrongPassphrase, "old passphrase must not work after rotation")
+	require.NoError(t, dir.Unlock("new-secret"))
+
+	require.NoError(t, dir.ChangeKeyPassphrase("new-secret", ""))
+
+	dir = open()
+	require.False(t, dir.Locked(), "storage must not be locked after disabling the encryption")
+	require.True(t, device.Wrapped().Equals(dir.Device().Wrapped()))
+}
+
+func TestMigrateLegacyDeviceKey(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, copyDir("./testdata/mintter-test-db-snapshot", tmpDir))
+
+	legacyPath := filepath.Join(tmpDir, legacyDeviceKeyPath)
+	want, err := crypto.UnmarshalPrivateKey(must.Do2(os.ReadFile(legacyPath)))
+	require.NoError(t, err)
+
+	dir, err := New(tmpDir, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, dir.Migrate())
+
+	require.True(t, want.Equals(dir.Device().Wrapped()), "device key must be preserved by the migration")
+
+	_, err = os.Stat(legacyPath)
+	require.ErrorIs(t, err, os.ErrNotExist, "legacy key file must be removed after migration")
+
+	ks, err := readKeystoreFile(tmpDir)
+	require.NoError(t, err)
+	require.False(t, ks.Encrypted(), "migrated key must not be encrypted")
+}
@@ -23,7 +23,7 @@ Current data dir layout:
 ├─ db/
 │  ├─ db.sqlite
 ├─ keys/
-│  ├─ libp2p_id_ed25519
+│  ├─ device_key.json
 │  ├─ mintter_id_ed25519.pub
 ├─ mintterd.conf
 ├─ VERSION
@@ -465,14 +465,307 @@ var migrations = []migration{
 			);
 		`))
 	}},
+	{Version: "2024-04-10.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS subscriptions (
+				iri TEXT PRIMARY KEY,
+				insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+			) WITHOUT ROWID;
+		`))
+	}},
+	{Version: "2024-04-12.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE VIRTUAL TABLE IF NOT EXISTS fts USING fts5(
+				raw_content,
+				tokenize = 'unicode61 remove_diacritics 2',
+				prefix = '2 3'
+			);
+
+			CREATE TABLE IF NOT EXISTS fts_index (
+				id INTEGER PRIMARY KEY,
+				blob_id INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				type TEXT NOT NULL,
+				block_id TEXT DEFAULT ('') NOT NULL,
+				resource INTEGER REFERENCES resources (id) ON DELETE CASCADE NOT NULL,
+				ts INTEGER NOT NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS fts_index_by_blob ON fts_index (blob_id);
+			CREATE INDEX IF NOT EXISTS fts_index_by_resource ON fts_index (resource, type, block_id, ts);
+
+			CREATE TRIGGER IF NOT EXISTS fts_index_after_delete AFTER DELETE ON fts_index BEGIN
+				DELETE FROM fts WHERE rowid = OLD.id;
+			END;
+
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`))
+	}},
+	{Version: "2024-04-19.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS sync_history (
+				id INTEGER PRIMARY KEY,
+				kind TEXT NOT NULL,
+				target TEXT NOT NULL,
+				start_time INTEGER NOT NULL,
+				end_time INTEGER NOT NULL,
+				wanted_blobs INTEGER DEFAULT (0) NOT NULL,
+				fetched_blobs INTEGER DEFAULT (0) NOT NULL,
+				failed_blobs INTEGER DEFAULT (0) NOT NULL,
+				pushed_blobs INTEGER DEFAULT (0) NOT NULL,
+				error TEXT DEFAULT ('') NOT NULL,
+				cursor TEXT DEFAULT ('') NOT NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS sync_history_by_target ON sync_history (kind, target, id);
+		`))
+	}},
+	{Version: "2024-04-22.01", Run: func(d *Dir, _ *sqlite.Conn) error {
+		return migrateLegacyDeviceKey(d.path)
+	}},
+	{Version: "2024-04-24.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			DROP VIEW IF EXISTS key_delegations_view;
+			DROP TABLE IF EXISTS key_delegations;
+
+			CREATE TABLE key_delegations (
+				id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE NOT NULL,
+				issuer INTEGER REFERENCES public_keys (id),
+				delegate INTEGER REFERENCES public_keys (id),
+				not_after INTEGER DEFAULT (0) NOT NULL
+			) WITHOUT ROWID;
+
+			CREATE INDEX key_delegations_by_issuer ON key_delegations (issuer, delegate);
+			CREATE INDEX key_delegations_by_delegate ON key_delegations (delegate, issuer);
+
+			CREATE VIEW key_delegations_view AS
+			SELECT
+				kd.id AS blob,
+				blobs.codec AS blob_codec,
+				blobs.multihash AS blob_multihash,
+				iss.principal AS issuer,
+				del.principal AS delegate,
+				kd.not_after AS not_after
+			FROM key_delegations kd
+			JOIN blobs INDEXED BY blobs_metadata ON blobs.id = kd.id
+			JOIN public_keys iss ON iss.id = kd.issuer
+			JOIN public_keys del ON del.id = kd.delegate;
+
+			CREATE TABLE IF NOT EXISTS key_revocations (
+				id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE NOT NULL,
+				issuer INTEGER REFERENCES public_keys (id) NOT NULL,
+				delegate INTEGER REFERENCES public_keys (id) NOT NULL,
+				revoke_time INTEGER NOT NULL
+			) WITHOUT ROWID;
+
+			CREATE INDEX IF NOT EXISTS key_revocations_by_issuer ON key_revocations (issuer, delegate, revoke_time);
+			CREATE INDEX IF NOT EXISTS key_revocations_by_delegate ON key_revocations (delegate, issuer);
+
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`))
+	}},
+	{Version: "2024-04-26.01", Run: func(d *Dir, conn *sqlite.Conn) error {
+		acc, ok, err := migrateLegacyAccountKey(d.path)
+		if err != nil {
+			return fmt.Errorf("failed to migrate account key: %w", err)
+		}
+
+		oldTrustedAccounts := mustCount(conn, "trusted_accounts")
+		oldDrafts := mustCount(conn, "drafts")
+
+		// Existing drafts and trusted accounts belong to the only account we used to have.
+		// Without any account there must be nothing to carry over.
+		var accountID int64
+		if ok {
+			accountID, err = ensurePublicKey(conn, acc.Principal())
+			if err != nil {
+				return err
+			}
+		} else {
+			oldTrustedAccounts, oldDrafts = 0, 0
+		}
+
+		accountIDStr := strconv.FormatInt(accountID, 10)
+
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			DROP VIEW IF EXISTS drafts_view;
+			DROP INDEX IF EXISTS drafts_by_blob;
+			DROP INDEX IF EXISTS drafts_unique;
+
+			ALTER TABLE trusted_accounts RENAME TO trusted_accounts_old;
+			ALTER TABLE drafts RENAME TO drafts_old;
+
+			CREATE TABLE trusted_accounts (
+				account INTEGER REFERENCES public_keys (id) NOT NULL,
+				id INTEGER REFERENCES public_keys (id) NOT NULL,
+				PRIMARY KEY (account, id)
+			) WITHOUT ROWID;
+
+			CREATE INDEX trusted_accounts_by_id ON trusted_accounts (id, account);
+
+			CREATE TABLE drafts (
+				account INTEGER REFERENCES public_keys (id) NOT NULL,
+				resource INTEGER REFERENCES resources (id) NOT NULL,
+				blob INTEGER REFERENCES blobs (id) ON DELETE CASCADE NOT NULL,
+				PRIMARY KEY (resource, blob)
+			) WITHOUT ROWID;
+
+			CREATE INDEX drafts_by_blob ON drafts (blob);
+			CREATE UNIQUE INDEX drafts_unique ON drafts (account, resource);
+
+			CREATE VIEW drafts_view AS
+			SELECT
+				drafts.account AS account_id,
+				drafts.resource AS resource_id,
+				drafts.blob AS blob_id,
+				resources.iri AS resource,
+				blobs.codec AS codec,
+				blobs.multihash AS multihash
+			FROM drafts
+			JOIN resources ON resources.id = drafts.resource
+			JOIN blobs INDEXED BY blobs_metadata ON blobs.id = drafts.blob;
+		`)); err != nil {
+			return err
+		}
+
+		if ok {
+			if err := sqlitex.ExecScript(conn, `
+				INSERT INTO trusted_accounts (account, id) SELECT `+accountIDStr+`, id FROM trusted_accounts_old;
+				INSERT INTO drafts (account, resource, blob) SELECT `+accountIDStr+`, resource, blob FROM drafts_old;
+			`); err != nil {
+				return err
+			}
+		}
+
+		if err := sqlitex.ExecScript(conn, `
+			DROP TABLE trusted_accounts_old;
+			DROP TABLE drafts_old;
+		`); err != nil {
+			return err
+		}
+
+		if n := mustCount(conn, "trusted_accounts"); n != oldTrustedAccounts {
+			return fmt.Errorf("trusted accounts count mismatch: %d != %d", oldTrustedAccounts, n)
+		}
+
+		if n := mustCount(conn, "drafts"); n != oldDrafts {
+			return fmt.Errorf("drafts count mismatch: %d != %d", oldDrafts, n)
+		}
+
+		return nil
+	}},
+	{Version: "2024-04-29.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		// Group changes are now checked against the roles of their signers,
+		// so we need to reindex them.
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS unauthorized_changes (
+				id INTEGER PRIMARY KEY REFERENCES structural_blobs (id) ON DELETE CASCADE NOT NULL
+			) WITHOUT ROWID;
+
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`))
+	}},
+	{Version: "2024-05-02.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		// Members can now be removed from groups, and groups can be private,
+		// so we need to reindex them.
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS group_keys (
+				id INTEGER PRIMARY KEY REFERENCES structural_blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				group_id INTEGER REFERENCES resources (id) NOT NULL,
+				key_id TEXT NOT NULL
+			) WITHOUT ROWID;
+
+			CREATE INDEX IF NOT EXISTS group_keys_by_group ON group_keys (group_id, key_id);
+
+			CREATE TABLE IF NOT EXISTS private_blobs (
+				id INTEGER PRIMARY KEY REFERENCES structural_blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				group_id INTEGER REFERENCES resources (id) NOT NULL
+			) WITHOUT ROWID;
+
+			CREATE INDEX IF NOT EXISTS private_blobs_by_group ON private_blobs (group_id);
+
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`))
+	}},
+	{Version: "2024-05-06.01", Run: func(d *Dir, conn *sqlite.Conn) error {
+		// Wallet credentials used to be stored in plain text.
+		// If the device key is encrypted we can't seal them here,
+		// so the daemon does it once the key is unlocked.
+		ks, err := readKeystoreFile(d.path)
+		if err != nil {
+			return fmt.Errorf("failed to load device keystore: %w", err)
+		}
+
+		if ks.Encrypted() {
+			return nil
+		}
+
+		pk, err := ks.Open("")
+		if err != nil {
+			return err
+		}
+
+		kp, err := core.NewKeyPair(pk)
+		if err != nil {
+			return err
+		}
+
+		return SealWalletCredentials(conn, kp)
+	}},
+	{Version: "2024-05-07.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		// Changes with missing deps used to be rejected, now they are kept as pending.
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS pending_changes (
+				id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				resource TEXT NOT NULL
+			) WITHOUT ROWID;
+
+			CREATE INDEX IF NOT EXISTS pending_changes_by_resource ON pending_changes (resource);
+		`))
+	}},
+	{Version: "2024-05-08.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		// New subscriptions used to reset all the syncing cursors, now they are backfilled separately.
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS syncing_backfills (
+				peer INTEGER REFERENCES public_keys (id) ON DELETE CASCADE NOT NULL,
+				resource TEXT NOT NULL,
+				PRIMARY KEY (peer, resource)
+			) WITHOUT ROWID;
+		`))
+	}},
+	{Version: "2024-05-09.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS pinned_blobs (
+				id INTEGER PRIMARY KEY REFERENCES blobs (id) ON DELETE CASCADE NOT NULL,
+				insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+			) WITHOUT ROWID;
+		`))
+	}},
+	{Version: "2024-05-10.01", Run: func(_ *Dir, conn *sqlite.Conn) error {
+		// Activity feed subscriptions used to follow the blob IDs, and missed the blobs indexed after receiving them.
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS indexed_blobs (
+				seq INTEGER PRIMARY KEY AUTOINCREMENT,
+				id INTEGER UNIQUE REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL
+			);
+
+			INSERT OR IGNORE INTO indexed_blobs (id)
+			SELECT id FROM structural_blobs ORDER BY id;
+		`))
+	}},
 }
 
 const (
 	keysDir = "keys"
 	dbDir   = "db"
 
-	devicePrivateKeyPath = keysDir + "/libp2p_id_ed25519"
-	accountKeyPath       = keysDir + "/mintter_id_ed25519.pub"
+	deviceKeystorePath = keysDir + "/device_key.json"
+	accountsPath       = keysDir + "/accounts.json"
+
+	// Device key was stored as a plain marshaled libp2p key before the keystore was introduced.
+	legacyDeviceKeyPath = keysDir + "/libp2p_id_ed25519"
+
+	// Only one account key was stored as a plain marshaled public key before the account registry was introduced.
+	legacyAccountKeyPath = keysDir + "/mintter_id_ed25519.pub"
 
 	versionFilename = "VERSION"
 )
@@ -512,8 +805,13 @@ func (d *Dir) init() (currentVersion string, err error) {
 		d.device = kp
 	}
 
-	if err := writeDeviceKeyFile(d.path, d.device.Wrapped()); err != nil {
-		return "", err
+	ks, err := sealKeystore(d.device.Wrapped(), "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create device keystore: %w", err)
+	}
+
+	if err := writeKeystoreFile(d.path, ks); err != nil {
+		return "", fmt.Errorf("failed to write device keystore: %w", err)
 	}
 
 	if err := writeVersionFile(d.path, currentVersion); err != nil {
@@ -582,66 +880,42 @@ func (d *Dir) migrate(currentVersion string) error {
 	}
 
 	// Preparing the device key.
-	{
-		kp, err := loadDeviceKeyFromFile(d.path)
-		if err != nil {
-			return fmt.Errorf("failed to load device key from file: %w", err)
-		}
-
-		if d.device.Wrapped() != nil {
-			if !d.device.Wrapped().Equals(kp.Wrapped()) {
-				return fmt.Errorf("device key loaded from file doesn't match the desired key")
-			}
-		} else {
-			d.device = kp
-		}
+	ks, err := readKeystoreFile(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to load device keystore: %w", err)
 	}
 
-	if err := d.maybeLoadAccountKey(); err != nil {
-		return fmt.Errorf("failed to load account key: %w", err)
+	// Encrypted keys are loaded when the storage is unlocked.
+	if ks.Encrypted() {
+		d.locked = true
+		return nil
 	}
 
-	return nil
+	return d.loadDeviceKey(ks, "")
 }
 
-func (d *Dir) maybeLoadAccountKey() error {
-	data, err := os.ReadFile(filepath.Join(d.path, accountKeyPath))
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return nil
-		}
-		return err
-	}
-
-	pub, err := crypto.UnmarshalPublicKey(data)
+// loadDeviceKey opens the device key from the keystore, and loads the registered accounts if there're any.
+func (d *Dir) loadDeviceKey(ks *keystoreFile, passphrase string) error {
+	pk, err := ks.Open(passphrase)
 	if err != nil {
 		return err
 	}
 
-	account, err := core.NewPublicKey(pub)
+	kp, err := core.NewKeyPair(pk)
 	if err != nil {
 		return err
 	}
 
-	return d.me.Resolve(core.NewIdentity(account, d.device))
-}
-
-// CommitAccount writes the account key to the disk and loads it into the storage.
-func (d *Dir) CommitAccount(acc core.PublicKey) error {
-	if _, ok := d.me.Get(); ok {
-		return fmt.Errorf("account is already initialized")
-	}
-
-	if err := writeAccountKeyFile(d.path, acc); err != nil {
-		return fmt.Errorf("failed to write account key file: %w", err)
-	}
-
-	if err := d.maybeLoadAccountKey(); err != nil {
-		return fmt.Errorf("failed to load account key file after writing: %w", err)
+	if d.device.Wrapped() != nil {
+		if !d.device.Wrapped().Equals(kp.Wrapped()) {
+			return fmt.Errorf("device key loaded from file doesn't match the desired key")
+		}
+	} else {
+		d.device = kp
 	}
 
-	if _, ok := d.me.Get(); !ok {
-		return fmt.Errorf("BUG: failed to resolve account key after writing")
+	if err := d.loadAccounts(); err != nil {
+		return fmt.Errorf("failed to load accounts: %w", err)
 	}
 
 	return nil
@@ -660,36 +934,34 @@ func writeVersionFile(dir, version string) error {
 	return os.WriteFile(filepath.Join(dir, versionFilename), []byte(version), 0600)
 }
 
-func writeDeviceKeyFile(dir string, pk crypto.PrivKey) error {
-	data, err := crypto.MarshalPrivateKey(pk)
+// migrateLegacyDeviceKey moves the plain device key file into the keystore.
+func migrateLegacyDeviceKey(dir string) error {
+	legacyPath := filepath.Join(dir, legacyDeviceKeyPath)
+
+	data, err := os.ReadFile(legacyPath)
 	if err != nil {
+		// The keystore must have been written already, if the migration was interrupted after that.
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
 		return err
 	}
 
-	return os.WriteFile(filepath.Join(dir, devicePrivateKeyPath), data, 0600)
-}
-
-func writeAccountKeyFile(dir string, pub core.PublicKey) error {
-	data, err := crypto.MarshalPublicKey(pub.Wrapped())
+	pk, err := crypto.UnmarshalPrivateKey(data)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to unmarshal private key for device: %w", err)
 	}
 
-	return os.WriteFile(filepath.Join(dir, accountKeyPath), data, 0600)
-}
-
-func loadDeviceKeyFromFile(dir string) (kp core.KeyPair, err error) {
-	data, err := os.ReadFile(filepath.Join(dir, devicePrivateKeyPath))
+	ks, err := sealKeystore(pk, "")
 	if err != nil {
-		return kp, fmt.Errorf("failed to read the file: %w", err)
+		return err
 	}
 
-	pk, err := crypto.UnmarshalPrivateKey(data)
-	if err != nil {
-		return kp, fmt.Errorf("failed to unmarshal private key for device: %w", err)
+	if err := writeKeystoreFile(dir, ks); err != nil {
+		return err
 	}
 
-	return core.NewKeyPair(pk)
+	return os.Remove(legacyPath)
 }
 
 func mustCount(conn *sqlite.Conn, table string) (count int) {
@@ -708,3 +980,27 @@ func mustCount(conn *sqlite.Conn, table string) (count int) {
 
 	return count
 }
+
+// ensurePublicKey returns the database ID of the public key, inserting it if needed.
+func ensurePublicKey(conn *sqlite.Conn, principal []byte) (id int64, err error) {
+	lookup := func() error {
+		return sqlitex.Exec(conn, "SELECT id FROM public_keys WHERE principal = ?", func(stmt *sqlite.Stmt) error {
+			id = stmt.ColumnInt64(0)
+			return nil
+		}, principal)
+	}
+
+	if err := lookup(); err != nil {
+		return 0, err
+	}
+
+	if id != 0 {
+		return id, nil
+	}
+
+	if err := sqlitex.Exec(conn, "INSERT INTO public_keys (principal) VALUES (?)", nil, principal); err != nil {
+		return 0, err
+	}
+
+	return conn.LastInsertRowID(), nil
+}
@@ -79,6 +79,7 @@ const (
 // Table drafts.
 const (
 	Drafts         sqlitegen.Table  = "drafts"
+	DraftsAccount  sqlitegen.Column = "drafts.account"
 	DraftsBlob     sqlitegen.Column = "drafts.blob"
 	DraftsResource sqlitegen.Column = "drafts.resource"
 )
@@ -86,6 +87,7 @@ const (
 // Table drafts. Plain strings.
 const (
 	T_Drafts         = "drafts"
+	C_DraftsAccount  = "drafts.account"
 	C_DraftsBlob     = "drafts.blob"
 	C_DraftsResource = "drafts.resource"
 )
@@ -93,6 +95,7 @@ const (
 // Table drafts_view.
 const (
 	DraftsView           sqlitegen.Table  = "drafts_view"
+	DraftsViewAccountID  sqlitegen.Column = "drafts_view.account_id"
 	DraftsViewBlobID     sqlitegen.Column = "drafts_view.blob_id"
 	DraftsViewCodec      sqlitegen.Column = "drafts_view.codec"
 	DraftsViewMultihash  sqlitegen.Column = "drafts_view.multihash"
@@ -103,6 +106,7 @@ const (
 // Table drafts_view. Plain strings.
 const (
 	T_DraftsView           = "drafts_view"
+	C_DraftsViewAccountID  = "drafts_view.account_id"
 	C_DraftsViewBlobID     = "drafts_view.blob_id"
 	C_DraftsViewCodec      = "drafts_view.codec"
 	C_DraftsViewMultihash  = "drafts_view.multihash"
@@ -110,6 +114,132 @@ const (
 	C_DraftsViewResourceID = "drafts_view.resource_id"
 )
 
+// Table fts.
+const (
+	Fts           sqlitegen.Table  = "fts"
+	FtsFts        sqlitegen.Column = "fts.fts"
+	FtsRank       sqlitegen.Column = "fts.rank"
+	FtsRawContent sqlitegen.Column = "fts.raw_content"
+)
+
+// Table fts. Plain strings.
+const (
+	T_Fts           = "fts"
+	C_FtsFts        = "fts.fts"
+	C_FtsRank       = "fts.rank"
+	C_FtsRawContent = "fts.raw_content"
+)
+
+// Table fts_config.
+const (
+	FtsConfig  sqlitegen.Table  = "fts_config"
+	FtsConfigK sqlitegen.Column = "fts_config.k"
+	FtsConfigV sqlitegen.Column = "fts_config.v"
+)
+
+// Table fts_config. Plain strings.
+const (
+	T_FtsConfig  = "fts_config"
+	C_FtsConfigK = "fts_config.k"
+	C_FtsConfigV = "fts_config.v"
+)
+
+// Table fts_content.
+const (
+	FtsContent   sqlitegen.Table  = "fts_content"
+	FtsContentC0 sqlitegen.Column = "fts_content.c0"
+	FtsContentID sqlitegen.Column = "fts_content.id"
+)
+
+// Table fts_content. Plain strings.
+const (
+	T_FtsContent   = "fts_content"
+	C_FtsContentC0 = "fts_content.c0"
+	C_FtsContentID = "fts_content.id"
+)
+
+// Table fts_data.
+const (
+	FtsData      sqlitegen.Table  = "fts_data"
+	FtsDataBlock sqlitegen.Column = "fts_data.block"
+	FtsDataID    sqlitegen.Column = "fts_data.id"
+)
+
+// Table fts_data. Plain strings.
+const (
+	T_FtsData      = "fts_data"
+	C_FtsDataBlock = "fts_data.block"
+	C_FtsDataID    = "fts_data.id"
+)
+
+// Table fts_docsize.
+const (
+	FtsDocsize   sqlitegen.Table  = "fts_docsize"
+	FtsDocsizeID sqlitegen.Column = "fts_docsize.id"
+	FtsDocsizeSz sqlitegen.Column = "fts_docsize.sz"
+)
+
+// Table fts_docsize. Plain strings.
+const (
+	T_FtsDocsize   = "fts_docsize"
+	C_FtsDocsizeID = "fts_docsize.id"
+	C_FtsDocsizeSz = "fts_docsize.sz"
+)
+
+// Table fts_idx.
+const (
+	FtsIdx      sqlitegen.Table  = "fts_idx"
+	FtsIdxPgno  sqlitegen.Column = "fts_idx.pgno"
+	FtsIdxSegid sqlitegen.Column = "fts_idx.segid"
+	FtsIdxTerm  sqlitegen.Column = "fts_idx.term"
+)
+
+// Table fts_idx. Plain strings.
+const (
+	T_FtsIdx      = "fts_idx"
+	C_FtsIdxPgno  = "fts_idx.pgno"
+	C_FtsIdxSegid = "fts_idx.segid"
+	C_FtsIdxTerm  = "fts_idx.term"
+)
+
+// Table fts_index.
+const (
+	FtsIndex         sqlitegen.Table  = "fts_index"
+	FtsIndexBlobID   sqlitegen.Column = "fts_index.blob_id"
+	FtsIndexBlockID  sqlitegen.Column = "fts_index.block_id"
+	FtsIndexID       sqlitegen.Column = "fts_index.id"
+	FtsIndexResource sqlitegen.Column = "fts_index.resource"
+	FtsIndexTs       sqlitegen.Column = "fts_index.ts"
+	FtsIndexType     sqlitegen.Column = "fts_index.type"
+)
+
+// Table fts_index. Plain strings.
+const (
+	T_FtsIndex         = "fts_index"
+	C_FtsIndexBlobID   = "fts_index.blob_id"
+	C_FtsIndexBlockID  = "fts_index.block_id"
+	C_FtsIndexID       = "fts_index.id"
+	C_FtsIndexResource = "fts_index.resource"
+	C_FtsIndexTs       = "fts_index.ts"
+	C_FtsIndexType     = "fts_index.type"
+)
+
+// Table group_keys.
+const (
+	GroupKeys        sqlitegen.Table  = "group_keys"
+	GroupKeysGroupID sqlitegen.Column = "group_keys.group_id"
+	GroupKeysID      sqlitegen.Column = "group_keys.id"
+	GroupKeysKeyID   sqlitegen.Column = "group_keys.key_id"
+)
+
+// Table group_keys. Plain strings.
+const (
+	T_GroupKeys        = "group_keys"
+	C_GroupKeysGroupID = "group_keys.group_id"
+	C_GroupKeysID      = "group_keys.id"
+	C_GroupKeysKeyID   = "group_keys.key_id"
+)
+
 // Table group_sites.
 const (
 	GroupSites               sqlitegen.Table  = "group_sites"
@@ -136,12 +266,27 @@ const (
 	C_GroupSitesURL            = "group_sites.url"
 )
 
+// Table indexed_blobs.
+const (
+	IndexedBlobs    sqlitegen.Table  = "indexed_blobs"
+	IndexedBlobsID  sqlitegen.Column = "indexed_blobs.id"
+	IndexedBlobsSeq sqlitegen.Column = "indexed_blobs.seq"
+)
+
+// Table indexed_blobs. Plain strings.
+const (
+	T_IndexedBlobs    = "indexed_blobs"
+	C_IndexedBlobsID  = "indexed_blobs.id"
+	C_IndexedBlobsSeq = "indexed_blobs.seq"
+)
+
 // Table key_delegations.
 const (
 	KeyDelegations         sqlitegen.Table  = "key_delegations"
 	KeyDelegationsDelegate sqlitegen.Column = "key_delegations.delegate"
 	KeyDelegationsID       sqlitegen.Column = "key_delegations.id"
 	KeyDelegationsIssuer   sqlitegen.Column = "key_delegations.issuer"
+	KeyDelegationsNotAfter sqlitegen.Column = "key_delegations.not_after"
 )
 
 // Table key_delegations. Plain strings.
@@ -150,6 +295,7 @@ const (
 	C_KeyDelegationsDelegate = "key_delegations.delegate"
 	C_KeyDelegationsID       = "key_delegations.id"
 	C_KeyDelegationsIssuer   = "key_delegations.issuer"
+	C_KeyDelegationsNotAfter = "key_delegations.not_after"
 )
 
 // Table key_delegations_view.
@@ -160,6 +306,7 @@ const (
 	KeyDelegationsViewBlobMultihash sqlitegen.Column = "key_delegations_view.blob_multihash"
 	KeyDelegationsViewDelegate      sqlitegen.Column = "key_delegations_view.delegate"
 	KeyDelegationsViewIssuer        sqlitegen.Column = "key_delegations_view.issuer"
+	KeyDelegationsViewNotAfter      sqlitegen.Column = "key_delegations_view.not_after"
 )
 
 // Table key_delegations_view. Plain strings.
@@ -170,6 +317,25 @@ const (
 	C_KeyDelegationsViewBlobMultihash = "key_delegations_view.blob_multihash"
 	C_KeyDelegationsViewDelegate      = "key_delegations_view.delegate"
 	C_KeyDelegationsViewIssuer        = "key_delegations_view.issuer"
+	C_KeyDelegationsViewNotAfter      = "key_delegations_view.not_after"
+)
+
+// Table key_revocations.
+const (
+	KeyRevocations           sqlitegen.Table  = "key_revocations"
+	KeyRevocationsDelegate   sqlitegen.Column = "key_revocations.delegate"
+	KeyRevocationsID         sqlitegen.Column = "key_revocations.id"
+	KeyRevocationsIssuer     sqlitegen.Column = "key_revocations.issuer"
+	KeyRevocationsRevokeTime sqlitegen.Column = "key_revocations.revoke_time"
+)
+
+// Table key_revocations. Plain strings.
+const (
+	T_KeyRevocations           = "key_revocations"
+	C_KeyRevocationsDelegate   = "key_revocations.delegate"
+	C_KeyRevocationsID         = "key_revocations.id"
+	C_KeyRevocationsIssuer     = "key_revocations.issuer"
+	C_KeyRevocationsRevokeTime = "key_revocations.revoke_time"
 )
 
 // Table kv.
@@ -202,6 +368,48 @@ const (
 	C_MetaViewPrincipal = "meta_view.principal"
 )
 
+// Table pending_changes.
+const (
+	PendingChanges         sqlitegen.Table  = "pending_changes"
+	PendingChangesID       sqlitegen.Column = "pending_changes.id"
+	PendingChangesResource sqlitegen.Column = "pending_changes.resource"
+)
+
+// Table pending_changes. Plain strings.
+const (
+	T_PendingChanges         = "pending_changes"
+	C_PendingChangesID       = "pending_changes.id"
+	C_PendingChangesResource = "pending_changes.resource"
+)
+
+// Table pinned_blobs.
+const (
+	PinnedBlobs           sqlitegen.Table  = "pinned_blobs"
+	PinnedBlobsID         sqlitegen.Column = "pinned_blobs.id"
+	PinnedBlobsInsertTime sqlitegen.Column = "pinned_blobs.insert_time"
+)
+
+// Table pinned_blobs. Plain strings.
+const (
+	T_PinnedBlobs           = "pinned_blobs"
+	C_PinnedBlobsID         = "pinned_blobs.id"
+	C_PinnedBlobsInsertTime = "pinned_blobs.insert_time"
+)
+
+// Table private_blobs.
+const (
+	PrivateBlobs        sqlitegen.Table  = "private_blobs"
+	PrivateBlobsGroupID sqlitegen.Column = "private_blobs.group_id"
+	PrivateBlobsID      sqlitegen.Column = "private_blobs.id"
+)
+
+// Table private_blobs. Plain strings.
+const (
+	T_PrivateBlobs        = "private_blobs"
+	C_PrivateBlobsGroupID = "private_blobs.group_id"
+	C_PrivateBlobsID      = "private_blobs.id"
+)
+
 // Table public_keys.
 const (
 	PublicKeys          sqlitegen.Table  = "public_keys"
@@ -320,6 +528,66 @@ const (
 	C_StructuralBlobsViewTs         = "structural_blobs_view.ts"
 )
 
+// Table subscriptions.
+const (
+	Subscriptions           sqlitegen.Table  = "subscriptions"
+	SubscriptionsInsertTime sqlitegen.Column = "subscriptions.insert_time"
+	SubscriptionsIRI        sqlitegen.Column = "subscriptions.iri"
+)
+
+// Table subscriptions. Plain strings.
+const (
+	T_Subscriptions           = "subscriptions"
+	C_SubscriptionsInsertTime = "subscriptions.insert_time"
+	C_SubscriptionsIRI        = "subscriptions.iri"
+)
+
+// Table sync_history.
+const (
+	SyncHistory             sqlitegen.Table  = "sync_history"
+	SyncHistoryCursor       sqlitegen.Column = "sync_history.cursor"
+	SyncHistoryEndTime      sqlitegen.Column = "sync_history.end_time"
+	SyncHistoryError        sqlitegen.Column = "sync_history.error"
+	SyncHistoryFailedBlobs  sqlitegen.Column = "sync_history.failed_blobs"
+	SyncHistoryFetchedBlobs sqlitegen.Column = "sync_history.fetched_blobs"
+	SyncHistoryID           sqlitegen.Column = "sync_history.id"
+	SyncHistoryKind         sqlitegen.Column = "sync_history.kind"
+	SyncHistoryPushedBlobs  sqlitegen.Column = "sync_history.pushed_blobs"
+	SyncHistoryStartTime    sqlitegen.Column = "sync_history.start_time"
+	SyncHistoryTarget       sqlitegen.Column = "sync_history.target"
+	SyncHistoryWantedBlobs  sqlitegen.Column = "sync_history.wanted_blobs"
+)
+
+// Table sync_history. Plain strings.
+const (
+	T_SyncHistory             = "sync_history"
+	C_SyncHistoryCursor       = "sync_history.cursor"
+	C_SyncHistoryEndTime      = "sync_history.end_time"
+	C_SyncHistoryError        = "sync_history.error"
+	C_SyncHistoryFailedBlobs  = "sync_history.failed_blobs"
+	C_SyncHistoryFetchedBlobs = "sync_history.fetched_blobs"
+	C_SyncHistoryID           = "sync_history.id"
+	C_SyncHistoryKind         = "sync_history.kind"
+	C_SyncHistoryPushedBlobs  = "sync_history.pushed_blobs"
+	C_SyncHistoryStartTime    = "sync_history.start_time"
+	C_SyncHistoryTarget       = "sync_history.target"
+	C_SyncHistoryWantedBlobs  = "sync_history.wanted_blobs"
+)
+
+// Table syncing_backfills.
+const (
+	SyncingBackfills         sqlitegen.Table  = "syncing_backfills"
+	SyncingBackfillsPeer     sqlitegen.Column = "syncing_backfills.peer"
+	SyncingBackfillsResource sqlitegen.Column = "syncing_backfills.resource"
+)
+
+// Table syncing_backfills. Plain strings.
+const (
+	T_SyncingBackfills         = "syncing_backfills"
+	C_SyncingBackfillsPeer     = "syncing_backfills.peer"
+	C_SyncingBackfillsResource = "syncing_backfills.resource"
+)
+
 // Table syncing_cursors.
 const (
 	SyncingCursors       sqlitegen.Table  = "syncing_cursors"
@@ -336,14 +604,28 @@ const (
 
 // Table trusted_accounts.
 const (
-	TrustedAccounts   sqlitegen.Table  = "trusted_accounts"
-	TrustedAccountsID sqlitegen.Column = "trusted_accounts.id"
+	TrustedAccounts        sqlitegen.Table  = "trusted_accounts"
+	TrustedAccountsAccount sqlitegen.Column = "trusted_accounts.account"
+	TrustedAccountsID      sqlitegen.Column = "trusted_accounts.id"
 )
 
 // Table trusted_accounts. Plain strings.
 const (
-	T_TrustedAccounts   = "trusted_accounts"
-	C_TrustedAccountsID = "trusted_accounts.id"
+	T_TrustedAccounts        = "trusted_accounts"
+	C_TrustedAccountsAccount = "trusted_accounts.account"
+	C_TrustedAccountsID      = "trusted_accounts.id"
+)
+
+// Table unauthorized_changes.
+const (
+	UnauthorizedChanges   sqlitegen.Table  = "unauthorized_changes"
+	UnauthorizedChangesID sqlitegen.Column = "unauthorized_changes.id"
+)
+
+// Table unauthorized_changes. Plain strings.
+const (
+	T_UnauthorizedChanges   = "unauthorized_changes"
+	C_UnauthorizedChangesID = "unauthorized_changes.id"
 )
 
 // Table wallets.
@@ -390,13 +672,38 @@ var Schema = sqlitegen.Schema{
 		DeletedResourcesIRI:             {Table: DeletedResources, SQLType: "TEXT"},
 		DeletedResourcesMeta:            {Table: DeletedResources, SQLType: "TEXT"},
 		DeletedResourcesReason:          {Table: DeletedResources, SQLType: "TEXT"},
+		DraftsAccount:                   {Table: Drafts, SQLType: "INTEGER"},
 		DraftsBlob:                      {Table: Drafts, SQLType: "INTEGER"},
 		DraftsResource:                  {Table: Drafts, SQLType: "INTEGER"},
+		DraftsViewAccountID:             {Table: DraftsView, SQLType: "INTEGER"},
 		DraftsViewBlobID:                {Table: DraftsView, SQLType: "INTEGER"},
 		DraftsViewCodec:                 {Table: DraftsView, SQLType: "INTEGER"},
 		DraftsViewMultihash:             {Table: DraftsView, SQLType: "BLOB"},
 		DraftsViewResource:              {Table: DraftsView, SQLType: "TEXT"},
 		DraftsViewResourceID:            {Table: DraftsView, SQLType: "INTEGER"},
+		FtsFts:                          {Table: Fts, SQLType: ""},
+		FtsRank:                         {Table: Fts, SQLType: ""},
+		FtsRawContent:                   {Table: Fts, SQLType: ""},
+		FtsConfigK:                      {Table: FtsConfig, SQLType: ""},
+		FtsConfigV:                      {Table: FtsConfig, SQLType: ""},
+		FtsContentC0:                    {Table: FtsContent, SQLType: ""},
+		FtsContentID:                    {Table: FtsContent, SQLType: "INTEGER"},
+		FtsDataBlock:                    {Table: FtsData, SQLType: "BLOB"},
+		FtsDataID:                       {Table: FtsData, SQLType: "INTEGER"},
+		FtsDocsizeID:                    {Table: FtsDocsize, SQLType: "INTEGER"},
+		FtsDocsizeSz:                    {Table: FtsDocsize, SQLType: "BLOB"},
+		FtsIdxPgno:                      {Table: FtsIdx, SQLType: ""},
+		FtsIdxSegid:                     {Table: FtsIdx, SQLType: ""},
+		FtsIdxTerm:                      {Table: FtsIdx, SQLType: ""},
+		FtsIndexBlobID:                  {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexBlockID:                 {Table: FtsIndex, SQLType: "TEXT"},
+		FtsIndexID:                      {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexResource:                {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexTs:                      {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexType:                    {Table: FtsIndex, SQLType: "TEXT"},
+		GroupKeysGroupID:                {Table: GroupKeys, SQLType: "INTEGER"},
+		GroupKeysID:                     {Table: GroupKeys, SQLType: "INTEGER"},
+		GroupKeysKeyID:                  {Table: GroupKeys, SQLType: "TEXT"},
 		GroupSitesGroupID:               {Table: GroupSites, SQLType: "TEXT"},
 		GroupSitesHLCOrigin:             {Table: GroupSites, SQLType: "TEXT"},
 		GroupSitesHLCTime:               {Table: GroupSites, SQLType: "INTEGER"},
@@ -405,19 +712,33 @@ var Schema = sqlitegen.Schema{
 		GroupSitesLastSyncTime:          {Table: GroupSites, SQLType: "INTEGER"},
 		GroupSitesRemoteVersion:         {Table: GroupSites, SQLType: "TEXT"},
 		GroupSitesURL:                   {Table: GroupSites, SQLType: "TEXT"},
+		IndexedBlobsID:                  {Table: IndexedBlobs, SQLType: "INTEGER"},
+		IndexedBlobsSeq:                 {Table: IndexedBlobs, SQLType: "INTEGER"},
 		KeyDelegationsDelegate:          {Table: KeyDelegations, SQLType: "INTEGER"},
 		KeyDelegationsID:                {Table: KeyDelegations, SQLType: "INTEGER"},
 		KeyDelegationsIssuer:            {Table: KeyDelegations, SQLType: "INTEGER"},
+		KeyDelegationsNotAfter:          {Table: KeyDelegations, SQLType: "INTEGER"},
 		KeyDelegationsViewBlob:          {Table: KeyDelegationsView, SQLType: "INTEGER"},
 		KeyDelegationsViewBlobCodec:     {Table: KeyDelegationsView, SQLType: "INTEGER"},
 		KeyDelegationsViewBlobMultihash: {Table: KeyDelegationsView, SQLType: "BLOB"},
 		KeyDelegationsViewDelegate:      {Table: KeyDelegationsView, SQLType: "BLOB"},
 		KeyDelegationsViewIssuer:        {Table: KeyDelegationsView, SQLType: "BLOB"},
+		KeyDelegationsViewNotAfter:      {Table: KeyDelegationsView, SQLType: "INTEGER"},
+		KeyRevocationsDelegate:          {Table: KeyRevocations, SQLType: "INTEGER"},
+		KeyRevocationsID:                {Table: KeyRevocations, SQLType: "INTEGER"},
+		KeyRevocationsIssuer:            {Table: KeyRevocations, SQLType: "INTEGER"},
+		KeyRevocationsRevokeTime:        {Table: KeyRevocations, SQLType: "INTEGER"},
 		KVKey:                           {Table: KV, SQLType: "TEXT"},
 		KVValue:                         {Table: KV, SQLType: "TEXT"},
 		MetaViewIRI:                     {Table: MetaView, SQLType: "TEXT"},
 		MetaViewMeta:                    {Table: MetaView, SQLType: "TEXT"},
 		MetaViewPrincipal:               {Table: MetaView, SQLType: "BLOB"},
+		PendingChangesID:                {Table: PendingChanges, SQLType: "INTEGER"},
+		PendingChangesResource:          {Table: PendingChanges, SQLType: "TEXT"},
+		PinnedBlobsID:                   {Table: PinnedBlobs, SQLType: "INTEGER"},
+		PinnedBlobsInsertTime:           {Table: PinnedBlobs, SQLType: "INTEGER"},
+		PrivateBlobsGroupID:             {Table: PrivateBlobs, SQLType: "INTEGER"},
+		PrivateBlobsID:                  {Table: PrivateBlobs, SQLType: "INTEGER"},
 		PublicKeysID:                    {Table: PublicKeys, SQLType: "INTEGER"},
 		PublicKeysPrincipal:             {Table: PublicKeys, SQLType: "BLOB"},
 		ResourceLinksID:                 {Table: ResourceLinks, SQLType: "INTEGER"},
@@ -447,9 +768,26 @@ var Schema = sqlitegen.Schema{
 		StructuralBlobsViewResourceID:   {Table: StructuralBlobsView, SQLType: "INTEGER"},
 		StructuralBlobsViewSize:         {Table: StructuralBlobsView, SQLType: "INTEGER"},
 		StructuralBlobsViewTs:           {Table: StructuralBlobsView, SQLType: "INTEGER"},
+		SubscriptionsInsertTime:         {Table: Subscriptions, SQLType: "INTEGER"},
+		SubscriptionsIRI:                {Table: Subscriptions, SQLType: "TEXT"},
+		SyncHistoryCursor:               {Table: SyncHistory, SQLType: "TEXT"},
+		SyncHistoryEndTime:              {Table: SyncHistory, SQLType: "INTEGER"},
+		SyncHistoryError:                {Table: SyncHistory, SQLType: "TEXT"},
+		SyncHistoryFailedBlobs:          {Table: SyncHistory, SQLType: "INTEGER"},
+		SyncHistoryFetchedBlobs:         {Table: SyncHistory, SQLType: "INTEGER"},
+		SyncHistoryID:                   {Table: SyncHistory, SQLType: "INTEGER"},
+		SyncHistoryKind:                 {Table: SyncHistory, SQLType: "TEXT"},
+		SyncHistoryPushedBlobs:          {Table: SyncHistory, SQLType: "INTEGER"},
+		SyncHistoryStartTime:            {Table: SyncHistory, SQLType: "INTEGER"},
+		SyncHistoryTarget:               {Table: SyncHistory, SQLType: "TEXT"},
+		SyncHistoryWantedBlobs:          {Table: SyncHistory, SQLType: "INTEGER"},
+		SyncingBackfillsPeer:            {Table: SyncingBackfills, SQLType: "INTEGER"},
+		SyncingBackfillsResource:        {Table: SyncingBackfills, SQLType: "TEXT"},
 		SyncingCursorsCursor:            {Table: SyncingCursors, SQLType: "TEXT"},
 		SyncingCursorsPeer:              {Table: SyncingCursors, SQLType: "INTEGER"},
+		TrustedAccountsAccount:          {Table: TrustedAccounts, SQLType: "INTEGER"},
 		TrustedAccountsID:               {Table: TrustedAccounts, SQLType: "INTEGER"},
+		UnauthorizedChangesID:           {Table: UnauthorizedChanges, SQLType: "INTEGER"},
 		WalletsAddress:                  {Table: Wallets, SQLType: "TEXT"},
 		WalletsBalance:                  {Table: Wallets, SQLType: "INTEGER"},
 		WalletsID:                       {Table: Wallets, SQLType: "TEXT"},
@@ -81,6 +81,51 @@ FROM structural_blobs
 JOIN blobs ON blobs.id = structural_blobs.id
 JOIN resources ON structural_blobs.resource = resources.id;
 
+-- Stores changes which we keep, but exclude from the state of their entities,
+-- because their signers didn't have enough permissions to make them.
+CREATE TABLE unauthorized_changes (
+    id INTEGER PRIMARY KEY REFERENCES structural_blobs (id) ON DELETE CASCADE NOT NULL
+) WITHOUT ROWID;
+
+-- Stores changes and group keys which we have, but can't index yet, because some of their deps are not indexed.
+-- They are indexed as soon as all of their deps are. Until then they are not part of their entities.
+CREATE TABLE pending_changes (
+    id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    -- IRI of the entity the change belongs to.
+    resource TEXT NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX pending_changes_by_resource ON pending_changes (resource);
+
+-- Sequence of the structural blobs in the order they were indexed.
+-- Blob IDs are allocated when blobs are received, but some blobs are indexed much later,
+-- e.g. pending changes when their deps arrive, or drafts when they get published.
+-- Activity feed subscriptions follow this sequence to not miss any of them.
+-- Rows are kept when reindexing, so the order of the already indexed blobs doesn't change.
+CREATE TABLE indexed_blobs (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    id INTEGER UNIQUE REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL
+);
+
+-- Stores GroupKey blobs which distribute the content keys of private groups to the devices of the members.
+-- The same key can be distributed by multiple blobs, e.g. when new members are added to the group.
+CREATE TABLE group_keys (
+    id INTEGER PRIMARY KEY REFERENCES structural_blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    group_id INTEGER REFERENCES resources (id) NOT NULL,
+    key_id TEXT NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX group_keys_by_group ON group_keys (group_id, key_id);
+
+-- Stores blobs with content sealed for a private group.
+-- These blobs are only served to the devices of the group members.
+CREATE TABLE private_blobs (
+    id INTEGER PRIMARY KEY REFERENCES structural_blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    group_id INTEGER REFERENCES resources (id) NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX private_blobs_by_group ON private_blobs (group_id);
+
 -- View blobs metadata It returns the latest non null title or the 
 -- latest blob in case of untitled meta.
 CREATE VIEW meta_view AS
@@ -119,10 +164,12 @@ JOIN resources res ON res.id = lb.resource
 JOIN public_keys pk ON pk.id = lb.author;
 
 -- Stores extra information for key delegation blobs.
+-- Not after is the Unix timestamp (in seconds) when the delegation expires, or 0 if it never does.
 CREATE TABLE key_delegations (
     id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE NOT NULL,
     issuer INTEGER REFERENCES public_keys (id),
-    delegate INTEGER REFERENCES public_keys (id)
+    delegate INTEGER REFERENCES public_keys (id),
+    not_after INTEGER DEFAULT (0) NOT NULL
 ) WITHOUT ROWID;
 
 CREATE INDEX key_delegations_by_issuer ON key_delegations (issuer, delegate);
@@ -134,12 +181,26 @@ SELECT
     blobs.codec AS blob_codec,
     blobs.multihash AS blob_multihash,
     iss.principal AS issuer,
-    del.principal AS delegate
+    del.principal AS delegate,
+    kd.not_after AS not_after
 FROM key_delegations kd
 JOIN blobs INDEXED BY blobs_metadata ON blobs.id = kd.id
 JOIN public_keys iss ON iss.id = kd.issuer
 JOIN public_keys del ON del.id = kd.delegate;
 
+-- Stores extra information for key revocation blobs.
+-- Revoke time is the Unix timestamp (in seconds) after which blobs signed by the delegate
+-- are no longer accepted on behalf of the issuer.
+CREATE TABLE key_revocations (
+    id INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE NOT NULL,
+    issuer INTEGER REFERENCES public_keys (id) NOT NULL,
+    delegate INTEGER REFERENCES public_keys (id) NOT NULL,
+    revoke_time INTEGER NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX key_revocations_by_issuer ON key_revocations (issuer, delegate, revoke_time);
+CREATE INDEX key_revocations_by_delegate ON key_revocations (delegate, issuer);
+
 -- Stores hypermedia resources.
 -- All resources are identified by an IRI[iri],
 -- might have an owner identified by a public key.
@@ -199,12 +260,22 @@ CREATE INDEX resource_links_by_source ON resource_links (source, is_pinned, targ
 CREATE INDEX resource_links_by_target ON resource_links (target, source);
 
 -- Stores the accounts that used marked as trusted.
+-- Each account registered on this device has its own set of trusted accounts.
 CREATE TABLE trusted_accounts (
-    id INTEGER PRIMARY KEY REFERENCES public_keys (id) NOT NULL
+    -- Account of this device which trusts the other account.
+    account INTEGER REFERENCES public_keys (id) NOT NULL,
+    -- The trusted account.
+    id INTEGER REFERENCES public_keys (id) NOT NULL,
+    PRIMARY KEY (account, id)
 ) WITHOUT ROWID;
 
--- Draft changes. Only one draft is allowed for now.
+CREATE INDEX trusted_accounts_by_id ON trusted_accounts (id, account);
+
+-- Draft changes. Only one draft per resource is allowed for now
+-- for each account registered on this device.
 CREATE TABLE drafts (
+    -- Account of this device which owns the draft.
+    account INTEGER REFERENCES public_keys (id) NOT NULL,
     resource INTEGER REFERENCES resources (id) NOT NULL,
     blob INTEGER REFERENCES blobs (id) ON DELETE CASCADE NOT NULL,
     PRIMARY KEY (resource, blob)
@@ -212,13 +283,21 @@ CREATE TABLE drafts (
 
 CREATE INDEX drafts_by_blob ON drafts (blob);
 
+-- Blobs we want to keep even if they are not linked from any content we keep,
+-- e.g. the files uploaded on this device. Used as roots for garbage collection.
+CREATE TABLE pinned_blobs (
+    id INTEGER PRIMARY KEY REFERENCES blobs (id) ON DELETE CASCADE NOT NULL,
+    insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+) WITHOUT ROWID;
+
 -- Index to ensure only one draft is allowed. Defining it separately,
 -- so it's easier to drop eventually without a complex migration.
-CREATE UNIQUE INDEX drafts_unique ON drafts (resource);
+CREATE UNIQUE INDEX drafts_unique ON drafts (account, resource);
 
 -- View of drafts with dereferenced foreign keys.
 CREATE VIEW drafts_view AS
 SELECT
+    drafts.account AS account_id,
     drafts.resource AS resource_id,
     drafts.blob AS blob_id,
     resources.iri AS resource,
@@ -279,3 +358,77 @@ CREATE TABLE syncing_cursors (
     peer INTEGER PRIMARY KEY REFERENCES public_keys (id) ON DELETE CASCADE NOT NULL,
     cursor TEXT NOT NULL
 ) WITHOUT ROWID;
+
+-- Stores the resources the user is interested in.
+-- When there're any subscriptions, we only sync
+-- the blobs related to the subscribed resources.
+-- Otherwise we sync everything our peers have.
+CREATE TABLE subscriptions (
+    iri TEXT PRIMARY KEY,
+    insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+) WITHOUT ROWID;
+
+-- Stores the resources whose related blobs we've listed from the peer from the beginning.
+-- The syncing cursor of the peer only covers these resources. Blobs of new subscriptions,
+-- or of new content in the subscribed groups, are listed without the cursor first.
+CREATE TABLE syncing_backfills (
+    peer INTEGER REFERENCES public_keys (id) ON DELETE CASCADE NOT NULL,
+    resource TEXT NOT NULL,
+    PRIMARY KEY (peer, resource)
+) WITHOUT ROWID;
+
+-- Full-text search index over the textual content of structural blobs.
+-- Rows are never written directly, but through the fts_index table,
+-- which links each row to the blob it was extracted from.
+CREATE VIRTUAL TABLE fts USING fts5(
+    raw_content,
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '2 3'
+);
+
+-- Attributes of the rows in the fts table. The id matches the rowid in the fts table.
+CREATE TABLE fts_index (
+    id INTEGER PRIMARY KEY,
+    -- Blob the content was extracted from.
+    blob_id INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    -- Type of the content: title, document, description, alias, comment.
+    type TEXT NOT NULL,
+    -- ID of the block for block-based content. Empty otherwise.
+    block_id TEXT DEFAULT ('') NOT NULL,
+    -- Resource the content belongs to. For comments this is the commented resource.
+    resource INTEGER REFERENCES resources (id) ON DELETE CASCADE NOT NULL,
+    -- Timestamp of the blob. Only the most recent content for the same resource, type and block is relevant.
+    ts INTEGER NOT NULL
+);
+
+CREATE INDEX fts_index_by_blob ON fts_index (blob_id);
+CREATE INDEX fts_index_by_resource ON fts_index (resource, type, block_id, ts);
+
+CREATE TRIGGER fts_index_after_delete AFTER DELETE ON fts_index BEGIN
+    DELETE FROM fts WHERE rowid = OLD.id;
+END;
+
+-- History of the sync attempts with peers, group sites,
+-- and of the discovery of entities in the network.
+-- Only the most recent attempts are kept, both for each target and overall.
+CREATE TABLE sync_history (
+    id INTEGER PRIMARY KEY,
+    -- Kind of the sync: peer, site, or discovery.
+    kind TEXT NOT NULL,
+    -- Peer ID, site URL, or entity ID we've synced with.
+    target TEXT NOT NULL,
+    -- Start and end time of the attempt as Unix timestamps in milliseconds.
+    start_time INTEGER NOT NULL,
+    end_time INTEGER NOT NULL,
+    -- Number of blobs we wanted, fetched, failed to fetch, and pushed during the attempt.
+    wanted_blobs INTEGER DEFAULT (0) NOT NULL,
+    fetched_blobs INTEGER DEFAULT (0) NOT NULL,
+    failed_blobs INTEGER DEFAULT (0) NOT NULL,
+    pushed_blobs INTEGER DEFAULT (0) NOT NULL,
+    -- Error message if the attempt failed. Empty otherwise.
+    error TEXT DEFAULT ('') NOT NULL,
+    -- Sync cursor of the peer after the attempt, if any.
+    cursor TEXT DEFAULT ('') NOT NULL
+);
+
+CREATE INDEX sync_history_by_target ON sync_history (kind, target, id);
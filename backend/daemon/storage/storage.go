@@ -7,6 +7,7 @@ import (
 	"mintter/backend/logging"
 	"mintter/backend/pkg/future"
 	"path/filepath"
+	"sync"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"go.uber.org/zap"
@@ -17,8 +18,14 @@ type Dir struct {
 	path string
 	log  *zap.Logger
 
-	device core.KeyPair
-	me     future.Value[core.Identity]
+	// Guards the device key, which can be unlocked after the storage is loaded,
+	// and the registry of accounts.
+	mu       sync.Mutex
+	locked   bool
+	device   core.KeyPair
+	accounts []core.PublicKey
+	active   core.Principal
+	me       future.Value[core.Identity]
 }
 
 // InitRepo initializes the storage directory.
@@ -105,6 +112,82 @@ func (d *Dir) SQLitePath() string {
 }
 
 // Device returns the device key pair.
+// It's empty while the storage is locked.
 func (d *Dir) Device() core.KeyPair {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	return d.device
 }
+
+// Locked reports whether the device key is encrypted, and must be unlocked before the storage can be used.
+func (d *Dir) Locked() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.locked
+}
+
+// Unlock decrypts the device key with the passphrase, and loads the registered accounts if there're any.
+func (d *Dir) Unlock(passphrase string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.locked {
+		return fmt.Errorf("device key is not locked")
+	}
+
+	ks, err := readKeystoreFile(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to load device keystore: %w", err)
+	}
+
+	if err := d.loadDeviceKey(ks, passphrase); err != nil {
+		return err
+	}
+
+	d.locked = false
+
+	return nil
+}
+
+// KeyEncrypted reports whether the device key is stored encrypted with a passphrase.
+func (d *Dir) KeyEncrypted() (bool, error) {
+	ks, err := readKeystoreFile(d.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to load device keystore: %w", err)
+	}
+
+	return ks.Encrypted(), nil
+}
+
+// ChangeKeyPassphrase encrypts the device key with the new passphrase.
+// The old passphrase is ignored if the key is not encrypted yet.
+// Empty new passphrase stores the key without encryption.
+func (d *Dir) ChangeKeyPassphrase(oldPassphrase, newPassphrase string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.locked {
+		return ErrKeyLocked
+	}
+
+	ks, err := readKeystoreFile(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to load device keystore: %w", err)
+	}
+
+	pk, err := ks.Open(oldPassphrase)
+	if err != nil {
+		return err
+	}
+
+	if !pk.Equals(d.device.Wrapped()) {
+		return fmt.Errorf("BUG: device key in the keystore doesn't match the loaded key")
+	}
+
+	ks, err = sealKeystore(pk, newPassphrase)
+	if err != nil {
+		return fmt.Errorf("failed to seal device key: %w", err)
+	}
+
+	return writeKeystoreFile(d.path, ks)
+}
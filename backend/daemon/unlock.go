@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"mintter/backend/config"
+	"mintter/backend/daemon/storage"
+	daemonpb "mintter/backend/genproto/daemon/v1alpha"
+	"mintter/backend/logging"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errDaemonLocked = status.Error(codes.FailedPrecondition, "daemon is locked: unlock the device key first")
+
+var unlockMethod = "/" + daemonpb.Daemon_ServiceDesc.ServiceName + "/Unlock"
+
+// Each unlock attempt derives the key with argon2, which is expensive in memory and CPU,
+// so after failed attempts the next ones are rejected for an exponentially growing period.
+const (
+	unlockBackoffBase = time.Second
+	unlockBackoffMax  = time.Minute
+)
+
+// WaitUnlock blocks until the device key of the storage is unlocked, if it's encrypted.
+// While the key is locked, only the Unlock method of the Daemon API is served,
+// over gRPC and gRPC-Web on the same ports as the fully loaded daemon.
+// The ports are released before returning, so the daemon can be loaded afterwards.
+func WaitUnlock(ctx context.Context, cfg config.Config, r *storage.Dir) (err error) {
+	if !r.Locked() {
+		return nil
+	}
+
+	grpcLis, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.GRPC.Port))
+	if err != nil {
+		return err
+	}
+
+	httpLis, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.HTTP.Port))
+	if err != nil {
+		return multierr.Combine(err, grpcLis.Close())
+	}
+
+	log := logging.New("mintter/daemon", cfg.LogLevel)
+	log.Info("DaemonLocked",
+		zap.String("grpcListener", grpcLis.Addr().String()),
+		zap.String("httpListener", httpLis.Addr().String()),
+	)
+
+	if err := serveLocked(ctx, r, grpcLis, httpLis); err != nil {
+		return err
+	}
+
+	log.Info("DaemonUnlocked")
+
+	return nil
+}
+
+// serveLocked serves the Unlock API on the given listeners until the storage is unlocked.
+func serveLocked(ctx context.Context, r *storage.Dir, grpcLis, httpLis net.Listener) error {
+	srv := &lockedServer{
+		repo:     r,
+		unlocked: make(chan struct{}),
+	}
+
+	grpcSrv := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if info.FullMethod != unlockMethod {
+				return nil, errDaemonLocked
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(any, grpc.ServerStream, *grpc.StreamServerInfo, grpc.StreamHandler) error {
+			return errDaemonLocked
+		}),
+		grpc.UnknownServiceHandler(func(any, grpc.ServerStream) error {
+			return errDaemonLocked
+		}),
+	)
+	daemonpb.RegisterDaemonServer(grpcSrv, srv)
+
+	router := &Router{r: mux.NewRouter()}
+	setupGRPCWebHandler(router, grpcSrv)
+
+	httpSrv := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		IdleTimeout:       20 * time.Second,
+		Handler:           router.r,
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return grpcSrv.Serve(grpcLis)
+	})
+
+	g.Go(func() error {
+		err := httpSrv.Serve(httpLis)
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		select {
+		case <-gctx.Done():
+			err = gctx.Err()
+		case <-srv.unlocked:
+		}
+
+		grpcSrv.GracefulStop()
+		return multierr.Combine(err, httpSrv.Shutdown(context.Background()))
+	})
+
+	return g.Wait()
+}
+
+// lockedServer implements the Daemon API while the device key is locked.
+type lockedServer struct {
+	daemonpb.UnimplementedDaemonServer
+
+	repo     *storage.Dir
+	once     sync.Once
+	unlocked chan struct{}
+
+	// Attempts are serialized, so concurrent calls can't bypass the backoff.
+	mu       sync.Mutex
+	failures int
+	retryAt  time.Time
+}
+
+// Unlock implements the corresponding gRPC method.
+func (srv *lockedServer) Unlock(_ context.Context, in *daemonpb.UnlockRequest) (*emptypb.Empty, error) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if wait := time.Until(srv.retryAt); wait > 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many failed unlock attempts: retry in %s", wait.Round(time.Second))
+	}
+
+	if err := srv.repo.Unlock(in.Passphrase); err != nil {
+		if errors.Is(err, storage.ErrWrongPassphrase) {
+			srv.failures++
+			srv.retryAt = time.Now().Add(unlockBackoff(srv.failures))
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, err
+	}
+
+	srv.once.Do(func() {
+		close(srv.unlocked)
+	})
+
+	return &emptypb.Empty{}, nil
+}
+
+// unlockBackoff returns how long to wait before the next unlock attempt after the given number of consecutive failures.
+func unlockBackoff(failures int) time.Duration {
+	// Avoiding the overflow of the shift.
+	if failures > 16 {
+		return unlockBackoffMax
+	}
+
+	d := unlockBackoffBase << (failures - 1)
+	if d > unlockBackoffMax {
+		return unlockBackoffMax
+	}
+
+	return d
+}
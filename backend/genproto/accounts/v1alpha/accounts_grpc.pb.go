@@ -38,6 +38,13 @@ type AccountsClient interface {
 	// Set or unset the trustness of an account. An account is untrusted by default except for our own.
 	// Returns the modified account.
 	SetAccountTrust(ctx context.Context, in *SetAccountTrustRequest, opts ...grpc.CallOption) (*Account, error)
+	// Lists all the known devices of an Account, including the expired and revoked ones.
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// Revokes one of the devices of our own Account.
+	// Changes and comments signed by the device after the revocation are rejected.
+	// The revocation must be signed by the Account key, so the mnemonic is required.
+	// Returns the revoked device.
+	RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*Device, error)
 }
 
 type accountsClient struct {
@@ -84,6 +91,24 @@ func (c *accountsClient) SetAccountTrust(ctx context.Context, in *SetAccountTrus
 	return out, nil
 }
 
+func (c *accountsClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.accounts.v1alpha.Accounts/ListDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsClient) RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/com.mintter.accounts.v1alpha.Accounts/RevokeDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountsServer is the server API for Accounts service.
 // All implementations should embed UnimplementedAccountsServer
 // for forward compatibility
@@ -104,6 +129,13 @@ type AccountsServer interface {
 	// Set or unset the trustness of an account. An account is untrusted by default except for our own.
 	// Returns the modified account.
 	SetAccountTrust(context.Context, *SetAccountTrustRequest) (*Account, error)
+	// Lists all the known devices of an Account, including the expired and revoked ones.
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// Revokes one of the devices of our own Account.
+	// Changes and comments signed by the device after the revocation are rejected.
+	// The revocation must be signed by the Account key, so the mnemonic is required.
+	// Returns the revoked device.
+	RevokeDevice(context.Context, *RevokeDeviceRequest) (*Device, error)
 }
 
 // UnimplementedAccountsServer should be embedded to have forward compatible implementations.
@@ -122,6 +154,12 @@ func (UnimplementedAccountsServer) ListAccounts(context.Context, *ListAccountsRe
 func (UnimplementedAccountsServer) SetAccountTrust(context.Context, *SetAccountTrustRequest) (*Account, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetAccountTrust not implemented")
 }
+func (UnimplementedAccountsServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedAccountsServer) RevokeDevice(context.Context, *RevokeDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDevice not implemented")
+}
 
 // UnsafeAccountsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to AccountsServer will
@@ -206,6 +244,42 @@ func _Accounts_SetAccountTrust_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Accounts_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.accounts.v1alpha.Accounts/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounts_RevokeDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).RevokeDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.accounts.v1alpha.Accounts/RevokeDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).RevokeDevice(ctx, req.(*RevokeDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Accounts_ServiceDesc is the grpc.ServiceDesc for Accounts service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +303,14 @@ var Accounts_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetAccountTrust",
 			Handler:    _Accounts_SetAccountTrust_Handler,
 		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _Accounts_ListDevices_Handler,
+		},
+		{
+			MethodName: "RevokeDevice",
+			Handler:    _Accounts_RevokeDevice_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "accounts/v1alpha/accounts.proto",
@@ -25,6 +25,9 @@ type ActivityFeedClient interface {
 	// Lists the recent activity events,
 	// sorted by locally observed time (newest first).
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	// Streams the new activity events as soon as they are observed locally,
+	// sorted by locally observed time (oldest first).
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (ActivityFeed_SubscribeEventsClient, error)
 }
 
 type activityFeedClient struct {
@@ -44,6 +47,38 @@ func (c *activityFeedClient) ListEvents(ctx context.Context, in *ListEventsReque
 	return out, nil
 }
 
+func (c *activityFeedClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (ActivityFeed_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ActivityFeed_ServiceDesc.Streams[0], "/com.mintter.activity.v1alpha.ActivityFeed/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &activityFeedSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ActivityFeed_SubscribeEventsClient interface {
+	Recv() (*SubscribeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type activityFeedSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *activityFeedSubscribeEventsClient) Recv() (*SubscribeEventsResponse, error) {
+	m := new(SubscribeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ActivityFeedServer is the server API for ActivityFeed service.
 // All implementations should embed UnimplementedActivityFeedServer
 // for forward compatibility
@@ -51,6 +86,9 @@ type ActivityFeedServer interface {
 	// Lists the recent activity events,
 	// sorted by locally observed time (newest first).
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	// Streams the new activity events as soon as they are observed locally,
+	// sorted by locally observed time (oldest first).
+	SubscribeEvents(*SubscribeEventsRequest, ActivityFeed_SubscribeEventsServer) error
 }
 
 // UnimplementedActivityFeedServer should be embedded to have forward compatible implementations.
@@ -60,6 +98,9 @@ type UnimplementedActivityFeedServer struct {
 func (UnimplementedActivityFeedServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
 }
+func (UnimplementedActivityFeedServer) SubscribeEvents(*SubscribeEventsRequest, ActivityFeed_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
 
 // UnsafeActivityFeedServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ActivityFeedServer will
@@ -90,6 +131,27 @@ func _ActivityFeed_ListEvents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActivityFeed_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActivityFeedServer).SubscribeEvents(m, &activityFeedSubscribeEventsServer{stream})
+}
+
+type ActivityFeed_SubscribeEventsServer interface {
+	Send(*SubscribeEventsResponse) error
+	grpc.ServerStream
+}
+
+type activityFeedSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *activityFeedSubscribeEventsServer) Send(m *SubscribeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ActivityFeed_ServiceDesc is the grpc.ServiceDesc for ActivityFeed service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -102,6 +164,12 @@ var ActivityFeed_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ActivityFeed_ListEvents_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _ActivityFeed_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "activity/v1alpha/activity.proto",
 }
@@ -30,11 +30,41 @@ type DaemonClient interface {
 	GenMnemonic(ctx context.Context, in *GenMnemonicRequest, opts ...grpc.CallOption) (*GenMnemonicResponse, error)
 	// After generating the seed, this call is used to commit the seed and
 	// create an account binding between the device and account.
+	// More accounts can be registered on the same device, the first one becomes the active account.
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	// Get generic information about the running node.
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*Info, error)
 	// Force-trigger periodic background sync of Mintter objects.
 	ForceSync(ctx context.Context, in *ForceSyncRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Deletes the blobs that are not reachable from the content we want to keep,
+	// and evicts content from other accounts if the configured disk quota is exceeded.
+	// Use dry run to only get the report of what would be deleted.
+	CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*GarbageCollectionReport, error)
+	// Lists the sync jobs that are currently queued or running, and the recently finished ones.
+	ListSyncJobs(ctx context.Context, in *ListSyncJobsRequest, opts ...grpc.CallOption) (*ListSyncJobsResponse, error)
+	// Same as ForceSync, but blocks until the sync is finished, streaming its progress.
+	// The last message of the stream has the done flag set, and summarizes the results.
+	ForceSyncWithProgress(ctx context.Context, in *ForceSyncRequest, opts ...grpc.CallOption) (Daemon_ForceSyncWithProgressClient, error)
+	// Gets the status of syncing with each peer, site, or discovered entity,
+	// based on the most recent attempts recorded in the sync history.
+	GetSyncStatus(ctx context.Context, in *GetSyncStatusRequest, opts ...grpc.CallOption) (*SyncStatus, error)
+	// Lists the recorded sync attempts, most recent first.
+	ListSyncHistory(ctx context.Context, in *ListSyncHistoryRequest, opts ...grpc.CallOption) (*ListSyncHistoryResponse, error)
+	// Unlocks the device key encrypted with a passphrase.
+	// While the device key is locked, the daemon only serves this method,
+	// and starts normally after the key is unlocked.
+	// After a wrong passphrase, the next attempts are rejected with RESOURCE_EXHAUSTED for an exponentially growing period.
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Changes the passphrase used to encrypt the device key.
+	// Setting a passphrase for a key that is not encrypted enables the encryption,
+	// and setting an empty passphrase disables it.
+	ChangeKeyPassphrase(ctx context.Context, in *ChangeKeyPassphraseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the accounts registered on this device.
+	// Requests can select the account to act on behalf of with the x-mintter-account metadata header,
+	// otherwise the active account is used.
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// Makes another registered account the active one.
+	SwitchAccount(ctx context.Context, in *SwitchAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type daemonClient struct {
@@ -81,6 +111,110 @@ func (c *daemonClient) ForceSync(ctx context.Context, in *ForceSyncRequest, opts
 	return out, nil
 }
 
+func (c *daemonClient) CollectGarbage(ctx context.Context, in *CollectGarbageRequest, opts ...grpc.CallOption) (*GarbageCollectionReport, error) {
+	out := new(GarbageCollectionReport)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/CollectGarbage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListSyncJobs(ctx context.Context, in *ListSyncJobsRequest, opts ...grpc.CallOption) (*ListSyncJobsResponse, error) {
+	out := new(ListSyncJobsResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/ListSyncJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ForceSyncWithProgress(ctx context.Context, in *ForceSyncRequest, opts ...grpc.CallOption) (Daemon_ForceSyncWithProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[0], "/com.mintter.daemon.v1alpha.Daemon/ForceSyncWithProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonForceSyncWithProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Daemon_ForceSyncWithProgressClient interface {
+	Recv() (*SyncProgress, error)
+	grpc.ClientStream
+}
+
+type daemonForceSyncWithProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonForceSyncWithProgressClient) Recv() (*SyncProgress, error) {
+	m := new(SyncProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daemonClient) GetSyncStatus(ctx context.Context, in *GetSyncStatusRequest, opts ...grpc.CallOption) (*SyncStatus, error) {
+	out := new(SyncStatus)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/GetSyncStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListSyncHistory(ctx context.Context, in *ListSyncHistoryRequest, opts ...grpc.CallOption) (*ListSyncHistoryResponse, error) {
+	out := new(ListSyncHistoryResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/ListSyncHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/Unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ChangeKeyPassphrase(ctx context.Context, in *ChangeKeyPassphraseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/ChangeKeyPassphrase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/ListAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) SwitchAccount(ctx context.Context, in *SwitchAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.daemon.v1alpha.Daemon/SwitchAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DaemonServer is the server API for Daemon service.
 // All implementations should embed UnimplementedDaemonServer
 // for forward compatibility
@@ -92,11 +226,41 @@ type DaemonServer interface {
 	GenMnemonic(context.Context, *GenMnemonicRequest) (*GenMnemonicResponse, error)
 	// After generating the seed, this call is used to commit the seed and
 	// create an account binding between the device and account.
+	// More accounts can be registered on the same device, the first one becomes the active account.
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	// Get generic information about the running node.
 	GetInfo(context.Context, *GetInfoRequest) (*Info, error)
 	// Force-trigger periodic background sync of Mintter objects.
 	ForceSync(context.Context, *ForceSyncRequest) (*emptypb.Empty, error)
+	// Deletes the blobs that are not reachable from the content we want to keep,
+	// and evicts content from other accounts if the configured disk quota is exceeded.
+	// Use dry run to only get the report of what would be deleted.
+	CollectGarbage(context.Context, *CollectGarbageRequest) (*GarbageCollectionReport, error)
+	// Lists the sync jobs that are currently queued or running, and the recently finished ones.
+	ListSyncJobs(context.Context, *ListSyncJobsRequest) (*ListSyncJobsResponse, error)
+	// Same as ForceSync, but blocks until the sync is finished, streaming its progress.
+	// The last message of the stream has the done flag set, and summarizes the results.
+	ForceSyncWithProgress(*ForceSyncRequest, Daemon_ForceSyncWithProgressServer) error
+	// Gets the status of syncing with each peer, site, or discovered entity,
+	// based on the most recent attempts recorded in the sync history.
+	GetSyncStatus(context.Context, *GetSyncStatusRequest) (*SyncStatus, error)
+	// Lists the recorded sync attempts, most recent first.
+	ListSyncHistory(context.Context, *ListSyncHistoryRequest) (*ListSyncHistoryResponse, error)
+	// Unlocks the device key encrypted with a passphrase.
+	// While the device key is locked, the daemon only serves this method,
+	// and starts normally after the key is unlocked.
+	// After a wrong passphrase, the next attempts are rejected with RESOURCE_EXHAUSTED for an exponentially growing period.
+	Unlock(context.Context, *UnlockRequest) (*emptypb.Empty, error)
+	// Changes the passphrase used to encrypt the device key.
+	// Setting a passphrase for a key that is not encrypted enables the encryption,
+	// and setting an empty passphrase disables it.
+	ChangeKeyPassphrase(context.Context, *ChangeKeyPassphraseRequest) (*emptypb.Empty, error)
+	// Lists the accounts registered on this device.
+	// Requests can select the account to act on behalf of with the x-mintter-account metadata header,
+	// otherwise the active account is used.
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	// Makes another registered account the active one.
+	SwitchAccount(context.Context, *SwitchAccountRequest) (*emptypb.Empty, error)
 }
 
 // UnimplementedDaemonServer should be embedded to have forward compatible implementations.
@@ -115,6 +279,33 @@ func (UnimplementedDaemonServer) GetInfo(context.Context, *GetInfoRequest) (*Inf
 func (UnimplementedDaemonServer) ForceSync(context.Context, *ForceSyncRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ForceSync not implemented")
 }
+func (UnimplementedDaemonServer) CollectGarbage(context.Context, *CollectGarbageRequest) (*GarbageCollectionReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectGarbage not implemented")
+}
+func (UnimplementedDaemonServer) ListSyncJobs(context.Context, *ListSyncJobsRequest) (*ListSyncJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSyncJobs not implemented")
+}
+func (UnimplementedDaemonServer) ForceSyncWithProgress(*ForceSyncRequest, Daemon_ForceSyncWithProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method ForceSyncWithProgress not implemented")
+}
+func (UnimplementedDaemonServer) GetSyncStatus(context.Context, *GetSyncStatusRequest) (*SyncStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncStatus not implemented")
+}
+func (UnimplementedDaemonServer) ListSyncHistory(context.Context, *ListSyncHistoryRequest) (*ListSyncHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSyncHistory not implemented")
+}
+func (UnimplementedDaemonServer) Unlock(context.Context, *UnlockRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (UnimplementedDaemonServer) ChangeKeyPassphrase(context.Context, *ChangeKeyPassphraseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeKeyPassphrase not implemented")
+}
+func (UnimplementedDaemonServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedDaemonServer) SwitchAccount(context.Context, *SwitchAccountRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchAccount not implemented")
+}
 
 // UnsafeDaemonServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DaemonServer will
@@ -199,6 +390,171 @@ func _Daemon_ForceSync_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_CollectGarbage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectGarbageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).CollectGarbage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/CollectGarbage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).CollectGarbage(ctx, req.(*CollectGarbageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListSyncJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSyncJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListSyncJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/ListSyncJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListSyncJobs(ctx, req.(*ListSyncJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ForceSyncWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ForceSyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).ForceSyncWithProgress(m, &daemonForceSyncWithProgressServer{stream})
+}
+
+type Daemon_ForceSyncWithProgressServer interface {
+	Send(*SyncProgress) error
+	grpc.ServerStream
+}
+
+type daemonForceSyncWithProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonForceSyncWithProgressServer) Send(m *SyncProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Daemon_GetSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).GetSyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/GetSyncStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).GetSyncStatus(ctx, req.(*GetSyncStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListSyncHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSyncHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListSyncHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/ListSyncHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListSyncHistory(ctx, req.(*ListSyncHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ChangeKeyPassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeKeyPassphraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ChangeKeyPassphrase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/ChangeKeyPassphrase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ChangeKeyPassphrase(ctx, req.(*ChangeKeyPassphraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/ListAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SwitchAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SwitchAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.daemon.v1alpha.Daemon/SwitchAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SwitchAccount(ctx, req.(*SwitchAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Daemon_ServiceDesc is the grpc.ServiceDesc for Daemon service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -222,7 +578,45 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ForceSync",
 			Handler:    _Daemon_ForceSync_Handler,
 		},
+		{
+			MethodName: "CollectGarbage",
+			Handler:    _Daemon_CollectGarbage_Handler,
+		},
+		{
+			MethodName: "ListSyncJobs",
+			Handler:    _Daemon_ListSyncJobs_Handler,
+		},
+		{
+			MethodName: "GetSyncStatus",
+			Handler:    _Daemon_GetSyncStatus_Handler,
+		},
+		{
+			MethodName: "ListSyncHistory",
+			Handler:    _Daemon_ListSyncHistory_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _Daemon_Unlock_Handler,
+		},
+		{
+			MethodName: "ChangeKeyPassphrase",
+			Handler:    _Daemon_ChangeKeyPassphrase_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _Daemon_ListAccounts_Handler,
+		},
+		{
+			MethodName: "SwitchAccount",
+			Handler:    _Daemon_SwitchAccount_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ForceSyncWithProgress",
+			Handler:       _Daemon_ForceSyncWithProgress_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon/v1alpha/daemon.proto",
 }
@@ -37,6 +37,11 @@ type DraftsClient interface {
 	ListDocumentDrafts(ctx context.Context, in *ListDocumentDraftsRequest, opts ...grpc.CallOption) (*ListDocumentDraftsResponse, error)
 	// Publishes a draft. I.e. draft will become a publication, and will no longer appear in drafts section.
 	PublishDraft(ctx context.Context, in *PublishDraftRequest, opts ...grpc.CallOption) (*Publication, error)
+	// Creates a draft from Markdown. If document ID is specified,
+	// the content of the document's draft is replaced with the one parsed from Markdown.
+	ImportMarkdown(ctx context.Context, in *ImportMarkdownRequest, opts ...grpc.CallOption) (*Document, error)
+	// Renders a draft or a publication as Markdown.
+	ExportMarkdown(ctx context.Context, in *ExportMarkdownRequest, opts ...grpc.CallOption) (*ExportMarkdownResponse, error)
 }
 
 type draftsClient struct {
@@ -110,6 +115,24 @@ func (c *draftsClient) PublishDraft(ctx context.Context, in *PublishDraftRequest
 	return out, nil
 }
 
+func (c *draftsClient) ImportMarkdown(ctx context.Context, in *ImportMarkdownRequest, opts ...grpc.CallOption) (*Document, error) {
+	out := new(Document)
+	err := c.cc.Invoke(ctx, "/com.mintter.documents.v1alpha.Drafts/ImportMarkdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *draftsClient) ExportMarkdown(ctx context.Context, in *ExportMarkdownRequest, opts ...grpc.CallOption) (*ExportMarkdownResponse, error) {
+	out := new(ExportMarkdownResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.documents.v1alpha.Drafts/ExportMarkdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DraftsServer is the server API for Drafts service.
 // All implementations should embed UnimplementedDraftsServer
 // for forward compatibility
@@ -128,6 +151,11 @@ type DraftsServer interface {
 	ListDocumentDrafts(context.Context, *ListDocumentDraftsRequest) (*ListDocumentDraftsResponse, error)
 	// Publishes a draft. I.e. draft will become a publication, and will no longer appear in drafts section.
 	PublishDraft(context.Context, *PublishDraftRequest) (*Publication, error)
+	// Creates a draft from Markdown. If document ID is specified,
+	// the content of the document's draft is replaced with the one parsed from Markdown.
+	ImportMarkdown(context.Context, *ImportMarkdownRequest) (*Document, error)
+	// Renders a draft or a publication as Markdown.
+	ExportMarkdown(context.Context, *ExportMarkdownRequest) (*ExportMarkdownResponse, error)
 }
 
 // UnimplementedDraftsServer should be embedded to have forward compatible implementations.
@@ -155,6 +183,12 @@ func (UnimplementedDraftsServer) ListDocumentDrafts(context.Context, *ListDocume
 func (UnimplementedDraftsServer) PublishDraft(context.Context, *PublishDraftRequest) (*Publication, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishDraft not implemented")
 }
+func (UnimplementedDraftsServer) ImportMarkdown(context.Context, *ImportMarkdownRequest) (*Document, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportMarkdown not implemented")
+}
+func (UnimplementedDraftsServer) ExportMarkdown(context.Context, *ExportMarkdownRequest) (*ExportMarkdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMarkdown not implemented")
+}
 
 // UnsafeDraftsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DraftsServer will
@@ -293,6 +327,42 @@ func _Drafts_PublishDraft_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Drafts_ImportMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DraftsServer).ImportMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.documents.v1alpha.Drafts/ImportMarkdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DraftsServer).ImportMarkdown(ctx, req.(*ImportMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Drafts_ExportMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DraftsServer).ExportMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.documents.v1alpha.Drafts/ExportMarkdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DraftsServer).ExportMarkdown(ctx, req.(*ExportMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Drafts_ServiceDesc is the grpc.ServiceDesc for Drafts service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -328,6 +398,14 @@ var Drafts_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PublishDraft",
 			Handler:    _Drafts_PublishDraft_Handler,
 		},
+		{
+			MethodName: "ImportMarkdown",
+			Handler:    _Drafts_ImportMarkdown_Handler,
+		},
+		{
+			MethodName: "ExportMarkdown",
+			Handler:    _Drafts_ExportMarkdown_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v1alpha/documents.proto",
@@ -345,6 +423,8 @@ type PublicationsClient interface {
 	PushPublication(ctx context.Context, in *PushPublicationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Lists publications owned by a given account.
 	ListAccountPublications(ctx context.Context, in *ListAccountPublicationsRequest, opts ...grpc.CallOption) (*ListPublicationsResponse, error)
+	// Compares two versions of a document.
+	DiffVersions(ctx context.Context, in *DiffVersionsRequest, opts ...grpc.CallOption) (*DiffVersionsResponse, error)
 }
 
 type publicationsClient struct {
@@ -391,6 +471,15 @@ func (c *publicationsClient) ListAccountPublications(ctx context.Context, in *Li
 	return out, nil
 }
 
+func (c *publicationsClient) DiffVersions(ctx context.Context, in *DiffVersionsRequest, opts ...grpc.CallOption) (*DiffVersionsResponse, error) {
+	out := new(DiffVersionsResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.documents.v1alpha.Publications/DiffVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PublicationsServer is the server API for Publications service.
 // All implementations should embed UnimplementedPublicationsServer
 // for forward compatibility
@@ -403,6 +492,8 @@ type PublicationsServer interface {
 	PushPublication(context.Context, *PushPublicationRequest) (*emptypb.Empty, error)
 	// Lists publications owned by a given account.
 	ListAccountPublications(context.Context, *ListAccountPublicationsRequest) (*ListPublicationsResponse, error)
+	// Compares two versions of a document.
+	DiffVersions(context.Context, *DiffVersionsRequest) (*DiffVersionsResponse, error)
 }
 
 // UnimplementedPublicationsServer should be embedded to have forward compatible implementations.
@@ -421,6 +512,9 @@ func (UnimplementedPublicationsServer) PushPublication(context.Context, *PushPub
 func (UnimplementedPublicationsServer) ListAccountPublications(context.Context, *ListAccountPublicationsRequest) (*ListPublicationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAccountPublications not implemented")
 }
+func (UnimplementedPublicationsServer) DiffVersions(context.Context, *DiffVersionsRequest) (*DiffVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffVersions not implemented")
+}
 
 // UnsafePublicationsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PublicationsServer will
@@ -505,6 +599,24 @@ func _Publications_ListAccountPublications_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Publications_DiffVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicationsServer).DiffVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.documents.v1alpha.Publications/DiffVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicationsServer).DiffVersions(ctx, req.(*DiffVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Publications_ServiceDesc is the grpc.ServiceDesc for Publications service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -528,6 +640,10 @@ var Publications_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAccountPublications",
 			Handler:    _Publications_ListAccountPublications_Handler,
 		},
+		{
+			MethodName: "DiffVersions",
+			Handler:    _Publications_DiffVersions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v1alpha/documents.proto",
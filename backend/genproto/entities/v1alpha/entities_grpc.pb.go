@@ -29,9 +29,10 @@ type EntitiesClient interface {
 	GetEntityTimeline(ctx context.Context, in *GetEntityTimelineRequest, opts ...grpc.CallOption) (*EntityTimeline, error)
 	// Triggers a best-effort discovery of an entity.
 	DiscoverEntity(ctx context.Context, in *DiscoverEntityRequest, opts ...grpc.CallOption) (*DiscoverEntityResponse, error)
-	// Finds the list of local entities whose titles match the input string.
-	// A fuzzy search is performed among documents, groups and accounts.
-	// For groups and documents, we match the title, while we match alias in accounts.
+	// Performs a full-text search among the locally available entities.
+	// It matches titles and content of documents, titles and descriptions of groups,
+	// aliases of accounts, and the content of comments.
+	// Results are sorted by relevance, with one result per entity.
 	SearchEntities(ctx context.Context, in *SearchEntitiesRequest, opts ...grpc.CallOption) (*SearchEntitiesResponse, error)
 	// Deletes an entity from the local node. It removes all the patches corresponding to it, including comments.
 	DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -42,6 +43,22 @@ type EntitiesClient interface {
 	UndeleteEntity(ctx context.Context, in *UndeleteEntityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// List mentions of a given Entity across the locally-available content.
 	ListEntityMentions(ctx context.Context, in *ListEntityMentionsRequest, opts ...grpc.CallOption) (*ListEntityMentionsResponse, error)
+	// Subscribes to an entity. Once there's at least one subscription,
+	// the node only syncs the blobs related to the subscribed entities from other peers,
+	// instead of syncing everything they have.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Removes the subscription to an entity.
+	// Blobs that are no longer needed can be garbage collected afterwards.
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the current subscriptions.
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	// Exports an entity with its full history into a CAR archive.
+	// The archive includes all the published changes, the comments targeting the entity,
+	// the key delegations needed to verify them, and the files embedded in the content.
+	ExportEntity(ctx context.Context, in *ExportEntityRequest, opts ...grpc.CallOption) (*ExportEntityResponse, error)
+	// Imports a CAR archive previously produced by ExportEntity.
+	// Signatures are verified before anything is stored.
+	ImportEntity(ctx context.Context, in *ImportEntityRequest, opts ...grpc.CallOption) (*ImportEntityResponse, error)
 }
 
 type entitiesClient struct {
@@ -124,6 +141,51 @@ func (c *entitiesClient) ListEntityMentions(ctx context.Context, in *ListEntityM
 	return out, nil
 }
 
+func (c *entitiesClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.entities.v1alpha.Entities/Subscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entitiesClient) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.entities.v1alpha.Entities/Unsubscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entitiesClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	out := new(ListSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.entities.v1alpha.Entities/ListSubscriptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entitiesClient) ExportEntity(ctx context.Context, in *ExportEntityRequest, opts ...grpc.CallOption) (*ExportEntityResponse, error) {
+	out := new(ExportEntityResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.entities.v1alpha.Entities/ExportEntity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entitiesClient) ImportEntity(ctx context.Context, in *ImportEntityRequest, opts ...grpc.CallOption) (*ImportEntityResponse, error) {
+	out := new(ImportEntityResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.entities.v1alpha.Entities/ImportEntity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EntitiesServer is the server API for Entities service.
 // All implementations should embed UnimplementedEntitiesServer
 // for forward compatibility
@@ -134,9 +196,10 @@ type EntitiesServer interface {
 	GetEntityTimeline(context.Context, *GetEntityTimelineRequest) (*EntityTimeline, error)
 	// Triggers a best-effort discovery of an entity.
 	DiscoverEntity(context.Context, *DiscoverEntityRequest) (*DiscoverEntityResponse, error)
-	// Finds the list of local entities whose titles match the input string.
-	// A fuzzy search is performed among documents, groups and accounts.
-	// For groups and documents, we match the title, while we match alias in accounts.
+	// Performs a full-text search among the locally available entities.
+	// It matches titles and content of documents, titles and descriptions of groups,
+	// aliases of accounts, and the content of comments.
+	// Results are sorted by relevance, with one result per entity.
 	SearchEntities(context.Context, *SearchEntitiesRequest) (*SearchEntitiesResponse, error)
 	// Deletes an entity from the local node. It removes all the patches corresponding to it, including comments.
 	DeleteEntity(context.Context, *DeleteEntityRequest) (*emptypb.Empty, error)
@@ -147,6 +210,22 @@ type EntitiesServer interface {
 	UndeleteEntity(context.Context, *UndeleteEntityRequest) (*emptypb.Empty, error)
 	// List mentions of a given Entity across the locally-available content.
 	ListEntityMentions(context.Context, *ListEntityMentionsRequest) (*ListEntityMentionsResponse, error)
+	// Subscribes to an entity. Once there's at least one subscription,
+	// the node only syncs the blobs related to the subscribed entities from other peers,
+	// instead of syncing everything they have.
+	Subscribe(context.Context, *SubscribeRequest) (*emptypb.Empty, error)
+	// Removes the subscription to an entity.
+	// Blobs that are no longer needed can be garbage collected afterwards.
+	Unsubscribe(context.Context, *UnsubscribeRequest) (*emptypb.Empty, error)
+	// Lists the current subscriptions.
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	// Exports an entity with its full history into a CAR archive.
+	// The archive includes all the published changes, the comments targeting the entity,
+	// the key delegations needed to verify them, and the files embedded in the content.
+	ExportEntity(context.Context, *ExportEntityRequest) (*ExportEntityResponse, error)
+	// Imports a CAR archive previously produced by ExportEntity.
+	// Signatures are verified before anything is stored.
+	ImportEntity(context.Context, *ImportEntityRequest) (*ImportEntityResponse, error)
 }
 
 // UnimplementedEntitiesServer should be embedded to have forward compatible implementations.
@@ -177,6 +256,21 @@ func (UnimplementedEntitiesServer) UndeleteEntity(context.Context, *UndeleteEnti
 func (UnimplementedEntitiesServer) ListEntityMentions(context.Context, *ListEntityMentionsRequest) (*ListEntityMentionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEntityMentions not implemented")
 }
+func (UnimplementedEntitiesServer) Subscribe(context.Context, *SubscribeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedEntitiesServer) Unsubscribe(context.Context, *UnsubscribeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (UnimplementedEntitiesServer) ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedEntitiesServer) ExportEntity(context.Context, *ExportEntityRequest) (*ExportEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportEntity not implemented")
+}
+func (UnimplementedEntitiesServer) ImportEntity(context.Context, *ImportEntityRequest) (*ImportEntityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportEntity not implemented")
+}
 
 // UnsafeEntitiesServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to EntitiesServer will
@@ -333,6 +427,96 @@ func _Entities_ListEntityMentions_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Entities_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntitiesServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.entities.v1alpha.Entities/Subscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntitiesServer).Subscribe(ctx, req.(*SubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entities_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntitiesServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.entities.v1alpha.Entities/Unsubscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntitiesServer).Unsubscribe(ctx, req.(*UnsubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entities_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntitiesServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.entities.v1alpha.Entities/ListSubscriptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntitiesServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entities_ExportEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntitiesServer).ExportEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.entities.v1alpha.Entities/ExportEntity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntitiesServer).ExportEntity(ctx, req.(*ExportEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entities_ImportEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntitiesServer).ImportEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.entities.v1alpha.Entities/ImportEntity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntitiesServer).ImportEntity(ctx, req.(*ImportEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Entities_ServiceDesc is the grpc.ServiceDesc for Entities service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -372,6 +556,26 @@ var Entities_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListEntityMentions",
 			Handler:    _Entities_ListEntityMentions_Handler,
 		},
+		{
+			MethodName: "Subscribe",
+			Handler:    _Entities_Subscribe_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _Entities_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _Entities_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "ExportEntity",
+			Handler:    _Entities_ExportEntity_Handler,
+		},
+		{
+			MethodName: "ImportEntity",
+			Handler:    _Entities_ImportEntity_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "entities/v1alpha/entities.proto",
@@ -20,6 +20,60 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Operations that can be performed on the site.
+type SiteOperation int32
+
+const (
+	// Zero value which is an invalid operation.
+	SiteOperation_SITE_OPERATION_UNSPECIFIED SiteOperation = 0
+	// Initializing the site to serve a group.
+	// Also requires the setup secret.
+	SiteOperation_INITIALIZE_SERVER SiteOperation = 1
+	// Publishing blobs to the site.
+	SiteOperation_PUBLISH_BLOBS SiteOperation = 2
+)
+
+// Enum value maps for SiteOperation.
+var (
+	SiteOperation_name = map[int32]string{
+		0: "SITE_OPERATION_UNSPECIFIED",
+		1: "INITIALIZE_SERVER",
+		2: "PUBLISH_BLOBS",
+	}
+	SiteOperation_value = map[string]int32{
+		"SITE_OPERATION_UNSPECIFIED": 0,
+		"INITIALIZE_SERVER":          1,
+		"PUBLISH_BLOBS":              2,
+	}
+)
+
+func (x SiteOperation) Enum() *SiteOperation {
+	p := new(SiteOperation)
+	*p = x
+	return p
+}
+
+func (x SiteOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SiteOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_groups_v1alpha_website_proto_enumTypes[0].Descriptor()
+}
+
+func (SiteOperation) Type() protoreflect.EnumType {
+	return &file_groups_v1alpha_website_proto_enumTypes[0]
+}
+
+func (x SiteOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SiteOperation.Descriptor instead.
+func (SiteOperation) EnumDescriptor() ([]byte, []int) {
+	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{0}
+}
+
 // Request for getting the public site information.
 type GetSiteInfoRequest struct {
 	state         protoimpl.MessageState
@@ -246,6 +300,200 @@ func (*PublishBlobsResponse) Descriptor() ([]byte, []int) {
 	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{4}
 }
 
+// Request for getting the permissions of the caller.
+type GetCallerPermissionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetCallerPermissionsRequest) Reset() {
+	*x = GetCallerPermissionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_groups_v1alpha_website_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCallerPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallerPermissionsRequest) ProtoMessage() {}
+
+func (x *GetCallerPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_groups_v1alpha_website_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallerPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*GetCallerPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{5}
+}
+
+// Permissions and capabilities of the caller on the site.
+type CallerPermissions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Account ID of the caller.
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Role of the caller in the group served by the site.
+	// Unspecified if the caller is not a member of the group, or the site is not initialized yet.
+	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=com.mintter.groups.v1alpha.Role" json:"role,omitempty"`
+	// Operations the caller is allowed to perform on the site.
+	AllowedOperations []SiteOperation `protobuf:"varint,3,rep,packed,name=allowed_operations,json=allowedOperations,proto3,enum=com.mintter.groups.v1alpha.SiteOperation" json:"allowed_operations,omitempty"`
+	// Human-readable explanation why the caller is not allowed to publish to the site.
+	// Empty if publishing is allowed.
+	PublishDeniedReason string `protobuf:"bytes,4,opt,name=publish_denied_reason,json=publishDeniedReason,proto3" json:"publish_denied_reason,omitempty"`
+	// Limits the site imposes on the published content.
+	StorageLimits *StorageLimits `protobuf:"bytes,5,opt,name=storage_limits,json=storageLimits,proto3" json:"storage_limits,omitempty"`
+	// Protocol features supported by the site.
+	Features []string `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+}
+
+func (x *CallerPermissions) Reset() {
+	*x = CallerPermissions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_groups_v1alpha_website_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallerPermissions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallerPermissions) ProtoMessage() {}
+
+func (x *CallerPermissions) ProtoReflect() protoreflect.Message {
+	mi := &file_groups_v1alpha_website_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallerPermissions.ProtoReflect.Descriptor instead.
+func (*CallerPermissions) Descriptor() ([]byte, []int) {
+	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CallerPermissions) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CallerPermissions) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *CallerPermissions) GetAllowedOperations() []SiteOperation {
+	if x != nil {
+		return x.AllowedOperations
+	}
+	return nil
+}
+
+func (x *CallerPermissions) GetPublishDeniedReason() string {
+	if x != nil {
+		return x.PublishDeniedReason
+	}
+	return ""
+}
+
+func (x *CallerPermissions) GetStorageLimits() *StorageLimits {
+	if x != nil {
+		return x.StorageLimits
+	}
+	return nil
+}
+
+func (x *CallerPermissions) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// Limits the site imposes on the published content.
+// Zero values mean there's no limit.
+type StorageLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of blobs in a single PublishBlobs request.
+	MaxBlobsPerRequest int64 `protobuf:"varint,1,opt,name=max_blobs_per_request,json=maxBlobsPerRequest,proto3" json:"max_blobs_per_request,omitempty"`
+	// Maximum size of a single blob in bytes.
+	MaxBlobSize int64 `protobuf:"varint,2,opt,name=max_blob_size,json=maxBlobSize,proto3" json:"max_blob_size,omitempty"`
+}
+
+func (x *StorageLimits) Reset() {
+	*x = StorageLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_groups_v1alpha_website_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageLimits) ProtoMessage() {}
+
+func (x *StorageLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_groups_v1alpha_website_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageLimits.ProtoReflect.Descriptor instead.
+func (*StorageLimits) Descriptor() ([]byte, []int) {
+	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StorageLimits) GetMaxBlobsPerRequest() int64 {
+	if x != nil {
+		return x.MaxBlobsPerRequest
+	}
+	return 0
+}
+
+func (x *StorageLimits) GetMaxBlobSize() int64 {
+	if x != nil {
+		return x.MaxBlobSize
+	}
+	return 0
+}
+
 // Publicly available information about the website.
 type PublicSiteInfo struct {
 	state         protoimpl.MessageState
@@ -264,7 +512,7 @@ type PublicSiteInfo struct {
 func (x *PublicSiteInfo) Reset() {
 	*x = PublicSiteInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_groups_v1alpha_website_proto_msgTypes[5]
+		mi := &file_groups_v1alpha_website_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -277,7 +525,7 @@ func (x *PublicSiteInfo) String() string {
 func (*PublicSiteInfo) ProtoMessage() {}
 
 func (x *PublicSiteInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_groups_v1alpha_website_proto_msgTypes[5]
+	mi := &file_groups_v1alpha_website_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,7 +538,7 @@ func (x *PublicSiteInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicSiteInfo.ProtoReflect.Descriptor instead.
 func (*PublicSiteInfo) Descriptor() ([]byte, []int) {
-	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{5}
+	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PublicSiteInfo) GetPeerInfo() *PeerInfo {
@@ -333,7 +581,7 @@ type PeerInfo struct {
 func (x *PeerInfo) Reset() {
 	*x = PeerInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_groups_v1alpha_website_proto_msgTypes[6]
+		mi := &file_groups_v1alpha_website_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -346,7 +594,7 @@ func (x *PeerInfo) String() string {
 func (*PeerInfo) ProtoMessage() {}
 
 func (x *PeerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_groups_v1alpha_website_proto_msgTypes[6]
+	mi := &file_groups_v1alpha_website_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,7 +607,7 @@ func (x *PeerInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerInfo.ProtoReflect.Descriptor instead.
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{6}
+	return file_groups_v1alpha_website_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PeerInfo) GetPeerId() string {
@@ -389,61 +637,107 @@ var file_groups_v1alpha_website_proto_rawDesc = []byte{
 	0x0a, 0x1c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
 	0x2f, 0x77, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a,
 	0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75,
-	0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65,
+	0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x1a, 0x1b, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x69,
+	0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4c, 0x0a,
+	0x17, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x1a, 0x0a, 0x18, 0x49,
+	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2b, 0x0a, 0x13, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x62,
+	0x6c, 0x6f, 0x62, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x42,
+	0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x0a, 0x1b,
+	0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe4, 0x02, 0x0a, 0x11,
+	0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x34, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65,
+	0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x58, 0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72,
+	0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e,
+	0x53, 0x69, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x32, 0x0a, 0x15, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x5f, 0x64, 0x65, 0x6e, 0x69,
+	0x65, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x13, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x44, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x22, 0x66, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x50, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6c,
+	0x6f, 0x62, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x93, 0x01, 0x0a, 0x0e, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x41, 0x0a,
+	0x09, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x58, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x0a, 0x07,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x2a, 0x59, 0x0a, 0x0d, 0x53, 0x69,
+	0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x53,
+	0x49, 0x54, 0x45, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x49,
+	0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52,
+	0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x42, 0x4c,
+	0x4f, 0x42, 0x53, 0x10, 0x02, 0x32, 0xe6, 0x03, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74,
+	0x65, 0x12, 0x69, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x47, 0x65,
 	0x74, 0x53, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x4c, 0x0a, 0x17, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x1a,
-	0x0a, 0x18, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2b, 0x0a, 0x13, 0x50, 0x75,
-	0x62, 0x6c, 0x69, 0x73, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x50, 0x75, 0x62, 0x6c, 0x69,
-	0x73, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x93, 0x01, 0x0a, 0x0e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x69, 0x74, 0x65, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x41, 0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74,
-	0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x65, 0x65,
-	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64,
-	0x12, 0x23, 0x0a, 0x0d, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64,
-	0x64, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73,
-	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x32,
-	0xe6, 0x02, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x12, 0x69, 0x0a, 0x0b, 0x47,
-	0x65, 0x74, 0x53, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x74, 0x65, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x53, 0x69,
-	0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x7d, 0x0a, 0x10, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x33, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69,
-	0x7a, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x34, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x49, 0x6e, 0x69,
-	0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
-	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x2f, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74,
+	0x1a, 0x2a, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x53, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x7d, 0x0a, 0x10,
+	0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x33, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x49, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74,
 	0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e,
+	0x68, 0x61, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x0c, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x2f, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7e,
+	0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x37, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e,
 	0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x6d, 0x69, 0x6e, 0x74,
-	0x74, 0x65, 0x72, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x67, 0x65, 0x6e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x3b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x70, 0x68, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x43, 0x61, 0x6c,
+	0x6c, 0x65, 0x72, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x30,
+	0x5a, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x3b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -458,29 +752,40 @@ func file_groups_v1alpha_website_proto_rawDescGZIP() []byte {
 	return file_groups_v1alpha_website_proto_rawDescData
 }
 
-var file_groups_v1alpha_website_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_groups_v1alpha_website_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_groups_v1alpha_website_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_groups_v1alpha_website_proto_goTypes = []interface{}{
-	(*GetSiteInfoRequest)(nil),       // 0: com.mintter.groups.v1alpha.GetSiteInfoRequest
-	(*InitializeServerRequest)(nil),  // 1: com.mintter.groups.v1alpha.InitializeServerRequest
-	(*InitializeServerResponse)(nil), // 2: com.mintter.groups.v1alpha.InitializeServerResponse
-	(*PublishBlobsRequest)(nil),      // 3: com.mintter.groups.v1alpha.PublishBlobsRequest
-	(*PublishBlobsResponse)(nil),     // 4: com.mintter.groups.v1alpha.PublishBlobsResponse
-	(*PublicSiteInfo)(nil),           // 5: com.mintter.groups.v1alpha.PublicSiteInfo
-	(*PeerInfo)(nil),                 // 6: com.mintter.groups.v1alpha.PeerInfo
+	(SiteOperation)(0),                  // 0: com.mintter.groups.v1alpha.SiteOperation
+	(*GetSiteInfoRequest)(nil),          // 1: com.mintter.groups.v1alpha.GetSiteInfoRequest
+	(*InitializeServerRequest)(nil),     // 2: com.mintter.groups.v1alpha.InitializeServerRequest
+	(*InitializeServerResponse)(nil),    // 3: com.mintter.groups.v1alpha.InitializeServerResponse
+	(*PublishBlobsRequest)(nil),         // 4: com.mintter.groups.v1alpha.PublishBlobsRequest
+	(*PublishBlobsResponse)(nil),        // 5: com.mintter.groups.v1alpha.PublishBlobsResponse
+	(*GetCallerPermissionsRequest)(nil), // 6: com.mintter.groups.v1alpha.GetCallerPermissionsRequest
+	(*CallerPermissions)(nil),           // 7: com.mintter.groups.v1alpha.CallerPermissions
+	(*StorageLimits)(nil),               // 8: com.mintter.groups.v1alpha.StorageLimits
+	(*PublicSiteInfo)(nil),              // 9: com.mintter.groups.v1alpha.PublicSiteInfo
+	(*PeerInfo)(nil),                    // 10: com.mintter.groups.v1alpha.PeerInfo
+	(Role)(0),                           // 11: com.mintter.groups.v1alpha.Role
 }
 var file_groups_v1alpha_website_proto_depIdxs = []int32{
-	6, // 0: com.mintter.groups.v1alpha.PublicSiteInfo.peer_info:type_name -> com.mintter.groups.v1alpha.PeerInfo
-	0, // 1: com.mintter.groups.v1alpha.Website.GetSiteInfo:input_type -> com.mintter.groups.v1alpha.GetSiteInfoRequest
-	1, // 2: com.mintter.groups.v1alpha.Website.InitializeServer:input_type -> com.mintter.groups.v1alpha.InitializeServerRequest
-	3, // 3: com.mintter.groups.v1alpha.Website.PublishBlobs:input_type -> com.mintter.groups.v1alpha.PublishBlobsRequest
-	5, // 4: com.mintter.groups.v1alpha.Website.GetSiteInfo:output_type -> com.mintter.groups.v1alpha.PublicSiteInfo
-	2, // 5: com.mintter.groups.v1alpha.Website.InitializeServer:output_type -> com.mintter.groups.v1alpha.InitializeServerResponse
-	4, // 6: com.mintter.groups.v1alpha.Website.PublishBlobs:output_type -> com.mintter.groups.v1alpha.PublishBlobsResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	11, // 0: com.mintter.groups.v1alpha.CallerPermissions.role:type_name -> com.mintter.groups.v1alpha.Role
+	0,  // 1: com.mintter.groups.v1alpha.CallerPermissions.allowed_operations:type_name -> com.mintter.groups.v1alpha.SiteOperation
+	8,  // 2: com.mintter.groups.v1alpha.CallerPermissions.storage_limits:type_name -> com.mintter.groups.v1alpha.StorageLimits
+	10, // 3: com.mintter.groups.v1alpha.PublicSiteInfo.peer_info:type_name -> com.mintter.groups.v1alpha.PeerInfo
+	1,  // 4: com.mintter.groups.v1alpha.Website.GetSiteInfo:input_type -> com.mintter.groups.v1alpha.GetSiteInfoRequest
+	2,  // 5: com.mintter.groups.v1alpha.Website.InitializeServer:input_type -> com.mintter.groups.v1alpha.InitializeServerRequest
+	4,  // 6: com.mintter.groups.v1alpha.Website.PublishBlobs:input_type -> com.mintter.groups.v1alpha.PublishBlobsRequest
+	6,  // 7: com.mintter.groups.v1alpha.Website.GetCallerPermissions:input_type -> com.mintter.groups.v1alpha.GetCallerPermissionsRequest
+	9,  // 8: com.mintter.groups.v1alpha.Website.GetSiteInfo:output_type -> com.mintter.groups.v1alpha.PublicSiteInfo
+	3,  // 9: com.mintter.groups.v1alpha.Website.InitializeServer:output_type -> com.mintter.groups.v1alpha.InitializeServerResponse
+	5,  // 10: com.mintter.groups.v1alpha.Website.PublishBlobs:output_type -> com.mintter.groups.v1alpha.PublishBlobsResponse
+	7,  // 11: com.mintter.groups.v1alpha.Website.GetCallerPermissions:output_type -> com.mintter.groups.v1alpha.CallerPermissions
+	8,  // [8:12] is the sub-list for method output_type
+	4,  // [4:8] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_groups_v1alpha_website_proto_init() }
@@ -488,6 +793,7 @@ func file_groups_v1alpha_website_proto_init() {
 	if File_groups_v1alpha_website_proto != nil {
 		return
 	}
+	file_groups_v1alpha_groups_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_groups_v1alpha_website_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSiteInfoRequest); i {
@@ -550,7 +856,7 @@ func file_groups_v1alpha_website_proto_init() {
 			}
 		}
 		file_groups_v1alpha_website_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublicSiteInfo); i {
+			switch v := v.(*GetCallerPermissionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -562,6 +868,42 @@ func file_groups_v1alpha_website_proto_init() {
 			}
 		}
 		file_groups_v1alpha_website_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallerPermissions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_groups_v1alpha_website_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_groups_v1alpha_website_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicSiteInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_groups_v1alpha_website_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerInfo); i {
 			case 0:
 				return &v.state
@@ -579,13 +921,14 @@ func file_groups_v1alpha_website_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_groups_v1alpha_website_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_groups_v1alpha_website_proto_goTypes,
 		DependencyIndexes: file_groups_v1alpha_website_proto_depIdxs,
+		EnumInfos:         file_groups_v1alpha_website_proto_enumTypes,
 		MessageInfos:      file_groups_v1alpha_website_proto_msgTypes,
 	}.Build()
 	File_groups_v1alpha_website_proto = out.File
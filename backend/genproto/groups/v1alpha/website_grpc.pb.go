@@ -28,7 +28,12 @@ type WebsiteClient interface {
 	// Initializes the server to become a website for a specific group.
 	InitializeServer(ctx context.Context, in *InitializeServerRequest, opts ...grpc.CallOption) (*InitializeServerResponse, error)
 	// Publishes blobs to the website.
+	// The caller must be allowed to perform the PUBLISH_BLOBS operation,
+	// and the request must fit within the storage limits of the site.
 	PublishBlobs(ctx context.Context, in *PublishBlobsRequest, opts ...grpc.CallOption) (*PublishBlobsResponse, error)
+	// Gets the permissions and capabilities of the caller on this site.
+	// Clients should use it to check what they are allowed to do before doing it.
+	GetCallerPermissions(ctx context.Context, in *GetCallerPermissionsRequest, opts ...grpc.CallOption) (*CallerPermissions, error)
 }
 
 type websiteClient struct {
@@ -66,6 +71,15 @@ func (c *websiteClient) PublishBlobs(ctx context.Context, in *PublishBlobsReques
 	return out, nil
 }
 
+func (c *websiteClient) GetCallerPermissions(ctx context.Context, in *GetCallerPermissionsRequest, opts ...grpc.CallOption) (*CallerPermissions, error) {
+	out := new(CallerPermissions)
+	err := c.cc.Invoke(ctx, "/com.mintter.groups.v1alpha.Website/GetCallerPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WebsiteServer is the server API for Website service.
 // All implementations should embed UnimplementedWebsiteServer
 // for forward compatibility
@@ -76,7 +90,12 @@ type WebsiteServer interface {
 	// Initializes the server to become a website for a specific group.
 	InitializeServer(context.Context, *InitializeServerRequest) (*InitializeServerResponse, error)
 	// Publishes blobs to the website.
+	// The caller must be allowed to perform the PUBLISH_BLOBS operation,
+	// and the request must fit within the storage limits of the site.
 	PublishBlobs(context.Context, *PublishBlobsRequest) (*PublishBlobsResponse, error)
+	// Gets the permissions and capabilities of the caller on this site.
+	// Clients should use it to check what they are allowed to do before doing it.
+	GetCallerPermissions(context.Context, *GetCallerPermissionsRequest) (*CallerPermissions, error)
 }
 
 // UnimplementedWebsiteServer should be embedded to have forward compatible implementations.
@@ -92,6 +111,9 @@ func (UnimplementedWebsiteServer) InitializeServer(context.Context, *InitializeS
 func (UnimplementedWebsiteServer) PublishBlobs(context.Context, *PublishBlobsRequest) (*PublishBlobsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishBlobs not implemented")
 }
+func (UnimplementedWebsiteServer) GetCallerPermissions(context.Context, *GetCallerPermissionsRequest) (*CallerPermissions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCallerPermissions not implemented")
+}
 
 // UnsafeWebsiteServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to WebsiteServer will
@@ -158,6 +180,24 @@ func _Website_PublishBlobs_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Website_GetCallerPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCallerPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebsiteServer).GetCallerPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.groups.v1alpha.Website/GetCallerPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebsiteServer).GetCallerPermissions(ctx, req.(*GetCallerPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Website_ServiceDesc is the grpc.ServiceDesc for Website service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,6 +217,10 @@ var Website_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PublishBlobs",
 			Handler:    _Website_PublishBlobs_Handler,
 		},
+		{
+			MethodName: "GetCallerPermissions",
+			Handler:    _Website_GetCallerPermissions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "groups/v1alpha/website.proto",
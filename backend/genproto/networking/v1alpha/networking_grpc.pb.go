@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -28,6 +29,14 @@ type NetworkingClient interface {
 	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
 	// Establishes a direct connection with a given peer explicitly.
 	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
+	// Returns the policy applied to the remote peers.
+	GetPeerPolicy(ctx context.Context, in *GetPeerPolicyRequest, opts ...grpc.CallOption) (*PeerPolicy, error)
+	// Puts a peer or an account into the allow or deny list of the peer policy.
+	SetPeerAccess(ctx context.Context, in *SetPeerAccessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Bans a peer from using our node until the ban expires.
+	BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lifts the ban of a peer.
+	UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type networkingClient struct {
@@ -65,6 +74,42 @@ func (c *networkingClient) Connect(ctx context.Context, in *ConnectRequest, opts
 	return out, nil
 }
 
+func (c *networkingClient) GetPeerPolicy(ctx context.Context, in *GetPeerPolicyRequest, opts ...grpc.CallOption) (*PeerPolicy, error) {
+	out := new(PeerPolicy)
+	err := c.cc.Invoke(ctx, "/com.mintter.networking.v1alpha.Networking/GetPeerPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkingClient) SetPeerAccess(ctx context.Context, in *SetPeerAccessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.networking.v1alpha.Networking/SetPeerAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkingClient) BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.networking.v1alpha.Networking/BanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkingClient) UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.mintter.networking.v1alpha.Networking/UnbanPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkingServer is the server API for Networking service.
 // All implementations should embed UnimplementedNetworkingServer
 // for forward compatibility
@@ -75,6 +120,14 @@ type NetworkingServer interface {
 	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
 	// Establishes a direct connection with a given peer explicitly.
 	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
+	// Returns the policy applied to the remote peers.
+	GetPeerPolicy(context.Context, *GetPeerPolicyRequest) (*PeerPolicy, error)
+	// Puts a peer or an account into the allow or deny list of the peer policy.
+	SetPeerAccess(context.Context, *SetPeerAccessRequest) (*emptypb.Empty, error)
+	// Bans a peer from using our node until the ban expires.
+	BanPeer(context.Context, *BanPeerRequest) (*emptypb.Empty, error)
+	// Lifts the ban of a peer.
+	UnbanPeer(context.Context, *UnbanPeerRequest) (*emptypb.Empty, error)
 }
 
 // UnimplementedNetworkingServer should be embedded to have forward compatible implementations.
@@ -90,6 +143,18 @@ func (UnimplementedNetworkingServer) ListPeers(context.Context, *ListPeersReques
 func (UnimplementedNetworkingServer) Connect(context.Context, *ConnectRequest) (*ConnectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Connect not implemented")
 }
+func (UnimplementedNetworkingServer) GetPeerPolicy(context.Context, *GetPeerPolicyRequest) (*PeerPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerPolicy not implemented")
+}
+func (UnimplementedNetworkingServer) SetPeerAccess(context.Context, *SetPeerAccessRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPeerAccess not implemented")
+}
+func (UnimplementedNetworkingServer) BanPeer(context.Context, *BanPeerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanPeer not implemented")
+}
+func (UnimplementedNetworkingServer) UnbanPeer(context.Context, *UnbanPeerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanPeer not implemented")
+}
 
 // UnsafeNetworkingServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to NetworkingServer will
@@ -156,6 +221,78 @@ func _Networking_Connect_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Networking_GetPeerPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkingServer).GetPeerPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.networking.v1alpha.Networking/GetPeerPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkingServer).GetPeerPolicy(ctx, req.(*GetPeerPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Networking_SetPeerAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPeerAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkingServer).SetPeerAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.networking.v1alpha.Networking/SetPeerAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkingServer).SetPeerAccess(ctx, req.(*SetPeerAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Networking_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkingServer).BanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.networking.v1alpha.Networking/BanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkingServer).BanPeer(ctx, req.(*BanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Networking_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkingServer).UnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.networking.v1alpha.Networking/UnbanPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkingServer).UnbanPeer(ctx, req.(*UnbanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Networking_ServiceDesc is the grpc.ServiceDesc for Networking service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -175,6 +312,22 @@ var Networking_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Connect",
 			Handler:    _Networking_Connect_Handler,
 		},
+		{
+			MethodName: "GetPeerPolicy",
+			Handler:    _Networking_GetPeerPolicy_Handler,
+		},
+		{
+			MethodName: "SetPeerAccess",
+			Handler:    _Networking_SetPeerAccess_Handler,
+		},
+		{
+			MethodName: "BanPeer",
+			Handler:    _Networking_BanPeer_Handler,
+		},
+		{
+			MethodName: "UnbanPeer",
+			Handler:    _Networking_UnbanPeer_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "networking/v1alpha/networking.proto",
@@ -29,6 +29,9 @@ type HandshakeInfo struct {
 	KeyDelegationCid []byte `protobuf:"bytes,1,opt,name=key_delegation_cid,json=keyDelegationCid,proto3" json:"key_delegation_cid,omitempty"`
 	// The bytes of the key delegation blob.
 	KeyDelegationData []byte `protobuf:"bytes,2,opt,name=key_delegation_data,json=keyDelegationData,proto3" json:"key_delegation_data,omitempty"`
+	// Optional protocol features that the peer supports.
+	// Peers must ignore the features they don't know about.
+	Features []string `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
 }
 
 func (x *HandshakeInfo) Reset() {
@@ -77,6 +80,13 @@ func (x *HandshakeInfo) GetKeyDelegationData() []byte {
 	return nil
 }
 
+func (x *HandshakeInfo) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
 // Request to list blobs.
 type ListBlobsRequest struct {
 	state         protoimpl.MessageState
@@ -85,6 +95,11 @@ type ListBlobsRequest struct {
 
 	// Optional. A cursor obtained from a previous request to resume the stream.
 	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Optional. Only list the blobs related to these resources:
+	// their changes, comments targeting them, and the key delegations of their authors.
+	// For groups it also includes the resources published in the group.
+	// Peers must advertise the corresponding feature in the handshake to support this.
+	Resources []string `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`
 }
 
 func (x *ListBlobsRequest) Reset() {
@@ -126,6 +141,256 @@ func (x *ListBlobsRequest) GetCursor() string {
 	return ""
 }
 
+func (x *ListBlobsRequest) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+// Request to reconcile blobs.
+type ReconcileBlobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Ranges of the caller's set of blobs with their fingerprints.
+	Ranges []*BlobRange `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+}
+
+func (x *ReconcileBlobsRequest) Reset() {
+	*x = ReconcileBlobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconcileBlobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBlobsRequest) ProtoMessage() {}
+
+func (x *ReconcileBlobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileBlobsRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileBlobsRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReconcileBlobsRequest) GetRanges() []*BlobRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+// Response to reconcile blobs.
+type ReconcileBlobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Ranges where the fingerprints from the request didn't match.
+	// Ranges that matched are omitted, so an empty response means the sets are equal.
+	Ranges []*BlobRange `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+}
+
+func (x *ReconcileBlobsResponse) Reset() {
+	*x = ReconcileBlobsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconcileBlobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileBlobsResponse) ProtoMessage() {}
+
+func (x *ReconcileBlobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileBlobsResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileBlobsResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReconcileBlobsResponse) GetRanges() []*BlobRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+// Range of blobs in the reconciliation order.
+// Ranges include their start bound, and exclude their end bound.
+type BlobRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Optional. Start of the range. If not set the range is unbounded.
+	Start *BlobBound `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Optional. End of the range. If not set the range is unbounded.
+	End *BlobBound `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Fingerprint of the blobs in the range. Not set when is_list is true.
+	Fingerprint []byte `protobuf:"bytes,3,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// Indicates that cids contains the full list of blobs in the range.
+	IsList bool `protobuf:"varint,4,opt,name=is_list,json=isList,proto3" json:"is_list,omitempty"`
+	// CIDs of all the blobs in the range. Only set when is_list is true.
+	Cids [][]byte `protobuf:"bytes,5,rep,name=cids,proto3" json:"cids,omitempty"`
+}
+
+func (x *BlobRange) Reset() {
+	*x = BlobRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobRange) ProtoMessage() {}
+
+func (x *BlobRange) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobRange.ProtoReflect.Descriptor instead.
+func (*BlobRange) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlobRange) GetStart() *BlobBound {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *BlobRange) GetEnd() *BlobBound {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *BlobRange) GetFingerprint() []byte {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return nil
+}
+
+func (x *BlobRange) GetIsList() bool {
+	if x != nil {
+		return x.IsList
+	}
+	return false
+}
+
+func (x *BlobRange) GetCids() [][]byte {
+	if x != nil {
+		return x.Cids
+	}
+	return nil
+}
+
+// Position of a blob in the reconciliation order.
+type BlobBound struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Timestamp of the blob in microseconds, or 0 for blobs without one.
+	Ts int64 `protobuf:"varint,1,opt,name=ts,proto3" json:"ts,omitempty"`
+	// Multihash of the blob.
+	Multihash []byte `protobuf:"bytes,2,opt,name=multihash,proto3" json:"multihash,omitempty"`
+}
+
+func (x *BlobBound) Reset() {
+	*x = BlobBound{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobBound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobBound) ProtoMessage() {}
+
+func (x *BlobBound) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobBound.ProtoReflect.Descriptor instead.
+func (*BlobBound) Descriptor() ([]byte, []int) {
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BlobBound) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+func (x *BlobBound) GetMultihash() []byte {
+	if x != nil {
+		return x.Multihash
+	}
+	return nil
+}
+
 // Request Invoice request.
 type RequestInvoiceRequest struct {
 	state         protoimpl.MessageState
@@ -145,7 +410,7 @@ type RequestInvoiceRequest struct {
 func (x *RequestInvoiceRequest) Reset() {
 	*x = RequestInvoiceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[2]
+		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -158,7 +423,7 @@ func (x *RequestInvoiceRequest) String() string {
 func (*RequestInvoiceRequest) ProtoMessage() {}
 
 func (x *RequestInvoiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[2]
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -171,7 +436,7 @@ func (x *RequestInvoiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestInvoiceRequest.ProtoReflect.Descriptor instead.
 func (*RequestInvoiceRequest) Descriptor() ([]byte, []int) {
-	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{2}
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RequestInvoiceRequest) GetAmountSats() int64 {
@@ -215,7 +480,7 @@ type RequestInvoiceResponse struct {
 func (x *RequestInvoiceResponse) Reset() {
 	*x = RequestInvoiceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[3]
+		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -228,7 +493,7 @@ func (x *RequestInvoiceResponse) String() string {
 func (*RequestInvoiceResponse) ProtoMessage() {}
 
 func (x *RequestInvoiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[3]
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,7 +506,7 @@ func (x *RequestInvoiceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestInvoiceResponse.ProtoReflect.Descriptor instead.
 func (*RequestInvoiceResponse) Descriptor() ([]byte, []int) {
-	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{3}
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RequestInvoiceResponse) GetPayReq() string {
@@ -262,12 +527,16 @@ type Blob struct {
 	// Clients must not expect that all blobs will have a cursor (might be done as an optimization),
 	// but whenever they see a cursor for a blob they have already processed, they should remember it for future requests.
 	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Indicates that the cursor provided in the request was not valid for this peer,
+	// and the stream was restarted from the beginning. Only set on the first blob of the stream.
+	// Clients should discard the cursor they have stored for this peer and use the new ones from this stream.
+	CursorReset bool `protobuf:"varint,3,opt,name=cursor_reset,json=cursorReset,proto3" json:"cursor_reset,omitempty"`
 }
 
 func (x *Blob) Reset() {
 	*x = Blob{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[4]
+		mi := &file_p2p_v1alpha_p2p_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -280,7 +549,7 @@ func (x *Blob) String() string {
 func (*Blob) ProtoMessage() {}
 
 func (x *Blob) ProtoReflect() protoreflect.Message {
-	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[4]
+	mi := &file_p2p_v1alpha_p2p_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +562,7 @@ func (x *Blob) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Blob.ProtoReflect.Descriptor instead.
 func (*Blob) Descriptor() ([]byte, []int) {
-	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{4}
+	return file_p2p_v1alpha_p2p_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Blob) GetCid() []byte {
@@ -310,60 +579,107 @@ func (x *Blob) GetCursor() string {
 	return ""
 }
 
+func (x *Blob) GetCursorReset() bool {
+	if x != nil {
+		return x.CursorReset
+	}
+	return false
+}
+
 var File_p2p_v1alpha_p2p_proto protoreflect.FileDescriptor
 
 var file_p2p_v1alpha_p2p_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x70, 0x32, 0x70, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2f, 0x70, 0x32,
 	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e,
 	0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x22, 0x6d, 0x0a, 0x0d, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x2c, 0x0a, 0x12, 0x6b, 0x65, 0x79, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x6b,
-	0x65, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x69, 0x64, 0x12,
-	0x2e, 0x0a, 0x13, 0x6b, 0x65, 0x79, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x6b, 0x65,
-	0x79, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x22,
-	0x2a, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x94, 0x01, 0x0a, 0x15,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
-	0x73, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f,
-	0x6c, 0x64, 0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0b, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a,
-	0x0d, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x61,
-	0x73, 0x68, 0x22, 0x31, 0x0a, 0x16, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76,
-	0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07,
-	0x70, 0x61, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
-	0x61, 0x79, 0x52, 0x65, 0x71, 0x22, 0x30, 0x0a, 0x04, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x10, 0x0a,
-	0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x32, 0xae, 0x02, 0x0a, 0x03, 0x50, 0x32, 0x50, 0x12,
-	0x5b, 0x0a, 0x09, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x26, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74,
-	0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x48,
-	0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x57, 0x0a, 0x09,
-	0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x2e,
-	0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74,
-	0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x42,
-	0x6c, 0x6f, 0x62, 0x30, 0x01, 0x12, 0x71, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69,
-	0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69,
-	0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2a, 0x5a, 0x28, 0x6d, 0x69, 0x6e, 0x74,
-	0x74, 0x65, 0x72, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x67, 0x65, 0x6e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x32, 0x70, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x3b, 0x70, 0x32, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x89, 0x01, 0x0a, 0x0d, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x2c, 0x0a, 0x12, 0x6b, 0x65, 0x79, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10,
+	0x6b, 0x65, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x69, 0x64,
+	0x12, 0x2e, 0x0a, 0x13, 0x6b, 0x65, 0x79, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x6b,
+	0x65, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x48, 0x0a, 0x10,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x53, 0x0a, 0x15, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3a, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32,
+	0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x54, 0x0a, 0x16, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74,
+	0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e,
+	0x42, 0x6c, 0x6f, 0x62, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x22, 0xca, 0x01, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x38, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x42, 0x6f, 0x75,
+	0x6e, 0x64, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x34, 0x0a, 0x03, 0x65, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e,
+	0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69,
+	0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x69, 0x64, 0x73, 0x22, 0x39,
+	0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x68, 0x61, 0x73, 0x68, 0x22, 0x94, 0x01, 0x0a, 0x15, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x53, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6d, 0x65, 0x6d, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f, 0x6c, 0x64,
+	0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x68, 0x6f, 0x6c, 0x64, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70,
+	0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x61, 0x73, 0x68,
+	0x22, 0x31, 0x0a, 0x16, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x79,
+	0x52, 0x65, 0x71, 0x22, 0x53, 0x0a, 0x04, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x63,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x5f,
+	0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x65, 0x74, 0x32, 0xa1, 0x03, 0x0a, 0x03, 0x50, 0x32, 0x50,
+	0x12, 0x5b, 0x0a, 0x09, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x12, 0x26, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x26, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74,
+	0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x57, 0x0a,
+	0x09, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x29, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74,
+	0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e,
+	0x42, 0x6c, 0x6f, 0x62, 0x30, 0x01, 0x12, 0x71, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d,
+	0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x62,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x6d,
+	0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x6f, 0x62,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x0e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x2e, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x32, 0x70, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2a, 0x5a, 0x28,
+	0x6d, 0x69, 0x6e, 0x74, 0x74, 0x65, 0x72, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f,
+	0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x32, 0x70, 0x2f, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x3b, 0x70, 0x32, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -378,26 +694,36 @@ func file_p2p_v1alpha_p2p_proto_rawDescGZIP() []byte {
 	return file_p2p_v1alpha_p2p_proto_rawDescData
 }
 
-var file_p2p_v1alpha_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_p2p_v1alpha_p2p_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_p2p_v1alpha_p2p_proto_goTypes = []interface{}{
 	(*HandshakeInfo)(nil),          // 0: com.mintter.p2p.v1alpha.HandshakeInfo
 	(*ListBlobsRequest)(nil),       // 1: com.mintter.p2p.v1alpha.ListBlobsRequest
-	(*RequestInvoiceRequest)(nil),  // 2: com.mintter.p2p.v1alpha.RequestInvoiceRequest
-	(*RequestInvoiceResponse)(nil), // 3: com.mintter.p2p.v1alpha.RequestInvoiceResponse
-	(*Blob)(nil),                   // 4: com.mintter.p2p.v1alpha.Blob
+	(*ReconcileBlobsRequest)(nil),  // 2: com.mintter.p2p.v1alpha.ReconcileBlobsRequest
+	(*ReconcileBlobsResponse)(nil), // 3: com.mintter.p2p.v1alpha.ReconcileBlobsResponse
+	(*BlobRange)(nil),              // 4: com.mintter.p2p.v1alpha.BlobRange
+	(*BlobBound)(nil),              // 5: com.mintter.p2p.v1alpha.BlobBound
+	(*RequestInvoiceRequest)(nil),  // 6: com.mintter.p2p.v1alpha.RequestInvoiceRequest
+	(*RequestInvoiceResponse)(nil), // 7: com.mintter.p2p.v1alpha.RequestInvoiceResponse
+	(*Blob)(nil),                   // 8: com.mintter.p2p.v1alpha.Blob
 }
 var file_p2p_v1alpha_p2p_proto_depIdxs = []int32{
-	0, // 0: com.mintter.p2p.v1alpha.P2P.Handshake:input_type -> com.mintter.p2p.v1alpha.HandshakeInfo
-	1, // 1: com.mintter.p2p.v1alpha.P2P.ListBlobs:input_type -> com.mintter.p2p.v1alpha.ListBlobsRequest
-	2, // 2: com.mintter.p2p.v1alpha.P2P.RequestInvoice:input_type -> com.mintter.p2p.v1alpha.RequestInvoiceRequest
-	0, // 3: com.mintter.p2p.v1alpha.P2P.Handshake:output_type -> com.mintter.p2p.v1alpha.HandshakeInfo
-	4, // 4: com.mintter.p2p.v1alpha.P2P.ListBlobs:output_type -> com.mintter.p2p.v1alpha.Blob
-	3, // 5: com.mintter.p2p.v1alpha.P2P.RequestInvoice:output_type -> com.mintter.p2p.v1alpha.RequestInvoiceResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	4, // 0: com.mintter.p2p.v1alpha.ReconcileBlobsRequest.ranges:type_name -> com.mintter.p2p.v1alpha.BlobRange
+	4, // 1: com.mintter.p2p.v1alpha.ReconcileBlobsResponse.ranges:type_name -> com.mintter.p2p.v1alpha.BlobRange
+	5, // 2: com.mintter.p2p.v1alpha.BlobRange.start:type_name -> com.mintter.p2p.v1alpha.BlobBound
+	5, // 3: com.mintter.p2p.v1alpha.BlobRange.end:type_name -> com.mintter.p2p.v1alpha.BlobBound
+	0, // 4: com.mintter.p2p.v1alpha.P2P.Handshake:input_type -> com.mintter.p2p.v1alpha.HandshakeInfo
+	1, // 5: com.mintter.p2p.v1alpha.P2P.ListBlobs:input_type -> com.mintter.p2p.v1alpha.ListBlobsRequest
+	2, // 6: com.mintter.p2p.v1alpha.P2P.ReconcileBlobs:input_type -> com.mintter.p2p.v1alpha.ReconcileBlobsRequest
+	6, // 7: com.mintter.p2p.v1alpha.P2P.RequestInvoice:input_type -> com.mintter.p2p.v1alpha.RequestInvoiceRequest
+	0, // 8: com.mintter.p2p.v1alpha.P2P.Handshake:output_type -> com.mintter.p2p.v1alpha.HandshakeInfo
+	8, // 9: com.mintter.p2p.v1alpha.P2P.ListBlobs:output_type -> com.mintter.p2p.v1alpha.Blob
+	3, // 10: com.mintter.p2p.v1alpha.P2P.ReconcileBlobs:output_type -> com.mintter.p2p.v1alpha.ReconcileBlobsResponse
+	7, // 11: com.mintter.p2p.v1alpha.P2P.RequestInvoice:output_type -> com.mintter.p2p.v1alpha.RequestInvoiceResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_p2p_v1alpha_p2p_proto_init() }
@@ -431,7 +757,7 @@ func file_p2p_v1alpha_p2p_proto_init() {
 			}
 		}
 		file_p2p_v1alpha_p2p_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestInvoiceRequest); i {
+			switch v := v.(*ReconcileBlobsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -443,7 +769,7 @@ func file_p2p_v1alpha_p2p_proto_init() {
 			}
 		}
 		file_p2p_v1alpha_p2p_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestInvoiceResponse); i {
+			switch v := v.(*ReconcileBlobsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -455,6 +781,54 @@ func file_p2p_v1alpha_p2p_proto_init() {
 			}
 		}
 		file_p2p_v1alpha_p2p_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_v1alpha_p2p_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobBound); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_v1alpha_p2p_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestInvoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_v1alpha_p2p_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestInvoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_p2p_v1alpha_p2p_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Blob); i {
 			case 0:
 				return &v.state
@@ -473,7 +847,7 @@ func file_p2p_v1alpha_p2p_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_p2p_v1alpha_p2p_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
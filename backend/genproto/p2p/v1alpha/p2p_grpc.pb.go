@@ -32,6 +32,15 @@ type P2PClient interface {
 	// and only asking for what's new since then in the next request.
 	// Clients must treat the cursor as an opaque string.
 	ListBlobs(ctx context.Context, in *ListBlobsRequest, opts ...grpc.CallOption) (P2P_ListBlobsClient, error)
+	// ReconcileBlobs performs one round of range-based set reconciliation of the blobs that peers have.
+	// Blobs are ordered by their timestamp (0 for blobs without one) and then by their multihash.
+	// The caller sends the fingerprints of some ranges of its own set of blobs,
+	// and the peer replies for each range where its own fingerprint doesn't match,
+	// either with the full list of blobs it has in that range, if the range is small enough,
+	// or with the fingerprints of smaller subranges, which the caller can compare and send back in the next round.
+	// This allows peers to find what they are missing in bandwidth proportional to the difference between their sets,
+	// rather than their total size. Only available for peers advertising the corresponding feature in the handshake.
+	ReconcileBlobs(ctx context.Context, in *ReconcileBlobsRequest, opts ...grpc.CallOption) (*ReconcileBlobsResponse, error)
 	// Request a peer to issue a lightning BOLT-11 invoice
 	RequestInvoice(ctx context.Context, in *RequestInvoiceRequest, opts ...grpc.CallOption) (*RequestInvoiceResponse, error)
 }
@@ -85,6 +94,15 @@ func (x *p2PListBlobsClient) Recv() (*Blob, error) {
 	return m, nil
 }
 
+func (c *p2PClient) ReconcileBlobs(ctx context.Context, in *ReconcileBlobsRequest, opts ...grpc.CallOption) (*ReconcileBlobsResponse, error) {
+	out := new(ReconcileBlobsResponse)
+	err := c.cc.Invoke(ctx, "/com.mintter.p2p.v1alpha.P2P/ReconcileBlobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *p2PClient) RequestInvoice(ctx context.Context, in *RequestInvoiceRequest, opts ...grpc.CallOption) (*RequestInvoiceResponse, error) {
 	out := new(RequestInvoiceResponse)
 	err := c.cc.Invoke(ctx, "/com.mintter.p2p.v1alpha.P2P/RequestInvoice", in, out, opts...)
@@ -108,6 +126,15 @@ type P2PServer interface {
 	// and only asking for what's new since then in the next request.
 	// Clients must treat the cursor as an opaque string.
 	ListBlobs(*ListBlobsRequest, P2P_ListBlobsServer) error
+	// ReconcileBlobs performs one round of range-based set reconciliation of the blobs that peers have.
+	// Blobs are ordered by their timestamp (0 for blobs without one) and then by their multihash.
+	// The caller sends the fingerprints of some ranges of its own set of blobs,
+	// and the peer replies for each range where its own fingerprint doesn't match,
+	// either with the full list of blobs it has in that range, if the range is small enough,
+	// or with the fingerprints of smaller subranges, which the caller can compare and send back in the next round.
+	// This allows peers to find what they are missing in bandwidth proportional to the difference between their sets,
+	// rather than their total size. Only available for peers advertising the corresponding feature in the handshake.
+	ReconcileBlobs(context.Context, *ReconcileBlobsRequest) (*ReconcileBlobsResponse, error)
 	// Request a peer to issue a lightning BOLT-11 invoice
 	RequestInvoice(context.Context, *RequestInvoiceRequest) (*RequestInvoiceResponse, error)
 }
@@ -122,6 +149,9 @@ func (UnimplementedP2PServer) Handshake(context.Context, *HandshakeInfo) (*Hands
 func (UnimplementedP2PServer) ListBlobs(*ListBlobsRequest, P2P_ListBlobsServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListBlobs not implemented")
 }
+func (UnimplementedP2PServer) ReconcileBlobs(context.Context, *ReconcileBlobsRequest) (*ReconcileBlobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileBlobs not implemented")
+}
 func (UnimplementedP2PServer) RequestInvoice(context.Context, *RequestInvoiceRequest) (*RequestInvoiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RequestInvoice not implemented")
 }
@@ -176,6 +206,24 @@ func (x *p2PListBlobsServer) Send(m *Blob) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _P2P_ReconcileBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(P2PServer).ReconcileBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.mintter.p2p.v1alpha.P2P/ReconcileBlobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(P2PServer).ReconcileBlobs(ctx, req.(*ReconcileBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _P2P_RequestInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequestInvoiceRequest)
 	if err := dec(in); err != nil {
@@ -205,6 +253,10 @@ var P2P_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Handshake",
 			Handler:    _P2P_Handshake_Handler,
 		},
+		{
+			MethodName: "ReconcileBlobs",
+			Handler:    _P2P_ReconcileBlobs_Handler,
+		},
 		{
 			MethodName: "RequestInvoice",
 			Handler:    _P2P_RequestInvoice_Handler,
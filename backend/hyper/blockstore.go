@@ -6,6 +6,7 @@ import (
 	"mintter/backend/hyper/hypersql"
 	"mintter/backend/ipfs"
 	"mintter/backend/pkg/dqb"
+	"sync/atomic"
 
 	"crawshaw.io/sqlite"
 	"crawshaw.io/sqlite/sqlitex"
@@ -33,6 +34,9 @@ type blockStore struct {
 	db      *sqlitex.Pool
 	encoder *zstd.Encoder
 	decoder *zstd.Decoder
+
+	// Incremented after blobs get deleted, for the caches of the stored blobs to know they are stale.
+	deletions atomic.Int64
 }
 
 // newBlockstore creates a new block store from a given connection pool.
@@ -272,6 +276,7 @@ func (b *blockStore) DeleteBlock(ctx context.Context, c cid.Cid) error {
 	defer release()
 
 	_, err = b.deleteBlock(conn, c)
+	b.deletions.Add(1)
 	return err
 }
 
@@ -0,0 +1,211 @@
+package hyper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mintter/backend/ipfs"
+	"mintter/backend/pkg/car"
+	"mintter/backend/pkg/dqb"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"go.uber.org/zap"
+)
+
+// ExportEntity writes the entity with its full history into a CAR archive.
+// The archive includes all the published changes of the entity, the comments targeting it,
+// the key delegations needed to verify them, and the IPFS files linked from the content.
+// Drafts are not exported.
+//
+// The root of the archive is the CID-encoded entity ID (see EntityID.CID).
+// Blobs are written in the order they can be imported, i.e. dependencies first.
+func (bs *Storage) ExportEntity(ctx context.Context, w io.Writer, eid EntityID) (count int, err error) {
+	root, err := eid.CID()
+	if err != nil {
+		return 0, err
+	}
+
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	var cids []cid.Cid
+	if err := sqlitex.Exec(conn, qExportEntityBlobs(), func(stmt *sqlite.Stmt) error {
+		cids = append(cids, cid.NewCidV1(uint64(stmt.ColumnInt64(0)), stmt.ColumnBytesUnsafe(1)))
+		return nil
+	}, eid.String()); err != nil {
+		return 0, fmt.Errorf("failed to list blobs of entity %s: %w", eid, err)
+	}
+
+	if len(cids) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrEntityNotFound, eid)
+	}
+
+	cw, err := car.NewWriter(w, root)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range cids {
+		blk, err := bs.bs.get(conn, c)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get blob %s: %w", c, err)
+		}
+
+		if err := cw.Put(blk); err != nil {
+			return 0, fmt.Errorf("failed to write blob %s: %w", c, err)
+		}
+	}
+
+	if err := cw.Flush(); err != nil {
+		return 0, err
+	}
+
+	return len(cids), nil
+}
+
+// ImportReport describes the result of importing a CAR archive.
+type ImportReport struct {
+	// Entities listed as roots of the archive.
+	Entities []EntityID
+
+	// Number of blobs in the archive.
+	Blobs int
+}
+
+// ImportEntities reads a CAR archive produced by ExportEntity, and stores all of its blobs.
+// Signatures of all the structural blobs are verified before anything is stored,
+// and the whole archive is imported atomically.
+func (bs *Storage) ImportEntities(ctx context.Context, r io.Reader) (report ImportReport, err error) {
+	cr, err := car.NewReader(r)
+	if err != nil {
+		return report, err
+	}
+
+	for _, root := range cr.Header.Roots {
+		eid, err := EntityIDFromCID(root)
+		if err != nil {
+			return report, fmt.Errorf("archive root is not an entity ID: %w", err)
+		}
+		report.Entities = append(report.Entities, eid)
+	}
+
+	var blobs []Blob
+	for {
+		blk, err := cr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		codec, _ := ipfs.DecodeCID(blk.Cid())
+		if !isIndexable(multicodec.Code(codec)) {
+			blobs = append(blobs, Blob{CID: blk.Cid(), Data: blk.RawData()})
+			continue
+		}
+
+		hb, err := DecodeBlob(blk.Cid(), blk.RawData())
+		if err != nil {
+			return report, err
+		}
+
+		if err := verifyBlob(hb); err != nil {
+			return report, fmt.Errorf("failed to verify blob %s: %w", hb.CID, err)
+		}
+
+		blobs = append(blobs, hb)
+	}
+
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return report, err
+	}
+	defer release()
+
+	if err := bs.withTx(conn, func() error {
+		for _, hb := range blobs {
+			if _, err := bs.saveBlob(conn, hb); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return report, fmt.Errorf("failed to import archive: %w", err)
+	}
+
+	report.Blobs = len(blobs)
+
+	bs.log.Debug("EntitiesImported", zap.Int("blobs", report.Blobs), zap.Int("entities", len(report.Entities)))
+
+	return report, nil
+}
+
+func verifyBlob(hb Blob) error {
+	switch v := hb.Decoded.(type) {
+	case KeyDelegation:
+		return v.Verify()
+	case KeyRevocation:
+		return v.Verify()
+	case Change:
+		return v.Verify()
+	case Comment:
+		return v.Verify()
+	}
+
+	return nil
+}
+
+var qExportEntityBlobs = dqb.Str(`
+	WITH RECURSIVE
+	entity (id) AS (
+		SELECT id FROM resources WHERE iri = :eid
+	),
+	content (id) AS (
+		SELECT structural_blobs.id
+		FROM structural_blobs
+		WHERE structural_blobs.resource IN entity
+		AND structural_blobs.type = 'Change'
+		AND structural_blobs.id NOT IN (SELECT blob FROM drafts)
+		UNION
+		SELECT source FROM resource_links
+		WHERE type = 'comment/target'
+		AND target IN entity
+	),
+	delegations (id) AS (
+		SELECT target FROM blob_links
+		WHERE source IN content
+		AND type IN ('change/auth', 'comment/auth')
+	),
+	files (id) AS (
+		SELECT blob_links.target
+		FROM blob_links
+		JOIN blobs ON blobs.id = blob_links.target
+		WHERE blob_links.source IN content
+		AND blobs.codec IN (0x70, 0x55)
+		UNION
+		SELECT blob_links.target
+		FROM blob_links
+		JOIN files ON files.id = blob_links.source
+	)
+	SELECT blobs.codec, blobs.multihash
+	FROM blobs
+	LEFT JOIN structural_blobs ON structural_blobs.id = blobs.id
+	WHERE blobs.size > 0
+	AND (blobs.id IN content OR blobs.id IN delegations OR blobs.id IN files)
+	ORDER BY
+		CASE
+			WHEN blobs.id IN delegations THEN 0
+			WHEN blobs.id IN files THEN 1
+			ELSE 2
+		END,
+		structural_blobs.ts,
+		blobs.id;
+`)
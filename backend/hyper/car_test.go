@@ -0,0 +1,118 @@
+package hyper
+
+import (
+	"bytes"
+	"context"
+	"mintter/backend/core/coretest"
+	"mintter/backend/hlc"
+	"mintter/backend/logging"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportEntity(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+
+	src := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+
+	delegations := map[string]Blob{}
+	for _, u := range []coretest.Tester{alice, bob} {
+		kd, err := NewKeyDelegation(u.Account, u.Device.PublicKey, time.Now().Add(-1*time.Hour))
+		require.NoError(t, err)
+		delegations[u.Account.Principal().String()] = kd.Blob()
+		require.NoError(t, src.SaveBlob(ctx, kd.Blob()))
+	}
+
+	create := func(u coretest.Tester, title string) (*Entity, []Blob) {
+		clock := hlc.NewClock()
+		ts := clock.MustNow()
+		createTime := ts.Time().Unix()
+
+		id, nonce := NewUnforgeableID("hm://d/", u.Account.Principal(), nil, createTime)
+		e := NewEntityWithClock(EntityID(id), clock)
+		del := delegations[u.Account.Principal().String()].CID
+
+		ch, err := e.CreateChange(ts, u.Device, del, map[string]any{
+			"nonce":      nonce,
+			"createTime": int(createTime),
+			"owner":      []byte(u.Account.Principal()),
+			"title":      title,
+		}, WithAction(ActionCreate))
+		require.NoError(t, err)
+		require.NoError(t, src.SaveBlob(ctx, ch))
+		out := []Blob{ch}
+
+		for i := 0; i < 2; i++ {
+			ch, err := e.CreateChange(e.NextTimestamp(), u.Device, del, map[string]any{
+				"count": i,
+			}, WithAction(ActionUpdate))
+			require.NoError(t, err)
+			require.NoError(t, src.SaveBlob(ctx, ch))
+			out = append(out, ch)
+		}
+
+		return e, out
+	}
+
+	e, _ := create(alice, "Hello")
+	eid := e.ID()
+
+	comment, err := NewComment(string(eid), cid.Undef, cid.Undef, e.NextTimestamp(), bob.Device, delegations[bob.Account.Principal().String()].CID, []CommentBlock{
+		{Block: Block{ID: "b1", Type: "paragraph", Text: "Nice doc!"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, src.SaveBlob(ctx, comment))
+
+	// Unrelated entity must not be exported.
+	_, otherChanges := create(bob, "Other")
+
+	var buf bytes.Buffer
+	count, err := src.ExportEntity(ctx, &buf, eid)
+	require.NoError(t, err)
+	require.Equal(t, 2+3+1, count, "archive must contain delegations, changes, and the comment")
+
+	_, err = src.ExportEntity(ctx, &bytes.Buffer{}, "hm://d/missing")
+	require.ErrorIs(t, err, ErrEntityNotFound)
+
+	dst := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+	report, err := dst.ImportEntities(ctx, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, []EntityID{eid}, report.Entities)
+	require.Equal(t, count, report.Blobs)
+
+	imported, err := dst.LoadEntity(ctx, eid)
+	require.NoError(t, err)
+	require.Equal(t, e.Heads(), imported.Heads())
+	for _, k := range []string{"title", "count"} {
+		want, _ := e.Get(k)
+		got, ok := imported.Get(k)
+		require.True(t, ok)
+		require.Equal(t, want, got)
+	}
+
+	ok, err := dst.IPFSBlockstoreReader().Has(ctx, comment.CID)
+	require.NoError(t, err)
+	require.True(t, ok, "comment must be imported")
+
+	ok, err = dst.IPFSBlockstoreReader().Has(ctx, otherChanges[0].CID)
+	require.NoError(t, err)
+	require.False(t, ok, "unrelated entities must not be imported")
+
+	// Importing the same archive again is a no-op.
+	_, err = dst.ImportEntities(ctx, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	// Corrupted archives must be rejected without storing anything.
+	data := bytes.Clone(buf.Bytes())
+	data[len(data)-1] ^= 0xff
+	fresh := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+	_, err = fresh.ImportEntities(ctx, bytes.NewReader(data))
+	require.Error(t, err)
+	_, err = fresh.LoadEntity(ctx, eid)
+	require.Error(t, err)
+}
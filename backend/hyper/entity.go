@@ -187,6 +187,13 @@ func (e *Entity) Version() Version {
 
 // ApplyChange to the internal state.
 func (e *Entity) ApplyChange(c cid.Cid, ch Change) error {
+	return e.applyChange(c, ch, false)
+}
+
+// applyChange adds the change to the DAG of the entity.
+// The patches of unauthorized changes are not applied to the state,
+// but the changes themselves are tracked, because other changes might depend on them.
+func (e *Entity) applyChange(c cid.Cid, ch Change, unauthorized bool) error {
 	if ch.Entity != e.id {
 		return fmt.Errorf("won't apply change from a different entity: want=%q, got=%q", e.id, ch.Entity)
 	}
@@ -231,7 +238,9 @@ func (e *Entity) ApplyChange(c cid.Cid, ch Change) error {
 		return err
 	}
 
-	e.state.ApplyPatch(int64(ch.HLCTime), OriginFromCID(c), ch.Patch)
+	if !unauthorized {
+		e.state.ApplyPatchWithID(int64(ch.HLCTime), OriginFromCID(c), c.String(), ch.Patch)
+	}
 	e.changes = append(e.changes, ParsedBlob[Change]{c, ch})
 	e.deps = append(e.deps, nil)
 	e.rdeps = append(e.rdeps, nil)
@@ -363,8 +372,9 @@ func addUnique(in []int, v int) []int {
 	return slices.Insert(in, targetIndex, v)
 }
 
-// OriginFromCID creates a CRDT origin from the last 8 chars of the hash.
+// OriginFromCID creates a CRDT origin from the last 9 chars of the hash.
 // Most of the time it's not needed, because HLC is very unlikely to collide.
+// It's not unique though, so the RGA characters use the whole CID as their origin.
 func OriginFromCID(c cid.Cid) string {
 	if !c.Defined() {
 		return ""
@@ -399,6 +409,14 @@ func WithMessage(msg string) ChangeOption {
 	}
 }
 
+// WithContentKey seals the patch of the change with the content key of a private group,
+// except for the public fields (see PublicPatchFields).
+func WithContentKey(key ContentKey) ChangeOption {
+	return func(c *Change) {
+		c.sealWith = &key
+	}
+}
+
 // CreateChange entity creating a change blob, and applying it to the internal state.
 func (e *Entity) CreateChange(ts hlc.Timestamp, signer core.KeyPair, delegation cid.Cid, patch map[string]any, opts ...ChangeOption) (hb Blob, err error) {
 	hb, err = NewChange(e.id, maps.Keys(e.heads), ts, signer, delegation, patch, opts...)
@@ -406,13 +424,22 @@ func (e *Entity) CreateChange(ts hlc.Timestamp, signer core.KeyPair, delegation
 		return hb, err
 	}
 
-	if err := e.ApplyChange(hb.CID, hb.Decoded.(Change)); err != nil {
+	if err := e.ApplyChange(hb.CID, openedChange(hb.Decoded.(Change), patch)); err != nil {
 		return hb, err
 	}
 
 	return hb, nil
 }
 
+// openedChange returns the sealed change with the plain text patch it was created from,
+// the same way it would be after opening it.
+func openedChange(ch Change, patch map[string]any) Change {
+	if ch.Sealed != nil {
+		ch.Patch = patch
+	}
+	return ch
+}
+
 // ReplaceChange creates a new change instead of an existing one. The change to replace must be the current head.
 func (e *Entity) ReplaceChange(old cid.Cid, ts hlc.Timestamp, signer core.KeyPair, delegation cid.Cid, patch map[string]any, opts ...ChangeOption) (hb Blob, err error) {
 	if len(e.heads) != 1 {
@@ -441,7 +468,7 @@ func (e *Entity) ReplaceChange(old cid.Cid, ts hlc.Timestamp, signer core.KeyPai
 		return hb, err
 	}
 
-	if err := e.ApplyChange(hb.CID, hb.Decoded.(Change)); err != nil {
+	if err := e.ApplyChange(hb.CID, openedChange(hb.Decoded.(Change), patch)); err != nil {
 		return hb, err
 	}
 
@@ -492,6 +519,10 @@ func (bs *Storage) ForEachComment(ctx context.Context, target string, fn func(c
 			return fmt.Errorf("forEachComment: failed to decode comment %s for target %s: %w", chcid, target, err)
 		}
 
+		if err := bs.openComment(conn, &cmt); err != nil {
+			return err
+		}
+
 		if err := fn(chcid, cmt, conn); err != nil {
 			return err
 		}
@@ -553,6 +584,10 @@ func (bs *Storage) ForEachChange(ctx context.Context, eid EntityID, fn func(c ci
 			return fmt.Errorf("forEachChange: failed to decode change %s for entity %s: %w", chcid, eid, err)
 		}
 
+		if err := bs.openChange(conn, &ch); err != nil {
+			return err
+		}
+
 		if err := fn(chcid, ch); err != nil {
 			return err
 		}
@@ -583,13 +618,19 @@ func (bs *Storage) LoadEntityAll(ctx context.Context, eid EntityID) (e *Entity,
 		return nil, status.Errorf(codes.NotFound, "entity %q not found", eid)
 	}
 
+	return bs.loadEntityAll(conn, edb.ResourcesID, eid)
+}
+
+// loadEntityAll loads the entity with all the changes using the given connection.
+func (bs *indexer) loadEntityAll(conn *sqlite.Conn, rid int64, eid EntityID) (e *Entity, err error) {
 	entity := NewEntity(eid)
 	buf := make([]byte, 0, 1024*1024) // preallocating 1MB for decompression.
 	if err := sqlitex.Exec(conn, qLoadEntityAll(), func(stmt *sqlite.Stmt) error {
 		var (
-			codec = stmt.ColumnInt64(0)
-			hash  = stmt.ColumnBytesUnsafe(1)
-			data  = stmt.ColumnBytesUnsafe(2)
+			codec        = stmt.ColumnInt64(0)
+			hash         = stmt.ColumnBytesUnsafe(1)
+			data         = stmt.ColumnBytesUnsafe(2)
+			unauthorized = stmt.ColumnInt(3) != 0
 		)
 
 		buf, err = bs.bs.decoder.DecodeAll(data, buf)
@@ -603,14 +644,18 @@ func (bs *Storage) LoadEntityAll(ctx context.Context, eid EntityID) (e *Entity,
 			return fmt.Errorf("loadEntity: failed to decode change %q for entity %q: %w", c, eid, err)
 		}
 
-		if err := entity.ApplyChange(c, ch); err != nil {
+		if err := bs.openChange(conn, &ch); err != nil {
+			return err
+		}
+
+		if err := entity.applyChange(c, ch, unauthorized); err != nil {
 			return err
 		}
 
 		// Reset the slice to reuse the underlying array for the next decompression.
 		buf = buf[:0]
 		return nil
-	}, edb.ResourcesID); err != nil {
+	}, rid); err != nil {
 		return nil, err
 	}
 	// TODO(burdiyan): this is not a great way to handle not found errors.
@@ -627,10 +672,12 @@ var qLoadEntityAll = dqb.Str(`
 	SELECT
 		blobs.codec,
 		blobs.multihash,
-		blobs.data
+		blobs.data,
+		unauthorized_changes.id IS NOT NULL
 	FROM structural_blobs
 	JOIN blobs ON blobs.id = structural_blobs.id
 	LEFT JOIN drafts ON drafts.resource = structural_blobs.resource AND drafts.blob = structural_blobs.id
+	LEFT JOIN unauthorized_changes ON unauthorized_changes.id = structural_blobs.id
 	WHERE structural_blobs.type = 'Change'
 	AND structural_blobs.resource = :entity
 	AND drafts.blob IS NULL
@@ -660,9 +707,10 @@ func (bs *Storage) LoadEntity(ctx context.Context, eid EntityID) (e *Entity, err
 	buf := make([]byte, 0, 1024*1024) // preallocating 1MB for decompression.
 	if err := sqlitex.Exec(conn, qLoadEntity(), func(stmt *sqlite.Stmt) error {
 		var (
-			codec = stmt.ColumnInt64(0)
-			hash  = stmt.ColumnBytesUnsafe(1)
-			data  = stmt.ColumnBytesUnsafe(2)
+			codec        = stmt.ColumnInt64(0)
+			hash         = stmt.ColumnBytesUnsafe(1)
+			data         = stmt.ColumnBytesUnsafe(2)
+			unauthorized = stmt.ColumnInt(3) != 0
 		)
 
 		buf, err = bs.bs.decoder.DecodeAll(data, buf)
@@ -676,7 +724,11 @@ func (bs *Storage) LoadEntity(ctx context.Context, eid EntityID) (e *Entity, err
 			return fmt.Errorf("loadEntity: failed to decode change %q for entity %q: %w", c, eid, err)
 		}
 
-		if err := entity.ApplyChange(c, ch); err != nil {
+		if err := bs.openChange(conn, &ch); err != nil {
+			return err
+		}
+
+		if err := entity.applyChange(c, ch, unauthorized); err != nil {
 			return err
 		}
 
@@ -717,7 +769,8 @@ var qLoadEntity = dqb.Str(`
 	SELECT
 		blobs.codec,
 		blobs.multihash,
-		blobs.data
+		blobs.data,
+		unauthorized_changes.id IS NOT NULL
 	FROM selected
 	-- Using cross join here to force the query planner to use the primary index on blobs.
 	-- Otherwise, for some reason the query planner chooses to scan over all the blobs table
@@ -725,6 +778,7 @@ var qLoadEntity = dqb.Str(`
 	-- But that's OK in this case, because it's a recursive query that we'll have to scan entirely anyway.
 	CROSS JOIN blobs ON blobs.id = selected.id
 	JOIN structural_blobs ON structural_blobs.id = selected.id
+	LEFT JOIN unauthorized_changes ON unauthorized_changes.id = selected.id
 	ORDER BY structural_blobs.ts;
 `)
 
@@ -734,9 +788,9 @@ type Draft struct {
 	Change Change
 }
 
-func (bs *Storage) LoadDraft(ctx context.Context, eid EntityID) (*Draft, error) {
+func (bs *Storage) LoadDraft(ctx context.Context, account core.Principal, eid EntityID) (*Draft, error) {
 	// load draft change
-	c, err := bs.FindDraft(ctx, eid)
+	c, err := bs.FindDraft(ctx, account, eid)
 	if err != nil {
 		return nil, err
 	}
@@ -746,6 +800,12 @@ func (bs *Storage) LoadDraft(ctx context.Context, eid EntityID) (*Draft, error)
 		return nil, err
 	}
 
+	if err := bs.Query(ctx, func(conn *sqlite.Conn) error {
+		return bs.openChange(conn, &ch)
+	}); err != nil {
+		return nil, err
+	}
+
 	var entity *Entity
 	if len(ch.Deps) == 0 {
 		entity = NewEntity(eid)
@@ -772,9 +832,9 @@ func (bs *Storage) LoadDraft(ctx context.Context, eid EntityID) (*Draft, error)
 	}, nil
 }
 
-// LoadDraftEntity includes draft changes.
-func (bs *Storage) LoadDraftEntity(ctx context.Context, eid EntityID) (*Entity, error) {
-	draft, err := bs.FindDraft(ctx, eid)
+// LoadDraftEntity includes draft changes of the account.
+func (bs *Storage) LoadDraftEntity(ctx context.Context, account core.Principal, eid EntityID) (*Entity, error) {
+	draft, err := bs.FindDraft(ctx, account, eid)
 	if err != nil {
 		return nil, err
 	}
@@ -782,15 +842,15 @@ func (bs *Storage) LoadDraftEntity(ctx context.Context, eid EntityID) (*Entity,
 	return bs.LoadEntityFromHeads(ctx, eid, draft)
 }
 
-// FindDraft for a given entity.
-func (bs *Storage) FindDraft(ctx context.Context, eid EntityID) (cid.Cid, error) {
+// FindDraft of the account for a given entity.
+func (bs *Storage) FindDraft(ctx context.Context, account core.Principal, eid EntityID) (cid.Cid, error) {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
 		return cid.Undef, err
 	}
 	defer release()
 
-	res, err := hypersql.DraftsGet(conn, string(eid))
+	res, err := hypersql.DraftsGet(conn, string(eid), account)
 	if err != nil {
 		return cid.Undef, err
 	}
@@ -842,7 +902,7 @@ func (bs *Storage) LoadEntityFromHeads(ctx context.Context, eid EntityID, heads
 // localHeads is a JSON-encoded array of integers corresponding to heads.
 type localHeads string
 
-func (bs *Storage) loadFromHeads(conn *sqlite.Conn, eid EntityID, heads localHeads) (e *Entity, err error) {
+func (bs *indexer) loadFromHeads(conn *sqlite.Conn, eid EntityID, heads localHeads) (e *Entity, err error) {
 	if heads == "" || heads == "null" {
 		heads = "[]"
 	}
@@ -874,7 +934,11 @@ func (bs *Storage) loadFromHeads(conn *sqlite.Conn, eid EntityID, heads localHea
 			return nil, fmt.Errorf("loadFromHeads: failed to decode change %s for entity %s: %w", chcid, eid, err)
 		}
 
-		if err := entity.ApplyChange(chcid, ch); err != nil {
+		if err := bs.openChange(conn, &ch); err != nil {
+			return nil, err
+		}
+
+		if err := entity.applyChange(chcid, ch, change.IsUnauthorized != 0); err != nil {
 			return nil, err
 		}
 
@@ -109,7 +109,7 @@ func TestTrustedEntities(t *testing.T) {
 
 		// TODO(burdian): trusted your own account should be done elsewhere
 		// to be less error-prone.
-		require.NoError(t, blobs.SetAccountTrust(ctx, user.Account.Principal()))
+		require.NoError(t, blobs.SetAccountTrust(ctx, user.Account.Principal(), user.Account.Principal()))
 
 		return testNode{
 			blobs:      blobs,
@@ -185,7 +185,7 @@ func TestTrustedEntities(t *testing.T) {
 	}
 	require.Equal(t, want, list, "list trusted entities must return our own account")
 
-	require.NoError(t, alice.blobs.SetAccountTrust(ctx, bob.me.Account().Principal()))
+	require.NoError(t, alice.blobs.SetAccountTrust(ctx, alice.me.Account().Principal(), bob.me.Account().Principal()))
 
 	list, err = alice.blobs.ListTrustedEntities(ctx, "*")
 	require.NoError(t, err)
@@ -262,9 +262,9 @@ func TestEntityMutation_Drafts(t *testing.T) {
 	})
 	require.NoError(t, err)
 	require.Equal(t, []cid.Cid{ch1.CID}, ch2.Decoded.(Change).Deps, "new change must have previous heads")
-	require.NoError(t, blobs.SaveDraftBlob(ctx, "foo", ch2))
+	require.NoError(t, blobs.SaveDraftBlob(ctx, alice.Account.Principal(), "foo", ch2))
 
-	ee, err = blobs.LoadDraftEntity(ctx, "foo")
+	ee, err = blobs.LoadDraftEntity(ctx, alice.Account.Principal(), "foo")
 	require.NoError(t, err)
 
 	require.Equal(t, map[cid.Cid]struct{}{ch2.CID: {}}, ee.heads)
@@ -274,9 +274,9 @@ func TestEntityMutation_Drafts(t *testing.T) {
 		"email": "alice@wonderland.com",
 	})
 	require.NoError(t, err)
-	require.NoError(t, blobs.ReplaceDraftBlob(ctx, "foo", ch2.CID, ch3))
+	require.NoError(t, blobs.ReplaceDraftBlob(ctx, alice.Account.Principal(), "foo", ch2.CID, ch3))
 
-	ee, err = blobs.LoadDraftEntity(ctx, "foo")
+	ee, err = blobs.LoadDraftEntity(ctx, alice.Account.Principal(), "foo")
 	require.NoError(t, err)
 	require.Equal(t, map[cid.Cid]struct{}{ch3.CID: {}}, ee.heads)
 	require.Equal(t, 2, len(ee.applied), "replaced draft must disappear")
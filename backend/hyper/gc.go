@@ -0,0 +1,348 @@
+package hyper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mintter/backend/core"
+	"mintter/backend/pkg/dqb"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	mGCReclaimableBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "mintter_blobs_reclaimable_bytes",
+		Help: "Number of bytes of blob data found unreachable by the last garbage collection, whether deleted or not.",
+	})
+
+	mGCDeletedBlobsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "mintter_blobs_gc_deleted_total",
+		Help: "The total number of blobs deleted by garbage collection.",
+	})
+)
+
+// EvictionReason is the reason recorded for entities evicted to satisfy the disk quota.
+const EvictionReason = "gc: disk quota exceeded"
+
+// GCOptions configures a single garbage collection run.
+type GCOptions struct {
+	// DryRun only computes the report without deleting anything.
+	DryRun bool
+
+	// QuotaBytes is the maximum number of bytes of blob data we want to keep.
+	// When exceeded, entities we don't own are evicted, least recently updated first.
+	// Zero means no quota.
+	QuotaBytes int64
+
+	// GracePeriod protects recently inserted blobs from being collected,
+	// because they might still be unreferenced while being uploaded or synced.
+	GracePeriod time.Duration
+}
+
+// GCReport describes the result of a garbage collection run.
+type GCReport struct {
+	DryRun bool
+
+	// Total number of blobs and bytes of blob data before the collection.
+	TotalBlobs int64
+	TotalBytes int64
+
+	// Blobs that are (or would be, in dry-run mode) deleted.
+	UnreachableBlobs int64
+	ReclaimableBytes int64
+
+	// Entities that are (or would be) evicted to satisfy the disk quota.
+	EvictedEntities []EntityID
+}
+
+// CollectGarbage deletes the blobs that are not reachable from the content we want to keep.
+//
+// We keep all the entities that are not deleted, unless there're some subscriptions,
+// in which case we only keep the subscribed entities (including the content of subscribed groups),
+// the entities owned by the given owner or by trusted accounts, the entities with drafts, and all the accounts.
+// From the blobs of those entities, their comments, drafts, and key delegations
+// we follow the blob links to find everything else that must be kept,
+// including files and pinned versions linked from the content.
+// Pinned blobs, like the files uploaded on this device, are always kept with everything they link to.
+//
+// Evicted entities are recorded as deleted, so that they are not synced again.
+func (bs *Storage) CollectGarbage(ctx context.Context, owner core.Principal, opts GCOptions) (report GCReport, err error) {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return report, err
+	}
+	defer release()
+
+	report.DryRun = opts.DryRun
+	graceTime := time.Now().Add(-opts.GracePeriod).Unix()
+
+	if err := sqlitex.WithTx(conn, func() error {
+		if err := sqlitex.Exec(conn, qGCTotals(), func(stmt *sqlite.Stmt) error {
+			report.TotalBlobs = stmt.ColumnInt64(0)
+			report.TotalBytes = stmt.ColumnInt64(1)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to count blobs: %w", err)
+		}
+
+		if opts.QuotaBytes > 0 {
+			_, garbageBytes, err := countGarbage(conn, owner, nil, graceTime)
+			if err != nil {
+				return err
+			}
+
+			evicted, err := findEvictions(conn, owner, report.TotalBytes-garbageBytes-opts.QuotaBytes)
+			if err != nil {
+				return err
+			}
+			report.EvictedEntities = evicted
+		}
+
+		report.UnreachableBlobs, report.ReclaimableBytes, err = countGarbage(conn, owner, report.EvictedEntities, graceTime)
+		if err != nil {
+			return err
+		}
+
+		if opts.DryRun {
+			return nil
+		}
+
+		for _, eid := range report.EvictedEntities {
+			if err := sqlitex.Exec(conn, qGCMarkEvicted(), nil, eid.String(), EvictionReason); err != nil {
+				return fmt.Errorf("failed to mark entity %s as evicted: %w", eid, err)
+			}
+		}
+
+		evicted, err := evictedJSON(report.EvictedEntities)
+		if err != nil {
+			return err
+		}
+
+		if err := sqlitex.Exec(conn, qGCDeleteGarbage(), nil, []byte(owner), evicted, graceTime); err != nil {
+			return fmt.Errorf("failed to delete unreachable blobs: %w", err)
+		}
+
+		if deleted := int64(conn.Changes()); deleted != report.UnreachableBlobs {
+			return fmt.Errorf("BUG: garbage collection deleted %d blobs, but %d were found unreachable", deleted, report.UnreachableBlobs)
+		}
+
+		return nil
+	}); err != nil {
+		return GCReport{}, err
+	}
+
+	if !opts.DryRun && report.UnreachableBlobs > 0 {
+		bs.bs.deletions.Add(1)
+	}
+
+	mGCReclaimableBytes.Set(float64(report.ReclaimableBytes))
+	if !opts.DryRun {
+		mGCDeletedBlobsTotal.Add(float64(report.UnreachableBlobs))
+	}
+
+	bs.log.Debug("GarbageCollectionFinished",
+		zap.Bool("dryRun", report.DryRun),
+		zap.Int64("unreachableBlobs", report.UnreachableBlobs),
+		zap.Int64("reclaimableBytes", report.ReclaimableBytes),
+		zap.Int("evictedEntities", len(report.EvictedEntities)),
+	)
+
+	return report, nil
+}
+
+// PinBlob protects the blob, and everything it links to, from garbage collection.
+// The blob must be stored already.
+func (bs *Storage) PinBlob(ctx context.Context, c cid.Cid) error {
+	ok, err := bs.bs.Has(ctx, c)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("can't pin blob %s: %w", c, format.ErrNotFound{Cid: c})
+	}
+
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qGCPinBlob(), nil, []byte(c.Hash())); err != nil {
+		return fmt.Errorf("failed to pin blob %s: %w", c, err)
+	}
+
+	return nil
+}
+
+func countGarbage(conn *sqlite.Conn, owner core.Principal, evicted []EntityID, graceTime int64) (count, size int64, err error) {
+	data, err := evictedJSON(evicted)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := sqlitex.Exec(conn, qGCCountGarbage(), func(stmt *sqlite.Stmt) error {
+		count = stmt.ColumnInt64(0)
+		size = stmt.ColumnInt64(1)
+		return nil
+	}, []byte(owner), data, graceTime); err != nil {
+		return 0, 0, fmt.Errorf("failed to find unreachable blobs: %w", err)
+	}
+
+	return count, size, nil
+}
+
+// evictedJSON encodes the list of evicted entities to be used with json_each in queries.
+func evictedJSON(evicted []EntityID) (string, error) {
+	if evicted == nil {
+		// Null would make json_each return a single NULL value.
+		evicted = []EntityID{}
+	}
+
+	data, err := json.Marshal(evicted)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// findEvictions returns the least recently updated entities that can be evicted,
+// until the sum of the size of their blobs exceeds the given number of bytes.
+func findEvictions(conn *sqlite.Conn, owner core.Principal, excess int64) (out []EntityID, err error) {
+	if excess <= 0 {
+		return nil, nil
+	}
+
+	if err := sqlitex.Exec(conn, qGCListEvictionCandidates(), func(stmt *sqlite.Stmt) error {
+		if excess <= 0 {
+			return nil
+		}
+		out = append(out, EntityID(stmt.ColumnText(0)))
+		excess -= stmt.ColumnInt64(1)
+		return nil
+	}, []byte(owner)); err != nil {
+		return nil, fmt.Errorf("failed to list entities for eviction: %w", err)
+	}
+
+	return out, nil
+}
+
+var qGCTotals = dqb.Str(`
+	SELECT count(), COALESCE(SUM(length(data)), 0)
+	FROM blobs;
+`)
+
+// gcProtectedCTE defines the sets of resources that we never evict:
+// those owned by us or our trusted accounts, and those we're subscribed to.
+const gcProtectedCTE = `
+	WITH RECURSIVE
+	owned (id) AS (
+		SELECT id FROM public_keys WHERE principal = :owner
+		UNION
+		SELECT id FROM trusted_accounts
+	),
+	subscribed (id) AS (
+		SELECT resources.id
+		FROM resources
+		JOIN subscriptions ON subscriptions.iri = resources.iri
+		UNION
+		SELECT resource_links.target
+		FROM resource_links
+		JOIN structural_blobs ON structural_blobs.id = resource_links.source
+		JOIN resources ON resources.id = structural_blobs.resource
+		JOIN subscriptions ON subscriptions.iri = resources.iri
+		WHERE resource_links.type = 'group/content'
+	)
+`
+
+// gcGarbageCTE extends gcProtectedCTE with the set of unreachable blobs.
+const gcGarbageCTE = gcProtectedCTE + `,
+	kept (id) AS (
+		SELECT resources.id
+		FROM resources
+		WHERE resources.iri NOT IN (SELECT iri FROM deleted_resources)
+		AND resources.iri NOT IN (SELECT value FROM json_each(:evicted))
+		AND (
+			NOT EXISTS (SELECT 1 FROM subscriptions)
+			OR resources.iri GLOB 'hm://a/*'
+			OR resources.owner IN owned
+			OR resources.id IN subscribed
+			OR resources.id IN (SELECT resource FROM drafts)
+		)
+	),
+	roots (id) AS (
+		SELECT id FROM structural_blobs WHERE resource IN kept
+		UNION
+		SELECT source FROM resource_links WHERE type = 'comment/target' AND target IN kept
+		UNION
+		SELECT blob FROM drafts
+		UNION
+		SELECT id FROM key_delegations
+		UNION
+		SELECT id FROM pinned_blobs
+		UNION
+		SELECT id FROM pending_changes
+		WHERE resource NOT IN (SELECT iri FROM deleted_resources)
+		AND resource NOT IN (SELECT value FROM json_each(:evicted))
+		UNION
+		SELECT id FROM blobs INDEXED BY blobs_metadata WHERE insert_time > :graceTime
+	),
+	reachable (id) AS (
+		SELECT id FROM roots
+		UNION
+		SELECT blob_links.target
+		FROM blob_links
+		JOIN reachable ON reachable.id = blob_links.source
+	),
+	garbage (id) AS (
+		SELECT id FROM blobs INDEXED BY blobs_metadata
+		WHERE id NOT IN reachable
+	)
+`
+
+var qGCCountGarbage = dqb.Str(gcGarbageCTE + `
+	SELECT count(), COALESCE(SUM(length(data)), 0)
+	FROM blobs
+	WHERE id IN garbage;
+`)
+
+var qGCDeleteGarbage = dqb.Str(gcGarbageCTE + `
+	DELETE FROM blobs
+	WHERE id IN garbage;
+`)
+
+var qGCListEvictionCandidates = dqb.Str(gcProtectedCTE + `
+	SELECT
+		resources.iri,
+		SUM(length(blobs.data)) AS size,
+		MAX(blobs.insert_time) AS last_update
+	FROM resources
+	JOIN structural_blobs ON structural_blobs.resource = resources.id
+	JOIN blobs ON blobs.id = structural_blobs.id
+	WHERE resources.iri NOT GLOB 'hm://a/*'
+	AND (resources.owner IS NULL OR resources.owner NOT IN owned)
+	AND resources.id NOT IN subscribed
+	AND resources.id NOT IN (SELECT resource FROM drafts)
+	AND resources.iri NOT IN (SELECT iri FROM deleted_resources)
+	GROUP BY resources.id
+	ORDER BY last_update, resources.iri;
+`)
+
+var qGCPinBlob = dqb.Str(`
+	INSERT OR IGNORE INTO pinned_blobs (id)
+	SELECT id FROM blobs
+	WHERE multihash = :hash
+	AND size >= 0;
+`)
+
+var qGCMarkEvicted = dqb.Str(`
+	INSERT OR IGNORE INTO deleted_resources (iri, reason) VALUES (?, ?);
+`)
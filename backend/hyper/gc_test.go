@@ -0,0 +1,122 @@
+package hyper
+
+import (
+	"context"
+	"mintter/backend/core/coretest"
+	"mintter/backend/hyper/hypersql"
+	"mintter/backend/ipfs"
+	"mintter/backend/logging"
+	"testing"
+	"time"
+
+	"crawshaw.io/sqlite"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+
+	db := newTestSQLite(t)
+	blobs := NewStorage(db, logging.New("mintter/hyper", "debug"))
+
+	delegations := map[string]Blob{}
+	for _, u := range []coretest.Tester{alice, bob} {
+		kd, err := NewKeyDelegation(u.Account, u.Device.PublicKey, time.Now().Add(-1*time.Hour))
+		require.NoError(t, err)
+		delegations[u.Account.Principal().String()] = kd.Blob()
+		require.NoError(t, blobs.SaveBlob(ctx, kd.Blob()))
+	}
+
+	createEntity := func(id EntityID, u coretest.Tester) []Blob {
+		e := NewEntity(id)
+		var out []Blob
+		for i := 0; i < 3; i++ {
+			ch, err := e.CreateChange(e.NextTimestamp(), u.Device, delegations[u.Account.Principal().String()].CID, map[string]any{
+				"counter": i,
+			})
+			require.NoError(t, err)
+			require.NoError(t, blobs.SaveBlob(ctx, ch))
+			out = append(out, ch)
+		}
+		return out
+	}
+
+	aliceChanges := createEntity("alice-thing", alice)
+	bobChanges := createEntity("bob-thing", bob)
+
+	orphanData := []byte("nobody links to this blob")
+	orphan, err := blocks.NewBlockWithCid(orphanData, ipfs.MustNewCID(multicodec.Raw, multicodec.Sha2_256, orphanData))
+	require.NoError(t, err)
+	require.NoError(t, blobs.IPFSBlockstore().Put(ctx, orphan))
+
+	pinnedData := []byte("an uploaded file nobody links to yet")
+	pinned, err := blocks.NewBlockWithCid(pinnedData, ipfs.MustNewCID(multicodec.Raw, multicodec.Sha2_256, pinnedData))
+	require.NoError(t, err)
+	require.Error(t, blobs.PinBlob(ctx, pinned.Cid()), "must not pin blobs we don't have")
+	require.NoError(t, blobs.IPFSBlockstore().Put(ctx, pinned))
+	require.NoError(t, blobs.PinBlob(ctx, pinned.Cid()))
+
+	hasBlob := func(c cid.Cid) bool {
+		ok, err := blobs.IPFSBlockstoreReader().Has(ctx, c)
+		require.NoError(t, err)
+		return ok
+	}
+
+	// Recent blobs are protected by the grace period.
+	report, err := blobs.CollectGarbage(ctx, alice.Account.Principal(), GCOptions{GracePeriod: time.Hour})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), report.UnreachableBlobs, "recent blobs must not be collected")
+	require.True(t, hasBlob(orphan.Cid()))
+
+	report, err = blobs.CollectGarbage(ctx, alice.Account.Principal(), GCOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), report.UnreachableBlobs, "only the orphan blob must be unreachable")
+	require.Greater(t, report.ReclaimableBytes, int64(0))
+	require.True(t, hasBlob(orphan.Cid()), "dry run must not delete anything")
+
+	report, err = blobs.CollectGarbage(ctx, alice.Account.Principal(), GCOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), report.UnreachableBlobs)
+	require.False(t, hasBlob(orphan.Cid()), "orphan blob must be deleted")
+	require.True(t, hasBlob(pinned.Cid()), "pinned blob must be kept")
+	for _, b := range append(aliceChanges, bobChanges...) {
+		require.True(t, hasBlob(b.CID), "changes must be kept")
+	}
+
+	// With a tiny quota bob's entity must be evicted, but not the one alice owns.
+	report, err = blobs.CollectGarbage(ctx, alice.Account.Principal(), GCOptions{DryRun: true, QuotaBytes: 1})
+	require.NoError(t, err)
+	require.Equal(t, []EntityID{"bob-thing"}, report.EvictedEntities)
+	require.Equal(t, int64(len(bobChanges)), report.UnreachableBlobs)
+	for _, b := range bobChanges {
+		require.True(t, hasBlob(b.CID), "dry run must not evict anything")
+	}
+
+	report, err = blobs.CollectGarbage(ctx, alice.Account.Principal(), GCOptions{QuotaBytes: 1})
+	require.NoError(t, err)
+	require.Equal(t, []EntityID{"bob-thing"}, report.EvictedEntities)
+	for _, b := range bobChanges {
+		require.False(t, hasBlob(b.CID), "bob's changes must be evicted")
+	}
+	for _, b := range aliceChanges {
+		require.True(t, hasBlob(b.CID), "alice's changes must be kept")
+	}
+	for _, b := range delegations {
+		require.True(t, hasBlob(b.CID), "key delegations must be kept")
+	}
+
+	_, err = blobs.LoadEntity(ctx, "alice-thing")
+	require.NoError(t, err)
+
+	require.NoError(t, blobs.Query(ctx, func(conn *sqlite.Conn) error {
+		res, err := hypersql.EntitiesLookupRemovedRecord(conn, "bob-thing")
+		require.NoError(t, err)
+		require.Equal(t, EvictionReason, res.DeletedResourcesReason, "evicted entity must be marked as deleted")
+		return nil
+	}))
+}
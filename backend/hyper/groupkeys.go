@@ -0,0 +1,419 @@
+package hyper
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mintter/backend/core"
+	groups "mintter/backend/genproto/groups/v1alpha"
+	"mintter/backend/hyper/hypersql"
+	"sync"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// ErrNoContentKey is returned when sealed content can't be opened,
+// because none of the known keys of the private group was distributed to our device,
+// e.g. when we are not a member of the group.
+var ErrNoContentKey = errors.New("no content key for private group")
+
+// ContentKey is a symmetric key used to seal the content of a private group.
+type ContentKey struct {
+	Group EntityID
+	ID    string
+	Key   []byte
+}
+
+// NewContentKey creates a new random content key for the group.
+func NewContentKey(group EntityID) (k ContentKey, err error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return k, err
+	}
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return k, err
+	}
+
+	return ContentKey{
+		Group: group,
+		ID:    hex.EncodeToString(id[:]),
+		Key:   key,
+	}, nil
+}
+
+// additionalData binds the sealed data to the group and the key.
+func (k ContentKey) additionalData() []byte {
+	return []byte(string(k.Group) + "#" + k.ID)
+}
+
+func (k ContentKey) seal(v any) (*Sealed, error) {
+	data, err := cbornode.DumpObject(v)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(k.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return &Sealed{
+		Group: k.Group,
+		KeyID: k.ID,
+		Nonce: nonce,
+		Data:  aead.Seal(nil, nonce, data, k.additionalData()),
+	}, nil
+}
+
+func (k ContentKey) open(s *Sealed, v any) error {
+	if s.Group != k.Group || s.KeyID != k.ID {
+		return fmt.Errorf("sealed content doesn't correspond to the content key %s of the group %s", k.ID, k.Group)
+	}
+
+	aead, err := chacha20poly1305.NewX(k.Key)
+	if err != nil {
+		return err
+	}
+
+	data, err := aead.Open(nil, s.Nonce, s.Data, k.additionalData())
+	if err != nil {
+		return fmt.Errorf("failed to open sealed content: %w", err)
+	}
+
+	return cbornode.DecodeInto(data, v)
+}
+
+// keyring holds our device key to open the content keys distributed with GroupKey blobs,
+// and caches the opened keys.
+type keyring struct {
+	mu     sync.Mutex
+	device *core.KeyPair
+	keys   map[string]ContentKey // group + key ID -> key.
+}
+
+func newKeyring() *keyring {
+	return &keyring{
+		keys: make(map[string]ContentKey),
+	}
+}
+
+// SetDeviceKey sets the key of our device which is used to open sealed content of private groups.
+// Sealed content can't be opened until the device key is set.
+func (bs *Storage) SetDeviceKey(kp core.KeyPair) {
+	bs.keys.mu.Lock()
+	defer bs.keys.mu.Unlock()
+
+	bs.keys.device = &kp
+}
+
+// contentKey finds the content key with the given ID that was distributed to our device.
+func (bs *indexer) contentKey(conn *sqlite.Conn, group EntityID, keyID string) (k ContentKey, err error) {
+	cacheKey := string(group) + "#" + keyID
+
+	bs.keys.mu.Lock()
+	k, ok := bs.keys.keys[cacheKey]
+	device := bs.keys.device
+	bs.keys.mu.Unlock()
+
+	if ok {
+		return k, nil
+	}
+
+	if device == nil {
+		return k, fmt.Errorf("%w: device key is not available", ErrNoContentKey)
+	}
+
+	dev := device.Principal().String()
+
+	if err := hypersql.GroupKeysList(conn, string(group), keyID, func(codec int64, hash []byte) error {
+		if k.Key != nil {
+			return nil
+		}
+
+		blk, err := bs.bs.get(conn, cid.NewCidV1(uint64(codec), hash))
+		if err != nil {
+			return err
+		}
+
+		var gk GroupKey
+		if err := cbornode.DecodeInto(blk.RawData(), &gk); err != nil {
+			return fmt.Errorf("failed to decode group key %s: %w", blk.Cid(), err)
+		}
+
+		for _, devices := range gk.Keys {
+			sealed, ok := devices[dev]
+			if !ok {
+				continue
+			}
+
+			key, err := device.OpenAnonymous(sealed)
+			if err != nil {
+				return fmt.Errorf("failed to open group key %s: %w", blk.Cid(), err)
+			}
+
+			k = ContentKey{Group: group, ID: keyID, Key: key}
+			return nil
+		}
+
+		return nil
+	}); err != nil {
+		return k, err
+	}
+
+	if k.Key == nil {
+		return k, fmt.Errorf("%w: key %s of group %s", ErrNoContentKey, keyID, group)
+	}
+
+	bs.keys.mu.Lock()
+	bs.keys.keys[cacheKey] = k
+	bs.keys.mu.Unlock()
+
+	return k, nil
+}
+
+// openChange decrypts the sealed part of the change and merges it into the patch.
+// The sealed field is preserved, so it's possible to know that the change was private.
+func (bs *indexer) openChange(conn *sqlite.Conn, ch *Change) error {
+	if ch.Sealed == nil {
+		return nil
+	}
+
+	key, err := bs.contentKey(conn, ch.Sealed.Group, ch.Sealed.KeyID)
+	if err != nil {
+		return err
+	}
+
+	var sealed map[string]any
+	if err := key.open(ch.Sealed, &sealed); err != nil {
+		return err
+	}
+
+	patch := make(map[string]any, len(ch.Patch)+len(sealed))
+	maps.Copy(patch, ch.Patch)
+	for k, v := range sealed {
+		if slices.Contains(PublicPatchFields, k) {
+			return fmt.Errorf("sealed patch must not contain public field %s", k)
+		}
+		patch[k] = v
+	}
+	ch.Patch = patch
+
+	return nil
+}
+
+// openComment decrypts the sealed body of the comment.
+func (bs *indexer) openComment(conn *sqlite.Conn, cmt *Comment) error {
+	if cmt.Sealed == nil {
+		return nil
+	}
+
+	key, err := bs.contentKey(conn, cmt.Sealed.Group, cmt.Sealed.KeyID)
+	if err != nil {
+		return err
+	}
+
+	return key.open(cmt.Sealed, &cmt.Body)
+}
+
+// OpenComment decrypts the sealed body of the comment if needed.
+func (bs *Storage) OpenComment(ctx context.Context, cmt *Comment) error {
+	if cmt.Sealed == nil {
+		return nil
+	}
+
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return bs.openComment(conn, cmt)
+}
+
+// CurrentContentKey returns the latest content key of the private group.
+// Our device must have received it from the owner or admins of the group.
+func (bs *Storage) CurrentContentKey(ctx context.Context, group EntityID) (k ContentKey, err error) {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return k, err
+	}
+	defer release()
+
+	keyID, err := hypersql.GroupKeysGetCurrent(conn, string(group))
+	if err != nil {
+		return k, err
+	}
+	if keyID == "" {
+		return k, fmt.Errorf("%w: group %s has no keys", ErrNoContentKey, group)
+	}
+
+	return bs.contentKey(conn, group, keyID)
+}
+
+// ContentKeys returns all the content keys of the private group our device has received,
+// from the oldest to the latest. Content created before the key was rotated is sealed with the older keys.
+func (bs *Storage) ContentKeys(ctx context.Context, group EntityID) (keys []ContentKey, err error) {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	ids, err := hypersql.GroupKeysListIDs(conn, string(group))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		k, err := bs.contentKey(conn, group, id)
+		if err != nil {
+			if errors.Is(err, ErrNoContentKey) {
+				continue
+			}
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+// EntityPrivateGroup returns the private group the content of the entity is sealed for,
+// or empty string if the entity is public.
+func (bs *Storage) EntityPrivateGroup(ctx context.Context, eid EntityID) (EntityID, error) {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	group, err := hypersql.PrivateBlobsGetResourceGroup(conn, string(eid))
+	if err != nil {
+		return "", err
+	}
+
+	return EntityID(group), nil
+}
+
+// CreateGroupKey creates a GroupKey blob distributing the content key
+// to the devices of all the current members of the group.
+// Devices that were revoked or have expired delegations don't receive the key.
+func (bs *Storage) CreateGroupKey(ctx context.Context, group *Entity, key ContentKey, signer core.KeyPair, delegation cid.Cid) (hb Blob, err error) {
+	if key.Group != group.ID() {
+		return hb, fmt.Errorf("content key %s doesn't belong to the group %s", key.ID, group.ID())
+	}
+
+	owner, err := GroupOwner(group)
+	if err != nil {
+		return hb, err
+	}
+
+	members := []core.Principal{owner}
+	for _, k := range group.State().Keys("members") {
+		acc, err := core.DecodePrincipal(k)
+		if err != nil {
+			return hb, fmt.Errorf("failed to parse group member as principal: %w", err)
+		}
+
+		role, err := GroupRole(group, acc)
+		if err != nil {
+			return hb, err
+		}
+
+		if role == groups.Role_ROLE_UNSPECIFIED || role == groups.Role_OWNER {
+			continue
+		}
+
+		members = append(members, acc)
+	}
+
+	devices := make(map[string][]core.Principal, len(members))
+	if err := bs.Query(ctx, func(conn *sqlite.Conn) error {
+		now := time.Now()
+		for _, acc := range members {
+			revoked := map[string]struct{}{}
+			if err := hypersql.KeyRevocationsList(conn, acc, func(delegate []byte, _ int64) error {
+				revoked[string(delegate)] = struct{}{}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			list, err := hypersql.KeyDelegationsList(conn, acc)
+			if err != nil {
+				return err
+			}
+
+			var devs []core.Principal
+			for _, kd := range list {
+				if _, ok := revoked[string(kd.KeyDelegationsViewDelegate)]; ok {
+					continue
+				}
+
+				if kd.KeyDelegationsViewNotAfter != 0 && now.After(time.Unix(kd.KeyDelegationsViewNotAfter, 0)) {
+					continue
+				}
+
+				devs = append(devs, core.Principal(kd.KeyDelegationsViewDelegate))
+			}
+
+			if len(devs) > 0 {
+				devices[acc.String()] = devs
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return hb, err
+	}
+
+	return NewGroupKey(key, maps.Keys(group.Heads()), group.NextTimestamp(), signer, delegation, devices)
+}
+
+// IsBlobAllowedForDevice checks whether the blob can be served to the remote device.
+// Blobs with private content are only served to the devices of the current members of the group.
+func (bs *Storage) IsBlobAllowedForDevice(ctx context.Context, c cid.Cid, device core.Principal) (allowed bool, err error) {
+	err = bs.Query(ctx, func(conn *sqlite.Conn) error {
+		res, err := hypersql.BlobsGetSize(conn, c.Hash())
+		if err != nil {
+			return err
+		}
+		if res.BlobsID == 0 {
+			allowed = true
+			return nil
+		}
+
+		allowed, err = CheckBlobAccess(conn, res.BlobsID, device)
+		return err
+	})
+	return allowed, err
+}
+
+// CheckBlobAccess is like IsBlobAllowedForDevice, but works with the ID of the blob in the database.
+func CheckBlobAccess(conn *sqlite.Conn, id int64, device core.Principal) (bool, error) {
+	group, err := hypersql.PrivateBlobsGetGroupID(conn, id)
+	if err != nil {
+		return false, err
+	}
+
+	if group == 0 {
+		return true, nil
+	}
+
+	return hypersql.GroupHasMemberDevice(conn, group, device)
+}
@@ -0,0 +1,181 @@
+package hyper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mintter/backend/core"
+	groups "mintter/backend/genproto/groups/v1alpha"
+)
+
+// ErrGroupPermissionDenied is returned when the signer of a group change
+// doesn't have enough permissions to make it.
+var ErrGroupPermissionDenied = errors.New("group permission denied")
+
+// GroupCapability is something members of a group can do depending on their role.
+type GroupCapability int
+
+// Capabilities of group members.
+const (
+	// GroupCapEditContent allows to change title, description and content of the group,
+	// and to publish the content to the site of the group.
+	GroupCapEditContent GroupCapability = iota + 1
+
+	// GroupCapManageMembers allows to add members and change their roles,
+	// except for granting or revoking the admin role.
+	GroupCapManageMembers
+
+	// GroupCapManageAdmins allows to grant and revoke the admin role.
+	GroupCapManageAdmins
+
+	// GroupCapManageSite allows to set up the site of the group.
+	GroupCapManageSite
+)
+
+var groupRoleCapabilities = map[groups.Role][]GroupCapability{
+	groups.Role_OWNER:  {GroupCapEditContent, GroupCapManageMembers, GroupCapManageAdmins, GroupCapManageSite},
+	groups.Role_ADMIN:  {GroupCapEditContent, GroupCapManageMembers},
+	groups.Role_EDITOR: {GroupCapEditContent},
+	groups.Role_VIEWER: nil,
+}
+
+// GroupRoleAllows checks whether members with the given role have the capability.
+func GroupRoleAllows(role groups.Role, capability GroupCapability) bool {
+	for _, c := range groupRoleCapabilities[role] {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupOwner returns the owner of the group entity.
+func GroupOwner(e *Entity) (core.Principal, error) {
+	if len(e.changes) == 0 {
+		return nil, fmt.Errorf("group %s has no changes", e.id)
+	}
+
+	owner, ok := e.changes[0].Data.Patch["owner"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("group %s doesn't have owner field", e.id)
+	}
+
+	return owner, nil
+}
+
+// GroupRole returns the role of the account in the current state of the group entity.
+// It returns unspecified role for accounts that are not members of the group.
+func GroupRole(e *Entity, acc core.Principal) (groups.Role, error) {
+	owner, err := GroupOwner(e)
+	if err != nil {
+		return groups.Role_ROLE_UNSPECIFIED, err
+	}
+
+	if bytes.Equal(owner, acc) {
+		return groups.Role_OWNER, nil
+	}
+
+	v, ok := e.Get("members", acc.String())
+	if !ok {
+		return groups.Role_ROLE_UNSPECIFIED, nil
+	}
+
+	return groupRoleFromValue(v)
+}
+
+// AuthorizeGroupChange checks whether the author is allowed to apply the patch
+// to the group in the given state. The state must be the one the change is based on,
+// i.e. the state at the deps of the change, to make the outcome independent
+// of the order in which concurrent changes are received.
+// It returns an error wrapping ErrGroupPermissionDenied if the author doesn't have enough permissions.
+func AuthorizeGroupChange(e *Entity, author core.Principal, patch map[string]any) error {
+	role, err := GroupRole(e, author)
+	if err != nil {
+		return err
+	}
+
+	if role == groups.Role_ROLE_UNSPECIFIED {
+		return fmt.Errorf("%w: account %s is not a member of the group %s", ErrGroupPermissionDenied, author, e.id)
+	}
+
+	allow := func(capability GroupCapability, field string) error {
+		if !GroupRoleAllows(role, capability) {
+			return fmt.Errorf("%w: role %s of account %s doesn't allow to change %s of the group %s", ErrGroupPermissionDenied, role, author, field, e.id)
+		}
+		return nil
+	}
+
+	for k, v := range patch {
+		switch k {
+		case "private":
+			return fmt.Errorf("%w: privacy of the group %s can't be changed after creation", ErrGroupPermissionDenied, e.id)
+		case "siteURL":
+			if err := allow(GroupCapManageSite, k); err != nil {
+				return err
+			}
+		case "members":
+			if err := allow(GroupCapManageMembers, k); err != nil {
+				return err
+			}
+
+			members, ok := v.(map[string]any)
+			if !ok {
+				return fmt.Errorf("group members must be a map, got %T", v)
+			}
+
+			for member, newRole := range members {
+				acc, err := core.DecodePrincipal(member)
+				if err != nil {
+					return fmt.Errorf("failed to parse group member as principal: %w", err)
+				}
+
+				oldRole, err := GroupRole(e, acc)
+				if err != nil {
+					return err
+				}
+
+				if oldRole == groups.Role_OWNER {
+					return fmt.Errorf("%w: role of the group owner can't be changed", ErrGroupPermissionDenied)
+				}
+
+				r, err := groupRoleFromValue(newRole)
+				if err != nil {
+					return err
+				}
+
+				if r == groups.Role_ADMIN || oldRole == groups.Role_ADMIN {
+					if err := allow(GroupCapManageAdmins, "admins"); err != nil {
+						return err
+					}
+				}
+			}
+		default:
+			if err := allow(GroupCapEditContent, k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// groupRoleFromValue converts the role from the patch of a group change.
+// Roles are integers, but depending on whether the change went through the encoding or not
+// they can be of different integer types.
+func groupRoleFromValue(v any) (groups.Role, error) {
+	var role groups.Role
+	switch vv := v.(type) {
+	case int:
+		role = groups.Role(vv)
+	case int64:
+		role = groups.Role(vv)
+	default:
+		return groups.Role_ROLE_UNSPECIFIED, fmt.Errorf("member must have valid role, got %T", v)
+	}
+
+	if _, ok := groups.Role_name[int32(role)]; !ok {
+		return groups.Role_ROLE_UNSPECIFIED, fmt.Errorf("unknown member role %d", role)
+	}
+
+	return role, nil
+}
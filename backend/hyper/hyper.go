@@ -48,14 +48,17 @@ func NewStorage(db *sqlitex.Pool, log *zap.Logger) *Storage {
 	bs := newBlockstore(db)
 
 	idx := &indexer{
-		db:  db,
-		log: log,
-		bs:  bs,
+		db:       db,
+		log:      log,
+		bs:       bs,
+		notifier: newIndexNotifier(),
+		texts:    newTextIndex(),
+		keys:     newKeyring(),
 	}
 
 	return &Storage{
 		db:              db,
-		bs:              &indexingBlockStore{blockStore: bs, indexBlob: idx.indexBlob},
+		bs:              &indexingBlockStore{blockStore: bs, indexer: idx},
 		log:             log,
 		indexer:         idx,
 		delegationCache: must.Do2(lru.New[cid.Cid, core.Principal](256)),
@@ -93,7 +96,7 @@ func (bs *Storage) SaveBlob(ctx context.Context, blob Blob) error {
 	}
 	defer release()
 
-	return sqlitex.WithTx(conn, func() error {
+	return bs.withTx(conn, func() error {
 		_, err := bs.saveBlob(conn, blob)
 		return err
 	})
@@ -119,8 +122,8 @@ func (bs *Storage) saveBlob(conn *sqlite.Conn, blob Blob) (id int64, err error)
 	return id, nil
 }
 
-// SetAccountTrust sets an account to trusted.
-func (bs *Storage) SetAccountTrust(ctx context.Context, acc []byte) error {
+// SetAccountTrust marks an account as trusted by one of our own accounts.
+func (bs *Storage) SetAccountTrust(ctx context.Context, account core.Principal, acc []byte) error {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
 		return err
@@ -128,12 +131,12 @@ func (bs *Storage) SetAccountTrust(ctx context.Context, acc []byte) error {
 	defer release()
 
 	return sqlitex.WithTx(conn, func() error {
-		return hypersql.SetAccountTrust(conn, acc)
+		return hypersql.SetAccountTrust(conn, account, acc)
 	})
 }
 
-// UnsetAccountTrust untrust the provided account.
-func (bs *Storage) UnsetAccountTrust(ctx context.Context, acc []byte) error {
+// UnsetAccountTrust untrust the provided account for one of our own accounts.
+func (bs *Storage) UnsetAccountTrust(ctx context.Context, account core.Principal, acc []byte) error {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
 		return err
@@ -141,18 +144,19 @@ func (bs *Storage) UnsetAccountTrust(ctx context.Context, acc []byte) error {
 	defer release()
 
 	return sqlitex.WithTx(conn, func() error {
-		return hypersql.UnsetAccountTrust(conn, acc)
+		return hypersql.UnsetAccountTrust(conn, account, acc)
 	})
 }
 
-func (bs *Storage) SaveDraftBlob(ctx context.Context, eid EntityID, blob Blob) error {
+// SaveDraftBlob saves the blob as the draft of the entity owned by the account.
+func (bs *Storage) SaveDraftBlob(ctx context.Context, account core.Principal, eid EntityID, blob Blob) error {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
 		return err
 	}
 	defer release()
 
-	return sqlitex.WithTx(conn, func() error {
+	return bs.withTx(conn, func() error {
 		id, err := bs.saveBlob(conn, blob)
 		if err != nil {
 			return err
@@ -170,7 +174,7 @@ func (bs *Storage) SaveDraftBlob(ctx context.Context, eid EntityID, blob Blob) e
 			panic("BUG: saveDraft: failed to lookup entity after inserting the blob")
 		}
 
-		return hypersql.DraftsInsert(conn, resp.ResourcesID, id)
+		return hypersql.DraftsInsert(conn, account, resp.ResourcesID, id)
 	})
 }
 
@@ -198,7 +202,7 @@ func (bs *Storage) ListEntities(ctx context.Context, pattern string) ([]EntityID
 	return out, nil
 }
 
-// ListTrustedEntities returns a list of entities matching the pattern owned by trusted accounts.
+// ListTrustedEntities returns a list of entities matching the pattern owned by accounts trusted by any of our accounts.
 func (bs *Storage) ListTrustedEntities(ctx context.Context, pattern string) ([]EntityID, error) {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
@@ -219,20 +223,20 @@ func (bs *Storage) ListTrustedEntities(ctx context.Context, pattern string) ([]E
 
 var qListTrustedEntitites = dqb.Str(`
 	SELECT resources.iri
-	FROM trusted_accounts
-	JOIN resources ON resources.owner = trusted_accounts.id
-	WHERE resources.iri GLOB :prefix
+	FROM resources
+	WHERE resources.owner IN (SELECT id FROM trusted_accounts)
+	AND resources.iri GLOB :prefix
 	ORDER BY resources.id
 `)
 
-func (bs *Storage) GetDraft(ctx context.Context, eid EntityID) (ch Change, err error) {
+func (bs *Storage) GetDraft(ctx context.Context, account core.Principal, eid EntityID) (ch Change, err error) {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
 		return ch, err
 	}
 	defer release()
 
-	res, err := hypersql.DraftsGet(conn, string(eid))
+	res, err := hypersql.DraftsGet(conn, string(eid), account)
 	if err != nil {
 		return ch, err
 	}
@@ -244,6 +248,10 @@ func (bs *Storage) GetDraft(ctx context.Context, eid EntityID) (ch Change, err e
 		return ch, err
 	}
 
+	if err := bs.openChange(conn, &ch); err != nil {
+		return ch, err
+	}
+
 	return ch, nil
 }
 
@@ -256,7 +264,7 @@ func (bs *Storage) PublishBlob(ctx context.Context, c cid.Cid) (cid.Cid, error)
 	defer release()
 
 	var out cid.Cid
-	if err := sqlitex.WithTx(conn, func() error {
+	if err := bs.withTx(conn, func() error {
 		newID, err := allocateBlobID(conn)
 		if err != nil {
 			return err
@@ -271,6 +279,13 @@ func (bs *Storage) PublishBlob(ctx context.Context, c cid.Cid) (cid.Cid, error)
 			return err
 		}
 
+		if err := hypersql.IndexedBlobsTouch(conn, newID); err != nil {
+			return err
+		}
+
+		// Published blobs become visible to the event listeners.
+		bs.notifier.markPending(conn)
+
 		out = cid.NewCidV1(uint64(res.BlobsCodec), res.BlobsMultihash)
 
 		return nil
@@ -285,8 +300,8 @@ func (bs *Storage) PublishBlob(ctx context.Context, c cid.Cid) (cid.Cid, error)
 	return out, nil
 }
 
-// PublishDraft publishes a draft.
-func (bs *Storage) PublishDraft(ctx context.Context, eid EntityID) (cid.Cid, error) {
+// PublishDraft publishes the draft of the account.
+func (bs *Storage) PublishDraft(ctx context.Context, account core.Principal, eid EntityID) (cid.Cid, error) {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
 		return cid.Undef, err
@@ -294,8 +309,8 @@ func (bs *Storage) PublishDraft(ctx context.Context, eid EntityID) (cid.Cid, err
 	defer release()
 
 	var out cid.Cid
-	if err := sqlitex.WithTx(conn, func() error {
-		res, err := hypersql.DraftsGet(conn, string(eid))
+	if err := bs.withTx(conn, func() error {
+		res, err := hypersql.DraftsGet(conn, string(eid), account)
 		if err != nil {
 			return err
 		}
@@ -316,6 +331,13 @@ func (bs *Storage) PublishDraft(ctx context.Context, eid EntityID) (cid.Cid, err
 			return err
 		}
 
+		if err := hypersql.IndexedBlobsTouch(conn, newID); err != nil {
+			return err
+		}
+
+		// Published blobs become visible to the event listeners.
+		bs.notifier.markPending(conn)
+
 		out = cid.NewCidV1(uint64(res.DraftsViewCodec), res.DraftsViewMultihash)
 
 		return nil
@@ -336,7 +358,13 @@ var qBlobsTouch = dqb.Str(`
 	WHERE id = :oldID;
 `)
 
-func (bs *Storage) DeleteDraft(ctx context.Context, eid EntityID) error {
+// BlobDeletions returns a counter that changes every time blobs are deleted from the storage.
+// Caches built from the stored blobs can compare it to find out they are stale.
+func (bs *Storage) BlobDeletions() int64 {
+	return bs.bs.deletions.Load()
+}
+
+func (bs *Storage) DeleteDraft(ctx context.Context, account core.Principal, eid EntityID) error {
 	conn, release, err := bs.db.Conn(ctx)
 	if err != nil {
 		return err
@@ -344,7 +372,7 @@ func (bs *Storage) DeleteDraft(ctx context.Context, eid EntityID) error {
 	defer release()
 
 	return sqlitex.WithTx(conn, func() error {
-		res, err := hypersql.DraftsGet(conn, string(eid))
+		res, err := hypersql.DraftsGet(conn, string(eid), account)
 		if err != nil {
 			return err
 		}
@@ -378,6 +406,9 @@ func (bs *Storage) DeleteEntity(ctx context.Context, eid EntityID) error {
 	}
 	defer release()
 
+	// The entity could be partially deleted even on failure, so we always report the deletion.
+	defer bs.bs.deletions.Add(1)
+
 	return sqlitex.WithTx(conn, func() error {
 		edb, err := hypersql.EntitiesLookupID(conn, string(eid))
 		if err != nil {
@@ -396,7 +427,7 @@ func (bs *Storage) DeleteEntity(ctx context.Context, eid EntityID) error {
 	})
 }
 
-func (bs *Storage) ReplaceDraftBlob(ctx context.Context, eid EntityID, old cid.Cid, blob Blob) error {
+func (bs *Storage) ReplaceDraftBlob(ctx context.Context, account core.Principal, eid EntityID, old cid.Cid, blob Blob) error {
 	if !old.Defined() {
 		return fmt.Errorf("BUG: can't replace: old CID is not defined")
 	}
@@ -407,7 +438,7 @@ func (bs *Storage) ReplaceDraftBlob(ctx context.Context, eid EntityID, old cid.C
 	}
 	defer release()
 
-	return sqlitex.WithTx(conn, func() error {
+	return bs.withTx(conn, func() error {
 		oldid, err := bs.bs.deleteBlock(conn, old)
 		if err != nil {
 			return err
@@ -437,7 +468,7 @@ func (bs *Storage) ReplaceDraftBlob(ctx context.Context, eid EntityID, old cid.C
 			panic("BUG: replaceDraft: failed to lookup entity after inserting the blob")
 		}
 
-		return hypersql.DraftsInsert(conn, resp.ResourcesID, id)
+		return hypersql.DraftsInsert(conn, account, resp.ResourcesID, id)
 	})
 }
 
@@ -559,6 +590,12 @@ func DecodeBlob(c cid.Cid, data []byte) (hb Blob, err error) {
 				return hb, err
 			}
 			hb.Decoded = v
+		case TypeKeyRevocation:
+			var v KeyRevocation
+			if err := cbornode.DecodeInto(data, &v); err != nil {
+				return hb, err
+			}
+			hb.Decoded = v
 		case TypeChange:
 			var v Change
 			if err := cbornode.DecodeInto(data, &v); err != nil {
@@ -571,6 +608,12 @@ func DecodeBlob(c cid.Cid, data []byte) (hb Blob, err error) {
 				return hb, err
 			}
 			hb.Decoded = v
+		case TypeGroupKey:
+			var v GroupKey
+			if err := cbornode.DecodeInto(data, &v); err != nil {
+				return hb, err
+			}
+			hb.Decoded = v
 		default:
 			return hb, fmt.Errorf("unknown hyper blob type: '%s'", v.Type)
 		}
@@ -586,7 +629,7 @@ func DecodeBlob(c cid.Cid, data []byte) (hb Blob, err error) {
 
 type indexingBlockStore struct {
 	*blockStore
-	indexBlob func(conn *sqlite.Conn, id int64, c cid.Cid, blob any) error
+	indexer *indexer
 }
 
 // The following Get methods are wrapped to make sure
@@ -673,7 +716,7 @@ func (b *indexingBlockStore) Put(ctx context.Context, block blocks.Block) error
 	}
 	defer release()
 
-	return sqlitex.WithTx(conn, func() error {
+	return b.indexer.withTx(conn, func() error {
 		codec, hash := ipfs.DecodeCID(block.Cid())
 		id, exists, err := b.putBlock(conn, 0, codec, hash, block.RawData())
 		if err != nil {
@@ -686,9 +729,9 @@ func (b *indexingBlockStore) Put(ctx context.Context, block blocks.Block) error
 
 		hb, err := DecodeBlob(block.Cid(), block.RawData())
 		if err != nil {
-			return err
+			return fmt.Errorf("%w: %w", ErrInvalidBlob, err)
 		}
-		return b.indexBlob(conn, id, hb.CID, hb.Decoded)
+		return b.indexer.indexBlob(conn, id, hb.CID, hb.Decoded)
 	})
 }
 
@@ -700,7 +743,7 @@ func (b *indexingBlockStore) PutMany(ctx context.Context, blocks []blocks.Block)
 	}
 	defer release()
 
-	return sqlitex.WithTx(conn, func() error {
+	return b.indexer.withTx(conn, func() error {
 		for _, blk := range blocks {
 			codec, hash := ipfs.DecodeCID(blk.Cid())
 			id, exists, err := b.putBlock(conn, 0, codec, hash, blk.RawData())
@@ -714,10 +757,10 @@ func (b *indexingBlockStore) PutMany(ctx context.Context, blocks []blocks.Block)
 
 			hb, err := DecodeBlob(blk.Cid(), blk.RawData())
 			if err != nil {
-				return err
+				return fmt.Errorf("%w: %w", ErrInvalidBlob, err)
 			}
 
-			if err := b.indexBlob(conn, id, hb.CID, hb.Decoded); err != nil {
+			if err := b.indexer.indexBlob(conn, id, hb.CID, hb.Decoded); err != nil {
 				return err
 			}
 		}
@@ -406,11 +406,12 @@ RETURNING public_keys.id AS public_keys_id`
 	return out, err
 }
 
-func SetAccountTrust(conn *sqlite.Conn, publicKeysPrincipal []byte) error {
-	const query = `INSERT OR REPLACE INTO trusted_accounts (id)
-VALUES ((SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :publicKeysPrincipal))`
+func SetAccountTrust(conn *sqlite.Conn, account []byte, publicKeysPrincipal []byte) error {
+	const query = `INSERT OR REPLACE INTO trusted_accounts (account, id)
+VALUES ((SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :account), (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :publicKeysPrincipal))`
 
 	before := func(stmt *sqlite.Stmt) {
+		stmt.SetBytes(":account", account)
 		stmt.SetBytes(":publicKeysPrincipal", publicKeysPrincipal)
 	}
 
@@ -426,11 +427,13 @@ VALUES ((SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :p
 	return err
 }
 
-func UnsetAccountTrust(conn *sqlite.Conn, publicKeysPrincipal []byte) error {
+func UnsetAccountTrust(conn *sqlite.Conn, account []byte, publicKeysPrincipal []byte) error {
 	const query = `DELETE FROM trusted_accounts
-WHERE trusted_accounts.id IN (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :publicKeysPrincipal)`
+WHERE trusted_accounts.account IN (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :account)
+AND trusted_accounts.id IN (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :publicKeysPrincipal)`
 
 	before := func(stmt *sqlite.Stmt) {
+		stmt.SetBytes(":account", account)
 		stmt.SetBytes(":publicKeysPrincipal", publicKeysPrincipal)
 	}
 
@@ -450,14 +453,16 @@ type IsTrustedAccountResult struct {
 	TrustedAccountsID int64
 }
 
-func IsTrustedAccount(conn *sqlite.Conn, principal []byte) (IsTrustedAccountResult, error) {
+func IsTrustedAccount(conn *sqlite.Conn, account []byte, principal []byte) (IsTrustedAccountResult, error) {
 	const query = `SELECT trusted_accounts.id
 FROM trusted_accounts
-WHERE trusted_accounts.id IN (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :principal)`
+WHERE trusted_accounts.account IN (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :account)
+AND trusted_accounts.id IN (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :principal)`
 
 	var out IsTrustedAccountResult
 
 	before := func(stmt *sqlite.Stmt) {
+		stmt.SetBytes(":account", account)
 		stmt.SetBytes(":principal", principal)
 	}
 
@@ -484,10 +489,11 @@ type KeyDelegationsListResult struct {
 	KeyDelegationsViewBlobMultihash []byte
 	KeyDelegationsViewIssuer        []byte
 	KeyDelegationsViewDelegate      []byte
+	KeyDelegationsViewNotAfter      int64
 }
 
 func KeyDelegationsList(conn *sqlite.Conn, keyDelegationsViewIssuer []byte) ([]KeyDelegationsListResult, error) {
-	const query = `SELECT key_delegations_view.blob, key_delegations_view.blob_codec, key_delegations_view.blob_multihash, key_delegations_view.issuer, key_delegations_view.delegate
+	const query = `SELECT key_delegations_view.blob, key_delegations_view.blob_codec, key_delegations_view.blob_multihash, key_delegations_view.issuer, key_delegations_view.delegate, key_delegations_view.not_after
 FROM key_delegations_view
 WHERE key_delegations_view.issuer = :keyDelegationsViewIssuer`
 
@@ -504,6 +510,7 @@ WHERE key_delegations_view.issuer = :keyDelegationsViewIssuer`
 			KeyDelegationsViewBlobMultihash: stmt.ColumnBytes(2),
 			KeyDelegationsViewIssuer:        stmt.ColumnBytes(3),
 			KeyDelegationsViewDelegate:      stmt.ColumnBytes(4),
+			KeyDelegationsViewNotAfter:      stmt.ColumnInt64(5),
 		})
 
 		return nil
@@ -886,11 +893,13 @@ type ChangesListFromChangeSetResult struct {
 	StructuralBlobsViewTs         int64
 	StructuralBlobsViewMultihash  []byte
 	StructuralBlobsViewSize       int64
+	IsUnauthorized                int64
 }
 
 func ChangesListFromChangeSet(conn *sqlite.Conn, cset string, structuralBlobsViewResource string) ([]ChangesListFromChangeSetResult, error) {
-	const query = `SELECT structural_blobs_view.blob_id, structural_blobs_view.codec, structural_blobs_view.data, structural_blobs_view.resource_id, structural_blobs_view.ts, structural_blobs_view.multihash, structural_blobs_view.size
+	const query = `SELECT structural_blobs_view.blob_id, structural_blobs_view.codec, structural_blobs_view.data, structural_blobs_view.resource_id, structural_blobs_view.ts, structural_blobs_view.multihash, structural_blobs_view.size, unauthorized_changes.id IS NOT NULL AS is_unauthorized
 FROM structural_blobs_view, json_each(:cset) AS cset
+LEFT JOIN unauthorized_changes ON unauthorized_changes.id = structural_blobs_view.blob_id
 WHERE structural_blobs_view.resource = :structuralBlobsViewResource
 AND structural_blobs_view.blob_id = cset.value
 ORDER BY structural_blobs_view.ts`
@@ -911,6 +920,7 @@ ORDER BY structural_blobs_view.ts`
 			StructuralBlobsViewTs:         stmt.ColumnInt64(4),
 			StructuralBlobsViewMultihash:  stmt.ColumnBytes(5),
 			StructuralBlobsViewSize:       stmt.ColumnInt64(6),
+			IsUnauthorized:                stmt.ColumnInt64(7),
 		})
 
 		return nil
@@ -1193,11 +1203,12 @@ AND resource_links.target = :resourceLinksTarget`
 	return out, err
 }
 
-func DraftsInsert(conn *sqlite.Conn, draftsResource int64, draftsBlob int64) error {
-	const query = `INSERT INTO drafts (resource, blob)
-VALUES (:draftsResource, :draftsBlob)`
+func DraftsInsert(conn *sqlite.Conn, account []byte, draftsResource int64, draftsBlob int64) error {
+	const query = `INSERT INTO drafts (account, resource, blob)
+VALUES ((SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :account), :draftsResource, :draftsBlob)`
 
 	before := func(stmt *sqlite.Stmt) {
+		stmt.SetBytes(":account", account)
 		stmt.SetInt64(":draftsResource", draftsResource)
 		stmt.SetInt64(":draftsBlob", draftsBlob)
 	}
@@ -1222,16 +1233,18 @@ type DraftsGetResult struct {
 	DraftsViewMultihash  []byte
 }
 
-func DraftsGet(conn *sqlite.Conn, draftsViewResource string) (DraftsGetResult, error) {
+func DraftsGet(conn *sqlite.Conn, draftsViewResource string, account []byte) (DraftsGetResult, error) {
 	const query = `SELECT drafts_view.blob_id, drafts_view.codec, drafts_view.resource, drafts_view.resource_id, drafts_view.multihash
 FROM drafts_view
 WHERE drafts_view.resource = :draftsViewResource
+AND drafts_view.account_id IN (SELECT public_keys.id FROM public_keys WHERE public_keys.principal = :account)
 LIMIT 1`
 
 	var out DraftsGetResult
 
 	before := func(stmt *sqlite.Stmt) {
 		stmt.SetText(":draftsViewResource", draftsViewResource)
+		stmt.SetBytes(":account", account)
 	}
 
 	onStep := func(i int, stmt *sqlite.Stmt) error {
@@ -130,9 +130,15 @@ func generateQueries() error {
 
 		qb.MakeQuery(s.Schema, "SetAccountTrust", sgen.QueryKindExec,
 			"INSERT OR REPLACE INTO", s.TrustedAccounts, qb.ListColShort(
+				s.TrustedAccountsAccount,
 				s.TrustedAccountsID,
 			), '\n',
 			"VALUES", qb.List(
+				qb.SubQuery(
+					"SELECT", s.PublicKeysID,
+					"FROM", s.PublicKeys,
+					"WHERE", s.PublicKeysPrincipal, "=", qb.Var("account", sgen.TypeBytes),
+				),
 				qb.SubQuery(
 					"SELECT", s.PublicKeysID,
 					"FROM", s.PublicKeys,
@@ -142,7 +148,12 @@ func generateQueries() error {
 		),
 		qb.MakeQuery(s.Schema, "UnsetAccountTrust", sgen.QueryKindExec,
 			"DELETE FROM", s.TrustedAccounts, '\n',
-			"WHERE", s.TrustedAccountsID, "IN", qb.SubQuery(
+			"WHERE", s.TrustedAccountsAccount, "IN", qb.SubQuery(
+				"SELECT", s.PublicKeysID,
+				"FROM", s.PublicKeys,
+				"WHERE", s.PublicKeysPrincipal, "=", qb.Var("account", sgen.TypeBytes),
+			), '\n',
+			"AND", s.TrustedAccountsID, "IN", qb.SubQuery(
 				"SELECT", s.PublicKeysID,
 				"FROM", s.PublicKeys,
 				"WHERE", s.PublicKeysPrincipal, "=", qb.VarCol(s.PublicKeysPrincipal),
@@ -153,7 +164,12 @@ func generateQueries() error {
 				s.TrustedAccountsID,
 			), '\n',
 			"FROM", s.TrustedAccounts, '\n',
-			"WHERE", s.TrustedAccountsID, "IN", qb.SubQuery(
+			"WHERE", s.TrustedAccountsAccount, "IN", qb.SubQuery(
+				"SELECT", s.PublicKeysID,
+				"FROM", s.PublicKeys,
+				"WHERE", s.PublicKeysPrincipal, "=", qb.Var("account", sgen.TypeBytes),
+			), '\n',
+			"AND", s.TrustedAccountsID, "IN", qb.SubQuery(
 				"SELECT", s.PublicKeysID,
 				"FROM", s.PublicKeys,
 				"WHERE", s.PublicKeysPrincipal, "=", qb.Var("principal", sgen.TypeBytes),
@@ -166,6 +182,7 @@ func generateQueries() error {
 				s.KeyDelegationsViewBlobMultihash,
 				s.KeyDelegationsViewIssuer,
 				s.KeyDelegationsViewDelegate,
+				s.KeyDelegationsViewNotAfter,
 			), '\n',
 			"FROM", s.KeyDelegationsView, '\n',
 			"WHERE", s.KeyDelegationsViewIssuer, "=", qb.VarCol(s.KeyDelegationsViewIssuer),
@@ -279,8 +296,10 @@ func generateQueries() error {
 				s.StructuralBlobsViewTs,
 				s.StructuralBlobsViewMultihash,
 				s.StructuralBlobsViewSize,
+				qb.ResultRaw(s.C_UnauthorizedChangesID+" IS NOT NULL AS is_unauthorized", "is_unauthorized", sgen.TypeInt),
 			), '\n',
 			"FROM", qb.Concat(s.StructuralBlobsView, ", ", "json_each(", qb.Var("cset", sgen.TypeText), ") AS cset"), '\n',
+			"LEFT JOIN", s.UnauthorizedChanges, "ON", s.UnauthorizedChangesID, "=", s.StructuralBlobsViewBlobID, '\n',
 			"WHERE", s.StructuralBlobsViewResource, "=", qb.VarColType(s.StructuralBlobsViewResource, sgen.TypeText), '\n',
 			"AND", s.StructuralBlobsViewBlobID, "= cset.value", '\n',
 			"ORDER BY", s.StructuralBlobsViewTs,
@@ -395,10 +414,16 @@ WHERE blob NOT IN deps`,
 
 		qb.MakeQuery(s.Schema, "DraftsInsert", sgen.QueryKindExec,
 			"INSERT INTO", s.Drafts, qb.ListColShort(
+				s.DraftsAccount,
 				s.DraftsResource,
 				s.DraftsBlob,
 			), '\n',
 			"VALUES", qb.List(
+				qb.SubQuery(
+					"SELECT", s.PublicKeysID,
+					"FROM", s.PublicKeys,
+					"WHERE", s.PublicKeysPrincipal, "=", qb.Var("account", sgen.TypeBytes),
+				),
 				qb.VarCol(s.DraftsResource),
 				qb.VarCol(s.DraftsBlob),
 			),
@@ -413,6 +438,11 @@ WHERE blob NOT IN deps`,
 			), '\n',
 			"FROM", s.DraftsView, '\n',
 			"WHERE", s.DraftsViewResource, "=", qb.VarColType(s.DraftsViewResource, sgen.TypeText), '\n',
+			"AND", s.DraftsViewAccountID, "IN", qb.SubQuery(
+				"SELECT", s.PublicKeysID,
+				"FROM", s.PublicKeys,
+				"WHERE", s.PublicKeysPrincipal, "=", qb.Var("account", sgen.TypeBytes),
+			), '\n',
 			"LIMIT 1",
 		),
 		qb.MakeQuery(s.Schema, "DraftsDelete", sgen.QueryKindExec,
@@ -139,6 +139,27 @@ var qStructuralBlobsInsert = dqb.Str(`
 	VALUES (?, ?, ?, ?, ?, ?);
 `)
 
+// IndexedBlobsInsertOrIgnore appends the blob to the sequence of indexed blobs,
+// unless it's already there, e.g. when reindexing.
+func IndexedBlobsInsertOrIgnore(conn *sqlite.Conn, id int64) error {
+	return sqlitex.Exec(conn, qIndexedBlobsInsertOrIgnore(), nil, id)
+}
+
+var qIndexedBlobsInsertOrIgnore = dqb.Str(`
+	INSERT OR IGNORE INTO indexed_blobs (id)
+	VALUES (?);
+`)
+
+// IndexedBlobsTouch moves the structural blob to the end of the sequence of indexed blobs.
+func IndexedBlobsTouch(conn *sqlite.Conn, id int64) error {
+	return sqlitex.Exec(conn, qIndexedBlobsTouch(), nil, id)
+}
+
+var qIndexedBlobsTouch = dqb.Str(`
+	INSERT OR REPLACE INTO indexed_blobs (id)
+	SELECT id FROM structural_blobs WHERE id = ?;
+`)
+
 // ResourcesMaybeSetOwner sets the owner of a resource if it's not set.
 func ResourcesMaybeSetOwner(conn *sqlite.Conn, id, owner int64) (updated bool, err error) {
 	if id == 0 {
@@ -209,7 +230,8 @@ var qResourcesUpdateMetadata = dqb.Str(`
 `)
 
 // KeyDelegationsInsertOrIgnore inserts a key delegation.
-func KeyDelegationsInsertOrIgnore(conn *sqlite.Conn, id, issuer, delegate int64) error {
+// Not after is the expiration Unix timestamp in seconds, or 0 if the delegation never expires.
+func KeyDelegationsInsertOrIgnore(conn *sqlite.Conn, id, issuer, delegate, notAfter int64) error {
 	if id == 0 {
 		return fmt.Errorf("must have ID")
 	}
@@ -222,10 +244,105 @@ func KeyDelegationsInsertOrIgnore(conn *sqlite.Conn, id, issuer, delegate int64)
 		return fmt.Errorf("must have delegate ID")
 	}
 
-	return sqlitex.Exec(conn, qKeyDelegationsInsert(), nil, id, issuer, delegate)
+	return sqlitex.Exec(conn, qKeyDelegationsInsert(), nil, id, issuer, delegate, notAfter)
+}
+
+var qKeyDelegationsInsert = dqb.Str(`INSERT OR IGNORE INTO key_delegations (id, issuer, delegate, not_after) VALUES (?, ?, ?, ?);`)
+
+// KeyDelegationsGet returns the issuer, the delegate and the expiration time of a key delegation by its multihash.
+// Returns zero values if the delegation is not indexed.
+func KeyDelegationsGet(conn *sqlite.Conn, multihash []byte) (issuer, delegate, notAfter int64, err error) {
+	err = sqlitex.Exec(conn, qKeyDelegationsGet(), func(stmt *sqlite.Stmt) error {
+		issuer = stmt.ColumnInt64(0)
+		delegate = stmt.ColumnInt64(1)
+		notAfter = stmt.ColumnInt64(2)
+		return nil
+	}, multihash)
+	return issuer, delegate, notAfter, err
 }
 
-var qKeyDelegationsInsert = dqb.Str(`INSERT OR IGNORE INTO key_delegations (id, issuer, delegate) VALUES (?, ?, ?);`)
+var qKeyDelegationsGet = dqb.Str(`
+	SELECT
+		key_delegations.issuer,
+		key_delegations.delegate,
+		key_delegations.not_after
+	FROM key_delegations
+	JOIN blobs INDEXED BY blobs_metadata_by_hash ON blobs.id = key_delegations.id
+	WHERE blobs.multihash = :multihash
+	LIMIT 1;
+`)
+
+// KeyRevocationsInsertOrIgnore inserts a key revocation.
+func KeyRevocationsInsertOrIgnore(conn *sqlite.Conn, id, issuer, delegate, revokeTime int64) error {
+	if id == 0 {
+		return fmt.Errorf("must have ID")
+	}
+
+	if issuer == 0 {
+		return fmt.Errorf("must have issuer ID")
+	}
+
+	if delegate == 0 {
+		return fmt.Errorf("must have delegate ID")
+	}
+
+	return sqlitex.Exec(conn, qKeyRevocationsInsert(), nil, id, issuer, delegate, revokeTime)
+}
+
+var qKeyRevocationsInsert = dqb.Str(`INSERT OR IGNORE INTO key_revocations (id, issuer, delegate, revoke_time) VALUES (?, ?, ?, ?);`)
+
+// UnauthorizedChangesInsertOrIgnore marks the change as unauthorized.
+func UnauthorizedChangesInsertOrIgnore(conn *sqlite.Conn, id int64) error {
+	if id == 0 {
+		return fmt.Errorf("must have ID")
+	}
+
+	return sqlitex.Exec(conn, qUnauthorizedChangesInsert(), nil, id)
+}
+
+var qUnauthorizedChangesInsert = dqb.Str(`INSERT OR IGNORE INTO unauthorized_changes (id) VALUES (?);`)
+
+// KeyRevocationsGetRevokeTime returns the earliest revocation time of the delegate key by the issuer,
+// and the ID of the earliest stored revocation blob, i.e. the moment we've learned about the revocation.
+// Both are 0 if the key is not revoked.
+func KeyRevocationsGetRevokeTime(conn *sqlite.Conn, issuer, delegate int64) (revokeTime, revocationID int64, err error) {
+	if err := sqlitex.Exec(conn, qKeyRevocationsGetRevokeTime(), func(stmt *sqlite.Stmt) error {
+		revokeTime = stmt.ColumnInt64(0)
+		revocationID = stmt.ColumnInt64(1)
+		return nil
+	}, issuer, delegate); err != nil {
+		return 0, 0, err
+	}
+
+	return revokeTime, revocationID, nil
+}
+
+var qKeyRevocationsGetRevokeTime = dqb.Str(`
+	SELECT
+		MIN(revoke_time),
+		MIN(id)
+	FROM key_revocations
+	WHERE issuer = :issuer
+	AND delegate = :delegate;
+`)
+
+// KeyRevocationsList lists the earliest revocation time for each of the revoked delegates of the issuer.
+func KeyRevocationsList(conn *sqlite.Conn, issuer []byte, fn func(delegate []byte, revokeTime int64) error) error {
+	return sqlitex.Exec(conn, qKeyRevocationsList(), func(stmt *sqlite.Stmt) error {
+		return fn(stmt.ColumnBytes(0), stmt.ColumnInt64(1))
+	}, issuer)
+}
+
+var qKeyRevocationsList = dqb.Str(`
+	SELECT
+		del.principal AS delegate,
+		MIN(kr.revoke_time) AS revoke_time
+	FROM key_revocations kr
+	JOIN public_keys iss ON iss.id = kr.issuer
+	JOIN public_keys del ON del.id = kr.delegate
+	WHERE iss.principal = :issuer
+	GROUP BY kr.delegate;
+`)
 
 // ResourceLinksInsert inserts a resource link.
 func ResourceLinksInsert(conn *sqlite.Conn, sourceBlob, targetResource int64, ltype string, isPinned bool, meta []byte) error {
@@ -237,13 +354,15 @@ var qResourceLinksInsert = dqb.Str(`
 	VALUES (?, ?, ?, ?, ?);
 `)
 
-// GroupListMembers lists all the members of a group.
-func GroupListMembers(conn *sqlite.Conn, resource, owner int64, fn func(principal []byte, role int64) error) error {
+// GroupListMembers lists all the member updates of a group in the order they were made,
+// so the last role of each member is the current one.
+// Member updates are only indexed for changes made by the owner or admins of the group.
+func GroupListMembers(conn *sqlite.Conn, resource int64, fn func(principal []byte, role int64) error) error {
 	return sqlitex.Exec(conn, qGroupListMembers(), func(stmt *sqlite.Stmt) error {
 		principal := stmt.ColumnBytes(0)
 		role := stmt.ColumnInt64(1)
 		return fn(principal, role)
-	}, resource, owner)
+	}, resource)
 }
 
 var qGroupListMembers = dqb.Str(`
@@ -255,6 +374,187 @@ var qGroupListMembers = dqb.Str(`
 	JOIN resources ON resources.id = resource_links.target
 	JOIN public_keys ON public_keys.id = resources.owner
 	WHERE structural_blobs.resource = :group
-	AND structural_blobs.author = :owner
-	AND resource_links.type = 'group/member';
+	AND resource_links.type = 'group/member'
+	ORDER BY structural_blobs.ts;
+`)
+
+// GroupKeysInsertOrIgnore inserts the GroupKey blob distributing the key with the given ID for the group.
+func GroupKeysInsertOrIgnore(conn *sqlite.Conn, id, group int64, keyID string) error {
+	return sqlitex.Exec(conn, qGroupKeysInsert(), nil, id, group, keyID)
+}
+
+var qGroupKeysInsert = dqb.Str(`INSERT OR IGNORE INTO group_keys (id, group_id, key_id) VALUES (?, ?, ?);`)
+
+// GroupKeysList lists all the GroupKey blobs distributing the key with the given ID for the group.
+func GroupKeysList(conn *sqlite.Conn, group, keyID string, fn func(codec int64, hash []byte) error) error {
+	return sqlitex.Exec(conn, qGroupKeysList(), func(stmt *sqlite.Stmt) error {
+		return fn(stmt.ColumnInt64(0), stmt.ColumnBytes(1))
+	}, group, keyID)
+}
+
+var qGroupKeysList = dqb.Str(`
+	SELECT
+		blobs.codec,
+		blobs.multihash
+	FROM group_keys
+	JOIN resources ON resources.id = group_keys.group_id
+	JOIN blobs ON blobs.id = group_keys.id
+	WHERE resources.iri = :group
+	AND group_keys.key_id = :key
+	ORDER BY group_keys.id DESC;
+`)
+
+// GroupKeysGetCurrent returns the ID of the latest key created for the group,
+// or empty string if the group has no keys.
+// Keys can be distributed multiple times, so keys are ordered by the time they were first distributed.
+func GroupKeysGetCurrent(conn *sqlite.Conn, group string) (string, error) {
+	var keyID string
+	if err := sqlitex.Exec(conn, qGroupKeysGetCurrent(), func(stmt *sqlite.Stmt) error {
+		keyID = stmt.ColumnText(0)
+		return nil
+	}, group); err != nil {
+		return "", err
+	}
+
+	return keyID, nil
+}
+
+var qGroupKeysGetCurrent = dqb.Str(`
+	SELECT
+		group_keys.key_id,
+		MIN(structural_blobs.ts) AS create_time
+	FROM group_keys
+	JOIN resources ON resources.id = group_keys.group_id
+	JOIN structural_blobs ON structural_blobs.id = group_keys.id
+	WHERE resources.iri = :group
+	GROUP BY group_keys.key_id
+	ORDER BY create_time DESC
+	LIMIT 1;
+`)
+
+// GroupKeysListIDs returns the IDs of all the keys created for the group, from the oldest to the latest.
+func GroupKeysListIDs(conn *sqlite.Conn, group string) ([]string, error) {
+	var out []string
+	if err := sqlitex.Exec(conn, qGroupKeysListIDs(), func(stmt *sqlite.Stmt) error {
+		out = append(out, stmt.ColumnText(0))
+		return nil
+	}, group); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var qGroupKeysListIDs = dqb.Str(`
+	SELECT
+		group_keys.key_id,
+		MIN(structural_blobs.ts) AS create_time
+	FROM group_keys
+	JOIN resources ON resources.id = group_keys.group_id
+	JOIN structural_blobs ON structural_blobs.id = group_keys.id
+	WHERE resources.iri = :group
+	GROUP BY group_keys.key_id
+	ORDER BY create_time;
+`)
+
+// PrivateBlobsInsertOrIgnore marks the blob as having private content of the group.
+func PrivateBlobsInsertOrIgnore(conn *sqlite.Conn, id, group int64) error {
+	return sqlitex.Exec(conn, qPrivateBlobsInsert(), nil, id, group)
+}
+
+var qPrivateBlobsInsert = dqb.Str(`INSERT OR IGNORE INTO private_blobs (id, group_id) VALUES (?, ?);`)
+
+// PrivateBlobsGetGroupID returns the ID of the group the private blob belongs to,
+// or 0 if the blob is public.
+func PrivateBlobsGetGroupID(conn *sqlite.Conn, id int64) (int64, error) {
+	var group int64
+	if err := sqlitex.Exec(conn, qPrivateBlobsGetGroupID(), func(stmt *sqlite.Stmt) error {
+		group = stmt.ColumnInt64(0)
+		return nil
+	}, id); err != nil {
+		return 0, err
+	}
+
+	return group, nil
+}
+
+var qPrivateBlobsGetGroupID = dqb.Str(`SELECT group_id FROM private_blobs WHERE id = ?;`)
+
+// PrivateBlobsGetResourceGroup returns the IRI of the group the content of the resource is private to,
+// or empty string if the resource is public.
+func PrivateBlobsGetResourceGroup(conn *sqlite.Conn, resource string) (string, error) {
+	var group string
+	if err := sqlitex.Exec(conn, qPrivateBlobsGetResourceGroup(), func(stmt *sqlite.Stmt) error {
+		group = stmt.ColumnText(0)
+		return nil
+	}, resource); err != nil {
+		return "", err
+	}
+
+	return group, nil
+}
+
+var qPrivateBlobsGetResourceGroup = dqb.Str(`
+	SELECT grp.iri
+	FROM private_blobs
+	JOIN structural_blobs ON structural_blobs.id = private_blobs.id
+	JOIN resources ON resources.id = structural_blobs.resource
+	JOIN resources grp ON grp.id = private_blobs.group_id
+	WHERE resources.iri = :resource
+	LIMIT 1;
+`)
+
+// GroupHasMemberDevice checks whether the device belongs to the owner or one of the current members of the group.
+// Devices with revoked or expired delegations are not considered.
+func GroupHasMemberDevice(conn *sqlite.Conn, group int64, device []byte) (bool, error) {
+	var ok bool
+	if err := sqlitex.Exec(conn, qGroupHasMemberDevice(), func(stmt *sqlite.Stmt) error {
+		ok = stmt.ColumnInt(0) != 0
+		return nil
+	}, device, group); err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+var qGroupHasMemberDevice = dqb.Str(`
+	WITH
+	accounts (id) AS (
+		SELECT kd.issuer
+		FROM key_delegations kd
+		JOIN public_keys del ON del.id = kd.delegate
+		WHERE del.principal = :device
+		AND (kd.not_after = 0 OR kd.not_after > CAST(strftime('%s', 'now') AS INTEGER))
+		AND NOT EXISTS (
+			SELECT 1
+			FROM key_revocations kr
+			WHERE kr.issuer = kd.issuer
+			AND kr.delegate = kd.delegate
+		)
+	),
+	roles (account, role, ts) AS (
+		SELECT
+			resources.owner,
+			resource_links.meta->>'r',
+			MAX(structural_blobs.ts)
+		FROM structural_blobs
+		JOIN resource_links ON resource_links.source = structural_blobs.id
+		JOIN resources ON resources.id = resource_links.target
+		WHERE structural_blobs.resource = :group
+		AND resource_links.type = 'group/member'
+		AND structural_blobs.id NOT IN (SELECT id FROM unauthorized_changes)
+		GROUP BY resources.owner
+	)
+	SELECT EXISTS (
+		SELECT 1
+		FROM resources
+		WHERE resources.id = :group
+		AND resources.owner IN accounts
+	) OR EXISTS (
+		SELECT 1
+		FROM roles
+		WHERE roles.account IN accounts
+		AND roles.role > 0
+	);
 `)
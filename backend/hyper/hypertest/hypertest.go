@@ -0,0 +1,39 @@
+// Package hypertest provides testing utilities for hyper entities.
+package hypertest
+
+import (
+	"mintter/backend/core"
+	"mintter/backend/hlc"
+	"mintter/backend/hyper"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+)
+
+// NewEntity creates a new entity with the unforgeable ID under the given prefix (e.g. hm://d/ or hm://g/)
+// owned by the account of me, and the change that brings it into life with the given patch.
+// The nonce, createTime, and owner fields are added to the patch automatically.
+// The returned blob is not stored anywhere.
+func NewEntity(t testing.TB, me core.Identity, delegation cid.Cid, prefix string, patch map[string]any) (*hyper.Entity, hyper.Blob) {
+	t.Helper()
+
+	clock := hlc.NewClock()
+	ts := clock.MustNow()
+	createTime := ts.Time().Unix()
+
+	id, nonce := hyper.NewUnforgeableID(prefix, me.Account().Principal(), nil, createTime)
+	e := hyper.NewEntityWithClock(hyper.EntityID(id), clock)
+
+	if patch == nil {
+		patch = make(map[string]any, 3)
+	}
+	patch["nonce"] = nonce
+	patch["createTime"] = int(createTime)
+	patch["owner"] = []byte(me.Account().Principal())
+
+	blob, err := e.CreateChange(ts, me.DeviceKey(), delegation, patch, hyper.WithAction(hyper.ActionCreate))
+	require.NoError(t, err)
+
+	return e, blob
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"mintter/backend/core"
 	"mintter/backend/daemon/storage"
@@ -31,9 +32,12 @@ import (
 const idPrefixLen = len("hm://x/") // common prefix length for all the Entities with unforgeable IDs.
 
 type indexer struct {
-	db  *sqlitex.Pool
-	log *zap.Logger
-	bs  *blockStore
+	db       *sqlitex.Pool
+	log      *zap.Logger
+	bs       *blockStore
+	notifier *indexNotifier
+	texts    *textIndex
+	keys     *keyring
 }
 
 // Reindex forces deletes all the information derived from the blobs and reindexes them.
@@ -58,17 +62,24 @@ func (bs *indexer) reindex(conn *sqlite.Conn) (err error) {
 	derivedTables := []string{
 		storage.T_BlobLinks,
 		storage.T_ResourceLinks,
+		storage.T_FtsIndex,
+		storage.T_UnauthorizedChanges,
+		storage.T_PendingChanges,
+		storage.T_GroupKeys,
+		storage.T_PrivateBlobs,
 		storage.T_StructuralBlobs,
 		storage.T_GroupSites,
 		storage.T_KeyDelegations,
+		storage.T_KeyRevocations,
 		// Not deleting from resources yet, because they are referenced in the drafts table,
 		// and we can't yet reconstruct the drafts table purely from the blobs.
 		// storage.T_Resources,
+		// Not deleting from indexed_blobs, to keep the order in which the blobs were indexed.
 	}
 
 	const q = "SELECT * FROM " + storage.T_Blobs
 
-	if err := sqlitex.WithTx(conn, func() error {
+	if err := bs.withTx(conn, func() error {
 		for _, table := range derivedTables {
 			if err := sqlitex.ExecTransient(conn, "DELETE FROM "+table, nil); err != nil {
 				return err
@@ -141,22 +152,45 @@ func (bs *indexer) MaybeReindex(ctx context.Context) error {
 	return nil
 }
 
+// ErrInvalidBlob is returned when a blob is rejected during indexing, because it's not valid,
+// e.g. it's signed with a revoked or expired key. Unlike other failures, these are permanent:
+// storing the same blob again would fail in the same way.
+var ErrInvalidBlob = errors.New("invalid blob")
+
+// invalidf returns an error for blobs that fail verification, e.g. with a bad signature or structure.
+// Blobs that fail because of something we don't have yet, like the key delegation or the private group,
+// must not use it, because storing them again later could succeed.
+func invalidf(format string, args ...any) error {
+	return fmt.Errorf("%w: %w", ErrInvalidBlob, fmt.Errorf(format, args...))
+}
+
 // indexBlob is an uber-function that knows about all types of blobs we want to index.
 // This is probably a bad idea to put here, but for now it's easier to work with that way.
 // TODO(burdiyan): eventually we might want to make this package agnostic to blob types.
-func (bs *indexer) indexBlob(conn *sqlite.Conn, id int64, c cid.Cid, blobData any) error {
+func (bs *indexer) indexBlob(conn *sqlite.Conn, id int64, c cid.Cid, blobData any) (err error) {
 	idx := newCtx(conn)
 
 	switch v := blobData.(type) {
 	case ipld.Node:
-		return bs.indexDagPB(idx, id, c, v)
+		err = bs.indexDagPB(idx, id, c, v)
 	case KeyDelegation:
-		return bs.indexKeyDelegation(idx, id, c, v)
+		err = bs.indexKeyDelegation(idx, id, c, v)
+	case KeyRevocation:
+		err = bs.indexKeyRevocation(idx, id, c, v)
 	case Change:
-		return bs.indexChange(idx, id, c, v)
+		err = bs.indexOrDefer(conn, id, c, v.Entity, v)
 	case Comment:
-		return bs.indexComment(idx, id, c, v)
+		err = bs.indexComment(idx, id, c, v)
+	case GroupKey:
+		err = bs.indexOrDefer(conn, id, c, v.Group, v)
+	default:
+		return nil
 	}
+	if err != nil {
+		return err
+	}
+
+	bs.notifier.markPending(conn)
 
 	return nil
 }
@@ -172,7 +206,7 @@ func (bs *indexer) indexDagPB(idx *indexingCtx, id int64, c cid.Cid, v ipld.Node
 
 		target, ok := pblink.Hash.Link().(cidlink.Link)
 		if !ok {
-			return fmt.Errorf("link is not CID: %v", pblink.Hash)
+			return invalidf("link is not CID: %v", pblink.Hash)
 		}
 
 		linkType := "dagpb/chunk"
@@ -195,15 +229,15 @@ func (bs *indexer) indexKeyDelegation(idx *indexingCtx, id int64, c cid.Cid, v K
 	// Validate key delegation.
 	{
 		if v.Purpose != DelegationPurposeRegistration {
-			return fmt.Errorf("unknown key delegation purpose %q", v.Purpose)
+			return invalidf("unknown key delegation purpose %q", v.Purpose)
 		}
 
 		if _, err := v.Issuer.Libp2pKey(); err != nil {
-			return fmt.Errorf("key delegation issuer is not a valid libp2p public key: %w", err)
+			return invalidf("key delegation issuer is not a valid libp2p public key: %w", err)
 		}
 
 		if _, err := v.Delegate.Libp2pKey(); err != nil {
-			return fmt.Errorf("key delegation delegate is not a valid libp2p public key: %w", err)
+			return invalidf("key delegation delegate is not a valid libp2p public key: %w", err)
 		}
 	}
 
@@ -223,7 +257,59 @@ func (bs *indexer) indexKeyDelegation(idx *indexingCtx, id int64, c cid.Cid, v K
 		return err
 	}
 
-	if err := hypersql.KeyDelegationsInsertOrIgnore(idx.conn, id, iss, del); err != nil {
+	var notAfter int64
+	if !v.NotAfter.IsZero() {
+		notAfter = v.NotAfter.Unix()
+	}
+
+	if err := hypersql.KeyDelegationsInsertOrIgnore(idx.conn, id, iss, del, notAfter); err != nil {
+		return err
+	}
+
+	return idx.SaveBlob(id, sb)
+}
+
+func (bs *indexer) indexKeyRevocation(idx *indexingCtx, id int64, c cid.Cid, v KeyRevocation) error {
+	// Validate key revocation.
+	{
+		// Revocations disable other keys, so we must never accept one that is not signed by the issuer.
+		if err := v.Verify(); err != nil {
+			return invalidf("failed to verify key revocation %s: %w", c, err)
+		}
+
+		if v.RevokeTime.IsZero() {
+			return invalidf("key revocation must have revoke time")
+		}
+
+		if _, err := v.Issuer.Libp2pKey(); err != nil {
+			return invalidf("key revocation issuer is not a valid libp2p public key: %w", err)
+		}
+
+		if _, err := v.Delegate.Libp2pKey(); err != nil {
+			return invalidf("key revocation delegate is not a valid libp2p public key: %w", err)
+		}
+	}
+
+	issuerProfile := IRI("hm://a/" + v.Issuer.String())
+
+	sb := newStructuralBlob(c, string(TypeKeyRevocation), v.Issuer, v.RevokeTime, issuerProfile, v.Issuer, time.Time{})
+
+	sb.AddResourceLink("kr/issuer", issuerProfile, false, nil)
+
+	iss, err := idx.ensurePubKey(v.Issuer)
+	if err != nil {
+		return err
+	}
+
+	del, err := idx.ensurePubKey(v.Delegate)
+	if err != nil {
+		return err
+	}
+
+	// Blobs signed by the delegate after the revoke time which we've indexed before learning about the revocation
+	// are not removed, because other blobs might already depend on them.
+	// New blobs like that will be rejected by checkDelegation.
+	if err := hypersql.KeyRevocationsInsertOrIgnore(idx.conn, id, iss, del, v.RevokeTime.Unix()); err != nil {
 		return err
 	}
 
@@ -233,6 +319,17 @@ func (bs *indexer) indexKeyDelegation(idx *indexingCtx, id int64, c cid.Cid, v K
 func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change) error {
 	// TODO(burdiyan): ensure there's only one change that brings an entity into life.
 
+	// Deps must be indexed first, because validating the change may need the state of the entity at its deps.
+	for _, dep := range v.Deps {
+		ok, err := idx.isChangeIndexed(dep)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: dep %s of change %s is not indexed", errMissingDeps, dep, c)
+		}
+	}
+
 	// Extracting author from the associated key delegation.
 	// TODO(burdiyan): need to improve this part, because it's kinda ugly.
 	// TODO(burdiyan): must verify the key delegation to make sure device really belongs to the account.
@@ -241,6 +338,20 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 		return err
 	}
 
+	if err := bs.checkDelegation(idx, id, v.Delegation, v.HLCTime.Time()); err != nil {
+		if !errors.Is(err, errNotVouched) {
+			return fmt.Errorf("change %s is not authorized: %w", c, err)
+		}
+
+		ok, verr := bs.isVouched(idx.conn, c, v.Entity)
+		if verr != nil {
+			return verr
+		}
+		if !ok {
+			return fmt.Errorf("change %s is not authorized: %w", c, err)
+		}
+	}
+
 	// Validate semantic meaning of Create/Update changes.
 	{
 		isHMEntity := v.Entity.HasPrefix("hm://")
@@ -249,50 +360,88 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 		// We want to ensure all changes to have action, but historically
 		// we've been creating Account-related changes without one, so we continue to allow that.
 		case isHMEntity && v.Action == "" && !isAccountChange:
-			return fmt.Errorf("non-account change %s must have an action specified", c)
+			return invalidf("non-account change %s must have an action specified", c)
 
 		// Changes with create action must have correct unforgeable ID and fields in their patch to validate it.
 		case isHMEntity && v.Action == ActionCreate:
 			nonce, ok := v.Patch["nonce"].([]byte)
 			if !ok {
-				return fmt.Errorf("change that creates an entity must have a nonce to verify the ID")
+				return invalidf("change that creates an entity must have a nonce to verify the ID")
 			}
 
 			ct, ok := v.Patch["createTime"].(int)
 			if !ok {
-				return fmt.Errorf("change that creates an entity must have a createTime field in its patch")
+				return invalidf("change that creates an entity must have a createTime field in its patch")
 			}
 
 			ownerField, ok := v.Patch["owner"].([]byte)
 			if !ok {
-				return fmt.Errorf("change that creates an entity must have an owner field in its patch")
+				return invalidf("change that creates an entity must have an owner field in its patch")
 			}
 
 			if !bytes.Equal(ownerField, author) {
-				return fmt.Errorf("owner field in the create change must correspond with the author of the change")
+				return invalidf("owner field in the create change must correspond with the author of the change")
 			}
 
 			if err := verifyUnforgeableID(v.Entity, idPrefixLen, ownerField, nonce, int64(ct)); err != nil {
-				return err
+				return invalidf("change %s has invalid entity ID: %w", c, err)
 			}
 
 		// Changes that are updates must not have any fields for verifying unforgeable IDs,
 		// and they must have at least one dep.
 		case isHMEntity && v.Action == ActionUpdate:
 			if len(v.Deps) == 0 {
-				return fmt.Errorf("change with Update action must have at least one dep")
+				return invalidf("change with Update action must have at least one dep")
 			}
 
 			if v.Patch["nonce"] != nil {
-				return fmt.Errorf("update change must not have nonce set")
+				return invalidf("update change must not have nonce set")
 			}
 
 			if v.Patch["owner"] != nil {
-				return fmt.Errorf("update change must not have owner field")
+				return invalidf("update change must not have owner field")
 			}
 
 			if v.Patch["createTime"] != nil {
-				return fmt.Errorf("update change must not have createTime field")
+				return invalidf("update change must not have createTime field")
+			}
+		}
+	}
+
+	// Content of documents in private groups must be sealed with the content key of the group.
+	var privateGroup EntityID
+	{
+		if v.Sealed != nil && !v.Entity.HasPrefix("hm://d/") {
+			return invalidf("only document changes can be sealed, got change %s for entity %s", c, v.Entity)
+		}
+
+		if v.Entity.HasPrefix("hm://d/") {
+			if v.Action == ActionCreate {
+				if group, ok := v.Patch["privateGroup"].(string); ok {
+					privateGroup = EntityID(group)
+				}
+			} else {
+				group, err := hypersql.PrivateBlobsGetResourceGroup(idx.conn, string(v.Entity))
+				if err != nil {
+					return err
+				}
+				privateGroup = EntityID(group)
+
+				if v.Patch["privateGroup"] != nil {
+					return invalidf("update change must not have privateGroup field")
+				}
+			}
+
+			if privateGroup != "" && !privateGroup.HasPrefix("hm://g/") {
+				return invalidf("private group of the document must be a group, got %s", privateGroup)
+			}
+
+			if privateGroup != "" && (v.Sealed == nil || v.Sealed.Group != privateGroup) {
+				return invalidf("changes of private documents must be sealed for the group %s", privateGroup)
+			}
+
+			if privateGroup == "" && v.Sealed != nil {
+				return invalidf("changes of public documents must not be sealed")
 			}
 		}
 	}
@@ -306,18 +455,20 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 		sb = newStructuralBlob(c, string(TypeChange), author, v.HLCTime.Time(), IRI(v.Entity), author, resourceTime)
 	}
 
-	// TODO(burdiyan): ensure deps are indexed, not just known.
-	// Although in practice deps must always be indexed first, but need to make sure.
 	for _, dep := range v.Deps {
-		if err := idx.AssertBlobData(dep); err != nil {
-			return fmt.Errorf("missing causal dependency %s of change %s", dep, c)
-		}
-
 		sb.AddBlobLink("change/dep", dep)
 	}
 
 	sb.AddBlobLink("change/auth", v.Delegation)
 
+	// Sealed content is only indexed if our device has the content key of the group,
+	// otherwise only the public part of the patch is indexed.
+	if v.Sealed != nil {
+		if err := bs.openChange(idx.conn, &v); err != nil && !errors.Is(err, ErrNoContentKey) {
+			return err
+		}
+	}
+
 	// TODO(burdiyan): remove this when all the tests are fixed. Sometimes CBOR codec decodes into
 	// different types than what was encoded, and we might not have accounted for that during indexing.
 	// So we re-encode the patch here to make sure.
@@ -341,7 +492,7 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 	case v.Entity.HasPrefix("hm://a/"):
 		res, err := hypersql.EntitiesLookupRemovedRecord(idx.conn, sb.Resource.ID.String())
 		if err == nil && res.DeletedResourcesIRI == sb.Resource.ID.String() {
-			return fmt.Errorf("Change belongs to a deleted account [%s]", res.DeletedResourcesIRI)
+			return invalidf("Change belongs to a deleted account [%s]", res.DeletedResourcesIRI)
 		}
 		if v, ok := v.Patch["avatar"].(cid.Cid); ok {
 			sb.AddBlobLink("account/avatar", v)
@@ -349,6 +500,7 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 
 		if alias, ok := v.Patch["alias"].(string); ok {
 			sb.Meta = alias
+			sb.AddFullText(IRI(v.Entity), "alias", "", alias)
 		}
 
 		if doc, ok := v.Patch["rootDocument"].(string); ok {
@@ -358,17 +510,22 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 		title, ok := v.Patch["title"].(string)
 		if ok {
 			sb.Meta = title
+			sb.AddFullText(IRI(v.Entity), "title", "", title)
 		}
 		blocks, ok := v.Patch["blocks"].(map[string]any)
 
 		res, err := hypersql.EntitiesLookupRemovedRecord(idx.conn, sb.Resource.ID.String())
 		if err == nil && res.DeletedResourcesIRI == sb.Resource.ID.String() {
-			return fmt.Errorf("Change belongs to a deleted document [%s]", res.DeletedResourcesIRI)
+			return invalidf("Change belongs to a deleted document [%s]", res.DeletedResourcesIRI)
 		}
 
 		if ok {
 			for id, blk := range blocks {
-				v, ok := blk.(map[string]any)["#map"]
+				m, ok := blk.(map[string]any)
+				if !ok {
+					continue
+				}
+				v, ok := m["#map"]
 				if !ok {
 					continue
 				}
@@ -379,11 +536,13 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 					return err
 				}
 				blk := &documents.Block{}
-				if err := protojson.Unmarshal(data, blk); err != nil {
+				// Block maps have some internal fields, like text anchors for annotations.
+				if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, blk); err != nil {
 					return err
 				}
 				blk.Id = id
 				blk.Revision = c.String()
+				sb.AddFullText(sb.Resource.ID, "document", blk.Id, blk.Text)
 				if err := indexURL(&sb, bs.log, blk.Id, "doc/"+blk.Type, blk.Ref); err != nil {
 					return err
 				}
@@ -396,77 +555,66 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 			}
 		}
 
-	case v.Entity.HasPrefix("hm://g/"):
-		authorEntity := IRI("hm://a/" + author.String())
-		title, ok := v.Patch["title"].(string)
-		if ok {
-			sb.Meta = title
+		// Block text is stored as RGA, so the patch only has the chunks inserted or deleted by this change.
+		// The whole text of the blocks is indexed once the transaction is done, see indexText.
+		if blocks := textBlocks(v.Patch); len(blocks) > 0 {
+			bs.texts.mark(idx.conn, textChange{ID: id, CID: c, Change: v, Blocks: blocks})
 		}
-		var currentRole groups.Role
+
+	case v.Entity.HasPrefix("hm://g/"):
 		res, err := hypersql.EntitiesLookupRemovedRecord(idx.conn, sb.Resource.ID.String())
 		if err == nil && res.DeletedResourcesIRI == sb.Resource.ID.String() {
-			return fmt.Errorf("Change belongs to a deleted group [%s]", res.DeletedResourcesIRI)
+			return invalidf("Change belongs to a deleted group [%s]", res.DeletedResourcesIRI)
 		}
+
 		if v.Action == ActionCreate {
-			currentRole = groups.Role_OWNER
-			sb.AddResourceLink("group/member", authorEntity, false, GroupLinkMeta{Role: currentRole})
+			sb.AddResourceLink("group/member", IRI("hm://a/"+author.String()), false, GroupLinkMeta{Role: groups.Role_OWNER})
 		} else {
-			// Only owner or members are allowed to make updates.
-			// TODO(burdiyan): need a better way to get resource owner.
-			rid, err := idx.ensureResource(IRI(v.Entity))
+			// Permissions are checked against the state of the group the change is based on.
+			// Changes from signers without enough permissions are kept to not break the DAG,
+			// but they are excluded from the group state, and nothing else is indexed for them.
+			state, err := bs.loadDeps(idx, v.Entity, v.Deps)
 			if err != nil {
 				return err
 			}
 
-			authorID, err := idx.ensurePubKey(author)
-			if err != nil {
-				return err
-			}
+			if err := AuthorizeGroupChange(state, author, v.Patch); err != nil {
+				if !errors.Is(err, ErrGroupPermissionDenied) {
+					return err
+				}
 
-			owner, err := hypersql.ResourceGetOwner(idx.conn, rid)
-			if err != nil {
-				return err
-			}
+				bs.log.Debug("UnauthorizedGroupChange", zap.String("cid", c.String()), zap.Error(err))
 
-			if owner == authorID {
-				currentRole = groups.Role_OWNER
-			} else {
-				role, err := hypersql.GetGroupRole(idx.conn, v.Entity.String(), authorEntity.String())
-				if err != nil {
+				if err := idx.SaveBlob(id, sb); err != nil {
 					return err
 				}
-				currentRole = groups.Role(role)
+
+				return hypersql.UnauthorizedChangesInsertOrIgnore(idx.conn, id)
 			}
 		}
 
-		if currentRole != groups.Role_OWNER && currentRole != groups.Role_EDITOR {
-			return fmt.Errorf("only members can change groups: account %q is not a member of the group %q", author, v.Entity)
+		title, ok := v.Patch["title"].(string)
+		if ok {
+			sb.Meta = title
+			sb.AddFullText(IRI(v.Entity), "title", "", title)
 		}
-
-		// Check if some of the owner-only fields are touched by non-owners
-		if currentRole != groups.Role_OWNER {
-			if v.Patch["siteURL"] != nil {
-				return fmt.Errorf("only group owner can set siteURL")
-			}
-
-			if v.Patch["members"] != nil {
-				return fmt.Errorf("only group owner can change members")
-			}
+		if description, ok := v.Patch["description"].(string); ok {
+			sb.AddFullText(IRI(v.Entity), "description", "", description)
 		}
 
 		// Validate site URL
 		if siteURL, ok := v.Patch["siteURL"].(string); ok {
 			u, err := url.Parse(siteURL)
 			if err != nil {
-				return fmt.Errorf("failed to parse site URL %s: %w", siteURL, err)
+				return invalidf("failed to parse site URL %s: %w", siteURL, err)
 			}
 
 			if u.Scheme != "http" && u.Scheme != "https" {
-				return fmt.Errorf("site URL must have http or https scheme, got %s", siteURL)
+				return invalidf("site URL must have http or https scheme, got %s", siteURL)
 			}
 
 			if siteURL != (&url.URL{Scheme: u.Scheme, Host: u.Host}).String() {
-				return fmt.Errorf("site URL must have only scheme and host, got %s", siteURL)
+				return invalidf("site URL must have only scheme and host, got %s", siteURL)
 			}
 
 			if err := hypersql.SitesInsertOrIgnore(idx.conn, v.Entity.String(), siteURL, int64(v.HLCTime), OriginFromCID(c)); err != nil {
@@ -493,53 +641,105 @@ func (bs *indexer) indexChange(idx *indexingCtx, id int64, c cid.Cid, v Change)
 			for k, v := range members {
 				acc, err := core.DecodePrincipal(k)
 				if err != nil {
-					return fmt.Errorf("failed to parse group member as principal: %w", err)
-				}
-
-				role, ok := v.(int)
-				if !ok {
-					return fmt.Errorf("member must have valid role")
+					return invalidf("failed to parse group member as principal: %w", err)
 				}
 
-				if role == int(groups.Role_ROLE_UNSPECIFIED) {
-					return fmt.Errorf("member role must be specified")
+				role, err := groupRoleFromValue(v)
+				if err != nil {
+					return err
 				}
 
-				if role == int(groups.Role_OWNER) {
-					return fmt.Errorf("owner role can't be used in updates")
+				if role == groups.Role_OWNER {
+					return invalidf("owner role can't be used in updates")
 				}
 
 				if _, _, err := idx.ensureAccount(acc); err != nil {
 					return err
 				}
 
-				sb.AddResourceLink("group/member", IRI("hm://a/"+acc.String()), false, GroupLinkMeta{Role: groups.Role(role)})
+				// Unspecified role means the member was removed from the group.
+				sb.AddResourceLink("group/member", IRI("hm://a/"+acc.String()), false, GroupLinkMeta{Role: role})
 			}
 		}
 	}
 
-	return idx.SaveBlob(id, sb)
+	if err := idx.SaveBlob(id, sb); err != nil {
+		return err
+	}
+
+	if privateGroup != "" {
+		gid, err := idx.ensureResource(IRI(privateGroup))
+		if err != nil {
+			return err
+		}
+
+		return hypersql.PrivateBlobsInsertOrIgnore(idx.conn, id, gid)
+	}
+
+	return nil
+}
+
+// loadDeps loads the state of the entity at the given deps.
+func (bs *indexer) loadDeps(idx *indexingCtx, eid EntityID, cids []cid.Cid) (*Entity, error) {
+	deps := make([]int64, 0, len(cids))
+	for _, dep := range cids {
+		id, err := idx.ensureBlob(dep)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, id)
+	}
+
+	heads, err := json.Marshal(deps)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := bs.loadFromHeads(idx.conn, eid, localHeads(heads))
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, fmt.Errorf("failed to load deps for entity %s", eid)
+	}
+
+	return e, nil
 }
 
 func (bs *indexer) indexComment(idx *indexingCtx, id int64, c cid.Cid, v Comment) error {
 	if v.Target == "" {
-		return fmt.Errorf("comment must have a target")
+		return invalidf("comment must have a target")
 	}
 
 	if !strings.HasPrefix(v.Target, "hm://") {
-		return fmt.Errorf("comment target must be a hypermedia resource, got %s", v.Target)
+		return invalidf("comment target must be a hypermedia resource, got %s", v.Target)
 	}
 	iri := strings.Split(v.Target, "?v=")[0]
+	iri, _, _ = strings.Cut(iri, "#")
 	res, err := hypersql.EntitiesLookupRemovedRecord(idx.conn, iri)
 	if err == nil && res.DeletedResourcesIRI == iri {
-		return fmt.Errorf("Comment references to a deleted entity [%s]", res.DeletedResourcesIRI)
+		return invalidf("Comment references to a deleted entity [%s]", res.DeletedResourcesIRI)
+	}
+
+	// Comments on private documents must be sealed with the content key of the group.
+	privateGroup, err := hypersql.PrivateBlobsGetResourceGroup(idx.conn, iri)
+	if err != nil {
+		return err
+	}
+
+	if privateGroup != "" && (v.Sealed == nil || v.Sealed.Group != EntityID(privateGroup)) {
+		return invalidf("comments on private documents must be sealed for the group %s", privateGroup)
+	}
+
+	if privateGroup == "" && v.Sealed != nil {
+		return fmt.Errorf("comments on public resources must not be sealed")
 	}
 
 	isReply := v.RepliedComment.Defined() || v.ThreadRoot.Defined()
 
 	if isReply {
 		if !v.RepliedComment.Defined() || !v.ThreadRoot.Defined() {
-			return fmt.Errorf("replies must have both repliedComment and threadRoot set")
+			return invalidf("replies must have both repliedComment and threadRoot set")
 		}
 
 		blk, err := bs.bs.get(idx.conn, v.RepliedComment)
@@ -554,30 +754,30 @@ func (bs *indexer) indexComment(idx *indexingCtx, id int64, c cid.Cid, v Comment
 
 		rc, ok := replied.Decoded.(Comment)
 		if !ok {
-			return fmt.Errorf("replied comment is not a comment, got %T", replied.Decoded)
+			return invalidf("replied comment is not a comment, got %T", replied.Decoded)
 		}
 
 		if v.HLCTime < rc.HLCTime {
-			return fmt.Errorf("reply must have a higher timestamp than the replied comment: failed to assert %s > %s", v.HLCTime, rc.HLCTime)
+			return invalidf("reply must have a higher timestamp than the replied comment: failed to assert %s > %s", v.HLCTime, rc.HLCTime)
 		}
 
 		repliedTarget, _, _ := strings.Cut(rc.Target, "?")
 		newReplyTarget, _, _ := strings.Cut(v.Target, "?")
 
 		if newReplyTarget != repliedTarget {
-			return fmt.Errorf("reply target '%s' doesn't match replied comment's target '%s'", newReplyTarget, repliedTarget)
+			return invalidf("reply target '%s' doesn't match replied comment's target '%s'", newReplyTarget, repliedTarget)
 		}
 
 		// Replies to replies must share the thread root.
 		// Replies to top-level comments must have thread root equal to the top-level comment itself.
 		if rc.ThreadRoot.Defined() {
 			if !v.ThreadRoot.Equals(rc.ThreadRoot) {
-				return fmt.Errorf("reply to reply thread roots don't match: '%s' != '%s'", v.ThreadRoot, rc.ThreadRoot)
+				return invalidf("reply to reply thread roots don't match: '%s' != '%s'", v.ThreadRoot, rc.ThreadRoot)
 			}
 		} else {
 			// TODO(burdiyan): this will not be true when we implement editing comments.
 			if !v.ThreadRoot.Equals(v.RepliedComment) {
-				return fmt.Errorf("reply to a top-level comment must have the same thread root as the replied comment: '%s' != '%s'", v.ThreadRoot, v.RepliedComment)
+				return invalidf("reply to a top-level comment must have the same thread root as the replied comment: '%s' != '%s'", v.ThreadRoot, v.RepliedComment)
 			}
 		}
 	}
@@ -587,6 +787,10 @@ func (bs *indexer) indexComment(idx *indexingCtx, id int64, c cid.Cid, v Comment
 		return err
 	}
 
+	if err := bs.checkDelegation(idx, id, v.Delegation, v.HLCTime.Time()); err != nil {
+		return fmt.Errorf("comment %s is not authorized: %w", c, err)
+	}
+
 	sb := newStructuralBlob(c, string(TypeComment), author, v.HLCTime.Time(), "", nil, time.Time{})
 
 	if err := indexURL(&sb, bs.log, "", "comment/target", v.Target); err != nil {
@@ -606,6 +810,8 @@ func (bs *indexer) indexComment(idx *indexingCtx, id int64, c cid.Cid, v Comment
 	var indexCommentContent func([]CommentBlock) error // workaround to allow recursive closure calls.
 	indexCommentContent = func(in []CommentBlock) error {
 		for _, blk := range in {
+			sb.AddFullText(IRI(iri), "comment", blk.ID, blk.Text)
+
 			if err := indexURL(&sb, bs.log, blk.ID, "comment/"+blk.Type, blk.Ref); err != nil {
 				return err
 			}
@@ -624,6 +830,13 @@ func (bs *indexer) indexComment(idx *indexingCtx, id int64, c cid.Cid, v Comment
 		return nil
 	}
 
+	// Like with changes, sealed comments are only indexed if we have the content key.
+	if v.Sealed != nil {
+		if err := bs.openComment(idx.conn, &v); err != nil && !errors.Is(err, ErrNoContentKey) {
+			return err
+		}
+	}
+
 	if err := indexCommentContent(v.Body); err != nil {
 		return err
 	}
@@ -632,9 +845,87 @@ func (bs *indexer) indexComment(idx *indexingCtx, id int64, c cid.Cid, v Comment
 		return fmt.Errorf("failed to index comment: %w", err)
 	}
 
+	if privateGroup != "" {
+		gid, err := idx.ensureResource(IRI(privateGroup))
+		if err != nil {
+			return err
+		}
+
+		return hypersql.PrivateBlobsInsertOrIgnore(idx.conn, id, gid)
+	}
+
 	return nil
 }
 
+func (bs *indexer) indexGroupKey(idx *indexingCtx, id int64, c cid.Cid, v GroupKey) error {
+	if err := v.Verify(); err != nil {
+		return invalidf("failed to verify group key %s: %w", c, err)
+	}
+
+	author, err := bs.getAuthorFromDelegation(idx, v.Delegation)
+	if err != nil {
+		return err
+	}
+
+	if err := bs.checkDelegation(idx, id, v.Delegation, v.HLCTime.Time()); err != nil {
+		return fmt.Errorf("group key %s is not authorized: %w", c, err)
+	}
+
+	if !v.Group.HasPrefix("hm://g/") {
+		return invalidf("group key %s must be for a group, got %s", c, v.Group)
+	}
+
+	if len(v.Deps) == 0 {
+		return invalidf("group key %s must have at least one dep", c)
+	}
+
+	sb := newStructuralBlob(c, string(TypeGroupKey), author, v.HLCTime.Time(), IRI(v.Group), nil, time.Time{})
+
+	for _, dep := range v.Deps {
+		ok, err := idx.isChangeIndexed(dep)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: dep %s of group key %s is not indexed", errMissingDeps, dep, c)
+		}
+
+		sb.AddBlobLink("groupkey/dep", dep)
+	}
+
+	sb.AddBlobLink("groupkey/auth", v.Delegation)
+
+	// Only those who can manage members of a private group can distribute its keys.
+	state, err := bs.loadDeps(idx, v.Group, v.Deps)
+	if err != nil {
+		return err
+	}
+
+	if private, _ := state.Get("private"); private != true {
+		return invalidf("group key %s is for a group that is not private", c)
+	}
+
+	role, err := GroupRole(state, author)
+	if err != nil {
+		return err
+	}
+
+	if !GroupRoleAllows(role, GroupCapManageMembers) {
+		return invalidf("group key %s is not authorized: %w", c, ErrGroupPermissionDenied)
+	}
+
+	if err := idx.SaveBlob(id, sb); err != nil {
+		return err
+	}
+
+	gid, err := idx.ensureResource(IRI(v.Group))
+	if err != nil {
+		return err
+	}
+
+	return hypersql.GroupKeysInsertOrIgnore(idx.conn, id, gid, v.KeyID)
+}
+
 func (bs *indexer) getAuthorFromDelegation(idx *indexingCtx, delegation cid.Cid) (core.Principal, error) {
 	// TODO(burdiyan): this is also quite stupid having to get it from the DB.
 	iss, err := hypersql.KeyDelegationsGetIssuer(idx.conn, delegation.Hash())
@@ -682,6 +973,73 @@ func (bs *indexer) getAuthorFromDelegation(idx *indexingCtx, delegation cid.Cid)
 	return author, nil
 }
 
+// errNotVouched is returned for blobs signed by a revoked key, which we've received after learning about the revocation.
+// The signing time is set by the signer, so a compromised key could backdate its blobs to before the revocation.
+// Such blobs are only accepted if some change from a valid key depends on them.
+var errNotVouched = fmt.Errorf("%w: signed by a revoked key, and not vouched for by any valid change", ErrInvalidBlob)
+
+// checkDelegation makes sure the key delegation was valid at the time a blob was signed with it:
+// the delegation must not be expired, and the delegate key must not be revoked by the issuer before that time.
+// The signing time is only trusted for blobs we've stored before the revocation,
+// i.e. with lower IDs than the revocation blob, otherwise errNotVouched is returned.
+func (bs *indexer) checkDelegation(idx *indexingCtx, id int64, delegation cid.Cid, signTime time.Time) error {
+	iss, del, notAfter, err := hypersql.KeyDelegationsGet(idx.conn, delegation.Hash())
+	if err != nil {
+		return err
+	}
+
+	// Same as when getting the author, the delegation might not be indexed yet when we are reindexing.
+	if iss == 0 {
+		blk, err := bs.bs.get(idx.conn, delegation)
+		if err != nil {
+			return err
+		}
+
+		var kd KeyDelegation
+		if err := cbornode.DecodeInto(blk.RawData(), &kd); err != nil {
+			return fmt.Errorf("failed to decode key delegation %s: %w", delegation, err)
+		}
+
+		iss, err = idx.ensurePubKey(kd.Issuer)
+		if err != nil {
+			return err
+		}
+
+		del, err = idx.ensurePubKey(kd.Delegate)
+		if err != nil {
+			return err
+		}
+
+		if !kd.NotAfter.IsZero() {
+			notAfter = kd.NotAfter.Unix()
+		}
+	}
+
+	if notAfter != 0 && signTime.After(time.Unix(notAfter, 0)) {
+		return invalidf("key delegation %s expired at %s", delegation, time.Unix(notAfter, 0).UTC())
+	}
+
+	revokeTime, revocationID, err := hypersql.KeyRevocationsGetRevokeTime(idx.conn, iss, del)
+	if err != nil {
+		return err
+	}
+
+	if revokeTime == 0 {
+		return nil
+	}
+
+	if !signTime.Before(time.Unix(revokeTime, 0)) {
+		return invalidf("delegate key of %s was revoked at %s", delegation, time.Unix(revokeTime, 0).UTC())
+	}
+
+	// Blob IDs are allocated in the order we store the blobs, which the signer can't influence.
+	if id > revocationID {
+		return fmt.Errorf("%w: delegate key of %s was revoked before we received the blob", errNotVouched, delegation)
+	}
+
+	return nil
+}
+
 func indexURL(sb *structuralBlob, log *zap.Logger, anchor, linkType, rawURL string) error {
 	if rawURL == "" {
 		return nil
@@ -720,14 +1078,14 @@ func indexURL(sb *structuralBlob, log *zap.Logger, anchor, linkType, rawURL stri
 	case u.Scheme == "hm" && u.Host == "c":
 		c, err := cid.Decode(strings.TrimPrefix(u.Path, "/"))
 		if err != nil {
-			return fmt.Errorf("failed to parse comment CID %s: %w", rawURL, err)
+			return invalidf("failed to parse comment CID %s: %w", rawURL, err)
 		}
 
 		sb.AddBlobLink(linkType, c)
 	case u.Scheme == "ipfs":
 		c, err := cid.Decode(u.Hostname())
 		if err != nil {
-			return fmt.Errorf("failed to parse IPFS URL %s: %w", rawURL, err)
+			return invalidf("failed to parse IPFS URL %s: %w", rawURL, err)
 		}
 
 		sb.AddBlobLink(linkType, c)
@@ -6,10 +6,12 @@ import (
 	"mintter/backend/core"
 	"mintter/backend/hyper/hypersql"
 	"mintter/backend/ipfs"
+	"mintter/backend/pkg/dqb"
 	"mintter/backend/pkg/maybe"
 	"time"
 
 	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
 	"github.com/ipfs/go-cid"
 )
 
@@ -34,6 +36,7 @@ type structuralBlob struct {
 	}
 	BlobLinks     []blobLink
 	ResourceLinks []resourceLink
+	FullText      []fullTextEntry
 	Meta          string
 }
 
@@ -63,6 +66,14 @@ func (sb *structuralBlob) AddResourceLink(linkType string, target IRI, isPinned
 	sb.ResourceLinks = append(sb.ResourceLinks, resourceLink{Type: linkType, Target: target, IsPinned: isPinned, Meta: meta})
 }
 
+// AddFullText adds searchable text content of the blob that belongs to the given resource.
+func (sb *structuralBlob) AddFullText(resource IRI, contentType, blockID, text string) {
+	if text == "" {
+		return
+	}
+	sb.FullText = append(sb.FullText, fullTextEntry{Resource: resource, Type: contentType, BlockID: blockID, Text: text})
+}
+
 type blobLink struct {
 	Type   string
 	Target cid.Cid
@@ -75,6 +86,13 @@ type resourceLink struct {
 	Meta     any
 }
 
+type fullTextEntry struct {
+	Resource IRI
+	Type     string
+	BlockID  string
+	Text     string
+}
+
 type indexingCtx struct {
 	conn *sqlite.Conn
 
@@ -147,6 +165,10 @@ func (idx *indexingCtx) SaveBlob(id int64, b structuralBlob) error {
 		return err
 	}
 
+	if err := hypersql.IndexedBlobsInsertOrIgnore(idx.conn, id); err != nil {
+		return err
+	}
+
 	for _, link := range b.BlobLinks {
 		tgt, err := idx.ensureBlob(link.Target)
 		if err != nil {
@@ -173,9 +195,51 @@ func (idx *indexingCtx) SaveBlob(id int64, b structuralBlob) error {
 		}
 	}
 
+	for _, ft := range b.FullText {
+		rid, err := idx.ensureResource(ft.Resource)
+		if err != nil {
+			return fmt.Errorf("failed to ensure resource %s: %w", ft.Resource, err)
+		}
+
+		if err := insertFullText(idx.conn, id, ft.Type, ft.BlockID, rid, b.Time.UnixMicro(), ft.Text); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// insertFullText adds the searchable text of the blob to the full-text index.
+func insertFullText(conn *sqlite.Conn, blobID int64, contentType, blockID string, resource, ts int64, text string) error {
+	if text == "" {
+		return nil
+	}
+
+	var rowid int64
+	if err := sqlitex.Exec(conn, qFullTextIndexInsert(), func(stmt *sqlite.Stmt) error {
+		rowid = stmt.ColumnInt64(0)
+		return nil
+	}, blobID, contentType, blockID, resource, ts); err != nil {
+		return fmt.Errorf("failed to insert full-text index attributes: %w", err)
+	}
+
+	if err := sqlitex.Exec(conn, qFullTextInsert(), nil, rowid, text); err != nil {
+		return fmt.Errorf("failed to insert full-text content: %w", err)
+	}
+
+	return nil
+}
+
+var qFullTextIndexInsert = dqb.Str(`
+	INSERT INTO fts_index (blob_id, type, block_id, resource, ts)
+	VALUES (?, ?, ?, ?, ?)
+	RETURNING id;
+`)
+
+var qFullTextInsert = dqb.Str(`
+	INSERT INTO fts (rowid, raw_content) VALUES (?, ?);
+`)
+
 func (idx *indexingCtx) AssertBlobData(c cid.Cid) (err error) {
 	delid, err := hypersql.BlobsGetSize(idx.conn, c.Hash())
 	if err != nil {
@@ -0,0 +1,374 @@
+package hyper
+
+import (
+	"context"
+	"mintter/backend/core"
+	"mintter/backend/core/coretest"
+	"mintter/backend/hlc"
+	"mintter/backend/logging"
+	"testing"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyRevocation(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	alice2 := coretest.NewTester("alice-2")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+
+	blobs := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+
+	start := time.Now().Add(-1 * time.Hour).Truncate(time.Second)
+
+	kd, err := NewKeyDelegation(alice.Account, alice2.Device.PublicKey, start)
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, kd.Blob()))
+
+	revokeTime := start.Add(30 * time.Minute)
+
+	// Change signed before the revocation.
+	e := NewEntity("foo")
+	ch1, err := e.CreateChange(hlc.FromTime(revokeTime.Add(-time.Minute)), alice2.Device, kd.Blob().CID, map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch1))
+
+	forged, err := NewKeyRevocation(bob.Account, alice2.Device.Principal(), revokeTime)
+	require.NoError(t, err)
+	forged.Issuer = alice.Account.Principal()
+	require.Error(t, blobs.SaveBlob(ctx, forged.Blob()), "revocations not signed by the issuer must be rejected")
+
+	kr, err := NewKeyRevocation(alice.Account, alice2.Device.Principal(), revokeTime)
+	require.NoError(t, err)
+	require.NoError(t, kr.Verify())
+	require.NoError(t, blobs.SaveBlob(ctx, kr.Blob()))
+
+	ch2, err := e.CreateChange(hlc.FromTime(revokeTime.Add(time.Minute)), alice2.Device, kd.Blob().CID, map[string]any{"name": "Mallory"})
+	require.NoError(t, err)
+	require.ErrorIs(t, blobs.SaveBlob(ctx, ch2), ErrInvalidBlob, "changes signed after revocation must be rejected")
+	blk, err := blocks.NewBlockWithCid(ch2.Data, ch2.CID)
+	require.NoError(t, err)
+	require.ErrorIs(t, blobs.IPFSBlockstore().Put(ctx, blk), ErrInvalidBlob, "rejection must be permanent when syncing too")
+
+	ok, err := blobs.bs.Has(ctx, ch2.CID)
+	require.NoError(t, err)
+	require.False(t, ok, "rejected change must not be stored")
+
+	ee, err := blobs.LoadEntity(ctx, "foo")
+	require.NoError(t, err)
+	name, _ := ee.Get("name")
+	require.Equal(t, "Alice", name, "changes signed before revocation must remain valid")
+
+	// Revocation must survive reindexing.
+	require.NoError(t, blobs.Reindex(ctx))
+	require.Error(t, blobs.SaveBlob(ctx, ch2))
+}
+
+func TestKeyRevocationBackdated(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	alice2 := coretest.NewTester("alice-2")
+	ctx := context.Background()
+
+	blobs := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+
+	start := time.Now().Add(-1 * time.Hour).Truncate(time.Second)
+	revokeTime := start.Add(30 * time.Minute)
+
+	kd1, err := NewKeyDelegation(alice.Account, alice.Device.PublicKey, start)
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, kd1.Blob()))
+
+	kd2, err := NewKeyDelegation(alice.Account, alice2.Device.PublicKey, start)
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, kd2.Blob()))
+
+	e := NewEntity("foo")
+	ch1, err := e.CreateChange(hlc.FromTime(revokeTime.Add(-10*time.Minute)), alice.Device, kd1.Blob().CID, map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch1))
+
+	kr, err := NewKeyRevocation(alice.Account, alice2.Device.Principal(), revokeTime)
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, kr.Blob()))
+
+	// The revoked device claims to have signed the change before the revocation,
+	// but we only receive it after we know about the revocation.
+	ch2, err := e.CreateChange(hlc.FromTime(revokeTime.Add(-time.Minute)), alice2.Device, kd2.Blob().CID, map[string]any{"name": "Mallory"})
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch2))
+
+	ee, err := blobs.LoadEntity(ctx, "foo")
+	require.NoError(t, err)
+	name, _ := ee.Get("name")
+	require.Equal(t, "Alice", name, "backdated change received after the revocation must not be indexed")
+
+	// A valid device building on top of the change vouches for it.
+	ch3, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd1.Blob().CID, map[string]any{"name": "Alice 3"})
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch3))
+
+	ee, err = blobs.LoadEntity(ctx, "foo")
+	require.NoError(t, err)
+	name, _ = ee.Get("name")
+	require.Equal(t, "Alice 3", name, "vouched change and its dependents must be indexed")
+	require.Len(t, ee.Heads(), 1)
+}
+
+func TestMissingDelegationIsRetryable(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	ctx := context.Background()
+
+	blobs := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+
+	kd, err := NewKeyDelegation(alice.Account, alice.Device.PublicKey, time.Now().Add(-1*time.Hour))
+	require.NoError(t, err)
+
+	e := NewEntity("foo")
+	ch, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+
+	err = blobs.SaveBlob(ctx, ch)
+	require.Error(t, err, "change must not be indexed without its key delegation")
+	require.NotErrorIs(t, err, ErrInvalidBlob, "missing key delegation must not reject the change permanently")
+
+	require.NoError(t, blobs.SaveBlob(ctx, kd.Blob()))
+	require.NoError(t, blobs.SaveBlob(ctx, ch), "change must be indexed once the key delegation arrives")
+
+	ee, err := blobs.LoadEntity(ctx, "foo")
+	require.NoError(t, err)
+	name, _ := ee.Get("name")
+	require.Equal(t, "Alice", name)
+}
+
+func TestKeyDelegationExpiration(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	ctx := context.Background()
+
+	blobs := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+
+	start := time.Now().Add(-1 * time.Hour).Truncate(time.Second)
+	notAfter := start.Add(30 * time.Minute)
+
+	_, err := NewKeyDelegation(alice.Account, alice.Device.PublicKey, start, WithNotAfter(start))
+	require.Error(t, err, "delegation must not expire before it's issued")
+
+	kd, err := NewKeyDelegation(alice.Account, alice.Device.PublicKey, start, WithNotAfter(notAfter))
+	require.NoError(t, err)
+	require.NoError(t, kd.Verify())
+	require.False(t, kd.ExpiredAt(notAfter))
+	require.True(t, kd.ExpiredAt(notAfter.Add(time.Second)))
+
+	blob := kd.Blob()
+	decoded, err := DecodeBlob(blob.CID, blob.Data)
+	require.NoError(t, err)
+	require.True(t, notAfter.Equal(decoded.Decoded.(KeyDelegation).NotAfter), "expiration time must survive encoding")
+	require.NoError(t, blobs.SaveBlob(ctx, blob))
+
+	e := NewEntity("foo")
+	ch1, err := e.CreateChange(hlc.FromTime(notAfter.Add(-time.Minute)), alice.Device, blob.CID, map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch1))
+
+	ch2, err := e.CreateChange(hlc.FromTime(notAfter.Add(time.Minute)), alice.Device, blob.CID, map[string]any{"name": "Alice 2"})
+	require.NoError(t, err)
+	require.ErrorIs(t, blobs.SaveBlob(ctx, ch2), ErrInvalidBlob, "changes signed after expiration must be rejected")
+}
+
+func TestPendingChanges(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	ctx := context.Background()
+
+	blobs := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+
+	kd, err := NewKeyDelegation(alice.Account, alice.Device.PublicKey, time.Now().Add(-1*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, kd.Blob()))
+
+	e := NewEntity("foo")
+	ch1, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	ch2, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{"name": "Alice 2"})
+	require.NoError(t, err)
+	ch3, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{"name": "Alice 3"})
+	require.NoError(t, err)
+
+	// Changes arriving before their deps are stored, but kept pending.
+	require.NoError(t, blobs.SaveBlob(ctx, ch3))
+	require.NoError(t, blobs.SaveBlob(ctx, ch2))
+
+	ok, err := blobs.bs.Has(ctx, ch3.CID)
+	require.NoError(t, err)
+	require.True(t, ok, "pending change must be stored")
+
+	_, err = blobs.LoadEntity(ctx, "foo")
+	require.Error(t, err, "pending changes must not be part of the entity")
+
+	// Once the missing dep arrives all the pending changes must be indexed.
+	require.NoError(t, blobs.SaveBlob(ctx, ch1))
+
+	ee, err := blobs.LoadEntity(ctx, "foo")
+	require.NoError(t, err)
+	name, _ := ee.Get("name")
+	require.Equal(t, "Alice 3", name)
+	require.Equal(t, map[cid.Cid]struct{}{ch3.CID: {}}, ee.Heads())
+
+	require.NoError(t, blobs.Reindex(ctx))
+	ee, err = blobs.LoadEntity(ctx, "foo")
+	require.NoError(t, err)
+	name, _ = ee.Get("name")
+	require.Equal(t, "Alice 3", name, "changes must be indexed regardless of their order during reindexing")
+}
+
+func TestIndexBlockText(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	ctx := context.Background()
+
+	blobs := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+
+	kd, err := NewKeyDelegation(alice.Account, alice.Device.PublicKey, time.Now().Add(-1*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, kd.Blob()))
+
+	clock := hlc.NewClock()
+	ts := clock.MustNow()
+	createTime := ts.Time().Unix()
+	id, nonce := NewUnforgeableID("hm://d/", alice.Account.Principal(), nil, createTime)
+	e := NewEntityWithClock(EntityID(id), clock)
+
+	textPatch := func(block, text string) map[string]any {
+		rga, _, err := e.State().RGA("text", block)
+		require.NoError(t, err)
+		chunk, _ := rga.Diff(text)
+		return map[string]any{block: map[string]any{"#rga": chunk}}
+	}
+
+	blockText := func(c cid.Cid, block string) (text string) {
+		require.NoError(t, blobs.Query(ctx, func(conn *sqlite.Conn) error {
+			return sqlitex.Exec(conn, `SELECT fts.raw_content
+				FROM fts
+				JOIN fts_index ON fts_index.id = fts.rowid
+				JOIN blobs ON blobs.id = fts_index.blob_id
+				WHERE blobs.multihash = ? AND fts_index.block_id = ?`, func(stmt *sqlite.Stmt) error {
+				text = stmt.ColumnText(0)
+				return nil
+			}, []byte(c.Hash()), block)
+		}))
+		return text
+	}
+
+	ch1, err := e.CreateChange(ts, alice.Device, kd.Blob().CID, map[string]any{
+		"nonce":      nonce,
+		"createTime": int(createTime),
+		"owner":      []byte(alice.Account.Principal()),
+		"text":       textPatch("b1", "Hello world"),
+	}, WithAction(ActionCreate))
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch1))
+	require.Equal(t, "Hello world", blockText(ch1.CID, "b1"))
+
+	ch2, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{
+		"text": textPatch("b1", "Hello brave new world"),
+	}, WithAction(ActionUpdate))
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch2))
+	require.Equal(t, "Hello brave new world", blockText(ch2.CID, "b1"), "block must be indexed with its whole text, not only the inserted runs")
+
+	ch3, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{
+		"text": textPatch("b1", "Hello new world"),
+	}, WithAction(ActionUpdate))
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch3))
+	require.Equal(t, "Hello new world", blockText(ch3.CID, "b1"), "deleted text must not be indexed")
+
+	var entries int
+	require.NoError(t, blobs.Query(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.Exec(conn, "SELECT COUNT(*) FROM fts_index WHERE block_id = 'b1'", func(stmt *sqlite.Stmt) error {
+			entries = stmt.ColumnInt(0)
+			return nil
+		})
+	}))
+	require.Equal(t, 1, entries, "block must have only the entry with its current text")
+
+	// Changes stored together, e.g. during syncing, are indexed with the text after all of them.
+	ch5, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{
+		"text": textPatch("b1", "Hello new world!"),
+	}, WithAction(ActionUpdate))
+	require.NoError(t, err)
+	ch6, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{
+		"text": textPatch("b1", "Hello new world!!"),
+	}, WithAction(ActionUpdate))
+	require.NoError(t, err)
+
+	var batch []blocks.Block
+	for _, hb := range []Blob{ch6, ch5} {
+		blk, err := blocks.NewBlockWithCid(hb.Data, hb.CID)
+		require.NoError(t, err)
+		batch = append(batch, blk)
+	}
+	require.NoError(t, blobs.IPFSBlockstore().PutMany(ctx, batch))
+	require.Equal(t, "Hello new world!!", blockText(ch6.CID, "b1"))
+
+	ch4, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{
+		"text": map[string]any{"b2": "x"},
+	}, WithAction(ActionUpdate))
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, ch4), "malformed block text must not break indexing")
+}
+
+func TestPendingGroupKeys(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	ctx := context.Background()
+
+	blobs := NewStorage(newTestSQLite(t), logging.New("mintter/hyper", "debug"))
+	blobs.SetDeviceKey(alice.Device)
+
+	kd, err := NewKeyDelegation(alice.Account, alice.Device.PublicKey, time.Now().Add(-1*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, blobs.SaveBlob(ctx, kd.Blob()))
+
+	clock := hlc.NewClock()
+	ts := clock.MustNow()
+	createTime := ts.Time().Unix()
+	id, nonce := NewUnforgeableID("hm://g/", alice.Account.Principal(), nil, createTime)
+	e := NewEntityWithClock(EntityID(id), clock)
+
+	ch1, err := e.CreateChange(ts, alice.Device, kd.Blob().CID, map[string]any{
+		"nonce":      nonce,
+		"createTime": int(createTime),
+		"owner":      []byte(alice.Account.Principal()),
+		"title":      "Secret Group",
+		"private":    true,
+	}, WithAction(ActionCreate))
+	require.NoError(t, err)
+
+	ch2, err := e.CreateChange(e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string]any{
+		"title": "Very Secret Group",
+	}, WithAction(ActionUpdate))
+	require.NoError(t, err)
+
+	key, err := NewContentKey(e.ID())
+	require.NoError(t, err)
+
+	gk, err := NewGroupKey(key, []cid.Cid{ch2.CID}, e.NextTimestamp(), alice.Device, kd.Blob().CID, map[string][]core.Principal{
+		alice.Account.Principal().String(): {alice.Device.Principal()},
+	})
+	require.NoError(t, err)
+
+	// Group keys arriving before the group changes they are based on must be kept pending.
+	require.NoError(t, blobs.SaveBlob(ctx, gk))
+	require.NoError(t, blobs.SaveBlob(ctx, ch2))
+
+	_, err = blobs.CurrentContentKey(ctx, e.ID())
+	require.ErrorIs(t, err, ErrNoContentKey, "pending group keys must not be used")
+
+	require.NoError(t, blobs.SaveBlob(ctx, ch1))
+
+	got, err := blobs.CurrentContentKey(ctx, e.ID())
+	require.NoError(t, err)
+	require.Equal(t, key, got, "group key must be indexed once the group changes are")
+}
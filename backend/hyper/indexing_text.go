@@ -0,0 +1,259 @@
+package hyper
+
+import (
+	"errors"
+	"fmt"
+	"mintter/backend/pkg/dqb"
+	"sort"
+	"sync"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/ipfs/go-cid"
+	"go.uber.org/zap"
+)
+
+// textIndex collects the changes of block text indexed within a transaction.
+// Block text is stored as RGA, so each change only has the chunks it inserted or deleted.
+// Instead of replaying the entity for every change, the text of the changed blocks
+// is indexed once per entity right before the transaction is committed.
+type textIndex struct {
+	mu      sync.Mutex
+	pending map[*sqlite.Conn]map[EntityID][]textChange
+}
+
+type textChange struct {
+	ID     int64
+	CID    cid.Cid
+	Change Change
+	Blocks []string
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		pending: make(map[*sqlite.Conn]map[EntityID][]textChange),
+	}
+}
+
+// mark records that the change modified the text of the blocks within the current transaction on conn.
+func (ti *textIndex) mark(conn *sqlite.Conn, tc textChange) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	m := ti.pending[conn]
+	if m == nil {
+		m = make(map[EntityID][]textChange)
+		ti.pending[conn] = m
+	}
+	m[tc.Change.Entity] = append(m[tc.Change.Entity], tc)
+}
+
+// take returns and forgets the changes marked within the current transaction on conn.
+func (ti *textIndex) take(conn *sqlite.Conn) map[EntityID][]textChange {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	m := ti.pending[conn]
+	delete(ti.pending, conn)
+	return m
+}
+
+// indexText indexes the text of the blocks changed within the current transaction on conn.
+// Published changes are indexed with the text from the state of the entity with all the changes we have,
+// so there's only one entry per block. Drafts are indexed with the text as it is in the draft.
+// Entities we fail to build the text for are skipped, because the blobs themselves are already indexed.
+func (bs *indexer) indexText(conn *sqlite.Conn) error {
+	changes := bs.texts.take(conn)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	idx := newCtx(conn)
+	for eid, list := range changes {
+		if err := bs.indexEntityText(idx, eid, list); err != nil {
+			var serr sqlite.Error
+			if errors.As(err, &serr) {
+				return err
+			}
+			bs.log.Warn("FailedToIndexText", zap.String("entity", string(eid)), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (bs *indexer) indexEntityText(idx *indexingCtx, eid EntityID, list []textChange) error {
+	rid, err := idx.ensureResource(IRI(eid))
+	if err != nil {
+		return err
+	}
+
+	// Latest published change for each block.
+	latest := make(map[string]textChange)
+	for _, tc := range list {
+		var indexed, draft bool
+		if err := sqlitex.Exec(idx.conn, qTextChangeStatus(), func(stmt *sqlite.Stmt) error {
+			indexed = true
+			draft = stmt.ColumnInt(0) != 0
+			return nil
+		}, tc.ID); err != nil {
+			return err
+		}
+
+		// The change could be rolled back after being marked, if indexing it has failed later.
+		if !indexed {
+			continue
+		}
+
+		if draft {
+			if err := bs.indexDraftText(idx, rid, tc); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, blk := range tc.Blocks {
+			if cur, ok := latest[blk]; !ok || cur.Change.HLCTime < tc.Change.HLCTime {
+				latest[blk] = tc
+			}
+		}
+	}
+
+	if len(latest) == 0 {
+		return nil
+	}
+
+	state, err := bs.loadEntityAll(idx.conn, rid, eid)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("failed to load entity %s", eid)
+	}
+
+	blocks := make([]string, 0, len(latest))
+	for blk := range latest {
+		blocks = append(blocks, blk)
+	}
+	sort.Strings(blocks)
+
+	for _, blk := range blocks {
+		tc := latest[blk]
+		ts := tc.Change.HLCTime.Time().UnixMicro()
+		blobID := tc.ID
+
+		// The text of the block is replaced by the text from the current state,
+		// but it must keep the time of the latest change, so it's not shadowed by the older entries.
+		if err := sqlitex.Exec(idx.conn, qTextLatestEntry(), func(stmt *sqlite.Stmt) error {
+			if t := stmt.ColumnInt64(1); t > ts {
+				blobID, ts = stmt.ColumnInt64(0), t
+			}
+			return nil
+		}, rid, blk); err != nil {
+			return err
+		}
+
+		if err := sqlitex.Exec(idx.conn, qTextDeleteEntries(), nil, rid, blk); err != nil {
+			return err
+		}
+
+		rga, ok, err := state.State().RGA("text", blk)
+		if err != nil {
+			return fmt.Errorf("failed to build text of block %s: %w", blk, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := insertFullText(idx.conn, blobID, "document", blk, rid, ts, rga.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexDraftText indexes the text of the blocks as it is in the draft.
+// Drafts are created locally one at a time, so it's fine to build the state for each of them.
+func (bs *indexer) indexDraftText(idx *indexingCtx, rid int64, tc textChange) error {
+	state := NewEntity(tc.Change.Entity)
+	if len(tc.Change.Deps) > 0 {
+		var err error
+		state, err = bs.loadDeps(idx, tc.Change.Entity, tc.Change.Deps)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := state.ApplyChange(tc.CID, tc.Change); err != nil {
+		return err
+	}
+
+	for _, blk := range tc.Blocks {
+		rga, ok, err := state.State().RGA("text", blk)
+		if err != nil {
+			return fmt.Errorf("failed to build text of block %s: %w", blk, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := insertFullText(idx.conn, tc.ID, "document", blk, rid, tc.Change.HLCTime.Time().UnixMicro(), rga.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// textBlocks returns the IDs of the blocks whose text is changed by the patch.
+func textBlocks(patch map[string]any) []string {
+	text, ok := patch["text"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var out []string
+	for id, blk := range text {
+		m, ok := blk.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := m["#rga"]; !ok {
+			continue
+		}
+
+		out = append(out, id)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+var qTextChangeStatus = dqb.Str(`
+	SELECT drafts.blob IS NOT NULL
+	FROM structural_blobs
+	LEFT JOIN drafts ON drafts.blob = structural_blobs.id
+	WHERE structural_blobs.id = :id;
+`)
+
+var qTextLatestEntry = dqb.Str(`
+	SELECT fts_index.blob_id, fts_index.ts
+	FROM fts_index
+	LEFT JOIN drafts ON drafts.blob = fts_index.blob_id
+	WHERE fts_index.resource = :resource
+	AND fts_index.type = 'document'
+	AND fts_index.block_id = :block
+	AND drafts.blob IS NULL
+	ORDER BY fts_index.ts DESC
+	LIMIT 1;
+`)
+
+var qTextDeleteEntries = dqb.Str(`
+	DELETE FROM fts_index
+	WHERE resource = :resource
+	AND type = 'document'
+	AND block_id = :block
+	AND blob_id NOT IN (SELECT blob FROM drafts);
+`)
@@ -0,0 +1,205 @@
+package hyper
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mintter/backend/hyper/hypersql"
+	"mintter/backend/pkg/dqb"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlobCursor is the position of a structural blob in a list ordered by the blob timestamp.
+// It's used for keyset pagination. The blob ID is a tie-breaker for blobs with the same timestamp.
+type BlobCursor struct {
+	Ts     int64 `json:"t"`
+	BlobID int64 `json:"b"`
+}
+
+// ListOptions control the pagination of the listing methods.
+type ListOptions struct {
+	// Cursor of the last seen item. Listing starts after it.
+	// Zero value starts from the beginning of the list.
+	Cursor BlobCursor
+	// Limit is the maximum number of items to return.
+	Limit int
+	// Reverse order lists newest items first.
+	Reverse bool
+}
+
+func (opts ListOptions) args() (ts, blobID int64) {
+	if opts.Reverse && opts.Cursor == (BlobCursor{}) {
+		return math.MaxInt64, math.MaxInt64
+	}
+
+	return opts.Cursor.Ts, opts.Cursor.BlobID
+}
+
+// ListComments iterates over comments of the target resource ordered by their timestamps.
+// If threadRoot is defined only the root comment and the replies within its thread are listed.
+func (bs *Storage) ListComments(ctx context.Context, target string, threadRoot cid.Cid, opts ListOptions, fn func(cur BlobCursor, c cid.Cid, cmt Comment) error) (err error) {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	defer sqlitex.Save(conn)(&err)
+
+	rdb, err := hypersql.EntitiesLookupID(conn, target)
+	if err != nil {
+		return err
+	}
+	if rdb.ResourcesID == 0 {
+		return status.Errorf(codes.NotFound, "resource %s not found: make sure resource ID doesn't have any additional parameters", target)
+	}
+
+	var rootID int64
+	if threadRoot.Defined() {
+		res, err := hypersql.BlobsGetSize(conn, threadRoot.Hash())
+		if err != nil {
+			return err
+		}
+		if res.BlobsID == 0 || res.BlobsSize < 0 {
+			return status.Errorf(codes.NotFound, "thread root comment %s not found", threadRoot)
+		}
+		rootID = res.BlobsID
+	}
+
+	ts, blobID := opts.args()
+
+	q := qListCommentsAsc
+	if opts.Reverse {
+		q = qListCommentsDesc
+	}
+
+	return bs.listBlobs(conn, q(), func(cur BlobCursor, c cid.Cid, data []byte) error {
+		var cmt Comment
+		if err := cbornode.DecodeInto(data, &cmt); err != nil {
+			return fmt.Errorf("listComments: failed to decode comment %s for target %s: %w", c, target, err)
+		}
+
+		if err := bs.openComment(conn, &cmt); err != nil {
+			return err
+		}
+		return fn(cur, c, cmt)
+	}, rdb.ResourcesID, rootID, ts, blobID, opts.Limit)
+}
+
+const qListCommentsTpl = `
+	SELECT
+		blobs.codec,
+		blobs.multihash,
+		blobs.data,
+		structural_blobs.ts,
+		blobs.id
+	FROM resource_links
+	JOIN structural_blobs ON structural_blobs.id = resource_links.source
+	JOIN blobs ON blobs.id = structural_blobs.id
+	WHERE resource_links.target = :resource
+	AND resource_links.type = 'comment/target'
+	AND (:thread_root = 0 OR blobs.id = :thread_root OR blobs.id IN (
+		SELECT blob_links.source
+		FROM blob_links
+		WHERE blob_links.target = :thread_root
+		AND blob_links.type = 'comment/thread-root'
+	))
+	AND (structural_blobs.ts, blobs.id) %s (:cursor_ts, :cursor_blob)
+	AND blobs.size > 0
+	ORDER BY structural_blobs.ts %s, blobs.id %s
+	LIMIT :limit;
+`
+
+var qListCommentsAsc = dqb.Q(func() string {
+	return fmt.Sprintf(qListCommentsTpl, ">", "ASC", "ASC")
+})
+
+var qListCommentsDesc = dqb.Q(func() string {
+	return fmt.Sprintf(qListCommentsTpl, "<", "DESC", "DESC")
+})
+
+// ListChanges iterates over changes of the entity ordered by their timestamps.
+func (bs *Storage) ListChanges(ctx context.Context, eid EntityID, opts ListOptions, fn func(cur BlobCursor, c cid.Cid, ch Change) error) (err error) {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	defer sqlitex.Save(conn)(&err)
+
+	edb, err := hypersql.EntitiesLookupID(conn, string(eid))
+	if err != nil {
+		return err
+	}
+	if edb.ResourcesID == 0 {
+		return status.Errorf(codes.NotFound, "entity %q not found", eid)
+	}
+
+	ts, blobID := opts.args()
+
+	q := qListChangesAsc
+	if opts.Reverse {
+		q = qListChangesDesc
+	}
+
+	return bs.listBlobs(conn, q(), func(cur BlobCursor, c cid.Cid, data []byte) error {
+		var ch Change
+		if err := cbornode.DecodeInto(data, &ch); err != nil {
+			return fmt.Errorf("listChanges: failed to decode change %s for entity %s: %w", c, eid, err)
+		}
+		return fn(cur, c, ch)
+	}, edb.ResourcesID, ts, blobID, opts.Limit)
+}
+
+const qListChangesTpl = `
+	SELECT
+		blobs.codec,
+		blobs.multihash,
+		blobs.data,
+		structural_blobs.ts,
+		blobs.id
+	FROM structural_blobs
+	JOIN blobs ON blobs.id = structural_blobs.id
+	WHERE structural_blobs.resource = :resource
+	AND structural_blobs.type = 'Change'
+	AND (structural_blobs.ts, blobs.id) %s (:cursor_ts, :cursor_blob)
+	AND blobs.size > 0
+	ORDER BY structural_blobs.ts %s, blobs.id %s
+	LIMIT :limit;
+`
+
+var qListChangesAsc = dqb.Q(func() string {
+	return fmt.Sprintf(qListChangesTpl, ">", "ASC", "ASC")
+})
+
+var qListChangesDesc = dqb.Q(func() string {
+	return fmt.Sprintf(qListChangesTpl, "<", "DESC", "DESC")
+})
+
+// listBlobs executes the listing query and passes the decompressed blob data to the callback.
+// The query must return codec, multihash, data, timestamp and ID of the blobs.
+func (bs *Storage) listBlobs(conn *sqlite.Conn, q string, fn func(cur BlobCursor, c cid.Cid, data []byte) error, args ...any) (err error) {
+	buf := make([]byte, 0, 1024*1024) // preallocating 1MB for decompression.
+	return sqlitex.Exec(conn, q, func(stmt *sqlite.Stmt) error {
+		var (
+			codec = stmt.ColumnInt64(0)
+			hash  = stmt.ColumnBytesUnsafe(1)
+			data  = stmt.ColumnBytesUnsafe(2)
+			cur   = BlobCursor{Ts: stmt.ColumnInt64(3), BlobID: stmt.ColumnInt64(4)}
+		)
+
+		buf, err = bs.bs.decoder.DecodeAll(data, buf[:0])
+		if err != nil {
+			return err
+		}
+
+		return fn(cur, cid.NewCidV1(uint64(codec), hash), buf)
+	}, args...)
+}
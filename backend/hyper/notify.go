@@ -0,0 +1,78 @@
+package hyper
+
+import (
+	"sync"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+)
+
+// indexNotifier wakes up the listeners when new blobs get indexed.
+// Notifications are delayed until the transaction that indexed the blobs is committed,
+// otherwise listeners could query the database before the new data is visible for them.
+type indexNotifier struct {
+	mu      sync.Mutex
+	pending map[*sqlite.Conn]struct{}
+	ch      chan struct{}
+}
+
+func newIndexNotifier() *indexNotifier {
+	return &indexNotifier{
+		pending: make(map[*sqlite.Conn]struct{}),
+		ch:      make(chan struct{}),
+	}
+}
+
+// markPending records that the current transaction on conn has indexed some blobs.
+func (n *indexNotifier) markPending(conn *sqlite.Conn) {
+	n.mu.Lock()
+	n.pending[conn] = struct{}{}
+	n.mu.Unlock()
+}
+
+// finish must be called after the transaction on conn is finished.
+// Listeners are notified only if the transaction was committed.
+func (n *indexNotifier) finish(conn *sqlite.Conn, committed bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.pending[conn]; !ok {
+		return
+	}
+	delete(n.pending, conn)
+
+	if !committed {
+		return
+	}
+
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
+
+func (n *indexNotifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ch
+}
+
+// IndexUpdates returns a channel that gets closed the next time new blobs are indexed.
+// The channel fires only once, so callers must request a new one after each notification.
+// To avoid missing updates callers should request the channel before querying the indexed data.
+func (bs *indexer) IndexUpdates() <-chan struct{} {
+	return bs.notifier.wait()
+}
+
+// withTx executes fn in a transaction, and notifies the listeners
+// about the blobs indexed within it after the transaction is committed.
+// The text of the blocks changed within the transaction is indexed before committing.
+func (bs *indexer) withTx(conn *sqlite.Conn, fn func() error) error {
+	err := sqlitex.WithTx(conn, func() error {
+		if err := fn(); err != nil {
+			return err
+		}
+		return bs.indexText(conn)
+	})
+	bs.texts.take(conn)
+	bs.notifier.finish(conn, err == nil)
+	return err
+}
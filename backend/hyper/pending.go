@@ -0,0 +1,256 @@
+package hyper
+
+import (
+	"errors"
+	"fmt"
+	"mintter/backend/pkg/dqb"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/ipfs/go-cid"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// errMissingDeps is returned when some of the causal dependencies of a change or a group key are not indexed yet.
+// Blobs arrive in any order during syncing, so such blobs are not rejected, but kept as pending
+// until all of their deps are indexed.
+var errMissingDeps = errors.New("missing causal dependencies")
+
+// indexOrDefer indexes the change or the group key, or marks it as pending if some of its deps are not indexed yet.
+// If the blob gets indexed, the pending blobs of the same entity that were waiting for it are indexed too.
+// Deferred changes are retried too, because they can vouch for the pending changes they depend on.
+func (bs *indexer) indexOrDefer(conn *sqlite.Conn, id int64, c cid.Cid, eid EntityID, v any) error {
+	err := bs.tryIndexPending(conn, id, c, v)
+	if canWait(v, err) {
+		bs.log.Debug("BlobIsPending", zap.String("cid", c.String()), zap.Error(err))
+		if err := sqlitex.Exec(conn, qPendingChangesInsert(), nil, id, eid.String()); err != nil {
+			return err
+		}
+		if _, ok := v.(Change); !ok {
+			return nil
+		}
+	} else if err != nil {
+		return err
+	}
+
+	return bs.indexPendingChanges(conn, eid)
+}
+
+// canWait checks if the blob failed to index because of something that might arrive later.
+// Changes signed by a revoked key can wait for a valid change that depends on them, see isVouched.
+func canWait(v any, err error) bool {
+	if errors.Is(err, errMissingDeps) {
+		return true
+	}
+
+	_, isChange := v.(Change)
+	return isChange && errors.Is(err, errNotVouched)
+}
+
+// tryIndexPending indexes the blob within a savepoint, so nothing is left behind if it fails.
+// It uses a fresh indexing context, because the cached IDs could be rolled back.
+func (bs *indexer) tryIndexPending(conn *sqlite.Conn, id int64, c cid.Cid, v any) (err error) {
+	defer sqlitex.Save(conn)(&err)
+
+	idx := newCtx(conn)
+
+	switch v := v.(type) {
+	case Change:
+		return bs.indexChange(idx, id, c, v)
+	case GroupKey:
+		return bs.indexGroupKey(idx, id, c, v)
+	default:
+		return fmt.Errorf("blob %s of type %T can't be pending", c, v)
+	}
+}
+
+// indexPendingChanges indexes the pending blobs of the entity whose deps are indexed now.
+// Indexing one blob can unblock others, so we repeat until there's no progress.
+// Pending blobs that turn out to be invalid once their deps are known are rejected,
+// and their data is dropped, as if we never had it. Other failures keep them pending.
+func (bs *indexer) indexPendingChanges(conn *sqlite.Conn, eid EntityID) error {
+	for {
+		pending, err := bs.listPendingBlobs(conn, eid)
+		if err != nil {
+			return err
+		}
+
+		var progress bool
+		for _, p := range pending {
+			err := p.err
+			if err == nil {
+				err = bs.tryIndexPending(conn, p.ID, p.CID, p.Decoded)
+			}
+
+			if canWait(p.Decoded, err) {
+				continue
+			}
+
+			// Failures that are not caused by the blob itself, e.g. a missing key delegation,
+			// keep the blob pending, so it's retried with the next change of the entity.
+			if err != nil && !errors.Is(err, ErrInvalidBlob) {
+				var serr sqlite.Error
+				if errors.As(err, &serr) {
+					return err
+				}
+				bs.log.Debug("PendingBlobFailed", zap.String("cid", p.CID.String()), zap.Error(err))
+				continue
+			}
+			progress = true
+
+			if err := sqlitex.Exec(conn, qPendingChangesDelete(), nil, p.ID); err != nil {
+				return err
+			}
+
+			if err != nil {
+				bs.log.Warn("PendingBlobRejected", zap.String("cid", p.CID.String()), zap.Error(err))
+				if err := sqlitex.Exec(conn, qBlobsForgetData(), nil, p.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !progress {
+			return nil
+		}
+	}
+}
+
+type pendingBlob struct {
+	ID int64
+	Blob
+
+	// Error decoding the blob.
+	err error
+}
+
+// listPendingBlobs returns the decoded pending blobs of the entity, in the order we've received them.
+func (bs *indexer) listPendingBlobs(conn *sqlite.Conn, eid EntityID) ([]pendingBlob, error) {
+	var pending []pendingBlob
+	if err := sqlitex.Exec(conn, qPendingChangesList(), func(stmt *sqlite.Stmt) error {
+		c := cid.NewCidV1(uint64(stmt.ColumnInt64(1)), stmt.ColumnBytes(2))
+		data, err := bs.bs.decoder.DecodeAll(stmt.ColumnBytes(3), nil)
+		if err != nil {
+			return fmt.Errorf("failed to decompress pending blob %s: %w", c, err)
+		}
+
+		hb, err := DecodeBlob(c, data)
+		if err != nil {
+			hb.CID = c
+			err = fmt.Errorf("%w: %w", ErrInvalidBlob, err)
+		}
+
+		pending = append(pending, pendingBlob{
+			ID:   stmt.ColumnInt64(0),
+			Blob: hb,
+			err:  err,
+		})
+		return nil
+	}, eid.String()); err != nil {
+		return nil, fmt.Errorf("failed to list pending blobs: %w", err)
+	}
+
+	return pending, nil
+}
+
+// isVouched checks if some pending change of the entity, signed by a valid key, depends on the change c,
+// directly or through other pending changes. A change signed by a revoked key that we've received
+// after the revocation can't be trusted to be signed when it claims, unless a valid change depends on it.
+func (bs *indexer) isVouched(conn *sqlite.Conn, c cid.Cid, eid EntityID) (bool, error) {
+	pending, err := bs.listPendingBlobs(conn, eid)
+	if err != nil {
+		return false, err
+	}
+
+	idx := newCtx(conn)
+	visited := map[cid.Cid]struct{}{}
+
+	var vouched func(c cid.Cid) (bool, error)
+	vouched = func(c cid.Cid) (bool, error) {
+		if _, ok := visited[c]; ok {
+			return false, nil
+		}
+		visited[c] = struct{}{}
+
+		for _, p := range pending {
+			ch, ok := p.Decoded.(Change)
+			if !ok || p.err != nil || !slices.Contains(ch.Deps, c) {
+				continue
+			}
+
+			if err := ch.Verify(); err != nil {
+				continue
+			}
+
+			err := bs.checkDelegation(idx, p.ID, ch.Delegation, ch.HLCTime.Time())
+			if err == nil {
+				return true, nil
+			}
+			if !errors.Is(err, errNotVouched) {
+				continue
+			}
+
+			ok, err = vouched(p.CID)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return vouched(c)
+}
+
+// isChangeIndexed checks if the change is stored and indexed.
+func (idx *indexingCtx) isChangeIndexed(c cid.Cid) (ok bool, err error) {
+	if err := sqlitex.Exec(idx.conn, qIsChangeIndexed(), func(stmt *sqlite.Stmt) error {
+		ok = true
+		return nil
+	}, []byte(c.Hash())); err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+var qIsChangeIndexed = dqb.Str(`
+	SELECT 1
+	FROM blobs
+	JOIN structural_blobs ON structural_blobs.id = blobs.id
+	WHERE blobs.multihash = :hash
+	AND blobs.size >= 0
+	LIMIT 1;
+`)
+
+var qPendingChangesInsert = dqb.Str(`
+	INSERT OR IGNORE INTO pending_changes (id, resource) VALUES (:id, :resource);
+`)
+
+var qPendingChangesList = dqb.Str(`
+	SELECT
+		blobs.id,
+		blobs.codec,
+		blobs.multihash,
+		blobs.data
+	FROM pending_changes
+	JOIN blobs ON blobs.id = pending_changes.id
+	WHERE pending_changes.resource = :resource
+	ORDER BY blobs.id;
+`)
+
+var qPendingChangesDelete = dqb.Str(`
+	DELETE FROM pending_changes WHERE id = :id;
+`)
+
+// qBlobsForgetData turns the blob into a placeholder for a blob we know about, but don't have.
+var qBlobsForgetData = dqb.Str(`
+	UPDATE blobs
+	SET data = NULL,
+		size = -1
+	WHERE id = :id;
+`)
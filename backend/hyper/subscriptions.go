@@ -0,0 +1,104 @@
+package hyper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mintter/backend/pkg/dqb"
+	"strings"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+)
+
+// ErrInvalidSubscription is returned when trying to subscribe to something we can't subscribe to.
+var ErrInvalidSubscription = errors.New("invalid subscription")
+
+// Subscription is a resource the user is interested in.
+type Subscription struct {
+	ID         EntityID
+	CreateTime time.Time
+}
+
+// Subscribe to the given entity, so that its related blobs are synced from peers.
+// Once there's at least one subscription, only the related blobs of the subscribed entities are synced.
+func (bs *Storage) Subscribe(ctx context.Context, eid EntityID) error {
+	if err := validateSubscription(eid); err != nil {
+		return err
+	}
+
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Syncing cursors don't cover the new subscription, so its related blobs
+	// will be listed from the beginning the next time we sync with each peer.
+	return sqlitex.Exec(conn, qInsertSubscription(), nil, eid.String())
+}
+
+var qInsertSubscription = dqb.Str(`
+	INSERT OR IGNORE INTO subscriptions (iri) VALUES (?);
+`)
+
+// Unsubscribe from the given entity. It's not an error to unsubscribe from an entity we're not subscribed to.
+func (bs *Storage) Unsubscribe(ctx context.Context, eid EntityID) error {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return sqlitex.Exec(conn, qDeleteSubscription(), nil, eid.String())
+}
+
+var qDeleteSubscription = dqb.Str(`
+	DELETE FROM subscriptions WHERE iri = ?;
+`)
+
+// ListSubscriptions returns all the subscriptions in the order they were created.
+func (bs *Storage) ListSubscriptions(ctx context.Context) (out []Subscription, err error) {
+	conn, release, err := bs.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return ListSubscriptions(conn)
+}
+
+// ListSubscriptions is like the corresponding method of the Storage, but works with an existing connection.
+func ListSubscriptions(conn *sqlite.Conn) (out []Subscription, err error) {
+	if err := sqlitex.Exec(conn, qListSubscriptions(), func(stmt *sqlite.Stmt) error {
+		out = append(out, Subscription{
+			ID:         EntityID(stmt.ColumnText(0)),
+			CreateTime: time.Unix(stmt.ColumnInt64(1), 0),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var qListSubscriptions = dqb.Str(`
+	SELECT iri, insert_time
+	FROM subscriptions
+	ORDER BY insert_time, iri;
+`)
+
+func validateSubscription(eid EntityID) error {
+	for _, prefix := range [...]string{"hm://d/", "hm://g/", "hm://a/"} {
+		if eid.HasPrefix(prefix) && len(eid) > len(prefix) {
+			if strings.ContainsAny(eid.TrimPrefix(prefix), "?#") {
+				return fmt.Errorf("%w: must not point to a specific version: %s", ErrInvalidSubscription, eid)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: can only subscribe to documents, groups, or accounts: %s", ErrInvalidSubscription, eid)
+}
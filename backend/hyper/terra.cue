@@ -55,6 +55,15 @@ package terra
 	delegate:  #Principal
 	issueTime: #TimeUnix
 	purpose:   "DeviceRegistration"
+	notAfter?: #TimeUnix
+}
+
+// KeyRevocation is a blob that revokes all the delegations from the issuer to the delegate key.
+#KeyRevocation: #BaseBlob & {
+	"@type":    "KeyRevocation"
+	issuer:     #Principal
+	delegate:   #Principal
+	revokeTime: #TimeUnix
 }
 
 // BaseChange is a common structure of all the Change blobs we produce.
@@ -9,6 +9,7 @@ import (
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	"github.com/polydawn/refmt/obj/atlas"
+	"golang.org/x/exp/slices"
 )
 
 var timeAtlas = atlas.BuildEntry(time.Time{}).Transform().
@@ -23,8 +24,11 @@ var timeAtlas = atlas.BuildEntry(time.Time{}).Transform().
 func init() {
 	cbornode.RegisterCborType(timeAtlas)
 	cbornode.RegisterCborType(KeyDelegation{})
+	cbornode.RegisterCborType(KeyRevocation{})
 	cbornode.RegisterCborType(Change{})
 	cbornode.RegisterCborType(Comment{})
+	cbornode.RegisterCborType(GroupKey{})
+	cbornode.RegisterCborType(Sealed{})
 	cbornode.RegisterCborType(Block{})
 	cbornode.RegisterCborType(Annotation{})
 	cbornode.RegisterCborType(CommentBlock{})
@@ -33,9 +37,11 @@ func init() {
 // Available types.
 const (
 	TypeKeyDelegation BlobType = "KeyDelegation"
+	TypeKeyRevocation BlobType = "KeyRevocation"
 	TypeChange        BlobType = "Change"
 	TypeDagPB         BlobType = "DagPB"
 	TypeComment       BlobType = "Comment"
+	TypeGroupKey      BlobType = "GroupKey"
 )
 
 // Delegation purposes.
@@ -58,11 +64,23 @@ type KeyDelegation struct {
 	Delegate  core.Principal `refmt:"delegate"`
 	IssueTime time.Time      `refmt:"issueTime"`
 	Purpose   string         `refmt:"purpose"`
-	Signature core.Signature `refmt:"sig,omitempty"` // omitempty for signing.
+	NotAfter  time.Time      `refmt:"notAfter,omitempty"` // Optional expiration time. Zero means the delegation never expires.
+	Signature core.Signature `refmt:"sig,omitempty"`      // omitempty for signing.
+}
+
+// DelegationOption is a functional option for creating KeyDelegations.
+type DelegationOption func(*KeyDelegation)
+
+// WithNotAfter sets the expiration time of the delegation.
+// Blobs signed by the delegate after this time are not accepted.
+func WithNotAfter(t time.Time) DelegationOption {
+	return func(kd *KeyDelegation) {
+		kd.NotAfter = t
+	}
 }
 
 // NewKeyDelegation creates a new signed key delegation from one key to another.
-func NewKeyDelegation(issuer core.KeyPair, delegate core.PublicKey, validFrom time.Time) (kd KeyDelegation, err error) {
+func NewKeyDelegation(issuer core.KeyPair, delegate core.PublicKey, validFrom time.Time, opts ...DelegationOption) (kd KeyDelegation, err error) {
 	if validFrom.IsZero() {
 		return kd, fmt.Errorf("must specify valid from timestamp")
 	}
@@ -74,6 +92,13 @@ func NewKeyDelegation(issuer core.KeyPair, delegate core.PublicKey, validFrom ti
 		Purpose:   "DeviceRegistration",
 		IssueTime: validFrom,
 	}
+	for _, o := range opts {
+		o(&d)
+	}
+
+	if !d.NotAfter.IsZero() && !d.NotAfter.After(d.IssueTime) {
+		return kd, fmt.Errorf("delegation must expire after it's issued")
+	}
 
 	data, err := cbornode.DumpObject(d)
 	if err != nil {
@@ -116,6 +141,77 @@ func (kd KeyDelegation) Blob() Blob {
 	return hb
 }
 
+// ExpiredAt checks whether the delegation is no longer valid at the given time.
+func (kd KeyDelegation) ExpiredAt(t time.Time) bool {
+	return !kd.NotAfter.IsZero() && t.After(kd.NotAfter)
+}
+
+// KeyRevocation is a signed payload which revokes all the delegations
+// from the issuer to the delegate key, e.g. when a device gets lost or compromised.
+// Blobs signed by the delegate at or after the revoke time are not accepted on behalf of the issuer.
+// Blobs signed before that time remain valid, because otherwise revoking a device would destroy its past work.
+type KeyRevocation struct {
+	Type       BlobType       `refmt:"@type"`
+	Issuer     core.Principal `refmt:"issuer"`
+	Delegate   core.Principal `refmt:"delegate"`
+	RevokeTime time.Time      `refmt:"revokeTime"`
+	Signature  core.Signature `refmt:"sig,omitempty"` // omitempty for signing.
+}
+
+// NewKeyRevocation creates a new signed revocation for the delegate key.
+func NewKeyRevocation(issuer core.KeyPair, delegate core.Principal, revokeTime time.Time) (kr KeyRevocation, err error) {
+	if revokeTime.IsZero() {
+		return kr, fmt.Errorf("must specify revoke timestamp")
+	}
+
+	r := KeyRevocation{
+		Type:       TypeKeyRevocation,
+		Issuer:     issuer.Principal(),
+		Delegate:   delegate,
+		RevokeTime: revokeTime,
+	}
+
+	data, err := cbornode.DumpObject(r)
+	if err != nil {
+		return kr, fmt.Errorf("failed to encode signing bytes for key revocation: %w", err)
+	}
+
+	sig, err := issuer.Sign(data)
+	if err != nil {
+		return kr, fmt.Errorf("failed to sign key revocation %w", err)
+	}
+
+	r.Signature = sig
+
+	return r, nil
+}
+
+// Verify signature of the revocation.
+func (kr KeyRevocation) Verify() error {
+	sig := kr.Signature
+	kr.Signature = nil
+
+	data, err := cbornode.DumpObject(kr)
+	if err != nil {
+		return fmt.Errorf("failed to encoding signing bytes to verify key revocation: %w", err)
+	}
+
+	if err := kr.Issuer.Verify(data, sig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Blob encodes the revocation into a blob.
+func (kr KeyRevocation) Blob() Blob {
+	hb, err := EncodeBlob(kr)
+	if err != nil {
+		panic(err)
+	}
+	return hb
+}
+
 // Actions for entity changes.
 const (
 	ActionCreate = "Create"
@@ -156,13 +252,25 @@ type Change struct {
 	// TODO(burdiyan): point to docs.
 	Patch map[string]any `refmt:"patch,omitempty"`
 
+	// Sealed is the encrypted part of the patch for entities private to a group.
+	// Only the fields needed to verify the entity stay in the plain text patch (see PublicPatchFields).
+	// When the change is opened the sealed fields are merged into the patch.
+	Sealed *Sealed `refmt:"sealed,omitempty"`
+
 	// Signer is the public key of the signer.
 	Signer core.Principal `refmt:"signer,omitempty"`
 
 	// Sig is the signature over the rest of the fields.
 	Sig core.Signature `refmt:"sig,omitempty"`
+
+	// sealWith is the key to seal the patch with when creating the change.
+	sealWith *ContentKey
 }
 
+// PublicPatchFields are the fields of the patch that are never sealed,
+// because they are needed to verify the entity without knowing the content key.
+var PublicPatchFields = []string{"nonce", "createTime", "owner", "privateGroup"}
+
 // NewChange creates a new Change blob.
 func NewChange(eid EntityID, deps []cid.Cid, ts hlc.Timestamp, signer core.KeyPair, delegation cid.Cid, patch map[string]any, opts ...ChangeOption) (hb Blob, err error) {
 	// Make sure deps field is not present in the patch if there're no deps.
@@ -189,6 +297,29 @@ func NewChange(eid EntityID, deps []cid.Cid, ts hlc.Timestamp, signer core.KeyPa
 		o(&ch)
 	}
 
+	if ch.sealWith != nil {
+		public := make(map[string]any, len(PublicPatchFields))
+		secret := make(map[string]any, len(patch))
+		for k, v := range patch {
+			if slices.Contains(PublicPatchFields, k) {
+				public[k] = v
+			} else {
+				secret[k] = v
+			}
+		}
+
+		ch.Sealed, err = ch.sealWith.seal(secret)
+		if err != nil {
+			return hb, fmt.Errorf("failed to seal change: %w", err)
+		}
+
+		ch.Patch = public
+		if len(public) == 0 {
+			ch.Patch = nil
+		}
+		ch.sealWith = nil
+	}
+
 	sigdata, err := cbornode.DumpObject(ch)
 	if err != nil {
 		return hb, fmt.Errorf("failed to encode signing bytes for change %w", err)
@@ -233,12 +364,25 @@ type Comment struct {
 	RepliedComment cid.Cid        `refmt:"repliedComment,omitempty"`
 	HLCTime        hlc.Timestamp  `refmt:"hlcTime"`
 	Body           []CommentBlock `refmt:"body"`
+	Sealed         *Sealed        `refmt:"sealed,omitempty"` // Encrypted body for comments on private entities.
 	Signer         core.Principal `refmt:"signer,omitempty"`
 	Sig            core.Signature `refmt:"sig,omitempty"`
+
+	sealWith *ContentKey
+}
+
+// CommentOption is a functional option for creating Comments.
+type CommentOption func(*Comment)
+
+// WithCommentContentKey seals the body of the comment with the content key of a private group.
+func WithCommentContentKey(key ContentKey) CommentOption {
+	return func(c *Comment) {
+		c.sealWith = &key
+	}
 }
 
 // NewComment creates a new Comment blob.
-func NewComment(target string, threadRoot, repliedComment cid.Cid, ts hlc.Timestamp, signer core.KeyPair, delegation cid.Cid, body []CommentBlock) (hb Blob, err error) {
+func NewComment(target string, threadRoot, repliedComment cid.Cid, ts hlc.Timestamp, signer core.KeyPair, delegation cid.Cid, body []CommentBlock, opts ...CommentOption) (hb Blob, err error) {
 	c := Comment{
 		Type:           TypeComment,
 		Delegation:     delegation,
@@ -249,6 +393,18 @@ func NewComment(target string, threadRoot, repliedComment cid.Cid, ts hlc.Timest
 		Body:           body,
 		Signer:         signer.Principal(),
 	}
+	for _, o := range opts {
+		o(&c)
+	}
+
+	if c.sealWith != nil {
+		c.Sealed, err = c.sealWith.seal(c.Body)
+		if err != nil {
+			return hb, fmt.Errorf("failed to seal comment: %w", err)
+		}
+		c.Body = nil
+		c.sealWith = nil
+	}
 
 	sigdata, err := cbornode.DumpObject(c)
 	if err != nil {
@@ -285,6 +441,102 @@ func (c Comment) Verify() error {
 	return nil
 }
 
+// Sealed is a piece of content encrypted with the content key of a private group.
+// The content is CBOR-encoded, and encrypted with XChaCha20-Poly1305.
+type Sealed struct {
+	Group EntityID `refmt:"group"`
+	KeyID string   `refmt:"keyID"`
+	Nonce []byte   `refmt:"nonce"`
+	Data  []byte   `refmt:"data"`
+}
+
+// GroupKey is a signed blob which distributes the content key of a private group to its members.
+// Account keys never leave the devices where they were created, so the content key is sealed
+// for each of the device keys delegated by each of the member accounts.
+// Must be signed by the owner or an admin of the group at the state of the deps.
+// The same key can be distributed multiple times, e.g. when new members are added.
+// When members are removed, a new key must be created.
+type GroupKey struct {
+	Type       BlobType                     `refmt:"@type"`
+	Group      EntityID                     `refmt:"group"`
+	KeyID      string                       `refmt:"keyID"`
+	Deps       []cid.Cid                    `refmt:"deps,omitempty"` // Heads of the group the membership is based on.
+	Keys       map[string]map[string][]byte `refmt:"keys"`           // Member account -> device -> sealed content key.
+	Delegation cid.Cid                      `refmt:"delegation"`
+	HLCTime    hlc.Timestamp                `refmt:"hlcTime"`
+	Signer     core.Principal               `refmt:"signer,omitempty"`
+	Sig        core.Signature               `refmt:"sig,omitempty"`
+}
+
+// NewGroupKey creates a new GroupKey blob, sealing the content key for the devices of each member.
+// Devices are keyed by the string-encoded principal of the member account.
+func NewGroupKey(key ContentKey, deps []cid.Cid, ts hlc.Timestamp, signer core.KeyPair, delegation cid.Cid, devices map[string][]core.Principal) (hb Blob, err error) {
+	if len(deps) == 0 {
+		return hb, fmt.Errorf("group key must have deps")
+	}
+
+	gk := GroupKey{
+		Type:       TypeGroupKey,
+		Group:      key.Group,
+		KeyID:      key.ID,
+		Deps:       SortCIDs(slices.Clone(deps)),
+		Keys:       make(map[string]map[string][]byte, len(devices)),
+		Delegation: delegation,
+		HLCTime:    ts,
+		Signer:     signer.Principal(),
+	}
+
+	for member, devs := range devices {
+		sealed := make(map[string][]byte, len(devs))
+		for _, dev := range devs {
+			pub, err := dev.Libp2pKey()
+			if err != nil {
+				return hb, err
+			}
+
+			pk, err := core.NewPublicKey(pub)
+			if err != nil {
+				return hb, err
+			}
+
+			sealed[dev.String()], err = pk.SealAnonymous(key.Key)
+			if err != nil {
+				return hb, fmt.Errorf("failed to seal group key for device %s: %w", dev, err)
+			}
+		}
+		gk.Keys[member] = sealed
+	}
+
+	sigdata, err := cbornode.DumpObject(gk)
+	if err != nil {
+		return hb, fmt.Errorf("failed to encode signing bytes for group key %w", err)
+	}
+
+	gk.Sig, err = signer.Sign(sigdata)
+	if err != nil {
+		return hb, fmt.Errorf("failed to sign group key: %w", err)
+	}
+
+	return EncodeBlob(gk)
+}
+
+// Verify group key signature.
+func (gk GroupKey) Verify() error {
+	sig := gk.Sig
+	gk.Sig = nil
+
+	data, err := cbornode.DumpObject(gk)
+	if err != nil {
+		return fmt.Errorf("failed to encoding signing bytes to verify group key blob: %w", err)
+	}
+
+	if err := gk.Signer.Verify(data, sig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Block is a block of text with annotations.
 type Block struct {
 	ID          string            `refmt:"id,omitempty"` // Omitempty when used in Documents.
@@ -82,10 +82,10 @@ type Bitswap struct {
 
 // NewBitswap creates a new Bitswap wrapper.
 // Users must call Close() for shutdown.
-func NewBitswap(host host.Host, rt routing.ContentRouting, bs blockstore.Blockstore) (*Bitswap, error) {
+func NewBitswap(host host.Host, rt routing.ContentRouting, bs blockstore.Blockstore, opts ...bitswap.Option) (*Bitswap, error) {
 	net := network.NewFromIpfsHost(host, rt)
 	ctx, cancel := context.WithCancel(context.Background())
-	b := bitswap.New(ctx, net, bs, bitswap.ProvideEnabled(true))
+	b := bitswap.New(ctx, net, bs, append([]bitswap.Option{bitswap.ProvideEnabled(true)}, opts...)...)
 
 	return &Bitswap{
 		Bitswap:        b,
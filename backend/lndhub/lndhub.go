@@ -58,6 +58,7 @@ type Client struct {
 	db              *sqlitex.Pool
 	WalletID        string
 	pubKey          *future.ReadOnly[string]
+	identity        *future.ReadOnly[core.Identity]
 	lndhubDomain    string
 	lnaddressDomain string
 }
@@ -109,6 +110,7 @@ func NewClient(ctx context.Context, h *http.Client, db *sqlitex.Pool, identity *
 		http:            h,
 		db:              db,
 		pubKey:          f.ReadOnly,
+		identity:        identity,
 		lndhubDomain:    lndhubDomain,
 		lnaddressDomain: lnaddressDomain,
 	}
@@ -136,6 +138,16 @@ func NewClient(ctx context.Context, h *http.Client, db *sqlitex.Pool, identity *
 	return &client
 }
 
+// deviceKey returns the key used to encrypt the wallet credentials in the database.
+func (c *Client) deviceKey(ctx context.Context) (core.KeyPair, error) {
+	id, err := c.identity.Await(ctx)
+	if err != nil {
+		return core.KeyPair{}, err
+	}
+
+	return id.DeviceKey(), nil
+}
+
 // GetLndhubDomain gets the lndhub domain set at creation.
 func (c *Client) GetLndhubDomain() string {
 	return c.lndhubDomain
@@ -199,11 +211,15 @@ func (c *Client) UpdateNickname(ctx context.Context, nickname string) error {
 	}
 	defer release()
 
-	login, err := lndhub.GetLogin(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
+	if err != nil {
+		return err
+	}
+	login, err := lndhub.GetLogin(conn, device, c.WalletID)
 	if err != nil {
 		return err
 	}
-	pass, err := lndhub.GetPassword(conn, c.WalletID)
+	pass, err := lndhub.GetPassword(conn, device, c.WalletID)
 	if err != nil {
 		return err
 	}
@@ -245,11 +261,15 @@ func (c *Client) GetLnAddress(ctx context.Context) (string, error) {
 	}
 	defer release()
 
-	login, err := lndhub.GetLogin(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
 	if err != nil {
 		return "", err
 	}
-	pass, err := lndhub.GetPassword(conn, c.WalletID)
+	login, err := lndhub.GetLogin(conn, device, c.WalletID)
+	if err != nil {
+		return "", err
+	}
+	pass, err := lndhub.GetPassword(conn, device, c.WalletID)
 	if err != nil {
 		return "", err
 	}
@@ -276,11 +296,15 @@ func (c *Client) Auth(ctx context.Context) (string, error) {
 	}
 	defer release()
 
-	login, err := lndhub.GetLogin(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
+	if err != nil {
+		return resp.AccessToken, err
+	}
+	login, err := lndhub.GetLogin(conn, device, c.WalletID)
 	if err != nil {
 		return resp.AccessToken, err
 	}
-	pass, err := lndhub.GetPassword(conn, c.WalletID)
+	pass, err := lndhub.GetPassword(conn, device, c.WalletID)
 	if err != nil {
 		return resp.AccessToken, err
 	}
@@ -299,7 +323,7 @@ func (c *Client) Auth(ctx context.Context) (string, error) {
 	if err != nil {
 		return resp.AccessToken, err
 	}
-	return resp.AccessToken, lndhub.SetToken(conn, c.WalletID, resp.AccessToken)
+	return resp.AccessToken, lndhub.SetToken(conn, device, c.WalletID, resp.AccessToken)
 }
 
 // GetBalance gets the confirmed balance in satoshis of the account.
@@ -318,7 +342,11 @@ func (c *Client) GetBalance(ctx context.Context) (uint64, error) {
 	defer release()
 
 	var resp balanceResponse
-	token, err := lndhub.GetToken(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
+	if err != nil {
+		return resp.Btc.Sats, err
+	}
+	token, err := lndhub.GetToken(conn, device, c.WalletID)
 	if err != nil {
 		return resp.Btc.Sats, err
 	}
@@ -348,7 +376,11 @@ func (c *Client) ListPaidInvoices(ctx context.Context) ([]Invoice, error) {
 	}
 
 	var resp ListInvoicesResponse
-	token, err := lndhub.GetToken(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
+	if err != nil {
+		return resp.Invoices, err
+	}
+	token, err := lndhub.GetToken(conn, device, c.WalletID)
 	if err != nil {
 		return resp.Invoices, err
 	}
@@ -378,7 +410,11 @@ func (c *Client) ListReceivedInvoices(ctx context.Context) ([]Invoice, error) {
 	}
 
 	var resp ListInvoicesResponse
-	token, err := lndhub.GetToken(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
+	if err != nil {
+		return resp.Invoices, err
+	}
+	token, err := lndhub.GetToken(conn, device, c.WalletID)
 	if err != nil {
 		return resp.Invoices, err
 	}
@@ -418,7 +454,11 @@ func (c *Client) CreateLocalInvoice(ctx context.Context, sats int64, memo string
 	}
 	defer release()
 
-	token, err := lndhub.GetToken(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
+	if err != nil {
+		return resp.PayReq, err
+	}
+	token, err := lndhub.GetToken(conn, device, c.WalletID)
 	if err != nil {
 		return resp.PayReq, err
 	}
@@ -504,7 +544,11 @@ func (c *Client) PayInvoice(ctx context.Context, payReq string, sats uint64) err
 	}
 	defer release()
 
-	token, err := lndhub.GetToken(conn, c.WalletID)
+	device, err := c.deviceKey(ctx)
+	if err != nil {
+		return err
+	}
+	token, err := lndhub.GetToken(conn, device, c.WalletID)
 	if err != nil {
 		return err
 	}
@@ -577,11 +621,15 @@ func (c *Client) do(ctx context.Context, conn *sqlite.Conn, request httpRequest,
 					var authResp authResponse
 					// Check if token expired and we need to issue one
 					if ok && strings.Contains(errMsg.(string), "bad auth") {
-						login, err := lndhub.GetLogin(conn, c.WalletID)
+						device, err := c.deviceKey(ctx)
+						if err != nil {
+							return err
+						}
+						login, err := lndhub.GetLogin(conn, device, c.WalletID)
 						if err != nil {
 							return err
 						}
-						pass, err := lndhub.GetPassword(conn, c.WalletID)
+						pass, err := lndhub.GetPassword(conn, device, c.WalletID)
 						if err != nil {
 							return err
 						}
@@ -601,7 +649,7 @@ func (c *Client) do(ctx context.Context, conn *sqlite.Conn, request httpRequest,
 						if err != nil {
 							return err
 						}
-						if err = lndhub.SetToken(conn, c.WalletID, authResp.AccessToken); err != nil {
+						if err = lndhub.SetToken(conn, device, c.WalletID, authResp.AccessToken); err != nil {
 							return err
 						}
 					}
@@ -62,7 +62,7 @@ func TestCreate(t *testing.T) {
 	require.NoError(t, identity.Resolve(core.NewIdentity(pubkey, keypair)))
 	lndHubClient.WalletID = credentials2Id("lndhub.go", login, password, lndhubDomain)
 
-	makeTestWallet(t, conn, walletsql.Wallet{
+	makeTestWallet(t, conn, keypair, walletsql.Wallet{
 		ID:      lndHubClient.WalletID,
 		Address: connectionURL,
 		Name:    nickname,
@@ -124,12 +124,8 @@ func randStringRunes(n int) string {
 	return string(b)
 }
 
-func makeTestWallet(t *testing.T, conn *sqlite.Conn, wallet walletsql.Wallet, login, pass, token string) {
-	binaryToken := []byte(token)   // TODO: encrypt the token before storing
-	binaryLogin := []byte(login)   // TODO: encrypt the login before storing
-	binaryPassword := []byte(pass) // TODO: encrypt the password before storing
-
-	require.NoError(t, walletsql.InsertWallet(conn, wallet, binaryLogin, binaryPassword, binaryToken))
+func makeTestWallet(t *testing.T, conn *sqlite.Conn, device core.KeyPair, wallet walletsql.Wallet, login, pass, token string) {
+	require.NoError(t, walletsql.InsertWallet(conn, device, wallet, []byte(login), []byte(pass), []byte(token)))
 }
 
 func makeConn(t *testing.T) (*sqlitex.Pool, error) {
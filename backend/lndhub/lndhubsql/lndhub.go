@@ -3,6 +3,8 @@ package lndhubsql
 import (
 	"errors"
 	"fmt"
+	"mintter/backend/core"
+	"mintter/backend/daemon/storage"
 
 	"crawshaw.io/sqlite"
 )
@@ -34,41 +36,55 @@ func GetAPIURL(conn *sqlite.Conn, id string) (string, error) {
 }
 
 // GetLogin returns the login used to connect to the wallet.
-func GetLogin(conn *sqlite.Conn, id string) (string, error) {
+// The login is decrypted with the device key.
+func GetLogin(conn *sqlite.Conn, device core.KeyPair, id string) (string, error) {
 	res, err := getLogin(conn, id)
-	if err == nil && len(res.WalletsLogin) == 0 {
+	if err != nil {
+		return "", err
+	}
+	if len(res.WalletsLogin) == 0 {
 		return "", fmt.Errorf("Could not find any login associated with provided id [%s]: %w", id, ErrEmptyResult)
 	}
-	// TODO: decrypt login before returning
-	return string(res.WalletsLogin[:]), err
+	login, err := storage.OpenCredential(device, res.WalletsLogin)
+	return string(login), err
 }
 
 // GetPassword returns the password used to connect to the wallet.
-func GetPassword(conn *sqlite.Conn, id string) (string, error) {
+// The password is decrypted with the device key.
+func GetPassword(conn *sqlite.Conn, device core.KeyPair, id string) (string, error) {
 	res, err := getPassword(conn, id)
-	if err == nil && len(res.WalletsPassword) == 0 {
+	if err != nil {
+		return "", err
+	}
+	if len(res.WalletsPassword) == 0 {
 		return "", fmt.Errorf("Could not find any password associated with provided id [%s]: %w", id, ErrEmptyResult)
 	}
-	// TODO: decrypt pass before returning
-	return string(res.WalletsPassword[:]), err
+	pass, err := storage.OpenCredential(device, res.WalletsPassword)
+	return string(pass), err
 }
 
 // GetToken returns the token used to connect to the wallet. The response is
-// a slice of bytes representing the token used to connect to the rest api.
-func GetToken(conn *sqlite.Conn, id string) (string, error) {
+// the token used to connect to the rest api, decrypted with the device key.
+func GetToken(conn *sqlite.Conn, device core.KeyPair, id string) (string, error) {
 	res, err := getToken(conn, id)
-	// TODO: decrypt token before returning
-	if err == nil && len(res.WalletsToken) == 0 {
+	if err != nil {
+		return "", err
+	}
+	if len(res.WalletsToken) == 0 {
 		return "", fmt.Errorf("Could not find any token associated with provided id [%s]: %w", id, ErrEmptyResult)
 	}
-	return string(res.WalletsToken[:]), err
+	token, err := storage.OpenCredential(device, res.WalletsToken)
+	return string(token), err
 }
 
 // SetToken stores the token to authenticate in non account routes
-// in lndhub.go.
-func SetToken(conn *sqlite.Conn, id, token string) error {
-	return setToken(conn, []byte(token), id)
-	// TODO: decrypt token before returning
+// in lndhub.go. The token is encrypted with the device key.
+func SetToken(conn *sqlite.Conn, device core.KeyPair, id, token string) error {
+	sealed, err := storage.SealCredential(device, []byte(token))
+	if err != nil {
+		return err
+	}
+	return setToken(conn, sealed, id)
 }
 
 // SetLoginSignature stores the sigature (hex representation) of the
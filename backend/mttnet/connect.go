@@ -33,6 +33,46 @@ var (
 	})
 )
 
+// Features advertised in the handshake.
+const (
+	// FeatureReconcileBlobs is advertised by peers that support the ReconcileBlobs RPC.
+	FeatureReconcileBlobs = "reconcile-blobs/v1"
+
+	// FeatureListBlobsFilter is advertised by peers that support filtering ListBlobs by resources.
+	FeatureListBlobsFilter = "list-blobs-filter/v1"
+)
+
+// supportedFeatures are advertised to other peers during the handshake.
+var supportedFeatures = []string{
+	FeatureReconcileBlobs,
+	FeatureListBlobsFilter,
+}
+
+// Peerstore key for the features the remote peer advertised in the handshake.
+const peerFeaturesKey = "mintter/features"
+
+// PeerSupports checks whether the remote peer has advertised support for the given feature
+// during the last handshake with it.
+func PeerSupports(ps peerstore.Peerstore, pid peer.ID, feature string) bool {
+	v, err := ps.Get(pid, peerFeaturesKey)
+	if err != nil {
+		return false
+	}
+
+	features, ok := v.([]string)
+	if !ok {
+		return false
+	}
+
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Increasing the default temporary TTL for peerstore, to ensure that we
 // don't forget the addresses between sync intervals.
 func init() {
@@ -187,6 +227,10 @@ func (n *Node) verifyHandshake(ctx context.Context, pid peer.ID, pb *p2p.Handsha
 		return fmt.Errorf("failed to save handshake key delegation blob: %w", err)
 	}
 
+	if err := n.p2p.Peerstore().Put(pid, peerFeaturesKey, pb.Features); err != nil {
+		return fmt.Errorf("failed to store peer features: %w", err)
+	}
+
 	n.p2p.ConnManager().Protect(pid, protocolSupportKey)
 
 	return nil
@@ -214,8 +258,8 @@ func (srv *rpcMux) Handshake(ctx context.Context, in *p2p.HandshakeInfo) (*p2p.H
 	}
 
 	if err := n.verifyHandshake(ctx, pid, in); err != nil {
-		// TODO(burdiyan): implement blocking and disconnecting from bad peers.
 		log.Warn("FailedToVerifyIncomingMintterHandshake", zap.Error(err))
+		n.policy.ReportViolation(pid, "bad handshake")
 		return nil, fmt.Errorf("you gave me a bad handshake")
 	}
 
@@ -239,6 +283,7 @@ func (n *Node) handshakeInfo(ctx context.Context) (*p2p.HandshakeInfo, error) {
 	hinfo := &p2p.HandshakeInfo{
 		KeyDelegationCid:  c.Bytes(),
 		KeyDelegationData: blk.RawData(),
+		Features:          supportedFeatures,
 	}
 
 	return hinfo, nil
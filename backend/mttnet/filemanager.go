@@ -53,15 +53,22 @@ type HTTPHandler interface {
 	UploadFile(http.ResponseWriter, *http.Request)
 }
 
+// BlobPinner protects blobs from garbage collection.
+type BlobPinner interface {
+	PinBlob(ctx context.Context, c cid.Cid) error
+}
+
 // FileManager is the main object to handle ipfs files.
 type FileManager struct {
 	log        *zap.Logger
 	DAGService ipld.DAGService
 	provider   provider.Provider
+	pins       BlobPinner
 }
 
 // NewFileManager creates a new fileManager instance.
-func NewFileManager(log *zap.Logger, bs blockstore.Blockstore, bitswap exchange.Interface, prov provider.Provider) *FileManager {
+// Uploaded files are pinned, so they are not garbage collected before they are linked from some content.
+func NewFileManager(log *zap.Logger, bs blockstore.Blockstore, bitswap exchange.Interface, prov provider.Provider, pins BlobPinner) *FileManager {
 	bsvc := blockservice.New(bs, bitswap)
 	// Don't close the blockservice, because it doesn't do anything useful.
 	// It's actually closing the exchange, which is not even its responsibility.
@@ -74,6 +81,7 @@ func NewFileManager(log *zap.Logger, bs blockstore.Blockstore, bitswap exchange.
 		log:        log,
 		provider:   prov,
 		DAGService: dag,
+		pins:       pins,
 	}
 }
 
@@ -182,6 +190,12 @@ func (fm *FileManager) UploadFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := fm.pins.PinBlob(r.Context(), n.Cid()); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Failed to pin file: %v", err.Error())
+		return
+	}
+
 	// Providing is best-effort so we don't fail the request if it fails.
 	if err = fm.provider.Provide(n.Cid()); err != nil {
 		fm.log.Warn("Failed to provide file", zap.Error(err))
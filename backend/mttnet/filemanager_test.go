@@ -2,6 +2,7 @@ package mttnet
 
 import (
 	"bytes"
+	"context"
 	"io/ioutil"
 	"mime/multipart"
 	"mintter/backend/core/coretest"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/ipfs/boxo/blockstore"
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/sync"
 	crypto "github.com/libp2p/go-libp2p/core/crypto"
@@ -72,6 +74,7 @@ func TestPostGet(t *testing.T) {
 	responseData, err := ioutil.ReadAll(res.Body)
 	require.NoError(t, err)
 	require.Equal(t, fileCID, string(responseData))
+	require.Equal(t, []string{fileCID}, server.pins.(*pinRecorder).pinned, "uploaded files must be pinned")
 	res = makeRequest(t, "GET", "/ipfs/"+string(responseData), nil, router)
 	require.Equal(t, http.StatusOK, res.Code)
 	require.Equal(t, fileBytes, res.Body.Bytes())
@@ -132,7 +135,16 @@ func makeManager(t *testing.T, k crypto.PrivKey) *FileManager {
 	providing, err := ipfs.NewProviderSystem(ds, n.Routing, bs.AllKeysChan)
 	require.NoError(t, err)
 
-	return NewFileManager(logging.New("mintter/ipfs", "debug"), bs, bitswap, providing)
+	return NewFileManager(logging.New("mintter/ipfs", "debug"), bs, bitswap, providing, &pinRecorder{})
+}
+
+type pinRecorder struct {
+	pinned []string
+}
+
+func (p *pinRecorder) PinBlob(ctx context.Context, c cid.Cid) error {
+	p.pinned = append(p.pinned, c.String())
+	return nil
 }
 
 // createFile0toBound creates a file with the number 0 to bound.
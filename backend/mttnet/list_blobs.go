@@ -1,16 +1,23 @@
 package mttnet
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"mintter/backend/core"
 	p2p "mintter/backend/genproto/p2p/v1alpha"
+	"mintter/backend/hyper"
 	"mintter/backend/pkg/dqb"
 
 	"crawshaw.io/sqlite"
 	"crawshaw.io/sqlite/sqlitex"
 	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	rpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 // ListBlobs lists all the blobs that the node has.
@@ -26,6 +33,7 @@ func (srv *rpcMux) ListBlobs(in *p2p.ListBlobsRequest, stream p2p.P2P_ListBlobsS
 	if in.Cursor != "" {
 		c, err = decodeCursor(in.Cursor)
 		if err != nil {
+			srv.reportViolation(ctx, "bad ListBlobs cursor")
 			return fmt.Errorf("failed to decode cursor %s: %w", in.Cursor, err)
 		}
 	}
@@ -45,29 +53,69 @@ func (srv *rpcMux) ListBlobs(in *p2p.ListBlobsRequest, stream p2p.P2P_ListBlobsS
 	// that is further than our real database. In this case we just pretend they don't have any cursor
 	// and we start from the beginning returning all the blobs in the list.
 	// It's not optimal, but this shouldn't happen too often to matter.
+	// We let the client know about it, so it can discard the invalid cursor.
+	var reset bool
 	if c.ID > maxid {
 		c.ID = 0
+		reset = true
 	}
 
-	return sqlitex.Exec(conn, qListBlobs(), func(stmt *sqlite.Stmt) error {
+	// Private blobs are only listed for the devices of the group members.
+	device, err := remoteDevice(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := qListBlobs()
+	args := []any{c.ID}
+	if len(in.Resources) > 0 {
+		if len(in.Resources) > maxListBlobsResources {
+			srv.reportViolation(ctx, "too many ListBlobs resources")
+			return status.Errorf(codes.InvalidArgument, "too many resources to filter: %d, max is %d", len(in.Resources), maxListBlobsResources)
+		}
+
+		resources, err := json.Marshal(in.Resources)
+		if err != nil {
+			return err
+		}
+
+		query = qListRelatedBlobs()
+		args = []any{string(resources), c.ID}
+	}
+
+	return sqlitex.Exec(conn, query, func(stmt *sqlite.Stmt) error {
 		id := stmt.ColumnInt64(0)
 		codec := stmt.ColumnInt64(1)
 		hash := stmt.ColumnBytesUnsafe(2)
 
-		c := cid.NewCidV1(uint64(codec), hash)
+		blob := cid.NewCidV1(uint64(codec), hash)
 
-		cur, err := encodeCursor(cursor{ID: id})
+		allowed, err := hyper.CheckBlobAccess(conn, id, device)
 		if err != nil {
-			srv.Node.log.Warn("FailedToEncodeCursor", zap.Error(err), zap.String("blob", c.String()))
-			return fmt.Errorf("failed to encode cursor: %w", err)
+			return err
+		}
+		if !allowed {
+			return nil
 		}
 
-		if err := stream.Send(&p2p.Blob{Cid: c.Bytes(), Cursor: cur}); err != nil {
+		// Key delegations of the new related blobs can be older than the cursor.
+		// They are sent without a cursor, so the client doesn't move its cursor back.
+		var cur string
+		if id > c.ID {
+			cur, err = encodeCursor(cursor{ID: id})
+			if err != nil {
+				srv.Node.log.Warn("FailedToEncodeCursor", zap.Error(err), zap.String("blob", blob.String()))
+				return fmt.Errorf("failed to encode cursor: %w", err)
+			}
+		}
+
+		if err := stream.Send(&p2p.Blob{Cid: blob.Bytes(), Cursor: cur, CursorReset: reset}); err != nil {
 			return err
 		}
+		reset = false
 
 		return nil
-	}, c.ID)
+	}, args...)
 }
 
 var qListBlobs = dqb.Str(`
@@ -82,6 +130,88 @@ var qListBlobs = dqb.Str(`
 	AND blobs.id > ?;
 `)
 
+// Maximum number of resources we accept as a filter for listing blobs.
+const maxListBlobsResources = 1000
+
+// qListRelatedBlobs is like qListBlobs, but only returns the blobs related to the given resources.
+// Those are the changes of the resources, the comments targeting them,
+// and the key delegations of the authors. For groups we also include the resources published in the group.
+// The cursor only applies to the related blobs, and their key delegations are always included,
+// because they are often older than the cursor. Blobs are returned in the order of their IDs,
+// so the key delegations come before the blobs signed with them.
+var qListRelatedBlobs = dqb.Str(`
+	WITH
+	wanted (id) AS (
+		SELECT resources.id
+		FROM resources, json_each(:resources) AS res
+		WHERE resources.iri = res.value
+	),
+	all_wanted (id) AS (
+		SELECT id FROM wanted
+		UNION
+		SELECT resource_links.target
+		FROM structural_blobs
+		JOIN resource_links ON resource_links.source = structural_blobs.id
+		WHERE structural_blobs.resource IN wanted
+		AND resource_links.type = 'group/content'
+	),
+	related (id) AS (
+		SELECT structural_blobs.id
+		FROM structural_blobs
+		WHERE structural_blobs.resource IN all_wanted
+		AND structural_blobs.id > :cursor
+		UNION
+		SELECT resource_links.source
+		FROM resource_links
+		WHERE resource_links.target IN all_wanted
+		AND resource_links.type = 'comment/target'
+		AND resource_links.source > :cursor
+	),
+	related_with_auth (id) AS (
+		SELECT id FROM related
+		UNION
+		SELECT blob_links.target
+		FROM blob_links
+		WHERE blob_links.source IN related
+		AND blob_links.type IN ('change/auth', 'comment/auth', 'groupkey/auth')
+	)
+	SELECT
+		blobs.id,
+		blobs.codec,
+		blobs.multihash
+	FROM blobs INDEXED BY blobs_metadata
+	LEFT OUTER JOIN drafts ON drafts.blob = blobs.id
+	WHERE blobs.size >= 0
+	AND drafts.blob IS NULL
+	AND blobs.id IN related_with_auth
+	ORDER BY blobs.id;
+`)
+
+// remotePeer returns the ID of the remote peer calling the RPC.
+func remotePeer(ctx context.Context) (peer.ID, error) {
+	info, ok := rpcpeer.FromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("BUG: no peer info in context for grpc")
+	}
+
+	return peer.Decode(info.Addr.String())
+}
+
+// remoteDevice returns the principal of the remote device calling the RPC.
+func remoteDevice(ctx context.Context) (core.Principal, error) {
+	pid, err := remotePeer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := pid.ExtractPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return core.PrincipalFromPubKey(pk), nil
+}
+
 func getMaxBlobID(conn *sqlite.Conn) (int64, error) {
 	var max int64
 	if err := sqlitex.Exec(conn, "SELECT MAX(rowid) FROM blobs", func(stmt *sqlite.Stmt) error {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"mintter/backend/core"
+	"mintter/backend/core/coretest"
 	p2p "mintter/backend/genproto/p2p/v1alpha"
 	"mintter/backend/hyper"
 	"mintter/backend/hyper/hypersql"
@@ -26,12 +27,7 @@ func TestListBlobs(t *testing.T) {
 	alice, stopalice := makeTestPeer(t, "alice")
 	defer stopalice()
 	ctx := context.Background()
-	lis := bufconn.Listen(1024 * 1024) // mocked connection.
-	go func() {
-		if err := alice.grpc.Serve(lis); err != nil {
-			panic(err)
-		}
-	}()
+	lis := serveTestPeer(t, alice, coretest.NewTester("bob").Device.PeerID())
 
 	del, err := getDelegation(ctx, alice.me, alice.blobs)
 	require.NoError(t, err)
@@ -57,7 +53,7 @@ func TestListBlobs(t *testing.T) {
 		"draftField": true,
 	})
 	require.NoError(t, err)
-	require.NoError(t, alice.blobs.SaveDraftBlob(ctx, entity.ID(), c2))
+	require.NoError(t, alice.blobs.SaveDraftBlob(ctx, alice.me.Account().Principal(), entity.ID(), c2))
 	blobs = flattenBlobStream(t, ctx, lis, cursor)
 	require.Len(t, blobs, 0, "alice must not list draft blobs")
 
@@ -76,7 +72,7 @@ func TestListBlobs(t *testing.T) {
 	blobs = flattenBlobStream(t, ctx, lis, cursor)
 	require.Len(t, blobs, 0, "alice must not list draft blobs")
 
-	_, err = alice.blobs.PublishDraft(ctx, entity.ID())
+	_, err = alice.blobs.PublishDraft(ctx, alice.me.Account().Principal(), entity.ID())
 	require.NoError(t, err, "alice must publish the draft")
 
 	blobs = flattenBlobStream(t, ctx, lis, cursor)
@@ -84,7 +80,49 @@ func TestListBlobs(t *testing.T) {
 	require.Equal(t, c2.CID.Bytes(), blobs[0].Cid, "alice draft blob CID must match")
 }
 
-func flattenBlobStream(t *testing.T, ctx context.Context, lis *bufconn.Listener, cursor string) []*p2p.Blob {
+func TestListRelatedBlobs(t *testing.T) {
+	t.Parallel()
+
+	alice, stopalice := makeTestPeer(t, "alice")
+	defer stopalice()
+	ctx := context.Background()
+	lis := serveTestPeer(t, alice, coretest.NewTester("bob").Device.PeerID())
+
+	del, err := getDelegation(ctx, alice.me, alice.blobs)
+	require.NoError(t, err)
+
+	newChange := func(eid string) hyper.Blob {
+		entity := hyper.NewEntity(hyper.EntityID(eid))
+		c, err := entity.CreateChange(entity.NextTimestamp(), alice.me.DeviceKey(), del, map[string]any{
+			"name": eid,
+		})
+		require.NoError(t, err)
+		require.NoError(t, alice.blobs.SaveBlob(ctx, c))
+		return c
+	}
+
+	foo := newChange("foo")
+
+	blobs := flattenBlobStream(t, ctx, lis, "", "foo")
+	require.Len(t, blobs, 2, "alice must list the change and its key delegation")
+	require.Equal(t, del.Bytes(), blobs[0].Cid, "key delegation must come before the change")
+	require.Equal(t, foo.CID.Bytes(), blobs[1].Cid)
+	cursor := blobs[1].Cursor
+
+	bar := newChange("bar")
+
+	blobs = flattenBlobStream(t, ctx, lis, cursor, "foo", "bar")
+	require.Len(t, blobs, 2, "alice must list the new change and its key delegation older than the cursor")
+	require.Equal(t, del.Bytes(), blobs[0].Cid)
+	require.Equal(t, "", blobs[0].Cursor, "blobs older than the cursor must not move the cursor back")
+	require.Equal(t, bar.CID.Bytes(), blobs[1].Cid)
+	require.NotEqual(t, "", blobs[1].Cursor)
+
+	blobs = flattenBlobStream(t, ctx, lis, blobs[1].Cursor, "foo", "bar")
+	require.Len(t, blobs, 0, "alice must not list anything after the cursor")
+}
+
+func flattenBlobStream(t *testing.T, ctx context.Context, lis *bufconn.Listener, cursor string, resources ...string) []*p2p.Blob {
 	t.Helper()
 
 	conn, err := grpc.DialContext(ctx, "peer", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
@@ -96,7 +134,7 @@ func flattenBlobStream(t *testing.T, ctx context.Context, lis *bufconn.Listener,
 	c := p2p.NewP2PClient(conn)
 
 	var out []*p2p.Blob
-	stream, err := c.ListBlobs(ctx, &p2p.ListBlobsRequest{Cursor: cursor})
+	stream, err := c.ListBlobs(ctx, &p2p.ListBlobsRequest{Cursor: cursor, Resources: resources})
 	require.NoError(t, err)
 
 	for {
@@ -0,0 +1,142 @@
+package mttnet
+
+import (
+	"context"
+	"io"
+	"mintter/backend/pkg/cleanup"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+)
+
+// localDiscoveryService is the DNS-SD service name Mintter peers announce themselves with on the local network.
+// It's different from the default libp2p one to avoid discovering unrelated libp2p peers.
+const localDiscoveryService = "_mintter._udp"
+
+// localPeers keeps track of the Mintter peers discovered on the local network.
+type localPeers struct {
+	mu      sync.Mutex
+	peers   map[peer.ID]struct{}
+	changed chan struct{}
+}
+
+func newLocalPeers() *localPeers {
+	return &localPeers{
+		peers:   make(map[peer.ID]struct{}),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+func (lp *localPeers) add(pid peer.ID) {
+	lp.mu.Lock()
+	_, ok := lp.peers[pid]
+	lp.peers[pid] = struct{}{}
+	lp.mu.Unlock()
+
+	if ok {
+		return
+	}
+
+	lp.notify()
+}
+
+// remove forgets the peer when it leaves the network. It returns false if the peer wasn't known.
+func (lp *localPeers) remove(pid peer.ID) bool {
+	lp.mu.Lock()
+	_, ok := lp.peers[pid]
+	delete(lp.peers, pid)
+	lp.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	lp.notify()
+	return true
+}
+
+func (lp *localPeers) notify() {
+	// Non-blocking notification. If there's a pending one already, it will cover this change as well.
+	select {
+	case lp.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (lp *localPeers) list() []peer.ID {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	return maps.Keys(lp.peers)
+}
+
+// LocalPeers returns the Mintter peers discovered on the local network, which we did the handshake with.
+// It's always empty unless local discovery is enabled in the config.
+func (n *Node) LocalPeers() []peer.ID {
+	return n.local.list()
+}
+
+// LocalPeersChanged returns a channel that receives a value when peers are discovered on the local network, or leave it.
+// Notifications are coalesced, so there's only one consumer of this channel.
+func (n *Node) LocalPeersChanged() <-chan struct{} {
+	return n.local.changed
+}
+
+// startLocalDiscovery starts announcing our node on the local network using mDNS,
+// and connecting to other Mintter peers found there.
+// Local peers are forgotten when we get disconnected from them.
+func (n *Node) startLocalDiscovery(ctx context.Context) (io.Closer, error) {
+	var clean cleanup.Stack
+
+	nb := &network.NotifyBundle{
+		DisconnectedF: func(net network.Network, c network.Conn) {
+			pid := c.RemotePeer()
+			if net.Connectedness(pid) == network.Connected {
+				return
+			}
+
+			if n.local.remove(pid) {
+				n.log.Debug("LocalPeerLost", zap.String("peer", pid.String()))
+			}
+		},
+	}
+	n.p2p.Host.Network().Notify(nb)
+	clean.AddErrFunc(func() error {
+		n.p2p.Host.Network().StopNotify(nb)
+		return nil
+	})
+
+	svc := mdns.NewMdnsService(n.p2p.Host, localDiscoveryService, localNotifee{ctx: ctx, n: n})
+	if err := svc.Start(); err != nil {
+		return nil, multierr.Combine(err, clean.Close())
+	}
+	clean.Add(svc)
+
+	return &clean, nil
+}
+
+type localNotifee struct {
+	ctx context.Context
+	n   *Node
+}
+
+// HandlePeerFound implements mdns.Notifee.
+func (ln localNotifee) HandlePeerFound(info peer.AddrInfo) {
+	// Connect does the handshake, which makes sure the peer is a valid Mintter peer.
+	go func() {
+		log := ln.n.log.With(zap.String("peer", info.ID.String()))
+
+		if err := ln.n.Connect(ln.ctx, info); err != nil {
+			log.Debug("FailedToConnectLocalPeer", zap.Error(err))
+			return
+		}
+
+		log.Debug("LocalPeerDiscovered")
+		ln.n.local.add(info.ID)
+	}()
+}
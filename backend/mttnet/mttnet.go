@@ -64,6 +64,9 @@ type WebsiteClient interface {
 
 	// PublishBlobs pushes given blobs to the site.
 	PublishBlobs(context.Context, *groups_proto.PublishBlobsRequest, ...grpc.CallOption) (*groups_proto.PublishBlobsResponse, error)
+
+	// GetCallerPermissions gets our permissions and capabilities on the site.
+	GetCallerPermissions(context.Context, *groups_proto.GetCallerPermissionsRequest, ...grpc.CallOption) (*groups_proto.CallerPermissions, error)
 }
 
 // DefaultRelays bootstrap mintter-owned relays so they can reserve slots to do holepunch.
@@ -121,6 +124,9 @@ type Node struct {
 	bitswap   *ipfs.Bitswap
 	providing provider.System
 	grpc      *grpc.Server
+	policy    *PeerPolicy
+	local     *localPeers
+	reconcile *reconcileIndex
 	quit      io.Closer
 	ready     chan struct{}
 	ctx       context.Context // will be set after calling Start()
@@ -137,7 +143,9 @@ func New(cfg config.P2P, db *sqlitex.Pool, blobs *hyper.Storage, me core.Identit
 	}
 	clean.Add(closeHost)
 
-	bitswap, err := ipfs.NewBitswap(host, host.Routing, blobs.IPFSBlockstore())
+	policy := newPeerPolicy(db, log, nil)
+
+	bitswap, err := ipfs.NewBitswap(host, host.Routing, blobs.IPFSBlockstore(), blockRequestFilter(blobs, policy, log))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start bitswap: %w", err)
 	}
@@ -149,7 +157,12 @@ func New(cfg config.P2P, db *sqlitex.Pool, blobs *hyper.Storage, me core.Identit
 	if log.Level() != zapcore.InvalidLevel { // Usually test with zap.NewNop()
 		logLevel = log.Level().String()
 	}
-	providing, err := ipfs.NewProviderSystem(host.Datastore(), host.Routing, makeProvidingStrategy(db, logLevel))
+	strategy, err := makeProvidingStrategy(db, cfg, me.DeviceKey().Principal(), logLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize providing strategy: %w", err)
+	}
+
+	providing, err := ipfs.NewProviderSystem(host.Datastore(), host.Routing, strategy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize providing: %w", err)
 	}
@@ -176,11 +189,16 @@ func New(cfg config.P2P, db *sqlitex.Pool, blobs *hyper.Storage, me core.Identit
 		p2p:       host,
 		bitswap:   bitswap,
 		providing: providing,
-		grpc:      grpc.NewServer(),
+		grpc:      grpc.NewServer(grpc.ChainUnaryInterceptor(policy.unaryInterceptor), grpc.ChainStreamInterceptor(policy.streamInterceptor)),
+		policy:    policy,
+		local:     newLocalPeers(),
+		reconcile: newReconcileIndex(blobs),
 		quit:      &clean,
 		ready:     make(chan struct{}),
 	}
 
+	policy.accountFor = n.AccountForDevice
+
 	rpc := &rpcMux{Node: n}
 	p2p.RegisterP2PServer(n.grpc, rpc)
 
@@ -221,6 +239,11 @@ func (n *Node) ProvideCID(c cid.Cid) error {
 	return nil
 }
 
+// Policy returns the policy applied to the remote peers.
+func (n *Node) Policy() *PeerPolicy {
+	return n.policy
+}
+
 // Bitswap returns the underlying Bitswap service.
 func (n *Node) Bitswap() *ipfs.Bitswap {
 	return n.bitswap
@@ -357,6 +380,10 @@ func (n *Node) Start(ctx context.Context) (err error) {
 
 	defer func() { n.log.Debug("P2PNodeFinished", zap.Error(err)) }()
 
+	if err := n.policy.load(ctx); err != nil {
+		return err
+	}
+
 	if err := n.startLibp2p(ctx); err != nil {
 		return err
 	}
@@ -368,6 +395,18 @@ func (n *Node) Start(ctx context.Context) (err error) {
 
 	g, ctx := errgroup.WithContext(ctx)
 
+	if n.cfg.LocalDiscovery {
+		mdns, err := n.startLocalDiscovery(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start local discovery: %w", err)
+		}
+
+		g.Go(func() error {
+			<-ctx.Done()
+			return mdns.Close()
+		})
+	}
+
 	// Start Mintter protocol listener over libp2p.
 	{
 		g.Go(func() error {
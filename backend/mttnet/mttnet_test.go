@@ -12,11 +12,14 @@ import (
 	"mintter/backend/logging"
 	"mintter/backend/pkg/future"
 	"mintter/backend/pkg/must"
+	"net"
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 var _ p2p.P2PServer = (*rpcMux)(nil)
@@ -86,3 +89,46 @@ func makeTestPeer(t *testing.T, name string) (*Node, context.CancelFunc) {
 
 	return n, cancel
 }
+
+// serveTestPeer serves the RPCs of the node over a mocked connection.
+// Connections look like they come from the remote peer, the same way they do with the libp2p transport,
+// so the RPC handlers can tell who's calling them.
+func serveTestPeer(t *testing.T, n *Node, remote peer.ID) *bufconn.Listener {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		if err := n.grpc.Serve(peerListener{Listener: lis, remote: remote}); err != nil {
+			panic(err)
+		}
+	}()
+
+	return lis
+}
+
+type peerListener struct {
+	net.Listener
+	remote peer.ID
+}
+
+func (l peerListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return peerConn{Conn: conn, remote: l.remote}, nil
+}
+
+type peerConn struct {
+	net.Conn
+	remote peer.ID
+}
+
+func (c peerConn) RemoteAddr() net.Addr { return peerAddr(c.remote) }
+
+type peerAddr peer.ID
+
+func (a peerAddr) Network() string { return "libp2p" }
+
+func (a peerAddr) String() string { return peer.ID(a).String() }
@@ -0,0 +1,545 @@
+package mttnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mintter/backend/core"
+	"mintter/backend/daemon/storage"
+	"sort"
+	"sync"
+	"time"
+
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/ipfs/boxo/bitswap"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	mPolicyRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mintter_peer_policy_rejections_total",
+		Help: "Number of incoming requests rejected by the peer policy.",
+	}, []string{"reason"})
+
+	mPeerBans = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "mintter_peer_bans_total",
+		Help: "Number of times peers got banned.",
+	})
+)
+
+const (
+	// Peers get banned after this many protocol violations.
+	banViolationsThreshold = 10
+
+	// DefaultBanDuration is used for bans when no duration is specified.
+	DefaultBanDuration = time.Hour
+)
+
+// Key of the kv table where the allow and deny lists are persisted.
+const peerPolicyKey = "peer_policy"
+
+// rateLimit defines a token bucket that refills at the rate of tokens per second
+// up to the burst size.
+type rateLimit struct {
+	Rate  float64
+	Burst float64
+}
+
+// Limits for the bitswap block requests are not for the whole request but for each wanted block.
+// Exceeding them only rejects the blocks, and doesn't count as a protocol violation.
+const limitBitswap = "bitswap"
+
+// peerRateLimits are applied to each remote peer separately.
+// The values are generous for well-behaved peers, which only sync periodically.
+var peerRateLimits = map[string]rateLimit{
+	"/com.mintter.p2p.v1alpha.P2P/ListBlobs":           {Rate: 1, Burst: 10},
+	"/com.mintter.p2p.v1alpha.P2P/ReconcileBlobs":      {Rate: 10, Burst: 50},
+	"/com.mintter.p2p.v1alpha.P2P/RequestInvoice":      {Rate: 0.2, Burst: 5},
+	"/com.mintter.groups.v1alpha.Website/PublishBlobs": {Rate: 1, Burst: 10},
+	limitBitswap: {Rate: 200, Burst: 2000},
+}
+
+// PeerAccess is the access level of a peer or an account in the peer policy.
+type PeerAccess int
+
+// Access levels of the peer policy.
+const (
+	PeerAccessDefault PeerAccess = iota // Not in any list.
+	PeerAccessAllow
+	PeerAccessDeny
+)
+
+// PeerBan describes a banned peer.
+type PeerBan struct {
+	Peer       peer.ID
+	ExpireTime time.Time
+	Violations int
+}
+
+// PeerPolicySnapshot is the current state of the peer policy.
+type PeerPolicySnapshot struct {
+	Allowed []string
+	Denied  []string
+	Bans    []PeerBan
+}
+
+type persistedPolicy struct {
+	Allowed []string `json:"allowed,omitempty"`
+	Denied  []string `json:"denied,omitempty"`
+}
+
+type bucketKey struct {
+	peer  peer.ID
+	limit string
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(now time.Time, l rateLimit) bool {
+	if b.isFull(now, l) {
+		b.tokens = l.Burst
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * l.Rate
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// isFull checks if the bucket would be refilled completely by now,
+// in which case it's the same as a new one.
+func (b *tokenBucket) isFull(now time.Time, l rateLimit) bool {
+	return b.last.IsZero() || b.tokens+now.Sub(b.last).Seconds()*l.Rate >= l.Burst
+}
+
+// When there're more buckets than this we drop the full ones.
+const maxTokenBuckets = 4096
+
+// PeerPolicy decides which remote peers can use our node, and how much.
+// Peers and accounts can be explicitly allowed or denied. Denying always wins.
+// When the allow list is not empty, only the peers in it, or the devices of the accounts in it, are served.
+// Peers that repeatedly violate the protocol or exceed the rate limits get banned for a while.
+// The allow and deny lists are persisted, bans are kept in memory.
+type PeerPolicy struct {
+	db         *sqlitex.Pool
+	log        *zap.Logger
+	accountFor func(context.Context, peer.ID) (core.Principal, error)
+	now        func() time.Time
+
+	mu         sync.Mutex
+	loaded     bool
+	allowed    map[string]struct{} // Peer IDs or account principals.
+	denied     map[string]struct{} // Peer IDs or account principals.
+	accounts   map[peer.ID]string  // Cache of device -> account.
+	bans       map[peer.ID]time.Time
+	violations map[peer.ID]int
+	buckets    map[bucketKey]*tokenBucket
+}
+
+func newPeerPolicy(db *sqlitex.Pool, log *zap.Logger, accountFor func(context.Context, peer.ID) (core.Principal, error)) *PeerPolicy {
+	return &PeerPolicy{
+		db:         db,
+		log:        log,
+		accountFor: accountFor,
+		now:        time.Now,
+		allowed:    make(map[string]struct{}),
+		denied:     make(map[string]struct{}),
+		accounts:   make(map[peer.ID]string),
+		bans:       make(map[peer.ID]time.Time),
+		violations: make(map[peer.ID]int),
+		buckets:    make(map[bucketKey]*tokenBucket),
+	}
+}
+
+// load reads the persisted allow and deny lists from the database.
+// It's called when the node starts, and is a no-op after the first successful call.
+func (pp *PeerPolicy) load(ctx context.Context) error {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	return pp.loadLocked(ctx)
+}
+
+func (pp *PeerPolicy) loadLocked(ctx context.Context) error {
+	if pp.loaded {
+		return nil
+	}
+
+	data, err := storage.GetKV(ctx, pp.db, peerPolicyKey)
+	if err != nil {
+		return fmt.Errorf("failed to load peer policy: %w", err)
+	}
+
+	if data != "" {
+		var v persistedPolicy
+		if err := json.Unmarshal([]byte(data), &v); err != nil {
+			return fmt.Errorf("failed to decode peer policy: %w", err)
+		}
+
+		for _, id := range v.Allowed {
+			pp.allowed[id] = struct{}{}
+		}
+		for _, id := range v.Denied {
+			pp.denied[id] = struct{}{}
+		}
+	}
+
+	pp.loaded = true
+
+	return nil
+}
+
+// SetAccess puts the peer or the account with the given ID into the corresponding list,
+// removing it from the other one. Default access removes the ID from both lists.
+func (pp *PeerPolicy) SetAccess(ctx context.Context, id string, access PeerAccess) error {
+	id, err := normalizePolicyID(id)
+	if err != nil {
+		return err
+	}
+
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	// Make sure we don't overwrite the persisted lists if the node hasn't started yet.
+	if err := pp.loadLocked(ctx); err != nil {
+		return err
+	}
+
+	delete(pp.allowed, id)
+	delete(pp.denied, id)
+
+	switch access {
+	case PeerAccessDefault:
+	case PeerAccessAllow:
+		pp.allowed[id] = struct{}{}
+	case PeerAccessDeny:
+		pp.denied[id] = struct{}{}
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid peer access %d", access)
+	}
+
+	data, err := json.Marshal(persistedPolicy{
+		Allowed: sortedKeys(pp.allowed),
+		Denied:  sortedKeys(pp.denied),
+	})
+	if err != nil {
+		return err
+	}
+
+	return storage.SetKV(ctx, pp.db, peerPolicyKey, string(data), true)
+}
+
+// Ban the peer for the given duration, or for the default duration if it's zero.
+func (pp *PeerPolicy) Ban(pid peer.ID, d time.Duration) {
+	if d <= 0 {
+		d = DefaultBanDuration
+	}
+
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	pp.ban(pid, d)
+}
+
+func (pp *PeerPolicy) ban(pid peer.ID, d time.Duration) {
+	pp.bans[pid] = pp.now().Add(d)
+	mPeerBans.Inc()
+	pp.log.Info("PeerBanned", zap.String("peer", pid.String()), zap.Duration("duration", d), zap.Int("violations", pp.violations[pid]))
+}
+
+// Unban lifts the ban of the peer and forgets its violations.
+func (pp *PeerPolicy) Unban(pid peer.ID) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	delete(pp.bans, pid)
+	delete(pp.violations, pid)
+}
+
+// ReportViolation records a protocol violation of the remote peer.
+// Peers that keep violating the protocol get banned.
+func (pp *PeerPolicy) ReportViolation(pid peer.ID, reason string) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	pp.reportViolation(pid, reason)
+}
+
+func (pp *PeerPolicy) reportViolation(pid peer.ID, reason string) {
+	pp.violations[pid]++
+	pp.log.Debug("PeerProtocolViolation", zap.String("peer", pid.String()), zap.String("reason", reason), zap.Int("violations", pp.violations[pid]))
+
+	if pp.violations[pid] >= banViolationsThreshold {
+		pp.ban(pid, DefaultBanDuration)
+		pp.violations[pid] = 0
+	}
+}
+
+// Snapshot returns the current state of the policy.
+func (pp *PeerPolicy) Snapshot() PeerPolicySnapshot {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	now := pp.now()
+
+	out := PeerPolicySnapshot{
+		Allowed: sortedKeys(pp.allowed),
+		Denied:  sortedKeys(pp.denied),
+	}
+
+	for pid, exp := range pp.bans {
+		if now.After(exp) {
+			continue
+		}
+
+		out.Bans = append(out.Bans, PeerBan{
+			Peer:       pid,
+			ExpireTime: exp,
+			Violations: pp.violations[pid],
+		})
+	}
+
+	sort.Slice(out.Bans, func(i, j int) bool { return out.Bans[i].Peer < out.Bans[j].Peer })
+
+	return out
+}
+
+// Check returns an error if the peer is not allowed to use our node.
+func (pp *PeerPolicy) Check(ctx context.Context, pid peer.ID) error {
+	return pp.check(ctx, pid, true)
+}
+
+func (pp *PeerPolicy) check(ctx context.Context, pid peer.ID, requireAllowed bool) error {
+	pp.mu.Lock()
+	exp, banned := pp.bans[pid]
+	if banned && pp.now().After(exp) {
+		delete(pp.bans, pid)
+		banned = false
+	}
+	needAccount := len(pp.allowed) > 0 || len(pp.denied) > 0
+	account, hasAccount := pp.accounts[pid]
+	pp.mu.Unlock()
+
+	if banned {
+		mPolicyRejections.WithLabelValues("banned").Inc()
+		return status.Errorf(codes.PermissionDenied, "peer %s is banned until %s", pid, exp.UTC().Format(time.RFC3339))
+	}
+
+	if !needAccount {
+		return nil
+	}
+
+	if !hasAccount && pp.accountFor != nil {
+		// Devices we haven't done the handshake with are unknown, so we can only match them by peer ID.
+		if acc, err := pp.accountFor(ctx, pid); err == nil {
+			account = acc.String()
+			hasAccount = true
+
+			pp.mu.Lock()
+			pp.accounts[pid] = account
+			pp.mu.Unlock()
+		}
+	}
+
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	if _, ok := pp.denied[pid.String()]; ok {
+		mPolicyRejections.WithLabelValues("denied").Inc()
+		return status.Errorf(codes.PermissionDenied, "peer %s is denied", pid)
+	}
+
+	if hasAccount {
+		if _, ok := pp.denied[account]; ok {
+			mPolicyRejections.WithLabelValues("denied").Inc()
+			return status.Errorf(codes.PermissionDenied, "account %s is denied", account)
+		}
+	}
+
+	if !requireAllowed || len(pp.allowed) == 0 {
+		return nil
+	}
+
+	if _, ok := pp.allowed[pid.String()]; ok {
+		return nil
+	}
+
+	if hasAccount {
+		if _, ok := pp.allowed[account]; ok {
+			return nil
+		}
+	}
+
+	mPolicyRejections.WithLabelValues("not_allowed").Inc()
+	return status.Errorf(codes.PermissionDenied, "peer %s is not in the allow list", pid)
+}
+
+// Limit takes a token from the peer's bucket for the given limit.
+// Exceeding the rate limit counts as a protocol violation.
+func (pp *PeerPolicy) Limit(pid peer.ID, limit string) error {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	if pp.take(pid, limit) {
+		return nil
+	}
+
+	mPolicyRejections.WithLabelValues("rate_limited").Inc()
+	pp.reportViolation(pid, "rate limit exceeded: "+limit)
+
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", limit)
+}
+
+// throttle is like Limit, but exceeding the rate limit is not a protocol violation.
+// It's used for bitswap, where well-behaved peers can legitimately want lots of blocks at once,
+// e.g. during the initial sync or when downloading large files.
+func (pp *PeerPolicy) throttle(pid peer.ID, limit string) bool {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	if pp.take(pid, limit) {
+		return true
+	}
+
+	mPolicyRejections.WithLabelValues("throttled").Inc()
+	return false
+}
+
+func (pp *PeerPolicy) take(pid peer.ID, limit string) bool {
+	l, ok := peerRateLimits[limit]
+	if !ok {
+		return true
+	}
+
+	now := pp.now()
+
+	k := bucketKey{peer: pid, limit: limit}
+	b := pp.buckets[k]
+	if b == nil {
+		if len(pp.buckets) >= maxTokenBuckets {
+			pp.pruneBuckets(now)
+		}
+		b = &tokenBucket{}
+		pp.buckets[k] = b
+	}
+
+	return b.take(now, l)
+}
+
+func (pp *PeerPolicy) pruneBuckets(now time.Time) {
+	for k, b := range pp.buckets {
+		if b.isFull(now, peerRateLimits[k.limit]) {
+			delete(pp.buckets, k)
+		}
+	}
+}
+
+func (pp *PeerPolicy) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := pp.checkRPC(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (pp *PeerPolicy) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := pp.checkRPC(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// Devices of the allowed accounts can only be recognized after the handshake,
+// so it's the only RPC that doesn't require the peer to be in the allow list.
+const handshakeMethod = "/com.mintter.p2p.v1alpha.P2P/Handshake"
+
+func (pp *PeerPolicy) checkRPC(ctx context.Context, method string) error {
+	pid, err := remotePeer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := pp.check(ctx, pid, method != handshakeMethod); err != nil {
+		return err
+	}
+
+	return pp.Limit(pid, method)
+}
+
+// reportViolation reports the protocol violation of the peer calling the RPC.
+func (srv *rpcMux) reportViolation(ctx context.Context, reason string) {
+	pid, err := remotePeer(ctx)
+	if err != nil {
+		return
+	}
+
+	srv.Node.policy.ReportViolation(pid, reason)
+}
+
+// blockRequestFilter makes bitswap serve blocks only to the peers allowed by the policy,
+// and serve private blobs only to the devices of the group members.
+func blockRequestFilter(blobs blobAccessChecker, pp *PeerPolicy, log *zap.Logger) bitswap.Option {
+	return bitswap.WithPeerBlockRequestFilter(func(pid peer.ID, c cid.Cid) bool {
+		if err := pp.Check(context.Background(), pid); err != nil {
+			return false
+		}
+
+		if !pp.throttle(pid, limitBitswap) {
+			return false
+		}
+
+		pk, err := pid.ExtractPublicKey()
+		if err != nil {
+			return false
+		}
+
+		allowed, err := blobs.IsBlobAllowedForDevice(context.Background(), c, core.PrincipalFromPubKey(pk))
+		if err != nil {
+			log.Debug("BlobAccessCheckError", zap.String("peer", pid.String()), zap.String("cid", c.String()), zap.Error(err))
+			return false
+		}
+
+		return allowed
+	})
+}
+
+type blobAccessChecker interface {
+	IsBlobAllowedForDevice(context.Context, cid.Cid, core.Principal) (bool, error)
+}
+
+// normalizePolicyID makes sure the ID is a valid peer ID or account principal.
+func normalizePolicyID(id string) (string, error) {
+	if acc, err := core.DecodePrincipal(id); err == nil {
+		return acc.String(), nil
+	}
+
+	pid, err := peer.Decode(id)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "%q is neither a peer ID nor an account ID", id)
+	}
+
+	return pid.String(), nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	out := maps.Keys(m)
+	sort.Strings(out)
+	return out
+}
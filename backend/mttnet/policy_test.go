@@ -0,0 +1,146 @@
+package mttnet
+
+import (
+	"context"
+	"fmt"
+	"mintter/backend/core"
+	"mintter/backend/core/coretest"
+	"mintter/backend/daemon/storage"
+	"mintter/backend/logging"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPeerPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+
+	accounts := map[peer.ID]core.Principal{
+		alice.Device.PeerID(): alice.Account.Principal(),
+		bob.Device.PeerID():   bob.Account.Principal(),
+		carol.Device.PeerID(): carol.Account.Principal(),
+	}
+	accountFor := func(ctx context.Context, pid peer.ID) (core.Principal, error) {
+		acc, ok := accounts[pid]
+		if !ok {
+			return nil, fmt.Errorf("unknown device %s", pid)
+		}
+		return acc, nil
+	}
+
+	db := storage.MakeTestDB(t)
+	pp := newPeerPolicy(db, logging.New("mintter/policy", "debug"), accountFor)
+	require.NoError(t, pp.load(ctx))
+
+	requireCode := func(want codes.Code, err error, msg string) {
+		t.Helper()
+		require.Error(t, err, msg)
+		require.Equal(t, want, status.Code(err), msg)
+	}
+
+	// Everyone is allowed by default.
+	require.NoError(t, pp.Check(ctx, alice.Device.PeerID()))
+	require.NoError(t, pp.Check(ctx, bob.Device.PeerID()))
+
+	// Denying an account denies its devices.
+	require.NoError(t, pp.SetAccess(ctx, bob.Account.Principal().String(), PeerAccessDeny))
+	require.NoError(t, pp.Check(ctx, alice.Device.PeerID()))
+	requireCode(codes.PermissionDenied, pp.Check(ctx, bob.Device.PeerID()), "bob's account must be denied")
+
+	// Non-empty allow list rejects everyone else.
+	require.NoError(t, pp.SetAccess(ctx, alice.Device.PeerID().String(), PeerAccessAllow))
+	require.NoError(t, pp.Check(ctx, alice.Device.PeerID()))
+	requireCode(codes.PermissionDenied, pp.Check(ctx, carol.Device.PeerID()), "carol must not be allowed")
+	require.NoError(t, pp.check(ctx, carol.Device.PeerID(), false), "carol must be able to do the handshake")
+	requireCode(codes.PermissionDenied, pp.check(ctx, bob.Device.PeerID(), false), "denied peers can't do the handshake")
+
+	require.NoError(t, pp.SetAccess(ctx, carol.Account.Principal().String(), PeerAccessAllow))
+	require.NoError(t, pp.Check(ctx, carol.Device.PeerID()))
+
+	// Deny wins over allow.
+	require.NoError(t, pp.SetAccess(ctx, carol.Device.PeerID().String(), PeerAccessDeny))
+	requireCode(codes.PermissionDenied, pp.Check(ctx, carol.Device.PeerID()), "denied device of an allowed account must be denied")
+
+	requireCode(codes.InvalidArgument, pp.SetAccess(ctx, "foo", PeerAccessAllow), "bad ids must fail")
+
+	{
+		reloaded := newPeerPolicy(db, logging.New("mintter/policy", "debug"), accountFor)
+		require.NoError(t, reloaded.load(ctx))
+		require.Equal(t, pp.Snapshot(), reloaded.Snapshot(), "policy must be persisted")
+
+		notLoaded := newPeerPolicy(db, logging.New("mintter/policy", "debug"), accountFor)
+		require.NoError(t, notLoaded.SetAccess(ctx, carol.Device.PeerID().String(), PeerAccessDeny))
+		require.Equal(t, pp.Snapshot(), notLoaded.Snapshot(), "changing the policy before loading must not drop the persisted lists")
+	}
+
+	// Removing from the lists brings back the default access.
+	for _, id := range []string{bob.Account.Principal().String(), alice.Device.PeerID().String(), carol.Account.Principal().String(), carol.Device.PeerID().String()} {
+		require.NoError(t, pp.SetAccess(ctx, id, PeerAccessDefault))
+	}
+	require.Equal(t, PeerPolicySnapshot{Allowed: []string{}, Denied: []string{}}, pp.Snapshot())
+	require.NoError(t, pp.Check(ctx, bob.Device.PeerID()))
+	require.NoError(t, pp.Check(ctx, carol.Device.PeerID()))
+}
+
+func TestPeerPolicyLimits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+
+	pp := newPeerPolicy(storage.MakeTestDB(t), logging.New("mintter/policy", "debug"), nil)
+	require.NoError(t, pp.load(ctx))
+
+	now := time.Now()
+	pp.now = func() time.Time { return now }
+
+	const method = "/com.mintter.p2p.v1alpha.P2P/ListBlobs"
+	limit := peerRateLimits[method]
+
+	for i := 0; i < int(limit.Burst); i++ {
+		require.NoError(t, pp.Limit(alice.Device.PeerID(), method), "must allow bursts")
+	}
+	err := pp.Limit(alice.Device.PeerID(), method)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), "must reject requests over the burst")
+	require.NoError(t, pp.Limit(bob.Device.PeerID(), method), "limits must be per peer")
+
+	now = now.Add(time.Duration(float64(time.Second) / limit.Rate))
+	require.NoError(t, pp.Limit(alice.Device.PeerID(), method), "tokens must be refilled over time")
+	require.Error(t, pp.Limit(alice.Device.PeerID(), method))
+
+	for i := 0; i < int(peerRateLimits[limitBitswap].Burst)*2; i++ {
+		pp.throttle(alice.Device.PeerID(), limitBitswap)
+	}
+	require.False(t, pp.throttle(alice.Device.PeerID(), limitBitswap), "must throttle bitswap requests over the burst")
+
+	// Two rate limit violations happened already. Throttled bitswap requests don't count.
+	for i := 0; i < banViolationsThreshold-3; i++ {
+		pp.ReportViolation(alice.Device.PeerID(), "test")
+	}
+	require.NoError(t, pp.Check(ctx, alice.Device.PeerID()), "must not ban before the threshold")
+
+	pp.ReportViolation(alice.Device.PeerID(), "test")
+	err = pp.Check(ctx, alice.Device.PeerID())
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "must ban after repeated violations")
+	require.Len(t, pp.Snapshot().Bans, 1)
+	require.Equal(t, alice.Device.PeerID(), pp.Snapshot().Bans[0].Peer)
+
+	now = now.Add(DefaultBanDuration + time.Second)
+	require.NoError(t, pp.Check(ctx, alice.Device.PeerID()), "bans must expire")
+	require.Len(t, pp.Snapshot().Bans, 0)
+
+	pp.Ban(bob.Device.PeerID(), time.Minute)
+	require.Error(t, pp.Check(ctx, bob.Device.PeerID()))
+	pp.Unban(bob.Device.PeerID())
+	require.NoError(t, pp.Check(ctx, bob.Device.PeerID()))
+}
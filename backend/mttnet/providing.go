@@ -2,24 +2,58 @@ package mttnet
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"math/rand"
+	"mintter/backend/config"
+	"mintter/backend/core"
 	"mintter/backend/hyper"
-	"mintter/backend/hyper/hypersql"
 	"mintter/backend/logging"
+	"mintter/backend/pkg/dqb"
+	"strings"
 	"time"
 
+	"crawshaw.io/sqlite"
 	"crawshaw.io/sqlite/sqlitex"
 	"github.com/ipfs/boxo/provider"
 	"github.com/ipfs/go-cid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
 )
 
 var randSrc = rand.NewSource(time.Now().UnixNano())
 
-func makeProvidingStrategy(db *sqlitex.Pool, logLevel string) provider.KeyChanFunc {
-	// This providing strategy returns all the CID known to the blockstore
-	// except those which are marked as draft changes.
-	// TODO(burdiyan): this is a temporary solution during the braking change.
+var (
+	mReprovideDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "mintter_reprovide_duration_seconds",
+		Help:    "Duration of a complete reproviding run.",
+		Buckets: []float64{1, 10, 60, 5 * 60, 15 * 60, 30 * 60, 60 * 60, 2 * 60 * 60, 4 * 60 * 60},
+	})
+
+	mReprovideQueueSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "mintter_reprovide_queue_size",
+		Help: "Number of entities waiting to be reprovided in the current run.",
+	})
+)
+
+func makeProvidingStrategy(db *sqlitex.Pool, cfg config.P2P, device core.Principal, logLevel string) (provider.KeyChanFunc, error) {
+	query, ok := providingQueries[cfg.ProvidingStrategy]
+	if !ok {
+		return nil, fmt.Errorf("unknown providing strategy %q", cfg.ProvidingStrategy)
+	}
+
+	for _, pin := range cfg.ProvidingPins {
+		eid := hyper.EntityID(pin)
+		if !eid.HasPrefix("hm://") || len(eid) <= len("hm://") || strings.ContainsAny(pin, "?#") {
+			return nil, fmt.Errorf("invalid pinned entity %q: must be an entity ID without version", pin)
+		}
+	}
+
+	pins, err := json.Marshal(cfg.ProvidingPins)
+	if err != nil {
+		return nil, err
+	}
 
 	log := logging.New("mintter/reprovider", logLevel)
 
@@ -29,29 +63,34 @@ func makeProvidingStrategy(db *sqlitex.Pool, logLevel string) provider.KeyChanFu
 		go func() {
 			defer close(ch)
 
+			start := time.Now()
+
 			conn, release, err := db.Conn(ctx)
 			if err != nil {
 				log.Error("Failed to open db connection", zap.Error(err))
 				return
 			}
 
-			// We want to provide all the entity IDs, so we convert them into raw CIDs,
+			// We want to provide the entity IDs, so we convert them into raw CIDs,
 			// similar to how libp2p discovery service is doing.
 
-			entities, err := hypersql.EntitiesListByPrefix(conn, "*")
+			entities, err := listProvidedEntities(conn, query, device, pins)
 			release()
 			if err != nil {
 				log.Error("Failed to list entities", zap.Error(err))
 				return
 			}
-			log.Info("Start reproviding", zap.Int("Number of entities", len(entities)))
+			log.Info("Start reproviding", zap.String("strategy", cfg.ProvidingStrategy), zap.Int("Number of entities", len(entities)))
+			mReprovideQueueSize.Set(float64(len(entities)))
+			defer mReprovideQueueSize.Set(0)
+
 			// Since reproviding takes long AND is has throttle limits, we are better off randomizing it.
 			r := rand.New(randSrc) //nolint:gosec
 			r.Shuffle(len(entities), func(i, j int) { entities[i], entities[j] = entities[j], entities[i] })
 			for _, e := range entities {
-				c, err := hyper.EntityID(e.ResourcesIRI).CID()
+				c, err := e.CID()
 				if err != nil {
-					log.Warn("BadEntityID", zap.Error(err), zap.String("entity", e.ResourcesIRI))
+					log.Warn("BadEntityID", zap.Error(err), zap.String("entity", e.String()))
 					return
 				}
 
@@ -61,11 +100,90 @@ func makeProvidingStrategy(db *sqlitex.Pool, logLevel string) provider.KeyChanFu
 					return
 				case ch <- c:
 					// Send
-					log.Debug("Reproviding", zap.String("entity", e.ResourcesIRI), zap.String("CID", c.String()))
+					mReprovideQueueSize.Dec()
+					log.Debug("Reproviding", zap.String("entity", e.String()), zap.String("CID", c.String()))
 				}
 			}
+			mReprovideDuration.Observe(time.Since(start).Seconds())
 			log.Info("Finish reproviding", zap.Int("Number of entities", len(entities)))
 		}()
 		return ch, nil
+	}, nil
+}
+
+func listProvidedEntities(conn *sqlite.Conn, query func() string, device core.Principal, pins []byte) (out []hyper.EntityID, err error) {
+	if err := sqlitex.Exec(conn, query(), func(stmt *sqlite.Stmt) error {
+		out = append(out, hyper.EntityID(stmt.ColumnText(0)))
+		return nil
+	}, []byte(device), pins); err != nil {
+		return nil, err
 	}
+
+	return out, nil
 }
+
+// providingQueries select the entities to reprovide for each of the providing strategies.
+// All of them accept the principal of our device, and the JSON array of pinned entities.
+var providingQueries = map[string]func() string{
+	"":                       qProvideAll,
+	config.ProvideAll:        qProvideAll,
+	config.ProvideOwn:        qProvideOwn,
+	config.ProvideTrusted:    qProvideTrusted,
+	config.ProvideSubscribed: qProvideSubscribed,
+	config.ProvidePinned:     qProvidePinned,
+}
+
+// Our accounts are the ones that delegated to our device key.
+const providingCTE = `
+	WITH
+	own (id) AS (
+		SELECT key_delegations.issuer
+		FROM key_delegations
+		JOIN public_keys ON public_keys.id = key_delegations.delegate
+		WHERE public_keys.principal = :device
+	),
+	pins (iri) AS (
+		SELECT value FROM json_each(:pins)
+	)
+`
+
+var qProvideAll = dqb.Str(providingCTE + `
+	SELECT iri FROM resources
+	UNION
+	SELECT iri FROM pins;
+`)
+
+var qProvideOwn = dqb.Str(providingCTE + `
+	SELECT iri FROM resources
+	WHERE owner IN own
+	UNION
+	SELECT iri FROM pins;
+`)
+
+var qProvideTrusted = dqb.Str(providingCTE + `
+	SELECT iri FROM resources
+	WHERE owner IN own
+	OR owner IN (SELECT id FROM trusted_accounts WHERE account IN own)
+	UNION
+	SELECT iri FROM pins;
+`)
+
+var qProvideSubscribed = dqb.Str(providingCTE + `
+	SELECT resources.iri
+	FROM resources
+	JOIN subscriptions ON subscriptions.iri = resources.iri
+	UNION
+	SELECT content.iri
+	FROM resource_links
+	JOIN structural_blobs ON structural_blobs.id = resource_links.source
+	JOIN resources ON resources.id = structural_blobs.resource
+	JOIN subscriptions ON subscriptions.iri = resources.iri
+	JOIN resources content ON content.id = resource_links.target
+	WHERE resource_links.type = 'group/content'
+	UNION
+	SELECT iri FROM pins;
+`)
+
+var qProvidePinned = dqb.Str(providingCTE + `
+	SELECT iri FROM pins;
+`)
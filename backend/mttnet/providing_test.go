@@ -0,0 +1,117 @@
+package mttnet
+
+import (
+	"context"
+	"encoding/json"
+	"mintter/backend/config"
+	"mintter/backend/core/coretest"
+	daemon "mintter/backend/daemon/api/daemon/v1alpha"
+	"mintter/backend/daemon/storage"
+	"mintter/backend/hlc"
+	"mintter/backend/hyper"
+	"mintter/backend/hyper/hypersql"
+	"mintter/backend/logging"
+	"testing"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvidingStrategies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+
+	db := storage.MakeTestDB(t)
+	blobs := hyper.NewStorage(db, logging.New("mintter/hyper", "debug"))
+	for _, u := range []coretest.Tester{alice, bob, carol} {
+		_, err := daemon.Register(ctx, blobs, u.Account, u.Device.PublicKey, time.Now())
+		require.NoError(t, err)
+	}
+
+	create := func(u coretest.Tester, prefix string, patch map[string]any) hyper.EntityID {
+		del, err := getDelegation(ctx, u.Identity, blobs)
+		require.NoError(t, err)
+
+		clock := hlc.NewClock()
+		ts := clock.MustNow()
+		createTime := ts.Time().Unix()
+
+		id, nonce := hyper.NewUnforgeableID(prefix, u.Account.Principal(), nil, createTime)
+		e := hyper.NewEntityWithClock(hyper.EntityID(id), clock)
+
+		patch["nonce"] = nonce
+		patch["createTime"] = int(createTime)
+		patch["owner"] = []byte(u.Account.Principal())
+
+		hb, err := e.CreateChange(ts, u.Device, del, patch, hyper.WithAction("Create"))
+		require.NoError(t, err)
+		require.NoError(t, blobs.SaveBlob(ctx, hb))
+
+		return e.ID()
+	}
+
+	aliceDoc := create(alice, "hm://d/", map[string]any{"title": "Alice's document"})
+	bobDoc := create(bob, "hm://d/", map[string]any{"title": "Bob's document"})
+	carolDoc := create(carol, "hm://d/", map[string]any{"title": "Carol's document"})
+	carolGroup := create(carol, "hm://g/", map[string]any{
+		"title": "Carol's group",
+		"content": map[string]any{
+			"/": string(carolDoc),
+		},
+	})
+
+	aliceAccount := hyper.EntityID("hm://a/" + alice.Account.Principal().String())
+	bobAccount := hyper.EntityID("hm://a/" + bob.Account.Principal().String())
+
+	require.NoError(t, blobs.Query(ctx, func(conn *sqlite.Conn) error {
+		return hypersql.SetAccountTrust(conn, alice.Account.Principal(), bob.Account.Principal())
+	}))
+	require.NoError(t, blobs.Subscribe(ctx, carolGroup))
+
+	pinned := hyper.EntityID("hm://d/pinned-document")
+
+	tests := []struct {
+		Strategy string
+		Want     []hyper.EntityID
+	}{
+		{Strategy: config.ProvideOwn, Want: []hyper.EntityID{aliceAccount, aliceDoc, pinned}},
+		{Strategy: config.ProvideTrusted, Want: []hyper.EntityID{aliceAccount, aliceDoc, bobAccount, bobDoc, pinned}},
+		{Strategy: config.ProvideSubscribed, Want: []hyper.EntityID{carolGroup, carolDoc, pinned}},
+		{Strategy: config.ProvidePinned, Want: []hyper.EntityID{pinned}},
+	}
+
+	pins, err := json.Marshal([]string{string(pinned)})
+	require.NoError(t, err)
+
+	// Alice's device is the one announcing the content.
+	device := alice.Device.Principal()
+
+	for _, tt := range tests {
+		var got []hyper.EntityID
+		require.NoError(t, blobs.Query(ctx, func(conn *sqlite.Conn) error {
+			got, err = listProvidedEntities(conn, providingQueries[tt.Strategy], device, pins)
+			return err
+		}))
+		require.ElementsMatch(t, tt.Want, got, "strategy %s must provide the right entities", tt.Strategy)
+	}
+
+	{
+		var got []hyper.EntityID
+		require.NoError(t, blobs.Query(ctx, func(conn *sqlite.Conn) error {
+			got, err = listProvidedEntities(conn, providingQueries[config.ProvideAll], device, pins)
+			return err
+		}))
+		require.Subset(t, got, []hyper.EntityID{aliceDoc, bobDoc, carolDoc, carolGroup, pinned}, "must provide everything")
+	}
+
+	_, err = makeProvidingStrategy(db, config.P2P{ProvidingStrategy: "foo"}, device, "debug")
+	require.Error(t, err, "unknown strategy must fail")
+
+	_, err = makeProvidingStrategy(db, config.P2P{ProvidingStrategy: config.ProvidePinned, ProvidingPins: []string{"hm://d/foo?v=bar"}}, device, "debug")
+	require.Error(t, err, "invalid pins must fail")
+}
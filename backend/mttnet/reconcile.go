@@ -0,0 +1,159 @@
+package mttnet
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	p2p "mintter/backend/genproto/p2p/v1alpha"
+
+	"crawshaw.io/sqlite"
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Ranges with no more than this number of blobs are replied with the full list of blobs,
+	// larger ranges are split into subranges.
+	reconcileListThreshold = 64
+
+	// Number of subranges to split a large range into.
+	reconcileSplitFactor = 16
+
+	// Maximum number of ranges we accept in a single request.
+	reconcileMaxRanges = 1024
+
+	// Size of the range fingerprint in bytes.
+	reconcileFingerprintSize = 16
+)
+
+// ReconcileBlobs performs one round of set reconciliation with the remote peer.
+func (srv *rpcMux) ReconcileBlobs(ctx context.Context, in *p2p.ReconcileBlobsRequest) (*p2p.ReconcileBlobsResponse, error) {
+	if len(in.Ranges) > reconcileMaxRanges {
+		srv.reportViolation(ctx, "too many ReconcileBlobs ranges")
+		return nil, status.Errorf(codes.InvalidArgument, "too many ranges in request: %d, max is %d", len(in.Ranges), reconcileMaxRanges)
+	}
+
+	// Private blobs are only reconciled with the devices of the group members.
+	device, err := remoteDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, release, err := srv.Node.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	snap, err := srv.Node.reconcile.snapshot(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := snap.view(conn, device)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &p2p.ReconcileBlobsResponse{}
+
+	for _, r := range in.Ranges {
+		i, j := v.bounds(r.Start, r.End)
+
+		if bytes.Equal(v.fingerprint(i, j), r.Fingerprint) {
+			continue
+		}
+
+		if v.count(i, j) <= reconcileListThreshold {
+			items := v.list(i, j)
+			rr := &p2p.BlobRange{
+				Start:  r.Start,
+				End:    r.End,
+				IsList: true,
+				Cids:   make([][]byte, len(items)),
+			}
+			for k, it := range items {
+				rr.Cids[k] = cid.NewCidV1(it.codec, it.multihash).Bytes()
+			}
+			out.Ranges = append(out.Ranges, rr)
+			continue
+		}
+
+		// Subranges are split by position in our index, but their bounds are always our visible items.
+		size := (j - i + reconcileSplitFactor - 1) / reconcileSplitFactor
+		for lo := i; lo < j; {
+			hi := j
+			if lo+size < j {
+				hi = v.visibleFrom(lo+size, j)
+			}
+
+			rr := &p2p.BlobRange{
+				Start:       r.Start,
+				End:         r.End,
+				Fingerprint: v.fingerprint(lo, hi),
+			}
+
+			if lo > i {
+				rr.Start = v.items[lo].bound()
+			}
+
+			if hi < j {
+				rr.End = v.items[hi].bound()
+			}
+
+			out.Ranges = append(out.Ranges, rr)
+			lo = hi
+		}
+	}
+
+	return out, nil
+}
+
+// BlobRangeFingerprint computes the fingerprint of our own blobs within the given range.
+// Nil bounds mean the range is unbounded on that side.
+func (n *Node) BlobRangeFingerprint(ctx context.Context, start, end *p2p.BlobBound) ([]byte, error) {
+	var snap *reconcileSnapshot
+	if err := n.db.Query(ctx, func(conn *sqlite.Conn) (err error) {
+		snap, err = n.reconcile.snapshot(conn)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	v := reconcileView{reconcileSnapshot: snap}
+	i, j := v.bounds(start, end)
+
+	return v.fingerprint(i, j), nil
+}
+
+type reconcileItem struct {
+	ts        int64
+	multihash []byte
+	codec     uint64
+}
+
+func (it reconcileItem) bound() *p2p.BlobBound {
+	return &p2p.BlobBound{Ts: it.ts, Multihash: it.multihash}
+}
+
+func (it reconcileItem) less(other reconcileItem) bool {
+	if it.ts != other.ts {
+		return it.ts < other.ts
+	}
+	return bytes.Compare(it.multihash, other.multihash) < 0
+}
+
+func (it reconcileItem) hash() [sha256.Size]byte {
+	return sha256.Sum256(it.multihash)
+}
+
+// fingerprintSum is the hash of the XOR of the hashes of all the items, and the number of items.
+// XOR makes it cheap to combine, and the hash on top avoids some trivial collisions.
+func fingerprintSum(acc [sha256.Size]byte, count int) []byte {
+	buf := binary.AppendUvarint(acc[:], uint64(count))
+	sum := sha256.Sum256(buf)
+
+	return sum[:reconcileFingerprintSize]
+}
@@ -0,0 +1,295 @@
+package mttnet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"mintter/backend/core"
+	p2p "mintter/backend/genproto/p2p/v1alpha"
+	"mintter/backend/hyper"
+	"mintter/backend/pkg/dqb"
+	"sort"
+	"sync"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+)
+
+// reconcileIndexTTL is how often the reconciliation index is rebuilt from scratch.
+// New blobs are added incrementally, and deletions are noticed through the deletion counter of the storage.
+// The rebuild only catches the changes we don't track, like blobs emptied in place,
+// and a stale index only delays the reconciliation of those blobs.
+const reconcileIndexTTL = 5 * time.Minute
+
+// reconcileIndex keeps our blobs in memory in the reconciliation order, along with the prefix XORs of their hashes.
+// This way the fingerprint of any range is computed with two binary searches,
+// instead of loading all the blobs within the range from the database.
+// The same index is used to serve the remote peers and to reconcile with them.
+type reconcileIndex struct {
+	// deletions returns a counter that changes every time blobs are deleted.
+	deletions func() int64
+
+	mu   sync.Mutex
+	snap *reconcileSnapshot
+}
+
+func newReconcileIndex(blobs *hyper.Storage) *reconcileIndex {
+	return &reconcileIndex{deletions: blobs.BlobDeletions}
+}
+
+// reconcileSnapshot is an immutable version of the index, safe to use concurrently.
+type reconcileSnapshot struct {
+	items []reconcileItem
+	// prefix[i] is the XOR of the hashes of items[:i].
+	prefix    [][sha256.Size]byte
+	lastID    int64
+	deletions int64
+	builtAt   time.Time
+}
+
+// snapshot returns the up to date version of the index.
+// Blobs always get a new ID when we store them, even if we knew about them before,
+// so the blobs with IDs higher than the last one we've seen are all we need to add.
+// The database is queried outside of the lock, so concurrent callers could do the same work,
+// but they don't wait for each other.
+func (idx *reconcileIndex) snapshot(conn *sqlite.Conn) (*reconcileSnapshot, error) {
+	// Reading the counter before querying, so deletions that happen concurrently are noticed the next time.
+	deletions := idx.deletions()
+
+	idx.mu.Lock()
+	snap := idx.snap
+	idx.mu.Unlock()
+
+	var maxID int64
+	if err := sqlitex.Exec(conn, qMaxBlobID(), func(stmt *sqlite.Stmt) error {
+		maxID = stmt.ColumnInt64(0)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if snap == nil || time.Since(snap.builtAt) > reconcileIndexTTL || maxID < snap.lastID || snap.deletions != deletions {
+		snap = &reconcileSnapshot{deletions: deletions, builtAt: time.Now()}
+	}
+
+	if snap.lastID == maxID && snap.prefix != nil {
+		idx.store(snap)
+		return snap, nil
+	}
+
+	var added []reconcileItem
+	if err := sqlitex.Exec(conn, qListReconcileItems(), func(stmt *sqlite.Stmt) error {
+		added = append(added, reconcileItem{
+			ts:        stmt.ColumnInt64(0),
+			multihash: stmt.ColumnBytes(1),
+			codec:     uint64(stmt.ColumnInt64(2)),
+		})
+		return nil
+	}, snap.lastID, maxID); err != nil {
+		return nil, fmt.Errorf("failed to list blobs for reconciliation: %w", err)
+	}
+
+	snap = snap.merge(added, maxID)
+	idx.store(snap)
+	return snap, nil
+}
+
+// store replaces the current snapshot, unless a concurrent caller has stored a more recent one.
+func (idx *reconcileIndex) store(snap *reconcileSnapshot) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if cur := idx.snap; cur != nil && (cur.deletions > snap.deletions || (cur.deletions == snap.deletions && cur.lastID > snap.lastID)) {
+		return
+	}
+	idx.snap = snap
+}
+
+// merge returns a new snapshot with the sorted items added.
+// New blobs are usually the most recent ones, so only the tail of the prefix XORs is recomputed.
+func (s *reconcileSnapshot) merge(added []reconcileItem, lastID int64) *reconcileSnapshot {
+	out := &reconcileSnapshot{
+		items:     make([]reconcileItem, 0, len(s.items)+len(added)),
+		lastID:    lastID,
+		deletions: s.deletions,
+		builtAt:   s.builtAt,
+	}
+
+	first := len(s.items)
+	if len(added) > 0 {
+		first = s.search(added[0].bound())
+	}
+
+	out.items = append(out.items, s.items[:first]...)
+	old := s.items[first:]
+	for len(old) > 0 || len(added) > 0 {
+		if len(added) == 0 || (len(old) > 0 && old[0].less(added[0])) {
+			out.items = append(out.items, old[0])
+			old = old[1:]
+			continue
+		}
+		out.items = append(out.items, added[0])
+		added = added[1:]
+	}
+
+	out.prefix = make([][sha256.Size]byte, len(out.items)+1)
+	if s.prefix != nil {
+		copy(out.prefix, s.prefix[:first+1])
+	}
+	for i := first; i < len(out.items); i++ {
+		h := out.items[i].hash()
+		for j := range h {
+			out.prefix[i+1][j] = out.prefix[i][j] ^ h[j]
+		}
+	}
+
+	return out
+}
+
+// search returns the position of the first item not less than the bound. Nil bound means the beginning.
+func (s *reconcileSnapshot) search(b *p2p.BlobBound) int {
+	if b == nil {
+		return 0
+	}
+
+	return sort.Search(len(s.items), func(i int) bool {
+		it := s.items[i]
+		return it.ts > b.Ts || (it.ts == b.Ts && bytes.Compare(it.multihash, b.Multihash) >= 0)
+	})
+}
+
+// bounds returns the positions of the items within the range. Nil end means the range is unbounded.
+func (s *reconcileSnapshot) bounds(start, end *p2p.BlobBound) (i, j int) {
+	i = s.search(start)
+	j = len(s.items)
+	if end != nil {
+		j = s.search(end)
+	}
+	if j < i {
+		j = i
+	}
+	return i, j
+}
+
+// reconcileView is the subset of the index visible to a remote device.
+type reconcileView struct {
+	*reconcileSnapshot
+
+	// Sorted positions of the private blobs the device can't access.
+	hidden []int
+}
+
+// view hides the private blobs the device can't access. Nil device means our own device, which can access everything.
+func (s *reconcileSnapshot) view(conn *sqlite.Conn, device core.Principal) (reconcileView, error) {
+	v := reconcileView{reconcileSnapshot: s}
+	if device == nil {
+		return v, nil
+	}
+
+	if err := sqlitex.Exec(conn, qListPrivateReconcileItems(), func(stmt *sqlite.Stmt) error {
+		allowed, err := hyper.CheckBlobAccess(conn, stmt.ColumnInt64(2), device)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			return nil
+		}
+
+		it := reconcileItem{ts: stmt.ColumnInt64(0), multihash: stmt.ColumnBytes(1)}
+		if pos := s.search(it.bound()); pos < len(s.items) && bytes.Equal(s.items[pos].multihash, it.multihash) {
+			v.hidden = append(v.hidden, pos)
+		}
+		return nil
+	}); err != nil {
+		return v, fmt.Errorf("failed to list private blobs for reconciliation: %w", err)
+	}
+
+	sort.Ints(v.hidden)
+	return v, nil
+}
+
+// hiddenWithin returns the hidden positions within [i, j).
+func (v reconcileView) hiddenWithin(i, j int) []int {
+	lo := sort.SearchInts(v.hidden, i)
+	hi := sort.SearchInts(v.hidden, j)
+	return v.hidden[lo:hi]
+}
+
+// count returns the number of visible items within [i, j).
+func (v reconcileView) count(i, j int) int {
+	return j - i - len(v.hiddenWithin(i, j))
+}
+
+// fingerprint of the visible items within [i, j).
+func (v reconcileView) fingerprint(i, j int) []byte {
+	var acc [sha256.Size]byte
+	for k := range acc {
+		acc[k] = v.prefix[i][k] ^ v.prefix[j][k]
+	}
+
+	hidden := v.hiddenWithin(i, j)
+	for _, pos := range hidden {
+		h := v.items[pos].hash()
+		for k := range acc {
+			acc[k] ^= h[k]
+		}
+	}
+
+	return fingerprintSum(acc, j-i-len(hidden))
+}
+
+// list returns the visible items within [i, j).
+func (v reconcileView) list(i, j int) []reconcileItem {
+	hidden := v.hiddenWithin(i, j)
+	out := make([]reconcileItem, 0, j-i-len(hidden))
+	for k := i; k < j; k++ {
+		if len(hidden) > 0 && hidden[0] == k {
+			hidden = hidden[1:]
+			continue
+		}
+		out = append(out, v.items[k])
+	}
+	return out
+}
+
+// visibleFrom returns the first visible position at or after i, but not after j.
+// Range bounds must be taken from the visible items, otherwise they would reveal the hidden ones.
+func (v reconcileView) visibleFrom(i, j int) int {
+	for _, pos := range v.hiddenWithin(i, j) {
+		if pos != i {
+			break
+		}
+		i++
+	}
+	return i
+}
+
+var qMaxBlobID = dqb.Str(`
+	SELECT COALESCE(MAX(id), 0) FROM blobs;
+`)
+
+var qListReconcileItems = dqb.Str(`
+	SELECT
+		COALESCE(structural_blobs.ts, 0) AS ts,
+		blobs.multihash,
+		blobs.codec
+	FROM blobs INDEXED BY blobs_metadata
+	LEFT OUTER JOIN structural_blobs ON structural_blobs.id = blobs.id
+	LEFT OUTER JOIN drafts ON drafts.blob = blobs.id
+	WHERE blobs.id > :afterID
+	AND blobs.id <= :maxID
+	AND blobs.size >= 0
+	AND drafts.blob IS NULL
+	ORDER BY ts, blobs.multihash;
+`)
+
+var qListPrivateReconcileItems = dqb.Str(`
+	SELECT
+		COALESCE(structural_blobs.ts, 0) AS ts,
+		blobs.multihash,
+		blobs.id
+	FROM private_blobs
+	JOIN blobs ON blobs.id = private_blobs.id
+	LEFT OUTER JOIN structural_blobs ON structural_blobs.id = blobs.id;
+`)
@@ -0,0 +1,254 @@
+package mttnet
+
+import (
+	"context"
+	"crypto/sha256"
+	p2p "mintter/backend/genproto/p2p/v1alpha"
+	"mintter/backend/hyper"
+	"mintter/backend/hyper/hypersql"
+	"mintter/backend/pkg/must"
+	"net"
+	"sort"
+	"strconv"
+	"testing"
+
+	"crawshaw.io/sqlite"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+)
+
+func TestReconcileBlobs(t *testing.T) {
+	t.Parallel()
+
+	alice, stopalice := makeTestPeer(t, "alice")
+	defer stopalice()
+	bob, stopbob := makeTestPeer(t, "bob")
+	defer stopbob()
+	ctx := context.Background()
+	lis := serveTestPeer(t, alice, bob.me.DeviceKey().PeerID())
+
+	conn, err := grpc.DialContext(ctx, "peer", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := p2p.NewP2PClient(conn)
+
+	del, err := getDelegation(ctx, alice.me, alice.blobs)
+	require.NoError(t, err)
+
+	// Creating enough blobs for the ranges to be split at least once.
+	entity := hyper.NewEntity("alice-test-id")
+	for i := 0; i < reconcileListThreshold*2; i++ {
+		c, err := entity.CreateChange(entity.NextTimestamp(), alice.me.DeviceKey(), del, map[string]any{
+			"counter": i,
+		})
+		require.NoError(t, err)
+		require.NoError(t, alice.blobs.SaveBlob(ctx, c))
+	}
+
+	cids, rounds := reconcileWith(t, ctx, client, bob)
+	require.Greater(t, rounds, 1, "large sets must be reconciled in multiple rounds")
+
+	missing := missingBlobs(t, ctx, bob, cids)
+	// Alice also has the key delegation and the profile change.
+	require.Len(t, missing, reconcileListThreshold*2+2, "bob must find all of alice's blobs")
+	for _, c := range missing {
+		blk, err := alice.blobs.IPFSBlockstoreReader().Get(ctx, c)
+		require.NoError(t, err)
+		require.NoError(t, bob.blobs.IPFSBlockstore().Put(ctx, blk))
+	}
+
+	// Sets are still not equal, because bob has some blobs that alice doesn't have,
+	// but bob must not find anything new, and only the differing ranges must be listed.
+	cids, _ = reconcileWith(t, ctx, client, bob)
+	require.Len(t, missingBlobs(t, ctx, bob, cids), 0, "bob must not miss any blobs after syncing")
+	require.Less(t, len(cids), reconcileListThreshold*2, "only the differing ranges must be listed")
+
+	c, err := entity.CreateChange(entity.NextTimestamp(), alice.me.DeviceKey(), del, map[string]any{
+		"name": "alice",
+	})
+	require.NoError(t, err)
+	require.NoError(t, alice.blobs.SaveBlob(ctx, c))
+
+	cids, _ = reconcileWith(t, ctx, client, bob)
+	missing = missingBlobs(t, ctx, bob, cids)
+	require.Len(t, missing, 1, "bob must only miss the new change")
+	require.True(t, missing[0].Equals(c.CID), "bob must find the new change")
+
+	// Private blobs must not be reconciled with devices that are not members of the group.
+	require.NoError(t, alice.blobs.Query(ctx, func(conn *sqlite.Conn) error {
+		group, err := hypersql.EntitiesInsertOrIgnore(conn, "hm://g/alice-private-group")
+		if err != nil {
+			return err
+		}
+
+		blob, err := hypersql.BlobsGetSize(conn, c.CID.Hash())
+		if err != nil {
+			return err
+		}
+
+		return hypersql.PrivateBlobsInsertOrIgnore(conn, blob.BlobsID, group.EntitiesID)
+	}))
+
+	cids, _ = reconcileWith(t, ctx, client, bob)
+	require.Len(t, missingBlobs(t, ctx, bob, cids), 0, "bob must not find the private change")
+}
+
+// reconcileWith runs the reconciliation rounds until completion
+// and returns the CIDs from all the ranges that were listed.
+func reconcileWith(t *testing.T, ctx context.Context, client p2p.P2PClient, n *Node) (cids []cid.Cid, rounds int) {
+	t.Helper()
+
+	fill := func(ranges []*p2p.BlobRange) {
+		for _, r := range ranges {
+			var err error
+			r.Fingerprint, err = n.BlobRangeFingerprint(ctx, r.Start, r.End)
+			require.NoError(t, err)
+		}
+	}
+
+	ranges := []*p2p.BlobRange{{}}
+	fill(ranges)
+
+	for len(ranges) > 0 {
+		rounds++
+		require.LessOrEqual(t, rounds, 10, "reconciliation must converge")
+
+		resp, err := client.ReconcileBlobs(ctx, &p2p.ReconcileBlobsRequest{Ranges: ranges})
+		require.NoError(t, err)
+
+		ranges = nil
+		for _, r := range resp.Ranges {
+			if r.IsList {
+				for _, data := range r.Cids {
+					cids = append(cids, must.Do2(cid.Cast(data)))
+				}
+				continue
+			}
+
+			remote := r.Fingerprint
+			fill([]*p2p.BlobRange{r})
+			if string(remote) != string(r.Fingerprint) {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+
+	return cids, rounds
+}
+
+func missingBlobs(t *testing.T, ctx context.Context, n *Node, cids []cid.Cid) []cid.Cid {
+	t.Helper()
+
+	var out []cid.Cid
+	for _, c := range cids {
+		ok, err := n.blobs.IPFSBlockstoreReader().Has(ctx, c)
+		require.NoError(t, err)
+		if !ok {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+func TestReconcileIndexMerge(t *testing.T) {
+	t.Parallel()
+
+	var items []reconcileItem
+	for i := 0; i < 100; i++ {
+		mh := sha256.Sum256([]byte(strconv.Itoa(i)))
+		items = append(items, reconcileItem{ts: int64(i % 7), multihash: mh[:], codec: uint64(multicodec.Raw)})
+	}
+
+	sorted := func(in []reconcileItem) []reconcileItem {
+		out := slices.Clone(in)
+		sort.Slice(out, func(i, j int) bool { return out[i].less(out[j]) })
+		return out
+	}
+
+	// Merging in batches must be the same as building from scratch.
+	want := (&reconcileSnapshot{}).merge(sorted(items), 3)
+	got := (&reconcileSnapshot{}).merge(sorted(items[:60]), 1).merge(sorted(items[60:90]), 2).merge(sorted(items[90:]), 3)
+	require.Equal(t, want.items, got.items)
+	require.Equal(t, want.prefix, got.prefix)
+
+	naive := func(items []reconcileItem) []byte {
+		var acc [sha256.Size]byte
+		for _, it := range items {
+			h := it.hash()
+			for i := range acc {
+				acc[i] ^= h[i]
+			}
+		}
+		return fingerprintSum(acc, len(items))
+	}
+
+	// Hidden items must be excluded from the fingerprints, and must never be used as bounds.
+	v := reconcileView{reconcileSnapshot: got, hidden: []int{10, 11, 50}}
+	i, j := v.bounds(got.items[5].bound(), got.items[60].bound())
+	require.Equal(t, 5, i)
+	require.Equal(t, 60, j)
+	require.Equal(t, 52, v.count(i, j))
+
+	visible := v.list(i, j)
+	require.Len(t, visible, 52)
+	require.Equal(t, naive(visible), v.fingerprint(i, j))
+	require.Equal(t, naive(got.items[i:j]), reconcileView{reconcileSnapshot: got}.fingerprint(i, j))
+
+	require.Equal(t, 12, v.visibleFrom(10, j))
+	require.Equal(t, 12, v.visibleFrom(12, j))
+	require.Equal(t, 11, v.visibleFrom(11, 11))
+}
+
+func TestReconcileIndexDeletions(t *testing.T) {
+	t.Parallel()
+
+	alice, stopalice := makeTestPeer(t, "alice")
+	defer stopalice()
+	ctx := context.Background()
+
+	del, err := getDelegation(ctx, alice.me, alice.blobs)
+	require.NoError(t, err)
+
+	before, err := alice.BlobRangeFingerprint(ctx, nil, nil)
+	require.NoError(t, err)
+
+	entity := hyper.NewEntity("alice-test-id")
+	c1, err := entity.CreateChange(entity.NextTimestamp(), alice.me.DeviceKey(), del, map[string]any{"name": "alice"})
+	require.NoError(t, err)
+	require.NoError(t, alice.blobs.SaveBlob(ctx, c1))
+
+	withC1, err := alice.BlobRangeFingerprint(ctx, nil, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, before, withC1, "new blobs must change the fingerprint")
+
+	// Using another entity, so the first change is not a dependency of the second one.
+	other := hyper.NewEntity("alice-other-id")
+	c2, err := other.CreateChange(other.NextTimestamp(), alice.me.DeviceKey(), del, map[string]any{"name": "alice 2"})
+	require.NoError(t, err)
+	require.NoError(t, alice.blobs.SaveBlob(ctx, c2))
+
+	withBoth, err := alice.BlobRangeFingerprint(ctx, nil, nil)
+	require.NoError(t, err)
+
+	// Deleting a blob that is not the most recent one, so the max blob ID doesn't change.
+	require.NoError(t, alice.blobs.IPFSBlockstore().DeleteBlock(ctx, c1.CID))
+
+	after, err := alice.BlobRangeFingerprint(ctx, nil, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, withBoth, after, "deleted blobs must be removed from the index without waiting for the rebuild")
+
+	fresh := newReconcileIndex(alice.blobs)
+	var snap *reconcileSnapshot
+	require.NoError(t, alice.db.Query(ctx, func(conn *sqlite.Conn) (err error) {
+		snap, err = fresh.snapshot(conn)
+		return err
+	}))
+	i, j := snap.bounds(nil, nil)
+	require.Equal(t, reconcileView{reconcileSnapshot: snap}.fingerprint(i, j), after, "index must match the one built from scratch")
+}
@@ -0,0 +1,164 @@
+// Package car implements a minimal reader and writer for CARv1 archives.
+// See https://ipld.io/specs/transport/car/carv1 for the format specification.
+package car
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+)
+
+// Version of the CAR format we support.
+const Version = 1
+
+// maxSectionSize protects from allocating huge buffers when reading corrupted archives.
+const maxSectionSize = 32 << 20 // 32MiB.
+
+func init() {
+	cbornode.RegisterCborType(Header{})
+}
+
+// Header of the CAR archive.
+type Header struct {
+	Roots   []cid.Cid `refmt:"roots"`
+	Version uint64    `refmt:"version"`
+}
+
+// Writer writes blocks into a CAR archive.
+type Writer struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+// NewWriter creates a new CAR writer and writes the header with the given roots.
+func NewWriter(w io.Writer, roots ...cid.Cid) (*Writer, error) {
+	data, err := cbornode.DumpObject(Header{Roots: roots, Version: Version})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CAR header: %w", err)
+	}
+
+	cw := &Writer{w: bufio.NewWriter(w)}
+	if err := cw.writeSection(data); err != nil {
+		return nil, fmt.Errorf("failed to write CAR header: %w", err)
+	}
+
+	return cw, nil
+}
+
+// Put writes a block into the archive.
+func (cw *Writer) Put(blk blocks.Block) error {
+	c := blk.Cid().Bytes()
+	data := blk.RawData()
+
+	n := binary.PutUvarint(cw.buf[:], uint64(len(c)+len(data)))
+	if _, err := cw.w.Write(cw.buf[:n]); err != nil {
+		return err
+	}
+
+	if _, err := cw.w.Write(c); err != nil {
+		return err
+	}
+
+	if _, err := cw.w.Write(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Flush must be called after all the blocks are written.
+func (cw *Writer) Flush() error {
+	return cw.w.Flush()
+}
+
+func (cw *Writer) writeSection(data []byte) error {
+	n := binary.PutUvarint(cw.buf[:], uint64(len(data)))
+	if _, err := cw.w.Write(cw.buf[:n]); err != nil {
+		return err
+	}
+
+	_, err := cw.w.Write(data)
+	return err
+}
+
+// Reader reads blocks from a CAR archive.
+type Reader struct {
+	Header Header
+
+	r *bufio.Reader
+}
+
+// NewReader creates a new CAR reader and reads the header.
+func NewReader(r io.Reader) (*Reader, error) {
+	cr := &Reader{r: bufio.NewReader(r)}
+
+	data, err := cr.readSection()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("failed to read CAR header: %w", err)
+	}
+
+	if err := cbornode.DecodeInto(data, &cr.Header); err != nil {
+		return nil, fmt.Errorf("failed to decode CAR header: %w", err)
+	}
+
+	if cr.Header.Version != Version {
+		return nil, fmt.Errorf("unsupported CAR version %d", cr.Header.Version)
+	}
+
+	return cr, nil
+}
+
+// Next reads the next block from the archive, verifying that its data matches the CID.
+// Returns io.EOF when there're no more blocks.
+func (cr *Reader) Next() (blocks.Block, error) {
+	data, err := cr.readSection()
+	if err != nil {
+		return nil, err
+	}
+
+	n, c, err := cid.CidFromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block CID: %w", err)
+	}
+
+	want, err := c.Prefix().Sum(data[n:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash block %s: %w", c, err)
+	}
+
+	if !want.Equals(c) {
+		return nil, fmt.Errorf("block data doesn't match its CID %s", c)
+	}
+
+	return blocks.NewBlockWithCid(data[n:], c)
+}
+
+func (cr *Reader) readSection() ([]byte, error) {
+	size, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 || size > maxSectionSize {
+		return nil, fmt.Errorf("invalid CAR section size %d", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(cr.r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
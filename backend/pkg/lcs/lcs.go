@@ -0,0 +1,73 @@
+// Package lcs finds the longest common subsequence between two sequences.
+package lcs
+
+// MaxCells limits the size of the matrix used to compute the longest common subsequence.
+// Inputs that differ by more than that are matched only by their common prefix and suffix,
+// i.e. the whole changed region is treated as replaced.
+const MaxCells = 1 << 20
+
+// Match finds the longest common subsequence between the old sequence a and the new sequence b,
+// using eq to compare the items. For each new item it returns the index of the matching old item,
+// or -1 if the item is new.
+func Match[A, B any](a []A, b []B, eq func(A, B) bool) []int {
+	keep := make([]int, len(b))
+	for i := range keep {
+		keep[i] = -1
+	}
+
+	// Trimming common prefix and suffix, which is very common for typical edits.
+	var prefix int
+	for prefix < len(a) && prefix < len(b) && eq(a[prefix], b[prefix]) {
+		keep[prefix] = prefix
+		prefix++
+	}
+
+	var suffix int
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && eq(a[len(a)-1-suffix], b[len(b)-1-suffix]) {
+		keep[len(b)-1-suffix] = len(a) - 1 - suffix
+		suffix++
+	}
+
+	aa := a[prefix : len(a)-suffix]
+	bb := b[prefix : len(b)-suffix]
+	if len(aa) == 0 || len(bb) == 0 || len(aa)*len(bb) > MaxCells {
+		return keep
+	}
+
+	// Classic dynamic programming solution, where lcs[i][j]
+	// is the length of the longest common subsequence of aa[i:] and bb[j:].
+	w := len(bb) + 1
+	lcs := make([]int, (len(aa)+1)*w)
+	for i := len(aa) - 1; i >= 0; i-- {
+		for j := len(bb) - 1; j >= 0; j-- {
+			switch {
+			case eq(aa[i], bb[j]):
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+				lcs[i*w+j] = lcs[(i+1)*w+j]
+			default:
+				lcs[i*w+j] = lcs[i*w+j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(aa) && j < len(bb); {
+		switch {
+		case eq(aa[i], bb[j]):
+			keep[prefix+j] = prefix + i
+			i++
+			j++
+		case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return keep
+}
+
+// Equal is the comparison function for sequences of comparable items.
+func Equal[T comparable](a, b T) bool {
+	return a == b
+}
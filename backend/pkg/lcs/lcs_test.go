@@ -0,0 +1,26 @@
+package lcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		Old  string
+		New  string
+		Want []int
+	}{
+		{Old: "", New: "abc", Want: []int{-1, -1, -1}},
+		{Old: "abc", New: "", Want: []int{}},
+		{Old: "abc", New: "abc", Want: []int{0, 1, 2}},
+		{Old: "abc", New: "axc", Want: []int{0, -1, 2}},
+		{Old: "abcd", New: "bd", Want: []int{1, 3}},
+		{Old: "kitten", New: "sitting", Want: []int{-1, 1, 2, 3, -1, 5, -1}},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.Want, Match([]rune(tt.Old), []rune(tt.New), Equal[rune]), "%q -> %q", tt.Old, tt.New)
+	}
+}
@@ -0,0 +1,222 @@
+package syncing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mintter/backend/core"
+	p2p "mintter/backend/genproto/p2p/v1alpha"
+	"mintter/backend/hyper"
+	"mintter/backend/pkg/dqb"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"github.com/ipfs/boxo/blockstore"
+	"github.com/ipfs/boxo/exchange"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+)
+
+// maxBackfillResources limits the number of resources we backfill with a single request,
+// to stay within the limits of the remote peer.
+const maxBackfillResources = 500
+
+// backfillPeer lists the related blobs of the resources our syncing cursor with the peer doesn't cover yet,
+// i.e. new subscriptions, or new content of the subscribed groups, from the beginning, and fetches those we don't have.
+// Listing them after the cursor would miss their blobs the peer had before.
+// Once all the blobs of a resource are fetched, the resource is covered by the cursor from then on.
+func backfillPeer(
+	ctx context.Context,
+	pid peer.ID,
+	c p2p.P2PClient,
+	bs blockstore.Blockstore,
+	sess exchange.Fetcher,
+	db *sqlitex.Pool,
+	log *zap.Logger,
+	p *Progress,
+) error {
+	pk, err := pid.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to extract public key from peer id %s: %w", pid, err)
+	}
+
+	remotePrincipal := core.PrincipalFromPubKey(pk)
+
+	var missing []string
+	if err := db.Query(ctx, func(conn *sqlite.Conn) error {
+		// Resources we are not interested in anymore must be backfilled again if we become interested in them later,
+		// because the cursor will have moved past their blobs.
+		if err := sqlitex.Exec(conn, qPruneBackfills(), nil, remotePrincipal); err != nil {
+			return err
+		}
+
+		return sqlitex.Exec(conn, qListMissingBackfills(), func(stmt *sqlite.Stmt) error {
+			missing = append(missing, stmt.ColumnText(0))
+			return nil
+		}, remotePrincipal)
+	}); err != nil {
+		return fmt.Errorf("failed to list resources to backfill: %w", err)
+	}
+
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > maxBackfillResources {
+			batch = batch[:maxBackfillResources]
+		}
+		missing = missing[len(batch):]
+
+		ok, err := backfillResources(ctx, c, bs, sess, log, batch, p)
+		if err != nil {
+			return err
+		}
+
+		// Resources we failed to get some blobs for are backfilled again next time.
+		if !ok {
+			continue
+		}
+
+		data, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+
+		if err := db.Query(ctx, func(conn *sqlite.Conn) error {
+			if err := sqlitex.Exec(conn, qEnsurePeerKey(), nil, remotePrincipal); err != nil {
+				return err
+			}
+
+			return sqlitex.Exec(conn, qInsertBackfills(), nil, remotePrincipal, string(data))
+		}); err != nil {
+			return fmt.Errorf("failed to save backfilled resources: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// backfillResources lists all the related blobs of the resources, and fetches those we don't have.
+// It returns false if some of the wanted blobs couldn't be fetched.
+func backfillResources(
+	ctx context.Context,
+	c p2p.P2PClient,
+	bs blockstore.Blockstore,
+	sess exchange.Fetcher,
+	log *zap.Logger,
+	resources []string,
+	p *Progress,
+) (ok bool, err error) {
+	stream, err := c.ListBlobs(ctx, &p2p.ListBlobsRequest{Resources: resources})
+	if err != nil {
+		return false, err
+	}
+
+	var want []cid.Cid
+	for {
+		obj, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return false, err
+		}
+
+		c, err := cid.Cast(obj.Cid)
+		if err != nil {
+			return false, err
+		}
+
+		has, err := bs.Has(ctx, c)
+		if err != nil {
+			return false, fmt.Errorf("failed to check if we have blob %s: %w", c, err)
+		}
+
+		if !has {
+			want = append(want, c)
+			mWantedBlobsTotal.Inc()
+		}
+	}
+
+	log.Debug("BackfillListed", zap.Int("resources", len(resources)), zap.Int("wanted", len(want)))
+
+	if len(want) == 0 {
+		return true, nil
+	}
+
+	p.AddWanted(len(want))
+	mSyncingWantedBlobs.Add(float64(len(want)))
+	defer mSyncingWantedBlobs.Sub(float64(len(want)))
+
+	ok = true
+	for _, c := range want {
+		blk, err := sess.GetBlock(ctx, c)
+		if err != nil {
+			log.Debug("FailedToGetWantedBlob", zap.String("cid", c.String()), zap.Error(err))
+			p.AddFailed(1)
+			ok = false
+			continue
+		}
+
+		if err := bs.Put(ctx, blk); err != nil {
+			p.AddFailed(1)
+			if errors.Is(err, hyper.ErrInvalidBlob) {
+				log.Warn("RejectedInvalidBlob", zap.String("cid", c.String()), zap.Error(err))
+				mRejectedBlobsTotal.Inc()
+				continue
+			}
+			log.Debug("FailedToSaveWantedBlob", zap.String("cid", c.String()), zap.Error(err))
+			ok = false
+			continue
+		}
+		p.AddFetched(1)
+	}
+
+	return ok, nil
+}
+
+// wantedResourcesCTE defines the resources we want to sync: our subscriptions, and the content of the subscribed groups.
+const wantedResourcesCTE = `
+	WITH
+	wanted (iri) AS (
+		SELECT iri FROM subscriptions
+		UNION
+		SELECT targets.iri
+		FROM resource_links
+		JOIN resources AS targets ON targets.id = resource_links.target
+		JOIN structural_blobs ON structural_blobs.id = resource_links.source
+		JOIN resources ON resources.id = structural_blobs.resource
+		JOIN subscriptions ON subscriptions.iri = resources.iri
+		WHERE resource_links.type = 'group/content'
+	)
+`
+
+var qListMissingBackfills = dqb.Str(wantedResourcesCTE + `
+	SELECT iri
+	FROM wanted
+	WHERE iri NOT IN (
+		SELECT resource
+		FROM syncing_backfills
+		WHERE peer = (SELECT id FROM public_keys WHERE principal = :peer)
+	)
+	ORDER BY iri;
+`)
+
+var qPruneBackfills = dqb.Str(wantedResourcesCTE + `
+	DELETE FROM syncing_backfills
+	WHERE peer = (SELECT id FROM public_keys WHERE principal = :peer)
+	AND resource NOT IN wanted;
+`)
+
+var qEnsurePeerKey = dqb.Str(`
+	INSERT OR IGNORE INTO public_keys (principal) VALUES (:peer);
+`)
+
+var qInsertBackfills = dqb.Str(`
+	INSERT OR IGNORE INTO syncing_backfills (peer, resource)
+	SELECT
+		(SELECT id FROM public_keys WHERE principal = :peer),
+		value
+	FROM json_each(:resources);
+`)
@@ -0,0 +1,180 @@
+package syncing
+
+import (
+	"context"
+	"io"
+	"mintter/backend/core"
+	"mintter/backend/core/coretest"
+	daemon "mintter/backend/daemon/api/daemon/v1alpha"
+	"mintter/backend/daemon/storage"
+	p2p "mintter/backend/genproto/p2p/v1alpha"
+	"mintter/backend/hlc"
+	"mintter/backend/hyper"
+	"mintter/backend/logging"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestBackfillPeer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	alice := coretest.NewTester("alice")
+
+	aliceBlobs := hyper.NewStorage(storage.MakeTestDB(t), logging.New("mintter/hyper", "debug"))
+	_, err := daemon.Register(ctx, aliceBlobs, alice.Account, alice.Device.PublicKey, time.Now())
+	require.NoError(t, err)
+
+	bobDB := storage.MakeTestDB(t)
+	bobBlobs := hyper.NewStorage(bobDB, logging.New("mintter/hyper", "debug"))
+
+	delegation := getDelegation(ctx, alice.Identity, aliceBlobs)
+	client := &listingClient{related: make(map[string][]cid.Cid)}
+	newDoc := func(title string) hyper.EntityID {
+		e, blob := createTestEntity(t, alice.Identity, delegation, "hm://d/", map[string]any{"title": title})
+		require.NoError(t, aliceBlobs.SaveBlob(ctx, blob))
+		client.related[e.ID().String()] = []cid.Cid{delegation, blob.CID}
+		return e.ID()
+	}
+	foo, bar := newDoc("Foo"), newDoc("Bar")
+
+	backfill := func() []string {
+		client.requested = nil
+		sess := &recordingFetcher{bs: aliceBlobs.IPFSBlockstore()}
+		require.NoError(t, backfillPeer(ctx, alice.Device.PeerID(), client, bobBlobs.IPFSBlockstore(), sess, bobDB, logging.New("mintter/syncing", "debug"), nil))
+		return client.requested
+	}
+
+	require.NoError(t, bobBlobs.Subscribe(ctx, foo))
+	require.Equal(t, []string{foo.String()}, backfill(), "new subscriptions must be backfilled")
+	for _, c := range client.related[foo.String()] {
+		ok, err := bobBlobs.IPFSBlockstoreReader().Has(ctx, c)
+		require.NoError(t, err)
+		require.True(t, ok, "backfill must fetch the related blobs")
+	}
+
+	require.Nil(t, backfill(), "backfilled subscriptions must not be backfilled again")
+
+	require.NoError(t, bobBlobs.Subscribe(ctx, bar))
+	require.Equal(t, []string{bar.String()}, backfill(), "only the new subscription must be backfilled")
+
+	require.NoError(t, bobBlobs.Unsubscribe(ctx, foo))
+	require.Nil(t, backfill())
+	require.NoError(t, bobBlobs.Subscribe(ctx, foo))
+	require.Equal(t, []string{foo.String()}, backfill(), "resubscribing must backfill again, because the cursor could move past the blobs")
+}
+
+func TestBackfillGroupContent(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	alice := coretest.NewTester("alice")
+
+	aliceBlobs := hyper.NewStorage(storage.MakeTestDB(t), logging.New("mintter/hyper", "debug"))
+	_, err := daemon.Register(ctx, aliceBlobs, alice.Account, alice.Device.PublicKey, time.Now())
+	require.NoError(t, err)
+
+	bobDB := storage.MakeTestDB(t)
+	bobBlobs := hyper.NewStorage(bobDB, logging.New("mintter/hyper", "debug"))
+
+	delegation := getDelegation(ctx, alice.Identity, aliceBlobs)
+	client := &listingClient{related: make(map[string][]cid.Cid)}
+
+	doc, docBlob := createTestEntity(t, alice.Identity, delegation, "hm://d/", map[string]any{"title": "Doc"})
+	require.NoError(t, aliceBlobs.SaveBlob(ctx, docBlob))
+	client.related[doc.ID().String()] = []cid.Cid{delegation, docBlob.CID}
+
+	group, groupBlob := createTestEntity(t, alice.Identity, delegation, "hm://g/", map[string]any{"title": "Group"})
+	require.NoError(t, aliceBlobs.SaveBlob(ctx, groupBlob))
+	client.related[group.ID().String()] = []cid.Cid{delegation, groupBlob.CID}
+
+	backfill := func() []string {
+		client.requested = nil
+		sess := &recordingFetcher{bs: aliceBlobs.IPFSBlockstore()}
+		require.NoError(t, backfillPeer(ctx, alice.Device.PeerID(), client, bobBlobs.IPFSBlockstore(), sess, bobDB, logging.New("mintter/syncing", "debug"), nil))
+		return client.requested
+	}
+
+	require.NoError(t, bobBlobs.Subscribe(ctx, group.ID()))
+	require.Equal(t, []string{group.ID().String()}, backfill())
+
+	// The document is published in the group later, and bob gets the group change with the regular sync,
+	// but the older blobs of the document are behind the cursor.
+	ch, err := group.CreateChange(group.NextTimestamp(), alice.Device, delegation, map[string]any{
+		"content": map[string]any{"/doc": doc.ID().String()},
+	}, hyper.WithAction(hyper.ActionUpdate))
+	require.NoError(t, err)
+	require.NoError(t, aliceBlobs.SaveBlob(ctx, ch))
+	require.NoError(t, bobBlobs.SaveBlob(ctx, ch))
+
+	require.Equal(t, []string{doc.ID().String()}, backfill(), "newly published group content must be backfilled")
+	ok, err := bobBlobs.IPFSBlockstoreReader().Has(ctx, docBlob.CID)
+	require.NoError(t, err)
+	require.True(t, ok, "backfill must fetch the blobs of the group content")
+
+	require.Nil(t, backfill(), "backfilled group content must not be backfilled again")
+}
+
+// createTestEntity creates a change that brings a new entity with the given prefix into life.
+func createTestEntity(t *testing.T, me core.Identity, delegation cid.Cid, prefix string, patch map[string]any) (*hyper.Entity, hyper.Blob) {
+	t.Helper()
+
+	clock := hlc.NewClock()
+	ts := clock.MustNow()
+	createTime := ts.Time().Unix()
+
+	id, nonce := hyper.NewUnforgeableID(prefix, me.Account().Principal(), nil, createTime)
+	e := hyper.NewEntityWithClock(hyper.EntityID(id), clock)
+
+	patch["nonce"] = nonce
+	patch["createTime"] = int(createTime)
+	patch["owner"] = []byte(me.Account().Principal())
+
+	blob, err := e.CreateChange(ts, me.DeviceKey(), delegation, patch, hyper.WithAction(hyper.ActionCreate))
+	require.NoError(t, err)
+
+	return e, blob
+}
+
+// listingClient lists the given related blobs for each resource, and records the requested resources.
+type listingClient struct {
+	p2p.P2PClient
+
+	related   map[string][]cid.Cid
+	requested []string
+}
+
+func (c *listingClient) ListBlobs(ctx context.Context, in *p2p.ListBlobsRequest, opts ...grpc.CallOption) (p2p.P2P_ListBlobsClient, error) {
+	c.requested = append(c.requested, in.Resources...)
+
+	s := &blobStream{}
+	for _, r := range in.Resources {
+		for _, c := range c.related[r] {
+			s.blobs = append(s.blobs, &p2p.Blob{Cid: c.Bytes()})
+		}
+	}
+
+	return s, nil
+}
+
+type blobStream struct {
+	grpc.ClientStream
+
+	blobs []*p2p.Blob
+}
+
+func (s *blobStream) Recv() (*p2p.Blob, error) {
+	if len(s.blobs) == 0 {
+		return nil, io.EOF
+	}
+
+	b := s.blobs[0]
+	s.blobs = s.blobs[1:]
+	return b, nil
+}
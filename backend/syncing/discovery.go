@@ -3,10 +3,15 @@ package syncing
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"mintter/backend/hyper"
 	"sync"
 	"time"
 
+	"github.com/ipfs/boxo/blockstore"
+	"github.com/ipfs/boxo/exchange"
+	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -15,20 +20,51 @@ import (
 
 const defaultDiscoveryTimeout = time.Second * 30
 
+// versionDiscoveryTimeout is the part of the discovery timeout we spend fetching a known version directly,
+// before falling back to syncing with the providers of the entity.
+const versionDiscoveryTimeout = time.Second * 10
+
 // DiscoverObject attempts to discover a given Mintter Object with an optional version specified.
 // If no version is specified it tries to find whatever is possible.
+// If the version is specified, its changes are fetched directly walking the change DAG from the heads,
+// and only if that fails we look for the providers of the entity and sync with them.
 func (s *Service) DiscoverObject(ctx context.Context, obj hyper.EntityID, ver hyper.Version) error {
-	// TODO(burdiyan): if we know the version, there's no need to finding provider peers
-	// for the permanode, we could be just looking for the leaf change CIDs, and walk up the
-	// change DAG. We are doing almost exactly that inside the syncFromVersion() method.
-
 	if s.cfg.NoDiscovery {
 		return status.Error(codes.FailedPrecondition, "remote content discovery is disabled")
 	}
 
+	target := string(obj)
+	if ver != "" {
+		target += "?v=" + ver.String()
+	}
+
+	return s.jobs.Run(ctx, JobKindDiscovery, target, PriorityInteractive, func(ctx context.Context, p *Progress) error {
+		return s.discoverObject(ctx, obj, ver, p)
+	})
+}
+
+func (s *Service) discoverObject(ctx context.Context, obj hyper.EntityID, ver hyper.Version, p *Progress) error {
 	ctx, cancel := context.WithTimeout(ctx, defaultDiscoveryTimeout)
 	defer cancel()
 
+	if ver != "" {
+		heads, err := ver.Parse()
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "bad version %s: %v", ver, err)
+		}
+
+		err = s.fetchVersion(ctx, obj, heads, p)
+		if err == nil {
+			return nil
+		}
+
+		s.log.Debug("FailedToFetchVersion",
+			zap.String("entity", string(obj)),
+			zap.String("version", ver.String()),
+			zap.Error(err),
+		)
+	}
+
 	c, err := obj.CID()
 	if err != nil {
 		return err
@@ -48,18 +84,25 @@ func (s *Service) DiscoverObject(ctx context.Context, obj hyper.EntityID, ver hy
 
 	var wg sync.WaitGroup
 
-	for p := range peers {
-		p := p
+	for prov := range peers {
+		prov := prov
+		// Can't sync with self.
+		if prov.ID == s.me.DeviceKey().PeerID() {
+			continue
+		}
+
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			log := s.log.With(
 				zap.String("entity", string(obj)),
 				zap.String("CID", c.String()),
-				zap.String("peer", p.String()),
+				zap.String("peer", prov.String()),
 			)
 			log.Debug("DiscoveredProvider")
-			if err := s.SyncWithPeer(ctx, p.ID); err != nil {
+			// Syncing with the provider within the discovery job,
+			// because waiting for other jobs from within a job could exhaust the job slots.
+			if err := s.syncWithPeer(ctx, prov.ID, p); err != nil {
 				log.Debug("FinishedSyncingWithProvider", zap.Error(err))
 				return
 			}
@@ -105,3 +148,140 @@ func (s *Service) hasBlocks(ctx context.Context, cids ...cid.Cid) (ok bool, err
 	}
 	return true, nil
 }
+
+func (s *Service) fetchVersion(ctx context.Context, obj hyper.EntityID, heads []cid.Cid, p *Progress) error {
+	ctx, cancel := context.WithTimeout(ctx, versionDiscoveryTimeout)
+	defer cancel()
+
+	sess := s.bitswap.NewSession(ctx)
+
+	return fetchChangeDAG(ctx, obj, heads, s.blobs.IPFSBlockstore(), sess, p)
+}
+
+// fetchChangeDAG fetches the changes of the entity reachable from the given heads,
+// walking the change DAG back until it reaches the changes we already have.
+// Key delegations of the fetched changes are fetched as well.
+// Each level of the DAG is requested at once, and the blobs are stored
+// after all of them are fetched, dependencies first, because blobs are indexed as they are stored.
+func fetchChangeDAG(ctx context.Context, obj hyper.EntityID, heads []cid.Cid, bs blockstore.Blockstore, sess exchange.Fetcher, p *Progress) error {
+	var (
+		fetched = map[cid.Cid]blocks.Block{}
+		links   = map[cid.Cid][]cid.Cid{}
+		seen    = map[cid.Cid]struct{}{}
+	)
+
+	next := heads
+	for len(next) > 0 {
+		var want []cid.Cid
+		for _, c := range next {
+			if _, ok := seen[c]; ok {
+				continue
+			}
+			seen[c] = struct{}{}
+
+			ok, err := bs.Has(ctx, c)
+			if err != nil {
+				return fmt.Errorf("failed to check if we have blob %s: %w", c, err)
+			}
+			if !ok {
+				want = append(want, c)
+			}
+		}
+		next = nil
+
+		if len(want) == 0 {
+			break
+		}
+
+		p.AddWanted(len(want))
+
+		ch, err := sess.GetBlocks(ctx, want)
+		if err != nil {
+			return err
+		}
+
+		var got int
+		for blk := range ch {
+			l, err := changeDAGLinks(obj, blk)
+			if err != nil {
+				return err
+			}
+			got++
+			p.AddFetched(1)
+
+			fetched[blk.Cid()] = blk
+			links[blk.Cid()] = l
+			next = append(next, l...)
+		}
+
+		if got < len(want) {
+			p.AddFailed(len(want) - got)
+			return fmt.Errorf("failed to fetch %d out of %d wanted blobs: %w", len(want)-got, len(want), ctx.Err())
+		}
+	}
+
+	var (
+		order   []blocks.Block
+		visited = make(map[cid.Cid]struct{}, len(fetched))
+		visit   func(c cid.Cid)
+	)
+	visit = func(c cid.Cid) {
+		if _, ok := visited[c]; ok {
+			return
+		}
+		visited[c] = struct{}{}
+
+		blk, ok := fetched[c]
+		if !ok {
+			return
+		}
+
+		for _, l := range links[c] {
+			visit(l)
+		}
+		order = append(order, blk)
+	}
+	for _, h := range heads {
+		visit(h)
+	}
+
+	for _, blk := range order {
+		if err := bs.Put(ctx, blk); err != nil {
+			// Invalid blobs are skipped, so they don't prevent us from storing the rest of the entity.
+			if errors.Is(err, hyper.ErrInvalidBlob) {
+				mRejectedBlobsTotal.Inc()
+				continue
+			}
+			return fmt.Errorf("failed to store blob %s: %w", blk.Cid(), err)
+		}
+	}
+
+	return nil
+}
+
+// changeDAGLinks returns the blobs the change depends on,
+// i.e. its dependencies and its key delegation.
+func changeDAGLinks(obj hyper.EntityID, blk blocks.Block) ([]cid.Cid, error) {
+	hb, err := hyper.DecodeBlob(blk.Cid(), blk.RawData())
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := hb.Decoded.(type) {
+	case hyper.Change:
+		if v.Entity != obj {
+			return nil, fmt.Errorf("change %s belongs to entity %s instead of %s", blk.Cid(), v.Entity, obj)
+		}
+
+		out := make([]cid.Cid, 0, len(v.Deps)+1)
+		out = append(out, v.Deps...)
+		if v.Delegation.Defined() {
+			out = append(out, v.Delegation)
+		}
+		return out, nil
+	case hyper.KeyDelegation:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected blob %s of type %T in the change DAG", blk.Cid(), v)
+	}
+}
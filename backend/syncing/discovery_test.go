@@ -0,0 +1,90 @@
+package syncing
+
+import (
+	"context"
+	"mintter/backend/core/coretest"
+	daemon "mintter/backend/daemon/api/daemon/v1alpha"
+	"mintter/backend/daemon/storage"
+	"mintter/backend/hyper"
+	"mintter/backend/logging"
+	"testing"
+	"time"
+
+	"github.com/ipfs/boxo/blockstore"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchChangeDAG(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	alice := coretest.NewTester("alice")
+
+	aliceBlobs := hyper.NewStorage(storage.MakeTestDB(t), logging.New("mintter/hyper", "debug"))
+	_, err := daemon.Register(ctx, aliceBlobs, alice.Account, alice.Device.PublicKey, time.Now())
+	require.NoError(t, err)
+	bobBlobs := hyper.NewStorage(storage.MakeTestDB(t), logging.New("mintter/hyper", "debug"))
+
+	delegation := getDelegation(ctx, alice.Identity, aliceBlobs)
+	entity := hyper.NewEntity("foo")
+	change := func(name string) hyper.Blob {
+		hb, err := entity.CreateChange(entity.NextTimestamp(), alice.Device, delegation, map[string]any{
+			"name": name,
+		})
+		require.NoError(t, err)
+		require.NoError(t, aliceBlobs.SaveBlob(ctx, hb))
+		return hb
+	}
+
+	c1 := change("alice")
+	c2 := change("alice-2")
+	c3 := change("alice-3")
+
+	sess := &recordingFetcher{bs: aliceBlobs.IPFSBlockstore()}
+	var p Progress
+	require.NoError(t, fetchChangeDAG(ctx, entity.ID(), []cid.Cid{c3.CID}, bobBlobs.IPFSBlockstore(), sess, &p))
+	require.ElementsMatch(t, []cid.Cid{c1.CID, c2.CID, c3.CID, delegation}, sess.requested, "must fetch the whole change DAG with the delegation")
+	require.Equal(t, int64(4), p.snapshot().FetchedBlobs)
+
+	{
+		e, err := bobBlobs.LoadEntity(ctx, entity.ID())
+		require.NoError(t, err)
+		name, _ := e.Get("name")
+		require.Equal(t, "alice-3", name)
+	}
+
+	c4 := change("alice-4")
+	sess.requested = nil
+	require.NoError(t, fetchChangeDAG(ctx, entity.ID(), []cid.Cid{c4.CID}, bobBlobs.IPFSBlockstore(), sess, nil))
+	require.Equal(t, []cid.Cid{c4.CID}, sess.requested, "must stop walking at the changes we already have")
+
+	err = fetchChangeDAG(ctx, hyper.EntityID("bar"), []cid.Cid{change("alice-5").CID}, bobBlobs.IPFSBlockstore(), sess, nil)
+	require.Error(t, err, "must reject changes of other entities")
+}
+
+// recordingFetcher serves blocks from a local blockstore and records which ones were requested.
+type recordingFetcher struct {
+	bs        blockstore.Blockstore
+	requested []cid.Cid
+}
+
+func (f *recordingFetcher) GetBlock(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	f.requested = append(f.requested, c)
+	return f.bs.Get(ctx, c)
+}
+
+func (f *recordingFetcher) GetBlocks(ctx context.Context, cids []cid.Cid) (<-chan blocks.Block, error) {
+	out := make(chan blocks.Block, len(cids))
+	for _, c := range cids {
+		f.requested = append(f.requested, c)
+		blk, err := f.bs.Get(ctx, c)
+		if err != nil {
+			continue
+		}
+		out <- blk
+	}
+	close(out)
+	return out, nil
+}
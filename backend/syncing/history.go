@@ -0,0 +1,286 @@
+package syncing
+
+import (
+	"context"
+	"fmt"
+	"mintter/backend/pkg/dqb"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxHistoryPerTarget is the number of sync attempts we keep in the history for each target.
+	maxHistoryPerTarget = 50
+
+	// maxHistoryTotal is the number of sync attempts we keep in the history overall,
+	// because the set of targets keeps growing as we meet new peers.
+	maxHistoryTotal = 10000
+
+	// maxHistoryAge is how long we keep the sync attempts in the history,
+	// so the targets we don't sync with anymore eventually go away.
+	maxHistoryAge = 30 * 24 * time.Hour
+)
+
+// SyncAttempt is a record of a finished sync job in the sync history.
+type SyncAttempt struct {
+	ID        int64
+	Kind      JobKind
+	Target    string
+	StartTime time.Time
+	EndTime   time.Time
+	Progress  ProgressSnapshot
+	Error     string
+}
+
+// SyncStatus is the status of syncing with a single target,
+// derived from the most recent attempts in the history.
+type SyncStatus struct {
+	LastAttempt SyncAttempt
+	// End time of the most recent successful attempt. Zero if there's none in the history.
+	LastOKTime time.Time
+	// Number of failed attempts since the last successful one.
+	ConsecutiveFailures int
+}
+
+// recordJob stores the finished job in the sync history.
+// Errors are only logged, because the history is only used for diagnostics.
+func (s *Service) recordJob(j JobInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var errmsg string
+	if j.Err != nil {
+		errmsg = j.Err.Error()
+	}
+
+	if err := s.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		if err := sqlitex.Exec(conn, qInsertSyncAttempt(), nil,
+			j.Kind.String(),
+			j.Target,
+			j.StartTime.UnixMilli(),
+			j.EndTime.UnixMilli(),
+			j.Progress.WantedBlobs,
+			j.Progress.FetchedBlobs,
+			j.Progress.FailedBlobs,
+			j.Progress.PushedBlobs,
+			errmsg,
+			j.Progress.Cursor,
+		); err != nil {
+			return err
+		}
+
+		return pruneSyncHistory(conn, j.Kind, j.Target, time.Now().Add(-maxHistoryAge), maxHistoryTotal)
+	}); err != nil {
+		s.log.Warn("FailedToRecordSyncAttempt", zap.String("kind", j.Kind.String()), zap.String("target", j.Target), zap.Error(err))
+	}
+}
+
+var qInsertSyncAttempt = dqb.Str(`
+	INSERT INTO sync_history (kind, target, start_time, end_time, wanted_blobs, fetched_blobs, failed_blobs, pushed_blobs, error, cursor)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+`)
+
+// pruneSyncHistory removes the old attempts for the target, the attempts that ended before the cutoff time,
+// and the oldest attempts beyond the total limit.
+func pruneSyncHistory(conn *sqlite.Conn, kind JobKind, target string, cutoff time.Time, keepTotal int) error {
+	if err := sqlitex.Exec(conn, qPruneSyncHistory(), nil, kind.String(), target, maxHistoryPerTarget); err != nil {
+		return err
+	}
+
+	if err := sqlitex.Exec(conn, qPruneSyncHistoryByAge(), nil, cutoff.UnixMilli()); err != nil {
+		return err
+	}
+
+	return sqlitex.Exec(conn, qPruneSyncHistoryTotal(), nil, keepTotal)
+}
+
+var qPruneSyncHistory = dqb.Str(`
+	DELETE FROM sync_history
+	WHERE kind = :kind
+	AND target = :target
+	AND id <= (
+		SELECT id
+		FROM sync_history
+		WHERE kind = :kind
+		AND target = :target
+		ORDER BY id DESC
+		LIMIT 1 OFFSET :keep
+	);
+`)
+
+var qPruneSyncHistoryByAge = dqb.Str(`
+	DELETE FROM sync_history
+	WHERE end_time < :cutoff;
+`)
+
+var qPruneSyncHistoryTotal = dqb.Str(`
+	DELETE FROM sync_history
+	WHERE id <= (
+		SELECT id
+		FROM sync_history
+		ORDER BY id DESC
+		LIMIT 1 OFFSET :keep
+	);
+`)
+
+// SyncStatus returns the status of syncing with each target in the history, most recently attempted first.
+// Zero kind and empty target match everything.
+func (s *Service) SyncStatus(ctx context.Context, kind JobKind, target string) ([]SyncStatus, error) {
+	conn, release, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var out []SyncStatus
+	if err := sqlitex.Exec(conn, qSyncStatus(), func(stmt *sqlite.Stmt) error {
+		a, err := scanSyncAttempt(stmt)
+		if err != nil {
+			return err
+		}
+
+		st := SyncStatus{
+			LastAttempt:         a,
+			ConsecutiveFailures: stmt.ColumnInt(12),
+		}
+		if stmt.ColumnType(11) != sqlite.SQLITE_NULL {
+			st.LastOKTime = time.UnixMilli(stmt.ColumnInt64(11))
+		}
+
+		out = append(out, st)
+		return nil
+	}, historyKindFilter(kind), target); err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+
+	return out, nil
+}
+
+var qSyncStatus = dqb.Str(`
+	SELECT
+		h.id,
+		h.kind,
+		h.target,
+		h.start_time,
+		h.end_time,
+		h.wanted_blobs,
+		h.fetched_blobs,
+		h.failed_blobs,
+		h.pushed_blobs,
+		h.error,
+		h.cursor,
+		(
+			SELECT max(ok.end_time)
+			FROM sync_history ok
+			WHERE ok.kind = h.kind
+			AND ok.target = h.target
+			AND ok.error = ''
+		) AS last_ok_time,
+		(
+			SELECT count()
+			FROM sync_history f
+			WHERE f.kind = h.kind
+			AND f.target = h.target
+			AND f.id > coalesce((
+				SELECT max(ok.id)
+				FROM sync_history ok
+				WHERE ok.kind = h.kind
+				AND ok.target = h.target
+				AND ok.error = ''
+			), 0)
+		) AS failures
+	FROM sync_history h
+	WHERE h.id IN (
+		SELECT max(id)
+		FROM sync_history
+		WHERE (:kind = '' OR kind = :kind)
+		AND (:target = '' OR target = :target)
+		GROUP BY kind, target
+	)
+	ORDER BY h.id DESC;
+`)
+
+// SyncHistory returns up to limit sync attempts with ID lower than beforeID, most recent first.
+// Zero beforeID starts from the most recent attempt. Zero kind and empty target match everything.
+func (s *Service) SyncHistory(ctx context.Context, kind JobKind, target string, beforeID int64, limit int) ([]SyncAttempt, error) {
+	conn, release, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	out := make([]SyncAttempt, 0, limit)
+	if err := sqlitex.Exec(conn, qSyncHistory(), func(stmt *sqlite.Stmt) error {
+		a, err := scanSyncAttempt(stmt)
+		if err != nil {
+			return err
+		}
+		out = append(out, a)
+		return nil
+	}, historyKindFilter(kind), target, beforeID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list sync history: %w", err)
+	}
+
+	return out, nil
+}
+
+var qSyncHistory = dqb.Str(`
+	SELECT
+		id,
+		kind,
+		target,
+		start_time,
+		end_time,
+		wanted_blobs,
+		fetched_blobs,
+		failed_blobs,
+		pushed_blobs,
+		error,
+		cursor
+	FROM sync_history
+	WHERE (:kind = '' OR kind = :kind)
+	AND (:target = '' OR target = :target)
+	AND (:before = 0 OR id < :before)
+	ORDER BY id DESC
+	LIMIT :limit;
+`)
+
+// scanSyncAttempt reads the attempt from the leading columns shared by the history queries.
+func scanSyncAttempt(stmt *sqlite.Stmt) (a SyncAttempt, err error) {
+	a.ID = stmt.ColumnInt64(0)
+	a.Kind, err = parseJobKind(stmt.ColumnText(1))
+	if err != nil {
+		return a, err
+	}
+	a.Target = stmt.ColumnText(2)
+	a.StartTime = time.UnixMilli(stmt.ColumnInt64(3))
+	a.EndTime = time.UnixMilli(stmt.ColumnInt64(4))
+	a.Progress = ProgressSnapshot{
+		WantedBlobs:  stmt.ColumnInt64(5),
+		FetchedBlobs: stmt.ColumnInt64(6),
+		FailedBlobs:  stmt.ColumnInt64(7),
+		PushedBlobs:  stmt.ColumnInt64(8),
+		Cursor:       stmt.ColumnText(10),
+	}
+	a.Error = stmt.ColumnText(9)
+	return a, nil
+}
+
+func historyKindFilter(kind JobKind) string {
+	if kind == 0 {
+		return ""
+	}
+	return kind.String()
+}
+
+func parseJobKind(s string) (JobKind, error) {
+	for _, k := range [...]JobKind{JobKindPeer, JobKindSite, JobKindDiscovery} {
+		if k.String() == s {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown sync job kind '%s'", s)
+}
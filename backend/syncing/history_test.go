@@ -0,0 +1,99 @@
+package syncing
+
+import (
+	"context"
+	"errors"
+	"mintter/backend/daemon/storage"
+	"strconv"
+	"testing"
+	"time"
+
+	"crawshaw.io/sqlite"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSyncHistory(t *testing.T) {
+	s := &Service{db: storage.MakeTestDB(t), log: zap.NewNop()}
+	ctx := context.Background()
+
+	start := time.UnixMilli(time.Now().UnixMilli())
+	record := func(kind JobKind, target string, err error) {
+		s.recordJob(JobInfo{
+			Kind:      kind,
+			Target:    target,
+			StartTime: start,
+			EndTime:   start.Add(time.Second),
+			Progress:  ProgressSnapshot{WantedBlobs: 3, FetchedBlobs: 2, FailedBlobs: 1, Cursor: "cursor-" + target},
+			Err:       err,
+		})
+	}
+
+	record(JobKindPeer, "alice", nil)
+	record(JobKindPeer, "alice", errors.New("connection refused"))
+	record(JobKindPeer, "alice", errors.New("connection refused"))
+	record(JobKindSite, "https://example.com", nil)
+
+	status, err := s.SyncStatus(ctx, 0, "")
+	require.NoError(t, err)
+	require.Len(t, status, 2)
+
+	require.Equal(t, JobKindSite, status[0].LastAttempt.Kind, "most recently attempted targets must go first")
+	require.Equal(t, 0, status[0].ConsecutiveFailures)
+	require.Equal(t, start.Add(time.Second), status[0].LastOKTime)
+
+	alice := status[1]
+	require.Equal(t, "alice", alice.LastAttempt.Target)
+	require.Equal(t, "connection refused", alice.LastAttempt.Error)
+	require.Equal(t, 2, alice.ConsecutiveFailures)
+	require.Equal(t, start.Add(time.Second), alice.LastOKTime, "last successful attempt must be reported after failures")
+	require.Equal(t, ProgressSnapshot{WantedBlobs: 3, FetchedBlobs: 2, FailedBlobs: 1, Cursor: "cursor-alice"}, alice.LastAttempt.Progress)
+	require.Equal(t, time.Second, alice.LastAttempt.EndTime.Sub(alice.LastAttempt.StartTime))
+
+	status, err = s.SyncStatus(ctx, JobKindSite, "")
+	require.NoError(t, err)
+	require.Len(t, status, 1, "status must be filtered by kind")
+
+	hist, err := s.SyncHistory(ctx, JobKindPeer, "alice", 0, 2)
+	require.NoError(t, err)
+	require.Len(t, hist, 2)
+	require.Greater(t, hist[0].ID, hist[1].ID, "history must be ordered from the most recent")
+
+	hist, err = s.SyncHistory(ctx, JobKindPeer, "alice", hist[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, hist, 1)
+	require.Equal(t, "", hist[0].Error)
+}
+
+func TestSyncHistoryPruning(t *testing.T) {
+	s := &Service{db: storage.MakeTestDB(t), log: zap.NewNop()}
+	ctx := context.Background()
+
+	now := time.Now()
+	for i := 0; i < maxHistoryPerTarget+10; i++ {
+		s.recordJob(JobInfo{Kind: JobKindPeer, Target: "alice", EndTime: now, Progress: ProgressSnapshot{Cursor: strconv.Itoa(i)}})
+	}
+	s.recordJob(JobInfo{Kind: JobKindPeer, Target: "bob", EndTime: now})
+
+	hist, err := s.SyncHistory(ctx, JobKindPeer, "alice", 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, hist, maxHistoryPerTarget, "history must be limited for each target")
+	require.Equal(t, strconv.Itoa(maxHistoryPerTarget+9), hist[0].Progress.Cursor, "most recent attempts must be kept")
+
+	hist, err = s.SyncHistory(ctx, JobKindPeer, "bob", 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, hist, 1, "pruning must only affect the same target")
+
+	s.recordJob(JobInfo{Kind: JobKindPeer, Target: "carol", EndTime: now.Add(-maxHistoryAge - time.Hour)})
+	hist, err = s.SyncHistory(ctx, JobKindPeer, "carol", 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, hist, 0, "attempts older than the max age must be pruned")
+
+	require.NoError(t, s.db.WithTx(ctx, func(conn *sqlite.Conn) error {
+		return pruneSyncHistory(conn, JobKindPeer, "bob", now.Add(-maxHistoryAge), 10)
+	}))
+	hist, err = s.SyncHistory(ctx, 0, "", 0, 1000)
+	require.NoError(t, err)
+	require.Len(t, hist, 10, "history must be limited in total")
+	require.Equal(t, "bob", hist[0].Target, "most recent attempts must be kept")
+}
@@ -0,0 +1,524 @@
+package syncing
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	mJobsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mintter_syncing_jobs_in_flight",
+		Help: "Number of sync jobs currently running.",
+	}, []string{"kind"})
+
+	mJobsQueued = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mintter_syncing_jobs_queued",
+		Help: "Number of sync jobs waiting for a free slot.",
+	}, []string{"kind"})
+
+	mJobsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mintter_syncing_jobs_total",
+		Help: "The total number of finished sync jobs.",
+	}, []string{"kind", "state"})
+)
+
+// JobKind is the kind of work a sync job performs.
+type JobKind uint8
+
+// Kinds of sync jobs.
+const (
+	JobKindPeer      JobKind = 1 // Syncing with a peer.
+	JobKindSite      JobKind = 2 // Syncing with a group site.
+	JobKindDiscovery JobKind = 3 // Discovering an entity from the providers in the network.
+)
+
+func (k JobKind) String() string {
+	switch k {
+	case JobKindPeer:
+		return "peer"
+	case JobKindSite:
+		return "site"
+	case JobKindDiscovery:
+		return "discovery"
+	default:
+		return "unknown"
+	}
+}
+
+// JobState is the lifecycle state of a sync job.
+type JobState uint8
+
+// States of sync jobs.
+const (
+	JobQueued    JobState = 1
+	JobRunning   JobState = 2
+	JobSucceeded JobState = 3
+	JobFailed    JobState = 4
+)
+
+func (s JobState) String() string {
+	switch s {
+	case JobQueued:
+		return "queued"
+	case JobRunning:
+		return "running"
+	case JobSucceeded:
+		return "succeeded"
+	case JobFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// JobPriority determines the order in which queued jobs get a free slot.
+// Jobs with higher priority go first.
+type JobPriority int8
+
+// Priorities of sync jobs.
+const (
+	// PriorityBackground is for periodic jobs. They respect the backoff after failures.
+	PriorityBackground JobPriority = 0
+	// PriorityInteractive is for jobs somebody is waiting for. They ignore the backoff.
+	PriorityInteractive JobPriority = 10
+)
+
+// ErrJobBackoff is returned when a background job is not started
+// because the previous jobs for the same target have failed recently.
+var ErrJobBackoff = errors.New("sync job target is in backoff after recent failures")
+
+// Backoff parameters for the targets of failed jobs.
+const (
+	jobBackoffBase = 30 * time.Second
+	jobBackoffMax  = time.Hour
+)
+
+// maxRecentJobs is the number of finished jobs we remember for inspection.
+const maxRecentJobs = 100
+
+// Progress of a sync job. It's safe for concurrent use,
+// and nil progress can be used to not track anything.
+type Progress struct {
+	wanted  atomic.Int64
+	fetched atomic.Int64
+	failed  atomic.Int64
+	pushed  atomic.Int64
+	cursor  atomic.Pointer[string]
+}
+
+// AddWanted records blobs that we want to fetch.
+func (p *Progress) AddWanted(n int) {
+	if p != nil {
+		p.wanted.Add(int64(n))
+	}
+}
+
+// AddFetched records blobs we've fetched successfully.
+func (p *Progress) AddFetched(n int) {
+	if p != nil {
+		p.fetched.Add(int64(n))
+	}
+}
+
+// AddFailed records blobs we've failed to fetch.
+func (p *Progress) AddFailed(n int) {
+	if p != nil {
+		p.failed.Add(int64(n))
+	}
+}
+
+// AddPushed records blobs we've pushed to the remote peer.
+func (p *Progress) AddPushed(n int) {
+	if p != nil {
+		p.pushed.Add(int64(n))
+	}
+}
+
+// SetCursor records the position of our sync cursor with the remote peer.
+func (p *Progress) SetCursor(cursor string) {
+	if p != nil {
+		p.cursor.Store(&cursor)
+	}
+}
+
+// ProgressSnapshot is the state of the progress at some point in time.
+type ProgressSnapshot struct {
+	WantedBlobs  int64
+	FetchedBlobs int64
+	FailedBlobs  int64
+	PushedBlobs  int64
+	Cursor       string
+}
+
+func (p *Progress) snapshot() ProgressSnapshot {
+	ps := ProgressSnapshot{
+		WantedBlobs:  p.wanted.Load(),
+		FetchedBlobs: p.fetched.Load(),
+		FailedBlobs:  p.failed.Load(),
+		PushedBlobs:  p.pushed.Load(),
+	}
+	if c := p.cursor.Load(); c != nil {
+		ps.Cursor = *c
+	}
+	return ps
+}
+
+// JobFunc performs the work of a sync job.
+type JobFunc func(ctx context.Context, p *Progress) error
+
+// JobInfo describes a sync job.
+type JobInfo struct {
+	ID         int64
+	Kind       JobKind
+	Target     string
+	Priority   JobPriority
+	State      JobState
+	CreateTime time.Time
+	StartTime  time.Time
+	EndTime    time.Time
+	Progress   ProgressSnapshot
+	Err        error
+	// Number of consecutive failures for the same target before this job.
+	Attempt int
+}
+
+type jobKey struct {
+	kind   JobKind
+	target string
+}
+
+type job struct {
+	id         int64
+	key        jobKey
+	priority   JobPriority
+	state      JobState
+	createTime time.Time
+	startTime  time.Time
+	endTime    time.Time
+	attempt    int
+	progress   Progress
+	err        error
+	// abandoned is set when the job was canceled by the context of the caller that started it,
+	// so the error doesn't say anything to the other callers waiting for the job.
+	abandoned bool
+
+	// Position in the queue heap, or -1 if not queued.
+	index int
+	// ready is closed when the job gets a slot.
+	ready chan struct{}
+	// done is closed when the job is finished.
+	done chan struct{}
+}
+
+func (j *job) info() JobInfo {
+	return JobInfo{
+		ID:         j.id,
+		Kind:       j.key.kind,
+		Target:     j.key.target,
+		Priority:   j.priority,
+		State:      j.state,
+		CreateTime: j.createTime,
+		StartTime:  j.startTime,
+		EndTime:    j.endTime,
+		Progress:   j.progress.snapshot(),
+		Err:        j.err,
+		Attempt:    j.attempt,
+	}
+}
+
+type backoffState struct {
+	failures int
+	retryAt  time.Time
+}
+
+// jobQueue runs sync jobs with limited concurrency, in the order of their priority.
+// Jobs of the same kind for the same target are deduplicated, and jobs of different kinds for the same target
+// run one after another, so that e.g. a site and a peer sync with the same peer don't overlap.
+// Failing targets are backed off exponentially using the same policy for every kind of job,
+// so a stuck site behaves the same way as a stuck peer.
+// Jobs run in the goroutines of their callers, so the queue doesn't need to be started.
+type jobQueue struct {
+	limit   int
+	timeout time.Duration
+
+	mu      sync.Mutex
+	nextID  int64
+	running int
+	queue   jobHeap
+	active  map[string]*job // Keyed by target.
+	recent  []*job
+	backoff map[jobKey]backoffState
+
+	// onFinish is called for every job that has been started, after it's finished.
+	// It's called outside of the lock, in the goroutine of the job.
+	onFinish func(JobInfo)
+}
+
+func newJobQueue(limit int, timeout time.Duration) *jobQueue {
+	if limit <= 0 {
+		limit = 1
+	}
+
+	return &jobQueue{
+		limit:   limit,
+		timeout: timeout,
+		active:  make(map[string]*job),
+		backoff: make(map[jobKey]backoffState),
+	}
+}
+
+// Run schedules the job and blocks until it's finished.
+// If a job of the same kind for the same target is already queued or running, Run waits for it instead of starting a new one.
+// If a job of a different kind for the same target is queued or running, Run waits for it to finish before scheduling the new one.
+func (q *jobQueue) Run(ctx context.Context, kind JobKind, target string, priority JobPriority, fn JobFunc) error {
+	key := jobKey{kind: kind, target: target}
+
+	q.mu.Lock()
+	for {
+		j, ok := q.active[target]
+		if !ok {
+			break
+		}
+
+		joined := j.key == key
+		if joined && priority > j.priority {
+			j.priority = priority
+			if j.index >= 0 {
+				heap.Fix(&q.queue, j.index)
+			}
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-j.done:
+		}
+
+		// Joined callers get the result of the job, unless it was abandoned by the caller that started it.
+		// In that case the error belongs to someone else's context, so we try again with ours.
+		if joined && !j.abandoned {
+			return j.err
+		}
+
+		q.mu.Lock()
+	}
+
+	q.pruneBackoff(time.Now())
+
+	bo := q.backoff[key]
+	if priority < PriorityInteractive && time.Now().Before(bo.retryAt) {
+		q.mu.Unlock()
+		return fmt.Errorf("%w: retry after %s", ErrJobBackoff, bo.retryAt.Format(time.RFC3339))
+	}
+
+	q.nextID++
+	j := &job{
+		id:         q.nextID,
+		key:        key,
+		priority:   priority,
+		state:      JobQueued,
+		createTime: time.Now(),
+		attempt:    bo.failures,
+		index:      -1,
+		ready:      make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	q.active[target] = j
+
+	if q.running < q.limit && q.queue.Len() == 0 {
+		q.start(j)
+	} else {
+		heap.Push(&q.queue, j)
+		mJobsQueued.WithLabelValues(kind.String()).Inc()
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		q.mu.Lock()
+		// The job could have been started concurrently, in which case we have to release the slot.
+		if j.index >= 0 {
+			heap.Remove(&q.queue, j.index)
+			mJobsQueued.WithLabelValues(kind.String()).Dec()
+		} else {
+			q.running--
+			mJobsInFlight.WithLabelValues(kind.String()).Dec()
+			q.startNext()
+		}
+		// The job never ran, so it doesn't say anything about the health of the target.
+		j.abandoned = true
+		q.finish(j, ctx.Err(), false)
+		q.mu.Unlock()
+		return ctx.Err()
+	case <-j.ready:
+	}
+
+	err := q.exec(ctx, j, fn)
+
+	q.mu.Lock()
+	q.running--
+	mJobsInFlight.WithLabelValues(kind.String()).Dec()
+	q.startNext()
+	j.abandoned = err != nil && ctx.Err() != nil
+	q.finish(j, err, !errors.Is(err, context.Canceled))
+	info := j.info()
+	q.mu.Unlock()
+
+	if q.onFinish != nil {
+		q.onFinish(info)
+	}
+
+	return err
+}
+
+func (q *jobQueue) exec(ctx context.Context, j *job, fn JobFunc) error {
+	if q.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.timeout)
+		defer cancel()
+	}
+
+	return fn(ctx, &j.progress)
+}
+
+// start must be called with the lock held.
+func (q *jobQueue) start(j *job) {
+	q.running++
+	j.state = JobRunning
+	j.startTime = time.Now()
+	mJobsInFlight.WithLabelValues(j.key.kind.String()).Inc()
+	close(j.ready)
+}
+
+// startNext must be called with the lock held.
+func (q *jobQueue) startNext() {
+	for q.running < q.limit && q.queue.Len() > 0 {
+		j := heap.Pop(&q.queue).(*job)
+		mJobsQueued.WithLabelValues(j.key.kind.String()).Dec()
+		q.start(j)
+	}
+}
+
+// finish must be called with the lock held.
+// Failed jobs put their target into backoff, unless told otherwise,
+// e.g. for canceled jobs, which don't say anything about the health of the target.
+func (q *jobQueue) finish(j *job, err error, backoff bool) {
+	j.endTime = time.Now()
+	j.err = err
+	switch {
+	case err == nil:
+		j.state = JobSucceeded
+		delete(q.backoff, j.key)
+	case !backoff:
+		j.state = JobFailed
+	default:
+		j.state = JobFailed
+		bo := q.backoff[j.key]
+		bo.failures++
+		delay := jobBackoffMax
+		if shift := bo.failures - 1; shift < 16 {
+			delay = jobBackoffBase << shift
+			if delay > jobBackoffMax {
+				delay = jobBackoffMax
+			}
+		}
+		bo.retryAt = j.endTime.Add(delay)
+		q.backoff[j.key] = bo
+	}
+
+	mJobsTotal.WithLabelValues(j.key.kind.String(), j.state.String()).Inc()
+
+	delete(q.active, j.key.target)
+
+	q.recent = append(q.recent, j)
+	if len(q.recent) > maxRecentJobs {
+		q.recent = q.recent[len(q.recent)-maxRecentJobs:]
+	}
+
+	close(j.done)
+}
+
+// pruneBackoff drops the backoff entries of the targets that haven't failed
+// for a while after they were allowed to retry, so we don't remember every target that has ever failed.
+// Must be called with the lock held.
+func (q *jobQueue) pruneBackoff(now time.Time) {
+	for k, bo := range q.backoff {
+		if now.After(bo.retryAt.Add(jobBackoffMax)) {
+			delete(q.backoff, k)
+		}
+	}
+}
+
+// Jobs returns the jobs that are currently queued or running, ordered by priority,
+// and the recently finished ones, most recent first.
+func (q *jobQueue) Jobs() (active, recent []JobInfo) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	active = make([]JobInfo, 0, len(q.active))
+	for _, j := range q.active {
+		active = append(active, j.info())
+	}
+	sort.Slice(active, func(i, j int) bool {
+		a, b := active[i], active[j]
+		if a.State != b.State {
+			return a.State == JobRunning
+		}
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.ID < b.ID
+	})
+
+	recent = make([]JobInfo, len(q.recent))
+	for i, j := range q.recent {
+		recent[len(q.recent)-1-i] = j.info()
+	}
+
+	return active, recent
+}
+
+// jobHeap is a priority queue of jobs waiting for a free slot.
+// Jobs with the same priority are served in FIFO order.
+type jobHeap []*job
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].id < h[j].id
+}
+
+func (h jobHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *jobHeap) Push(x any) {
+	j := x.(*job)
+	j.index = len(*h)
+	*h = append(*h, j)
+}
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	j := old[n-1]
+	old[n-1] = nil
+	j.index = -1
+	*h = old[:n-1]
+	return j
+}
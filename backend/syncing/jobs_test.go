@@ -0,0 +1,332 @@
+package syncing
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobQueuePriority(t *testing.T) {
+	q := newJobQueue(1, time.Minute)
+	ctx := context.Background()
+
+	// Occupying the only slot, so that other jobs get queued.
+	blocker := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = q.Run(ctx, JobKindPeer, "blocker", PriorityBackground, func(context.Context, *Progress) error {
+			close(started)
+			<-blocker
+			return nil
+		})
+	}()
+	<-started
+
+	var (
+		mu    sync.Mutex
+		order []string
+		wg    sync.WaitGroup
+	)
+
+	submit := func(target string, prio JobPriority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, q.Run(ctx, JobKindPeer, target, prio, func(context.Context, *Progress) error {
+				mu.Lock()
+				order = append(order, target)
+				mu.Unlock()
+				return nil
+			}))
+		}()
+
+		// Waiting until the job is queued to have deterministic order.
+		require.Eventually(t, func() bool {
+			active, _ := q.Jobs()
+			for _, j := range active {
+				if j.Target == target {
+					return j.State == JobQueued
+				}
+			}
+			return false
+		}, time.Second, time.Millisecond)
+	}
+
+	submit("bg-1", PriorityBackground)
+	submit("bg-2", PriorityBackground)
+	submit("interactive", PriorityInteractive)
+
+	active, _ := q.Jobs()
+	require.Len(t, active, 4)
+	require.Equal(t, "blocker", active[0].Target, "running jobs must go first")
+	require.Equal(t, JobRunning, active[0].State)
+	require.Equal(t, "interactive", active[1].Target, "queued jobs must be ordered by priority")
+
+	close(blocker)
+	wg.Wait()
+
+	require.Equal(t, []string{"interactive", "bg-1", "bg-2"}, order, "jobs must start by priority, and in FIFO order within the same priority")
+
+	_, recent := q.Jobs()
+	require.Len(t, recent, 4)
+	require.Equal(t, "bg-2", recent[0].Target, "recent jobs must be ordered from the most recent")
+	for _, j := range recent {
+		require.Equal(t, JobSucceeded, j.State)
+		require.False(t, j.StartTime.IsZero())
+		require.False(t, j.EndTime.IsZero())
+	}
+}
+
+func TestJobQueueConcurrencyLimit(t *testing.T) {
+	const limit = 3
+	q := newJobQueue(limit, time.Minute)
+	ctx := context.Background()
+
+	var (
+		mu      sync.Mutex
+		running int
+		maxSeen int
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, q.Run(ctx, JobKindPeer, strconv.Itoa(i), PriorityBackground, func(context.Context, *Progress) error {
+				mu.Lock()
+				running++
+				if running > maxSeen {
+					maxSeen = running
+				}
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil
+			}))
+		}(i)
+	}
+
+	wg.Wait()
+	require.LessOrEqual(t, maxSeen, limit, "jobs must not exceed the concurrency limit")
+}
+
+func TestJobQueueDeduplication(t *testing.T) {
+	q := newJobQueue(2, time.Minute)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var calls int
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- q.Run(ctx, JobKindSite, "https://example.com", PriorityBackground, func(_ context.Context, p *Progress) error {
+			calls++
+			p.AddWanted(10)
+			p.AddFetched(7)
+			p.AddFailed(3)
+			close(started)
+			<-release
+			return errors.New("site is down")
+		})
+	}()
+	<-started
+
+	active, _ := q.Jobs()
+	require.Len(t, active, 1)
+	require.Equal(t, ProgressSnapshot{WantedBlobs: 10, FetchedBlobs: 7, FailedBlobs: 3}, active[0].Progress, "progress must be visible while running")
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+
+	err := q.Run(ctx, JobKindSite, "https://example.com", PriorityInteractive, func(context.Context, *Progress) error {
+		t.Fatal("job for the same target must not run twice concurrently")
+		return nil
+	})
+	require.EqualError(t, err, "site is down", "joined callers must get the result of the running job")
+	require.EqualError(t, <-errc, "site is down")
+	require.Equal(t, 1, calls)
+
+	// Different kinds of jobs for the same target are not deduplicated.
+	require.NoError(t, q.Run(ctx, JobKindPeer, "https://example.com", PriorityBackground, func(context.Context, *Progress) error {
+		return nil
+	}))
+}
+
+func TestJobQueueBackoff(t *testing.T) {
+	q := newJobQueue(1, time.Minute)
+	ctx := context.Background()
+
+	fail := func(context.Context, *Progress) error { return errors.New("peer is unreachable") }
+	ok := func(context.Context, *Progress) error { return nil }
+
+	require.Error(t, q.Run(ctx, JobKindPeer, "alice", PriorityBackground, fail))
+
+	err := q.Run(ctx, JobKindPeer, "alice", PriorityBackground, ok)
+	require.ErrorIs(t, err, ErrJobBackoff, "background jobs must respect the backoff after failures")
+
+	require.NoError(t, q.Run(ctx, JobKindPeer, "bob", PriorityBackground, ok), "backoff must only affect the failed target")
+
+	require.Error(t, q.Run(ctx, JobKindPeer, "alice", PriorityInteractive, fail), "interactive jobs must ignore the backoff")
+
+	_, recent := q.Jobs()
+	require.Equal(t, 1, recent[0].Attempt, "attempts must count the previous failures")
+	require.Equal(t, JobFailed, recent[0].State)
+	require.EqualError(t, recent[0].Err, "peer is unreachable")
+
+	q.mu.Lock()
+	bo := q.backoff[jobKey{kind: JobKindPeer, target: "alice"}]
+	q.mu.Unlock()
+	require.Equal(t, 2, bo.failures)
+	require.WithinDuration(t, recent[0].EndTime.Add(2*jobBackoffBase), bo.retryAt, time.Millisecond, "backoff must grow exponentially")
+
+	require.NoError(t, q.Run(ctx, JobKindPeer, "alice", PriorityInteractive, ok))
+	require.NoError(t, q.Run(ctx, JobKindPeer, "alice", PriorityBackground, ok), "success must reset the backoff")
+}
+
+func TestJobQueueTimeout(t *testing.T) {
+	q := newJobQueue(1, 10*time.Millisecond)
+
+	err := q.Run(context.Background(), JobKindSite, "stuck", PriorityInteractive, func(ctx context.Context, _ *Progress) error {
+		_, ok := ctx.Deadline()
+		require.True(t, ok, "jobs must have a deadline")
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = q.Run(context.Background(), JobKindSite, "stuck", PriorityBackground, func(context.Context, *Progress) error { return nil })
+	require.ErrorIs(t, err, ErrJobBackoff, "timed out jobs must be backed off like any other failure")
+}
+
+func TestJobQueueCancelQueued(t *testing.T) {
+	q := newJobQueue(1, time.Minute)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = q.Run(context.Background(), JobKindPeer, "busy", PriorityBackground, func(context.Context, *Progress) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := q.Run(ctx, JobKindPeer, "waiting", PriorityBackground, func(context.Context, *Progress) error {
+		t.Fatal("canceled job must not run")
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	<-done
+
+	require.NoError(t, q.Run(context.Background(), JobKindPeer, "waiting", PriorityBackground, func(context.Context, *Progress) error {
+		return nil
+	}), "jobs canceled while queued must not put the target into backoff")
+
+	require.NoError(t, q.Run(context.Background(), JobKindPeer, "next", PriorityBackground, func(context.Context, *Progress) error {
+		return nil
+	}), "slots must be released after canceled and finished jobs")
+}
+
+func TestJobQueueSameTargetDifferentKinds(t *testing.T) {
+	q := newJobQueue(2, time.Minute)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Run(ctx, JobKindPeer, "alice", PriorityBackground, func(context.Context, *Progress) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	var peerDone bool
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		peerDone = true
+		close(release)
+	}()
+
+	require.NoError(t, q.Run(ctx, JobKindSite, "alice", PriorityInteractive, func(context.Context, *Progress) error {
+		require.True(t, peerDone, "site job must not run while the peer job for the same peer is running")
+		return nil
+	}))
+	require.NoError(t, <-done)
+}
+
+func TestJobQueueWaiterContext(t *testing.T) {
+	q := newJobQueue(1, time.Minute)
+
+	ownerCtx, cancelOwner := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Run(ownerCtx, JobKindPeer, "alice", PriorityBackground, func(ctx context.Context, _ *Progress) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	<-started
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancelOwner()
+	}()
+
+	var calls int
+	err := q.Run(context.Background(), JobKindPeer, "alice", PriorityInteractive, func(context.Context, *Progress) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err, "waiters must not get the context error of the caller that started the job")
+	require.Equal(t, 1, calls, "waiters must retry the job abandoned by its caller")
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestJobQueueBackoffPruning(t *testing.T) {
+	q := newJobQueue(1, time.Minute)
+	ctx := context.Background()
+
+	require.Error(t, q.Run(ctx, JobKindPeer, "alice", PriorityBackground, func(context.Context, *Progress) error {
+		return errors.New("peer is unreachable")
+	}))
+
+	q.mu.Lock()
+	bo := q.backoff[jobKey{kind: JobKindPeer, target: "alice"}]
+	bo.retryAt = time.Now().Add(-jobBackoffMax - time.Second)
+	q.backoff[jobKey{kind: JobKindPeer, target: "alice"}] = bo
+	q.mu.Unlock()
+
+	require.NoError(t, q.Run(ctx, JobKindPeer, "bob", PriorityBackground, func(context.Context, *Progress) error {
+		return nil
+	}))
+
+	q.mu.Lock()
+	require.Len(t, q.backoff, 0, "expired backoff entries must be dropped")
+	q.mu.Unlock()
+}
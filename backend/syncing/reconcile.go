@@ -0,0 +1,172 @@
+package syncing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	p2p "mintter/backend/genproto/p2p/v1alpha"
+	"mintter/backend/hyper"
+
+	"github.com/ipfs/boxo/blockstore"
+	"github.com/ipfs/boxo/exchange"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+)
+
+// maxReconcileRounds limits the number of round trips in a single reconciliation,
+// in case the remote peer never converges.
+const maxReconcileRounds = 32
+
+// reconcilePeer is like syncPeer, but instead of listing all the blobs of the remote peer,
+// it uses set reconciliation to find only those blobs we don't have.
+// It doesn't need any cursor, so it's robust to diverged histories and resets of the remote database.
+func reconcilePeer(
+	ctx context.Context,
+	pid peer.ID,
+	c p2p.P2PClient,
+	bs blockstore.Blockstore,
+	sess exchange.Fetcher,
+	local blobFingerprinter,
+	log *zap.Logger,
+	p *Progress,
+) (err error) {
+	mSyncsInFlight.Inc()
+	defer func() {
+		mSyncsInFlight.Dec()
+		mSyncsTotal.Inc()
+		if err != nil {
+			mSyncErrorsTotal.Inc()
+		}
+	}()
+
+	if _, ok := ctx.Deadline(); !ok {
+		panic("BUG: reconcilePeer must have timeout")
+	}
+
+	log = log.With(
+		zap.String("peer", pid.String()),
+	)
+
+	ranges := []*p2p.BlobRange{{}}
+	if err := fillFingerprints(ctx, local, ranges); err != nil {
+		return err
+	}
+
+	var (
+		want   []cid.Cid
+		seen   = make(map[cid.Cid]struct{})
+		rounds int
+	)
+	for len(ranges) > 0 {
+		if rounds >= maxReconcileRounds {
+			return fmt.Errorf("blob reconciliation with peer %s didn't converge after %d rounds", pid, rounds)
+		}
+		rounds++
+
+		resp, err := c.ReconcileBlobs(ctx, &p2p.ReconcileBlobsRequest{Ranges: ranges})
+		if err != nil {
+			return err
+		}
+
+		ranges = ranges[:0]
+		for _, r := range resp.Ranges {
+			if !r.IsList {
+				ranges = append(ranges, &p2p.BlobRange{
+					Start:       r.Start,
+					End:         r.End,
+					Fingerprint: r.Fingerprint,
+				})
+				continue
+			}
+
+			for _, data := range r.Cids {
+				c, err := cid.Cast(data)
+				if err != nil {
+					return err
+				}
+
+				if _, ok := seen[c]; ok {
+					continue
+				}
+				seen[c] = struct{}{}
+
+				ok, err := bs.Has(ctx, c)
+				if err != nil {
+					return fmt.Errorf("failed to check if we have blob %s: %w", c, err)
+				}
+
+				if !ok {
+					want = append(want, c)
+					mWantedBlobsTotal.Inc()
+				}
+			}
+		}
+
+		// Peer sent us the fingerprints of its subranges.
+		// We only need to continue with those that don't match ours.
+		remote := make([][]byte, len(ranges))
+		for i, r := range ranges {
+			remote[i] = r.Fingerprint
+		}
+
+		if err := fillFingerprints(ctx, local, ranges); err != nil {
+			return err
+		}
+
+		n := 0
+		for i, r := range ranges {
+			if !bytes.Equal(r.Fingerprint, remote[i]) {
+				ranges[n] = r
+				n++
+			}
+		}
+		ranges = ranges[:n]
+	}
+
+	log.Debug("BlobReconciliationFinished", zap.Int("rounds", rounds), zap.Int("wanted", len(want)))
+
+	if len(want) == 0 {
+		return nil
+	}
+
+	p.AddWanted(len(want))
+	mSyncingWantedBlobs.Add(float64(len(want)))
+	defer mSyncingWantedBlobs.Sub(float64(len(want)))
+
+	for _, c := range want {
+		blk, err := sess.GetBlock(ctx, c)
+		if err != nil {
+			log.Debug("FailedToGetWantedBlob", zap.String("cid", c.String()), zap.Error(err))
+			p.AddFailed(1)
+			continue
+		}
+
+		if err := bs.Put(ctx, blk); err != nil {
+			p.AddFailed(1)
+			if errors.Is(err, hyper.ErrInvalidBlob) {
+				log.Warn("RejectedInvalidBlob", zap.String("cid", c.String()), zap.Error(err))
+				mRejectedBlobsTotal.Inc()
+				continue
+			}
+			log.Debug("FailedToSaveWantedBlob", zap.String("cid", c.String()), zap.Error(err))
+			continue
+		}
+		p.AddFetched(1)
+	}
+
+	return nil
+}
+
+// fillFingerprints replaces the fingerprints of the ranges with the ones from our own set of blobs.
+func fillFingerprints(ctx context.Context, local blobFingerprinter, ranges []*p2p.BlobRange) error {
+	for _, r := range ranges {
+		fp, err := local.BlobRangeFingerprint(ctx, r.Start, r.End)
+		if err != nil {
+			return err
+		}
+		r.Fingerprint = fp
+	}
+	return nil
+}
@@ -22,20 +22,18 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
 )
 
-// Metrics. This is exported as a temporary measure,
-// because we have mostly the same code duplicated in groups and in syncing.
-//
-// TODO(burdiyan): refactor this to unify group syncing and normal periodic syncing.
+// Metrics.
 var (
-	MSyncingWantedBlobs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	mSyncingWantedBlobs = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "mintter_syncing_wanted_blobs",
 		Help: "Number of blobs we want to sync at this time. Same blob may be counted multiple times if it's wanted from multiple peers.",
-	}, []string{"package"})
+	})
 
 	mWantedBlobsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "mintter_syncing_wanted_blobs_total",
@@ -44,17 +42,27 @@ var (
 
 	mSyncsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "mintter_syncing_periodic_operations_total",
-		Help: "The total number of periodic sync operations performed with peers (groups don't count).",
+		Help: "The total number of sync operations performed with peers and sites.",
 	})
 
 	mSyncsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "mintter_syncing_operations_in_flight",
-		Help: "The number of periodic sync operations currently in-flight with peers (groups don't count).",
+		Help: "The number of sync operations currently in-flight with peers and sites.",
+	})
+
+	mCursorResetsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "mintter_syncing_cursor_resets_total",
+		Help: "The total number of times a peer reported our sync cursor as invalid and restarted listing blobs from the beginning.",
+	})
+
+	mRejectedBlobsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "mintter_syncing_rejected_blobs_total",
+		Help: "The total number of blobs we fetched from peers but rejected, because they were invalid.",
 	})
 
 	mSyncErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "mintter_syncing_periodic_errors_total",
-		Help: "The total number of errors encountered during periodic sync operations with peers (groups don't count).",
+		Help: "The total number of errors encountered during sync operations with peers and sites.",
 	})
 
 	mWorkers = promauto.NewGauge(prometheus.GaugeOpts{
@@ -79,12 +87,6 @@ var (
 	})
 )
 
-// Force metric to appear even if there's no blobs to sync.
-func init() {
-	MSyncingWantedBlobs.WithLabelValues("syncing").Set(0)
-	MSyncingWantedBlobs.WithLabelValues("groups").Set(0)
-}
-
 // netDialFunc is a function of the Mintter P2P node that creates an instance
 // of a P2P RPC client for a given remote Device ID.
 type netDialFunc func(context.Context, peer.ID) (p2p.P2PClient, error)
@@ -95,6 +97,18 @@ type bitswap interface {
 	FindProvidersAsync(context.Context, cid.Cid, int) <-chan peer.AddrInfo
 }
 
+// blobFingerprinter computes the fingerprints of our own blobs for set reconciliation.
+// The P2P node keeps the index for them, shared with the one it uses to serve the remote peers.
+type blobFingerprinter interface {
+	BlobRangeFingerprint(ctx context.Context, start, end *p2p.BlobBound) ([]byte, error)
+}
+
+// localPeers provides the peers discovered on the local network.
+type localPeers interface {
+	LocalPeers() []peer.ID
+	LocalPeersChanged() <-chan struct{}
+}
+
 // Service manages syncing of Mintter objects among peers.
 type Service struct {
 	cfg     config.Syncing
@@ -105,12 +119,18 @@ type Service struct {
 	bitswap bitswap
 	client  netDialFunc
 	host    host.Host
+	local   localPeers
+	index   blobFingerprinter
 
 	mu sync.Mutex // Ensures only one sync loop is running at a time.
 
 	wg        sync.WaitGroup
 	workers   map[peer.ID]*worker
 	semaphore chan struct{}
+
+	// All the syncing work goes through the job queue,
+	// to share the concurrency limits and the backoff of failing targets.
+	jobs *jobQueue
 }
 
 const (
@@ -130,9 +150,13 @@ func NewService(cfg config.Syncing, log *zap.Logger, me core.Identity, db *sqlit
 		bitswap:   net.Bitswap(),
 		client:    net.Client,
 		host:      net.Libp2p().Host,
+		local:     net,
+		index:     net,
 		workers:   make(map[peer.ID]*worker),
 		semaphore: make(chan struct{}, peerRoutingConcurrency),
+		jobs:      newJobQueue(cfg.MaxJobs, cfg.TimeoutPerPeer),
 	}
+	svc.jobs.onFinish = svc.recordJob
 
 	return svc
 }
@@ -140,6 +164,7 @@ func NewService(cfg config.Syncing, log *zap.Logger, me core.Identity, db *sqlit
 // Start the syncing service which will periodically refresh the list of peers
 // to sync with from the database, and schedule the worker loop for each peer,
 // creating new workers for newly added peers, and stopping workers for removed peers.
+// Workers for peers discovered on the local network are started as soon as they are found.
 func (s *Service) Start(ctx context.Context) (err error) {
 	s.log.Debug("SyncingServiceStarted")
 	defer func() {
@@ -165,6 +190,10 @@ func (s *Service) Start(ctx context.Context) (err error) {
 			}
 
 			t.Reset(s.cfg.RefreshInterval)
+		case <-s.local.LocalPeersChanged():
+			if err := s.refreshWorkers(ctx); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -185,12 +214,17 @@ func (s *Service) refreshWorkers(ctx context.Context) error {
 		return err
 	}
 
+	// Peers on the local network are synced with regardless of trust, for as long as we're connected to them.
+	for _, pid := range s.local.LocalPeers() {
+		peers[pid] = struct{}{}
+	}
+
 	var workersDiff int
 
 	// Starting workers for newly added trusted peers.
 	for pid := range peers {
 		if _, ok := s.workers[pid]; !ok {
-			w := newWorker(s.cfg, pid, s.log, s.client, s.host, s.blobs.IPFSBlockstore(), s.bitswap, s.db, s.semaphore)
+			w := newWorker(s.cfg, pid, s.log, s.client, s.host, s.blobs.IPFSBlockstore(), s.bitswap, s.db, s.index, s.semaphore, s.jobs)
 			s.wg.Add(1)
 			go w.start(ctx, &s.wg, s.cfg.Interval)
 			workersDiff++
@@ -261,10 +295,17 @@ var qListPeersToSync = dqb.Str(`
 	SELECT
 		del.principal AS delegate
 	FROM key_delegations
-	JOIN trusted_accounts ON trusted_accounts.id = key_delegations.issuer
 	JOIN public_keys del ON del.id = key_delegations.delegate
 	-- Skipping our own key delegation.
 	WHERE key_delegations.id != 1
+	-- Any of our accounts can trust the issuer.
+	AND key_delegations.issuer IN (SELECT id FROM trusted_accounts)
+	-- Skipping devices revoked by their accounts.
+	AND NOT EXISTS (
+		SELECT 1 FROM key_revocations
+		WHERE key_revocations.issuer = key_delegations.issuer
+		AND key_revocations.delegate = key_delegations.delegate
+	)
 `)
 
 // SyncAll attempts to sync the with all the peers at once.
@@ -320,35 +361,121 @@ func (s *Service) SyncAll(ctx context.Context) (res SyncResult, err error) {
 	return res, nil
 }
 
-// SyncWithPeer syncs all documents from a given peer. given no initial objectsOptionally.
-// if a list a list of initialObjects is provided, then only syncs objects from that list.
+// SyncWithPeer syncs all documents from a given peer, and blocks until finished.
+// It runs as an interactive sync job, so it doesn't respect the backoff of the peer.
 func (s *Service) SyncWithPeer(ctx context.Context, pid peer.ID) error {
 	// Can't sync with self.
 	if s.me.DeviceKey().PeerID() == pid {
 		return nil
 	}
 
-	{
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, connectTimeout)
-		defer cancel()
-	}
+	return s.jobs.Run(ctx, JobKindPeer, pid.String(), PriorityInteractive, func(ctx context.Context, p *Progress) error {
+		return s.syncWithPeer(ctx, pid, p)
+	})
+}
 
-	c, err := s.client(ctx, pid)
+// syncWithPeer does the actual syncing with the peer, outside of the job queue.
+// The context must have a deadline.
+func (s *Service) syncWithPeer(ctx context.Context, pid peer.ID, p *Progress) error {
+	c, err := s.dial(ctx, pid)
 	if err != nil {
 		return err
 	}
 
-	{
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, s.cfg.TimeoutPerPeer)
-		defer cancel()
+	bs := s.blobs.IPFSBlockstore()
+	bswap := s.bitswap.NewSession(ctx)
+
+	return syncPeerAuto(ctx, pid, c, s.host.Peerstore(), bs, bswap, s.db, s.index, s.log, p)
+}
+
+// PullFromPeer lists all the blobs of the remote peer, and fetches those we don't have.
+// It's meant to be used within a sync job, e.g. when syncing with a group site.
+// If listed is not nil, it's called for every blob the peer has listed, even if we already have it.
+// The context must have a deadline.
+func (s *Service) PullFromPeer(ctx context.Context, pid peer.ID, p *Progress, listed func(cid.Cid)) error {
+	c, err := s.dial(ctx, pid)
+	if err != nil {
+		return err
 	}
 
 	bs := s.blobs.IPFSBlockstore()
 	bswap := s.bitswap.NewSession(ctx)
 
-	return syncPeer(ctx, pid, c, bs, bswap, s.db, s.log)
+	return syncPeer(ctx, pid, c, bs, bswap, s.db, s.log, nil, p, listed)
+}
+
+func (s *Service) dial(ctx context.Context, pid peer.ID) (p2p.P2PClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	return s.client(ctx, pid)
+}
+
+// RunJob runs fn as a sync job of the given kind for the given target, and blocks until it's finished.
+// Jobs run with limited concurrency in the order of their priority, and have a timeout.
+// If a job for the same target is already in progress, RunJob waits for it instead of starting a new one.
+// Background jobs for targets that have failed recently return ErrJobBackoff without running.
+func (s *Service) RunJob(ctx context.Context, kind JobKind, target string, priority JobPriority, fn JobFunc) error {
+	return s.jobs.Run(ctx, kind, target, priority, fn)
+}
+
+// Jobs returns the sync jobs that are currently queued or running, ordered by priority,
+// and the recently finished ones, most recent first.
+func (s *Service) Jobs() (active, recent []JobInfo) {
+	return s.jobs.Jobs()
+}
+
+// syncPeerAuto picks the best way to sync with the given peer.
+// When we have subscriptions we only list the blobs related to them, which the peer must support.
+// Otherwise we use set reconciliation if the peer supports it, or list all the blobs the peer has.
+func syncPeerAuto(
+	ctx context.Context,
+	pid peer.ID,
+	c p2p.P2PClient,
+	ps peerstore.Peerstore,
+	bs blockstore.Blockstore,
+	sess exchange.Fetcher,
+	db *sqlitex.Pool,
+	local blobFingerprinter,
+	log *zap.Logger,
+	p *Progress,
+) error {
+	var resources []string
+	if err := db.Query(ctx, func(conn *sqlite.Conn) error {
+		subs, err := hyper.ListSubscriptions(conn)
+		if err != nil {
+			return err
+		}
+
+		resources = make([]string, len(subs))
+		for i, sub := range subs {
+			resources[i] = sub.ID.String()
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	if len(resources) > 0 {
+		// Older peers would ignore the filter and give us everything they have,
+		// which is exactly what we want to avoid by having subscriptions.
+		if !mttnet.PeerSupports(ps, pid, mttnet.FeatureListBlobsFilter) {
+			log.Debug("SyncSkippedPeerCantFilter", zap.String("peer", pid.String()))
+			return nil
+		}
+
+		if err := backfillPeer(ctx, pid, c, bs, sess, db, log, p); err != nil {
+			return err
+		}
+
+		return syncPeer(ctx, pid, c, bs, sess, db, log, resources, p, nil)
+	}
+
+	if mttnet.PeerSupports(ps, pid, mttnet.FeatureReconcileBlobs) {
+		return reconcilePeer(ctx, pid, c, bs, sess, local, log, p)
+	}
+
+	return syncPeer(ctx, pid, c, bs, sess, db, log, nil, p, nil)
 }
 
 func syncPeer(
@@ -359,6 +486,9 @@ func syncPeer(
 	sess exchange.Fetcher,
 	db *sqlitex.Pool,
 	log *zap.Logger,
+	resources []string,
+	p *Progress,
+	listed func(cid.Cid),
 ) (err error) {
 	mSyncsInFlight.Inc()
 	defer func() {
@@ -373,11 +503,6 @@ func syncPeer(
 		panic("BUG: syncPeer must have timeout")
 	}
 
-	stream, err := c.ListBlobs(ctx, &p2p.ListBlobsRequest{})
-	if err != nil {
-		return err
-	}
-
 	pk, err := pid.ExtractPublicKey()
 	if err != nil {
 		return fmt.Errorf("failed to extract public key from peer id %s: %w", pid, err)
@@ -385,12 +510,41 @@ func syncPeer(
 
 	remotePrincipal := core.PrincipalFromPubKey(pk)
 
+	cursor, err := GetCursor(ctx, db, remotePrincipal)
+	if err != nil {
+		return fmt.Errorf("failed to get sync cursor for peer %s: %w", pid, err)
+	}
+	p.SetCursor(cursor)
+
+	saveCursor := func(cursor string) error {
+		if err := SaveCursor(ctx, db, remotePrincipal, cursor); err != nil {
+			return err
+		}
+		p.SetCursor(cursor)
+		return nil
+	}
+
+	stream, err := c.ListBlobs(ctx, &p2p.ListBlobsRequest{Cursor: cursor, Resources: resources})
+	if err != nil {
+		return err
+	}
+
+	log = log.With(
+		zap.String("peer", pid.String()),
+	)
+
 	type wantBlob struct {
 		cid    cid.Cid
 		cursor string
+		// Cursor of the last blob before this one in the stream.
+		// It's the furthest we can advance if we fail to fetch this blob.
+		prevCursor string
 	}
 
-	var want []wantBlob
+	var (
+		want       []wantBlob
+		lastCursor = cursor
+	)
 	for {
 		obj, err := stream.Recv()
 		if err != nil {
@@ -400,59 +554,114 @@ func syncPeer(
 			return err
 		}
 
+		// The peer didn't recognize our cursor, and started from the beginning,
+		// so our stored cursor must be replaced with whatever we get from this stream.
+		if obj.CursorReset {
+			log.Debug("SyncCursorReset", zap.String("cursor", cursor))
+			mCursorResetsTotal.Inc()
+			lastCursor = ""
+		}
+
 		c, err := cid.Cast(obj.Cid)
 		if err != nil {
 			return err
 		}
 
+		if listed != nil {
+			listed(c)
+		}
+
 		ok, err := bs.Has(ctx, c)
 		if err != nil {
 			return fmt.Errorf("failed to check if we have blob %s: %w", c, err)
 		}
 
 		if !ok {
-			want = append(want, wantBlob{cid: c, cursor: obj.Cursor})
+			want = append(want, wantBlob{cid: c, cursor: obj.Cursor, prevCursor: lastCursor})
 			mWantedBlobsTotal.Inc()
 		}
+
+		if obj.Cursor != "" {
+			lastCursor = obj.Cursor
+		}
 	}
 
+	// We have everything the peer has, but we still want to remember
+	// where we stopped, so we don't list the same blobs again next time.
 	if len(want) == 0 {
+		if lastCursor != cursor {
+			return saveCursor(lastCursor)
+		}
 		return nil
 	}
 
-	MSyncingWantedBlobs.WithLabelValues("syncing").Add(float64(len(want)))
-	defer MSyncingWantedBlobs.WithLabelValues("syncing").Sub(float64(len(want)))
+	p.AddWanted(len(want))
+	mSyncingWantedBlobs.Add(float64(len(want)))
+	defer mSyncingWantedBlobs.Sub(float64(len(want)))
 
-	log = log.With(
-		zap.String("peer", pid.String()),
+	// We keep fetching after a failure to get as much as we can in this round,
+	// but we must not advance the cursor past the first blob we've failed to get,
+	// otherwise we'd never ask for it again.
+	var (
+		failed          bool
+		lastSavedCursor = cursor
 	)
 
-	var lastSavedCursor string
+	// markFailed saves the furthest cursor we can resume from after failing to get a wanted blob.
+	markFailed := func(c wantBlob) error {
+		if failed {
+			return nil
+		}
+		failed = true
+
+		if c.prevCursor == lastSavedCursor {
+			return nil
+		}
+		lastSavedCursor = c.prevCursor
+		return saveCursor(c.prevCursor)
+	}
+
 	for i, c := range want {
 		blk, err := sess.GetBlock(ctx, c.cid)
 		if err != nil {
 			log.Debug("FailedToGetWantedBlob", zap.String("cid", c.cid.String()), zap.Error(err))
+			p.AddFailed(1)
+			if err := markFailed(c); err != nil {
+				return err
+			}
 			continue
 		}
 
 		if err := bs.Put(ctx, blk); err != nil {
+			p.AddFailed(1)
+
+			// Invalid blobs would be rejected again if we asked for them next time,
+			// so we skip them without holding the cursor back.
+			if errors.Is(err, hyper.ErrInvalidBlob) {
+				log.Warn("RejectedInvalidBlob", zap.String("cid", c.cid.String()), zap.Error(err))
+				mRejectedBlobsTotal.Inc()
+				continue
+			}
+
 			log.Debug("FailedToSaveWantedBlob", zap.String("cid", c.cid.String()), zap.Error(err))
+			if err := markFailed(c); err != nil {
+				return err
+			}
 			continue
 		}
+		p.AddFetched(1)
 
 		// Save the cursor every N blobs instead of after every blob.
-		if i%50 == 0 {
-			if err := SaveCursor(ctx, db, remotePrincipal, c.cursor); err != nil {
+		if !failed && i%50 == 0 && c.cursor != "" {
+			if err := saveCursor(c.cursor); err != nil {
 				return err
 			}
 			lastSavedCursor = c.cursor
 		}
 	}
 
-	lastCursor := want[len(want)-1].cursor
-
-	if lastSavedCursor != lastCursor {
-		if err := SaveCursor(ctx, db, remotePrincipal, lastCursor); err != nil {
+	if !failed && lastSavedCursor != lastCursor {
+		if err := saveCursor(lastCursor); err != nil {
 			return err
 		}
 	}
@@ -3,12 +3,15 @@ package syncing
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"mintter/backend/config"
 	"mintter/backend/core"
 	"mintter/backend/core/coretest"
 	daemon "mintter/backend/daemon/api/daemon/v1alpha"
 	"mintter/backend/daemon/storage"
+	p2p "mintter/backend/genproto/p2p/v1alpha"
+	"mintter/backend/hlc"
 	"mintter/backend/hyper"
 	"mintter/backend/hyper/hypersql"
 	"mintter/backend/logging"
@@ -22,6 +25,8 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
 )
 
 func TestSync(t *testing.T) {
@@ -40,7 +45,7 @@ func TestSync(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, alice.Blobs.SaveBlob(ctx, blob))
 
-	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, alice.Syncer.me.Account().Principal()))
+	require.NoError(t, bob.Blobs.SetAccountTrust(ctx, bob.Syncer.me.Account().Principal(), alice.Syncer.me.Account().Principal()))
 
 	res, err := bob.Syncer.SyncAll(ctx)
 	require.NoError(t, err)
@@ -55,7 +60,239 @@ func TestSync(t *testing.T) {
 	}
 }
 
-func makeTestNode(t *testing.T, name string) testNode {
+func TestSyncIncremental(t *testing.T) {
+	t.Parallel()
+
+	alice := makeTestNode(t, "alice")
+	bob := makeTestNode(t, "bob")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	require.NoError(t, alice.Connect(ctx, bob.AddrInfo()))
+
+	entity := hyper.NewEntity("foo")
+	c1, err := entity.CreateChange(entity.NextTimestamp(), alice.ID().DeviceKey(), getDelegation(ctx, alice.ID(), alice.Blobs), map[string]any{
+		"name": "alice",
+	})
+	require.NoError(t, err)
+	require.NoError(t, alice.Blobs.SaveBlob(ctx, c1))
+
+	pid := alice.ID().DeviceKey().PeerID()
+	client, err := bob.Client(ctx, pid)
+	require.NoError(t, err)
+
+	sync := func() []*p2p.Blob {
+		cc := &recordingClient{P2PClient: client}
+		require.NoError(t, syncPeer(ctx, pid, cc, bob.Blobs.IPFSBlockstore(), bob.Bitswap().NewSession(ctx), bob.Syncer.db, bob.Syncer.log, nil, nil, nil))
+		return cc.blobs
+	}
+
+	blobs := sync()
+	require.Len(t, blobs, 3, "first sync must list all of alice's blobs")
+	{
+		ok, err := bob.Blobs.IPFSBlockstoreReader().Has(ctx, c1.CID)
+		require.NoError(t, err)
+		require.True(t, ok, "bob must have alice's change after first sync")
+	}
+
+	c2, err := entity.CreateChange(entity.NextTimestamp(), alice.ID().DeviceKey(), getDelegation(ctx, alice.ID(), alice.Blobs), map[string]any{
+		"name": "alice-2",
+	})
+	require.NoError(t, err)
+	require.NoError(t, alice.Blobs.SaveBlob(ctx, c2))
+
+	blobs = sync()
+	require.Len(t, blobs, 1, "second sync must only list new blobs")
+	require.Equal(t, c2.CID.Bytes(), blobs[0].Cid, "second sync must list the new change")
+	{
+		ok, err := bob.Blobs.IPFSBlockstoreReader().Has(ctx, c2.CID)
+		require.NoError(t, err)
+		require.True(t, ok, "bob must have alice's new change after second sync")
+	}
+
+	require.Len(t, sync(), 0, "nothing must be listed when there're no new blobs")
+
+	// Cursor pointing way beyond what alice has must be reset by alice.
+	// It's an opaque string for clients, but we know how mttnet encodes it.
+	staleCursor := base64.RawStdEncoding.EncodeToString([]byte(`{"ID":1000000}`))
+	require.NoError(t, SaveCursor(ctx, bob.Syncer.db, alice.ID().DeviceKey().Principal(), staleCursor))
+
+	blobs = sync()
+	require.Len(t, blobs, 4, "invalid cursor must make alice list everything")
+	require.True(t, blobs[0].CursorReset, "alice must signal the cursor reset")
+
+	cursor, err := GetCursor(ctx, bob.Syncer.db, alice.ID().DeviceKey().Principal())
+	require.NoError(t, err)
+	require.NotEqual(t, staleCursor, cursor, "stale cursor must be replaced even if there was nothing to fetch")
+	require.Len(t, sync(), 0, "sync after cursor reset must not list anything again")
+}
+
+func TestSyncReconcile(t *testing.T) {
+	t.Parallel()
+
+	alice := makeTestNode(t, "alice")
+	bob := makeTestNode(t, "bob")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	require.NoError(t, alice.Connect(ctx, bob.AddrInfo()))
+
+	pid := alice.ID().DeviceKey().PeerID()
+	require.True(t, mttnet.PeerSupports(bob.Libp2p().Host.Peerstore(), pid, mttnet.FeatureReconcileBlobs), "bob must know alice supports reconciliation")
+
+	entity := hyper.NewEntity("foo")
+	c1, err := entity.CreateChange(entity.NextTimestamp(), alice.ID().DeviceKey(), getDelegation(ctx, alice.ID(), alice.Blobs), map[string]any{
+		"name": "alice",
+	})
+	require.NoError(t, err)
+	require.NoError(t, alice.Blobs.SaveBlob(ctx, c1))
+
+	client, err := bob.Client(ctx, pid)
+	require.NoError(t, err)
+
+	cc := &recordingClient{P2PClient: client}
+	require.NoError(t, reconcilePeer(ctx, pid, cc, bob.Blobs.IPFSBlockstore(), bob.Bitswap().NewSession(ctx), bob.Syncer.index, bob.Syncer.log, nil))
+	require.Len(t, cc.blobs, 0, "reconciliation must not list blobs")
+	require.Greater(t, cc.rounds, 0, "reconciliation must talk to alice")
+
+	ok, err := bob.Blobs.IPFSBlockstoreReader().Has(ctx, c1.CID)
+	require.NoError(t, err)
+	require.True(t, ok, "bob must have alice's change after reconciliation")
+
+	cursor, err := GetCursor(ctx, bob.Syncer.db, alice.ID().DeviceKey().Principal())
+	require.NoError(t, err)
+	require.Equal(t, "", cursor, "reconciliation must not touch the cursor")
+}
+
+func TestSyncSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	alice := makeTestNode(t, "alice")
+	bob := makeTestNode(t, "bob")
+	ctx := context.Background()
+
+	require.NoError(t, alice.Connect(ctx, bob.AddrInfo()))
+
+	foo, fooChange := createTestDocument(t, alice, "Foo")
+	require.NoError(t, alice.Blobs.SaveBlob(ctx, fooChange))
+
+	bar, barChange := createTestDocument(t, alice, "Bar")
+	require.NoError(t, alice.Blobs.SaveBlob(ctx, barChange))
+
+	require.NoError(t, bob.Blobs.Subscribe(ctx, foo))
+	require.NoError(t, bob.Syncer.SyncWithPeer(ctx, alice.ID().DeviceKey().PeerID()))
+
+	has := func(c cid.Cid) bool {
+		ok, err := bob.Blobs.IPFSBlockstoreReader().Has(ctx, c)
+		require.NoError(t, err)
+		return ok
+	}
+
+	require.True(t, has(fooChange.CID), "bob must sync the subscribed document")
+	require.True(t, has(getDelegation(ctx, alice.ID(), alice.Blobs)), "bob must sync the key delegation of the author")
+	require.False(t, has(barChange.CID), "bob must not sync unsubscribed documents")
+
+	// Subscribing to a new document must get its older blobs too.
+	require.NoError(t, bob.Blobs.Subscribe(ctx, bar))
+	require.NoError(t, bob.Syncer.SyncWithPeer(ctx, alice.ID().DeviceKey().PeerID()))
+	require.True(t, has(barChange.CID), "bob must sync the newly subscribed document")
+}
+
+func TestSyncLocalPeers(t *testing.T) {
+	t.Parallel()
+
+	// Bob must sync with alice only because she's on the same local network:
+	// they are not connected explicitly, and the periodic sync of the trusted peers never kicks in.
+	cfg := func(cfg *config.Config) {
+		cfg.P2P.LocalDiscovery = true
+		cfg.Syncing.WarmupDuration = time.Hour
+		cfg.Syncing.Interval = time.Second
+	}
+
+	alice := makeTestNode(t, "alice", cfg)
+	bob := makeTestNode(t, "bob", cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, blob := createTestDocument(t, alice, "My document")
+	require.NoError(t, alice.Blobs.SaveBlob(ctx, blob))
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- bob.Syncer.Start(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return slices.Contains(bob.LocalPeers(), alice.ID().DeviceKey().PeerID())
+	}, 30*time.Second, 100*time.Millisecond, "bob must discover alice on the local network")
+
+	require.Eventually(t, func() bool {
+		ok, err := bob.Blobs.IPFSBlockstoreReader().Has(ctx, blob.CID)
+		require.NoError(t, err)
+		return ok
+	}, 30*time.Second, 100*time.Millisecond, "bob must sync alice's document without connecting explicitly")
+
+	cancel()
+	require.ErrorIs(t, <-errc, context.Canceled)
+}
+
+// createTestDocument creates a change that brings a new document into life.
+func createTestDocument(t *testing.T, n testNode, title string) (hyper.EntityID, hyper.Blob) {
+	t.Helper()
+
+	clock := hlc.NewClock()
+	ts := clock.MustNow()
+	createTime := ts.Time().Unix()
+
+	id, nonce := hyper.NewUnforgeableID("hm://d/", n.ID().Account().Principal(), nil, createTime)
+	e := hyper.NewEntityWithClock(hyper.EntityID(id), clock)
+
+	blob, err := e.CreateChange(ts, n.ID().DeviceKey(), getDelegation(context.Background(), n.ID(), n.Blobs), map[string]any{
+		"nonce":      nonce,
+		"title":      title,
+		"createTime": int(createTime),
+		"owner":      []byte(n.ID().Account().Principal()),
+	}, hyper.WithAction("Create"))
+	require.NoError(t, err)
+
+	return e.ID(), blob
+}
+
+type recordingClient struct {
+	p2p.P2PClient
+	blobs  []*p2p.Blob
+	rounds int
+}
+
+func (c *recordingClient) ReconcileBlobs(ctx context.Context, in *p2p.ReconcileBlobsRequest, opts ...grpc.CallOption) (*p2p.ReconcileBlobsResponse, error) {
+	c.rounds++
+	return c.P2PClient.ReconcileBlobs(ctx, in, opts...)
+}
+
+func (c *recordingClient) ListBlobs(ctx context.Context, in *p2p.ListBlobsRequest, opts ...grpc.CallOption) (p2p.P2P_ListBlobsClient, error) {
+	stream, err := c.P2PClient.ListBlobs(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingStream{P2P_ListBlobsClient: stream, c: c}, nil
+}
+
+type recordingStream struct {
+	p2p.P2P_ListBlobsClient
+	c *recordingClient
+}
+
+func (s *recordingStream) Recv() (*p2p.Blob, error) {
+	blob, err := s.P2P_ListBlobsClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+	s.c.blobs = append(s.c.blobs, blob)
+	return blob, nil
+}
+
+func makeTestNode(t *testing.T, name string, opts ...func(*config.Config)) testNode {
 	u := coretest.NewTester(name)
 	db := storage.MakeTestDB(t)
 
@@ -68,6 +305,9 @@ func makeTestNode(t *testing.T, name string) testNode {
 	cfg.P2P.NoRelay = true
 	cfg.P2P.BootstrapPeers = nil
 	cfg.P2P.NoMetrics = true
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	n, err := mttnet.New(cfg.P2P, db, blobs, u.Identity, must.Do2(zap.NewDevelopment()).Named(name), "debug")
 	require.NoError(t, err)
 
@@ -2,6 +2,7 @@ package syncing
 
 import (
 	"context"
+	"fmt"
 	"hash/fnv"
 	"mintter/backend/config"
 	"sync"
@@ -24,7 +25,9 @@ type worker struct {
 	bs         blockstore.Blockstore
 	bswap      bitswap
 	db         *sqlitex.Pool
+	local      blobFingerprinter
 	sema       chan struct{}
+	jobs       *jobQueue
 
 	// stop is assigned during start().
 	stop context.CancelFunc
@@ -39,7 +42,9 @@ func newWorker(
 	bs blockstore.Blockstore,
 	bswap bitswap,
 	db *sqlitex.Pool,
+	local blobFingerprinter,
 	semaphore chan struct{},
+	jobs *jobQueue,
 ) *worker {
 	log = log.With(
 		zap.String("peer", pid.String()),
@@ -53,7 +58,9 @@ func newWorker(
 		bs:         bs,
 		bswap:      bswap,
 		db:         db,
+		local:      local,
 		sema:       semaphore,
+		jobs:       jobs,
 	}
 }
 
@@ -173,18 +180,17 @@ func (sw *worker) start(ctx context.Context, wg *sync.WaitGroup, interval time.D
 }
 
 func (sw *worker) sync(ctx context.Context) {
-	ctx, cancel := context.WithTimeout(ctx, sw.cfg.TimeoutPerPeer)
-	defer cancel()
-
-	c, err := sw.clientFunc(ctx, sw.pid)
-	if err != nil {
-		sw.log.Debug("FailedToGetClient", zap.Error(err))
-		return
-	}
+	err := sw.jobs.Run(ctx, JobKindPeer, sw.pid.String(), PriorityBackground, func(ctx context.Context, p *Progress) error {
+		c, err := sw.clientFunc(ctx, sw.pid)
+		if err != nil {
+			return fmt.Errorf("failed to get client: %w", err)
+		}
 
-	sess := sw.bswap.NewSession(ctx)
+		sess := sw.bswap.NewSession(ctx)
 
-	if err := syncPeer(ctx, sw.pid, c, sw.bs, sess, sw.db, sw.log); err != nil {
+		return syncPeerAuto(ctx, sw.pid, c, sw.host.Peerstore(), sw.bs, sess, sw.db, sw.local, sw.log, p)
+	})
+	if err != nil {
 		sw.log.Debug("FailedToSync", zap.Error(err))
 	}
 }
@@ -39,6 +39,7 @@ type Service struct {
 	lightningClient lnclient
 	pool            *sqlitex.Pool
 	net             *future.ReadOnly[*mttnet.Node]
+	me              *future.ReadOnly[core.Identity]
 	log             *zap.Logger
 }
 
@@ -70,6 +71,7 @@ func New(ctx context.Context, log *zap.Logger, db *sqlitex.Pool, net *future.Rea
 			Lndhub: lndhub.NewClient(ctx, &http.Client{}, db, me, lndhubDomain, lnaddressDomain),
 		},
 		net: net,
+		me:  me,
 		log: log,
 	}
 	go func() {
@@ -196,8 +198,8 @@ func (srv *Service) P2PInvoiceRequest(ctx context.Context, account core.Principa
 
 // InsertWallet first tries to connect to the wallet with the provided credentials. On
 // success, gets the wallet balance and inserts all that information in the database.
-// InsertWallet returns the wallet actually inserted on success. The credentias are stored
-// in plain text at the moment.
+// InsertWallet returns the wallet actually inserted on success. The credentials are stored
+// encrypted with the device key.
 func (srv *Service) InsertWallet(ctx context.Context, credentialsURL, name string) (wallet.Wallet, error) {
 	var err error
 	var ret wallet.Wallet
@@ -252,7 +254,12 @@ func (srv *Service) InsertWallet(ctx context.Context, credentialsURL, name strin
 		creds.Nickname = newWallet.Nickname
 	}
 
-	if err = wallet.InsertWallet(conn, ret, []byte(creds.Login), []byte(creds.Password), []byte(creds.Token)); err != nil {
+	me, err := srv.me.Await(ctx)
+	if err != nil {
+		return ret, err
+	}
+
+	if err = wallet.InsertWallet(conn, me.DeviceKey(), ret, []byte(creds.Login), []byte(creds.Password), []byte(creds.Token)); err != nil {
 		srv.log.Debug("couldn't insert wallet", zap.String("msg", err.Error()))
 		if errors.Is(err, walletsql.ErrDuplicateIndex) {
 			return ret, fmt.Errorf("couldn't insert wallet %s in the database. ID already exists", name)
@@ -386,12 +393,16 @@ func (srv *Service) ExportWallet(ctx context.Context, walletID string) (string,
 			return "", err
 		}
 	} else {
-		login, err := lndhubsql.GetLogin(conn, walletID)
+		me, err := srv.me.Await(ctx)
+		if err != nil {
+			return "", err
+		}
+		login, err := lndhubsql.GetLogin(conn, me.DeviceKey(), walletID)
 		if err != nil {
 			srv.log.Debug(err.Error())
 			return "", err
 		}
-		password, err := lndhubsql.GetPassword(conn, walletID)
+		password, err := lndhubsql.GetPassword(conn, me.DeviceKey(), walletID)
 		if err != nil {
 			srv.log.Debug(err.Error())
 			return "", err
@@ -41,7 +41,7 @@ func TestModifyWallets(t *testing.T) {
 		conn, release, err := alice.pool.Conn(ctx)
 		require.NoError(t, err)
 		defer release()
-		_, err = lndhubsql.GetToken(conn, defaultWallet.ID)
+		_, err = lndhubsql.GetToken(conn, alice.me.MustGet().DeviceKey(), defaultWallet.ID)
 		return err == nil
 	}, 3*time.Second, 1*time.Second)
 	require.EqualValues(t, lndhubsql.LndhubGoWalletType, defaultWallet.Type)
@@ -84,7 +84,7 @@ func TestRequestLndHubInvoice(t *testing.T) {
 		conn, release, err := bob.pool.Conn(ctx)
 		require.NoError(t, err)
 		defer release()
-		_, err = lndhubsql.GetToken(conn, defaultWallet.ID)
+		_, err = lndhubsql.GetToken(conn, bob.me.MustGet().DeviceKey(), defaultWallet.ID)
 		return err == nil
 	}, 3*time.Second, 1*time.Second)
 	require.Eventually(t, func() bool {
@@ -3,6 +3,8 @@ package walletsql
 import (
 	"errors"
 	"fmt"
+	"mintter/backend/core"
+	"mintter/backend/daemon/storage"
 	"mintter/backend/lndhub/lndhubsql"
 	"strings"
 
@@ -88,13 +90,23 @@ func ListWallets(conn *sqlite.Conn, limit int) ([]Wallet, error) {
 // valid Wallet with all fields properly set. If this is the first
 // wallet, then it becomes default automatically. If token is not known at creation time
 // it can be null. Login and password, however have to be valid credentials.
-func InsertWallet(conn *sqlite.Conn, wallet Wallet, login, password, token []byte) error {
+// The credentials are encrypted with the device key before being stored.
+func InsertWallet(conn *sqlite.Conn, device core.KeyPair, wallet Wallet, login, password, token []byte) error {
 	if len(wallet.ID) != idcharLength {
 		return fmt.Errorf("wallet id must be a %d character string. Got %d", idcharLength, len(wallet.ID))
 	}
 
+	creds := [][]byte{login, password, token}
+	for i, c := range creds {
+		sealed, err := storage.SealCredential(device, c)
+		if err != nil {
+			return err
+		}
+		creds[i] = sealed
+	}
+
 	if err := insertWallet(conn, wallet.ID, wallet.Address, strings.ToLower(wallet.Type),
-		login, password, token, wallet.Name, int64(wallet.Balance)); err != nil {
+		creds[0], creds[1], creds[2], wallet.Name, int64(wallet.Balance)); err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
 			return fmt.Errorf("couldn't insert wallet: %w", ErrDuplicateIndex)
 		}
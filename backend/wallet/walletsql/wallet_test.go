@@ -2,10 +2,14 @@ package walletsql
 
 import (
 	"context"
+	"mintter/backend/core/coretest"
 	"mintter/backend/daemon/storage"
+	"mintter/backend/lndhub/lndhubsql"
 	"strings"
 	"testing"
 
+	"crawshaw.io/sqlite"
+	"crawshaw.io/sqlite/sqlitex"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,8 +45,10 @@ func TestQueries(t *testing.T) {
 	require.NoError(t, err)
 	defer release()
 
+	device := coretest.NewTester("alice").Device
+
 	{
-		err = InsertWallet(conn, Wallet{
+		err = InsertWallet(conn, device, Wallet{
 			ID:      id1,
 			Address: address1,
 			Name:    name1,
@@ -64,7 +70,7 @@ func TestQueries(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, defaultWallet.ID, got.WalletsID)
 
-		err = InsertWallet(conn, Wallet{
+		err = InsertWallet(conn, device, Wallet{
 			ID:      id2,
 			Address: address2,
 			Name:    name2,
@@ -86,7 +92,27 @@ func TestQueries(t *testing.T) {
 		require.Equal(t, strings.ToLower(type2), got.WalletsType)
 		require.Equal(t, balance2, got.WalletsBalance)
 
-		err = InsertWallet(conn, Wallet{
+		var rawToken []byte
+		require.NoError(t, sqlitex.Exec(conn, "SELECT token FROM wallets WHERE id = ?", func(stmt *sqlite.Stmt) error {
+			rawToken = stmt.ColumnBytes(0)
+			return nil
+		}, id2))
+		require.NotContains(t, string(rawToken), string(token), "credentials must be stored encrypted")
+
+		gotLogin, err := lndhubsql.GetLogin(conn, device, id2)
+		require.NoError(t, err)
+		require.Equal(t, string(login), gotLogin)
+		gotPass, err := lndhubsql.GetPassword(conn, device, id2)
+		require.NoError(t, err)
+		require.Equal(t, string(pass), gotPass)
+		gotToken, err := lndhubsql.GetToken(conn, device, id2)
+		require.NoError(t, err)
+		require.Equal(t, string(token), gotToken)
+
+		_, err = lndhubsql.GetToken(conn, coretest.NewTester("bob").Device, id2)
+		require.Error(t, err, "other device keys must not decrypt the credentials")
+
+		err = InsertWallet(conn, device, Wallet{
 			ID:      id2,
 			Name:    name2,
 			Type:    type2,
@@ -111,7 +137,7 @@ func TestQueries(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, newwallet1.Name, name2)
 
-		err = InsertWallet(conn, Wallet{
+		err = InsertWallet(conn, device, Wallet{
 			ID:      id3,
 			Name:    name3,
 			Type:    type3,
@@ -1,4 +1,5 @@
 import {
+  ChangeInfo,
   HMAccount,
   HMChangeInfo,
   HMComment,
@@ -211,7 +212,17 @@ const publicationRouter = router({
       const downstreamChanges: Map<string, Set<string>> = new Map()
       // pub.changes = pub.changes || []
       const {documentId} = input
-      const {changes} = await queryClient.changes.listChanges({documentId})
+      const changes: ChangeInfo[] = []
+      let pageToken = ''
+      do {
+        const res = await queryClient.changes.listChanges({
+          documentId,
+          pageSize: 200,
+          pageToken,
+        })
+        changes.push(...res.changes)
+        pageToken = res.nextPageToken
+      } while (pageToken)
       changes.forEach((change) => {
         const hmChange = hmChangeInfo(change)
         hmChange && changesIndex.set(change.id, hmChange)
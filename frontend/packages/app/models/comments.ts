@@ -166,10 +166,18 @@ export function useAllPublicationComments(docEid: string | undefined) {
   return useQuery({
     queryFn: async () => {
       if (!docEid) return []
-      let res = await grpcClient.comments.listComments({
-        target: createHmId('d', docEid),
-      })
-      return res.comments as unknown as HMComment[]
+      const comments: HMComment[] = []
+      let pageToken = ''
+      do {
+        const res = await grpcClient.comments.listComments({
+          target: createHmId('d', docEid),
+          pageSize: 200,
+          pageToken,
+        })
+        comments.push(...(res.comments as unknown as HMComment[]))
+        pageToken = res.nextPageToken
+      } while (pageToken)
+      return comments
     },
     enabled: !!docEid,
     refetchInterval: 10_000,
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { Account, GetAccountRequest, ListAccountsRequest, ListAccountsResponse, Profile, SetAccountTrustRequest } from "./accounts_pb";
+import { Account, Device, GetAccountRequest, ListAccountsRequest, ListAccountsResponse, ListDevicesRequest, ListDevicesResponse, Profile, RevokeDeviceRequest, SetAccountTrustRequest } from "./accounts_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -66,6 +66,31 @@ export const Accounts = {
       O: Account,
       kind: MethodKind.Unary,
     },
+    /**
+     * Lists all the known devices of an Account, including the expired and revoked ones.
+     *
+     * @generated from rpc com.mintter.accounts.v1alpha.Accounts.ListDevices
+     */
+    listDevices: {
+      name: "ListDevices",
+      I: ListDevicesRequest,
+      O: ListDevicesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Revokes one of the devices of our own Account.
+     * Changes and comments signed by the device after the revocation are rejected.
+     * The revocation must be signed by the Account key, so the mnemonic is required.
+     * Returns the revoked device.
+     *
+     * @generated from rpc com.mintter.accounts.v1alpha.Accounts.RevokeDevice
+     */
+    revokeDevice: {
+      name: "RevokeDevice",
+      I: RevokeDeviceRequest,
+      O: Device,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -4,7 +4,49 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3 } from "@bufbuild/protobuf";
+import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
+
+/**
+ * Status of a device of an Account.
+ *
+ * @generated from enum com.mintter.accounts.v1alpha.DeviceStatus
+ */
+export enum DeviceStatus {
+  /**
+   * Invalid default value.
+   *
+   * @generated from enum value: DEVICE_STATUS_UNSPECIFIED = 0;
+   */
+  DEVICE_STATUS_UNSPECIFIED = 0,
+
+  /**
+   * Device can act on behalf of the Account.
+   *
+   * @generated from enum value: ACTIVE = 1;
+   */
+  ACTIVE = 1,
+
+  /**
+   * Device delegation has expired.
+   *
+   * @generated from enum value: EXPIRED = 2;
+   */
+  EXPIRED = 2,
+
+  /**
+   * Device has been revoked by the Account.
+   *
+   * @generated from enum value: REVOKED = 3;
+   */
+  REVOKED = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(DeviceStatus)
+proto3.util.setEnumType(DeviceStatus, "com.mintter.accounts.v1alpha.DeviceStatus", [
+  { no: 0, name: "DEVICE_STATUS_UNSPECIFIED" },
+  { no: 1, name: "ACTIVE" },
+  { no: 2, name: "EXPIRED" },
+  { no: 3, name: "REVOKED" },
+]);
 
 /**
  * @generated from message com.mintter.accounts.v1alpha.GetAccountRequest
@@ -271,6 +313,27 @@ export class Device extends Message<Device> {
    */
   deviceId = "";
 
+  /**
+   * Status of the device.
+   *
+   * @generated from field: com.mintter.accounts.v1alpha.DeviceStatus status = 2;
+   */
+  status = DeviceStatus.DEVICE_STATUS_UNSPECIFIED;
+
+  /**
+   * Optional. Time after which the device can no longer act on behalf of the Account.
+   *
+   * @generated from field: google.protobuf.Timestamp expire_time = 3;
+   */
+  expireTime?: Timestamp;
+
+  /**
+   * Optional. Time when the device was revoked.
+   *
+   * @generated from field: google.protobuf.Timestamp revoke_time = 4;
+   */
+  revokeTime?: Timestamp;
+
   constructor(data?: PartialMessage<Device>) {
     super();
     proto3.util.initPartial(data, this);
@@ -280,6 +343,9 @@ export class Device extends Message<Device> {
   static readonly typeName = "com.mintter.accounts.v1alpha.Device";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "device_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "status", kind: "enum", T: proto3.getEnumType(DeviceStatus) },
+    { no: 3, name: "expire_time", kind: "message", T: Timestamp },
+    { no: 4, name: "revoke_time", kind: "message", T: Timestamp },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Device {
@@ -346,3 +412,136 @@ export class SetAccountTrustRequest extends Message<SetAccountTrustRequest> {
   }
 }
 
+/**
+ * @generated from message com.mintter.accounts.v1alpha.ListDevicesRequest
+ */
+export class ListDevicesRequest extends Message<ListDevicesRequest> {
+  /**
+   * ID of the Account to list the devices of. If empty - our own account will be used.
+   *
+   * @generated from field: string account_id = 1;
+   */
+  accountId = "";
+
+  constructor(data?: PartialMessage<ListDevicesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.accounts.v1alpha.ListDevicesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListDevicesRequest {
+    return new ListDevicesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListDevicesRequest {
+    return new ListDevicesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListDevicesRequest {
+    return new ListDevicesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListDevicesRequest | PlainMessage<ListDevicesRequest> | undefined, b: ListDevicesRequest | PlainMessage<ListDevicesRequest> | undefined): boolean {
+    return proto3.util.equals(ListDevicesRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.accounts.v1alpha.ListDevicesResponse
+ */
+export class ListDevicesResponse extends Message<ListDevicesResponse> {
+  /**
+   * Devices of the Account.
+   *
+   * @generated from field: repeated com.mintter.accounts.v1alpha.Device devices = 1;
+   */
+  devices: Device[] = [];
+
+  constructor(data?: PartialMessage<ListDevicesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.accounts.v1alpha.ListDevicesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "devices", kind: "message", T: Device, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListDevicesResponse {
+    return new ListDevicesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListDevicesResponse {
+    return new ListDevicesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListDevicesResponse {
+    return new ListDevicesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListDevicesResponse | PlainMessage<ListDevicesResponse> | undefined, b: ListDevicesResponse | PlainMessage<ListDevicesResponse> | undefined): boolean {
+    return proto3.util.equals(ListDevicesResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.accounts.v1alpha.RevokeDeviceRequest
+ */
+export class RevokeDeviceRequest extends Message<RevokeDeviceRequest> {
+  /**
+   * CID-encoded Peer ID of the device to revoke.
+   *
+   * @generated from field: string device_id = 1;
+   */
+  deviceId = "";
+
+  /**
+   * Mnemonic of our own Account, to sign the revocation.
+   *
+   * @generated from field: repeated string mnemonic = 2;
+   */
+  mnemonic: string[] = [];
+
+  /**
+   * Optional. Passphrase for the mnemonic.
+   *
+   * @generated from field: string passphrase = 3;
+   */
+  passphrase = "";
+
+  constructor(data?: PartialMessage<RevokeDeviceRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.accounts.v1alpha.RevokeDeviceRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "device_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "mnemonic", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "passphrase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RevokeDeviceRequest {
+    return new RevokeDeviceRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RevokeDeviceRequest {
+    return new RevokeDeviceRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RevokeDeviceRequest {
+    return new RevokeDeviceRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RevokeDeviceRequest | PlainMessage<RevokeDeviceRequest> | undefined, b: RevokeDeviceRequest | PlainMessage<RevokeDeviceRequest> | undefined): boolean {
+    return proto3.util.equals(RevokeDeviceRequest, a, b);
+  }
+}
+
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { ListEventsRequest, ListEventsResponse } from "./activity_pb";
+import { ListEventsRequest, ListEventsResponse, SubscribeEventsRequest, SubscribeEventsResponse } from "./activity_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -26,6 +26,18 @@ export const ActivityFeed = {
       O: ListEventsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Streams the new activity events as soon as they are observed locally,
+     * sorted by locally observed time (oldest first).
+     *
+     * @generated from rpc com.mintter.activity.v1alpha.ActivityFeed.SubscribeEvents
+     */
+    subscribeEvents: {
+      name: "SubscribeEvents",
+      I: SubscribeEventsRequest,
+      O: SubscribeEventsResponse,
+      kind: MethodKind.ServerStreaming,
+    },
   }
 } as const;
 
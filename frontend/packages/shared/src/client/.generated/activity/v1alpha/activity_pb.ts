@@ -158,6 +158,139 @@ export class ListEventsResponse extends Message<ListEventsResponse> {
   }
 }
 
+/**
+ * The request to subscribe to the new events.
+ *
+ * @generated from message com.mintter.activity.v1alpha.SubscribeEventsRequest
+ */
+export class SubscribeEventsRequest extends Message<SubscribeEventsRequest> {
+  /**
+   * Optional. The resume token from a previously received response.
+   * Events after the ones received before are streamed first,
+   * so clients don't miss any events between reconnects.
+   * Only the new events are streamed if empty.
+   *
+   * @generated from field: string resume_token = 1;
+   */
+  resumeToken = "";
+
+  /**
+   * Optional. If we want events from trusted peers only. All peers by default.
+   *
+   * @generated from field: bool trusted_only = 2;
+   */
+  trustedOnly = false;
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_users = 3;
+   */
+  filterUsers: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_event_type = 4;
+   */
+  filterEventType: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_resource = 5;
+   */
+  filterResource: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string add_linked_resource = 6;
+   */
+  addLinkedResource: string[] = [];
+
+  constructor(data?: PartialMessage<SubscribeEventsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.activity.v1alpha.SubscribeEventsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "resume_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "trusted_only", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "filter_users", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "filter_event_type", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "filter_resource", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "add_linked_resource", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SubscribeEventsRequest {
+    return new SubscribeEventsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SubscribeEventsRequest {
+    return new SubscribeEventsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SubscribeEventsRequest {
+    return new SubscribeEventsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SubscribeEventsRequest | PlainMessage<SubscribeEventsRequest> | undefined, b: SubscribeEventsRequest | PlainMessage<SubscribeEventsRequest> | undefined): boolean {
+    return proto3.util.equals(SubscribeEventsRequest, a, b);
+  }
+}
+
+/**
+ * The new event.
+ *
+ * @generated from message com.mintter.activity.v1alpha.SubscribeEventsResponse
+ */
+export class SubscribeEventsResponse extends Message<SubscribeEventsResponse> {
+  /**
+   * The event.
+   *
+   * @generated from field: com.mintter.activity.v1alpha.Event event = 1;
+   */
+  event?: Event;
+
+  /**
+   * The token to resume the subscription after this event.
+   *
+   * @generated from field: string resume_token = 2;
+   */
+  resumeToken = "";
+
+  constructor(data?: PartialMessage<SubscribeEventsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.activity.v1alpha.SubscribeEventsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "event", kind: "message", T: Event },
+    { no: 2, name: "resume_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SubscribeEventsResponse {
+    return new SubscribeEventsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SubscribeEventsResponse {
+    return new SubscribeEventsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SubscribeEventsResponse {
+    return new SubscribeEventsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SubscribeEventsResponse | PlainMessage<SubscribeEventsResponse> | undefined, b: SubscribeEventsResponse | PlainMessage<SubscribeEventsResponse> | undefined): boolean {
+    return proto3.util.equals(SubscribeEventsResponse, a, b);
+  }
+}
+
 /**
  * Description of the event occurred in the system.
  *
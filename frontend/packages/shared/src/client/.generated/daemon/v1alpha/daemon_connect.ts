@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { ForceSyncRequest, GenMnemonicRequest, GenMnemonicResponse, GetInfoRequest, Info, RegisterRequest, RegisterResponse } from "./daemon_pb";
+import { ChangeKeyPassphraseRequest, CollectGarbageRequest, ForceSyncRequest, GarbageCollectionReport, GenMnemonicRequest, GenMnemonicResponse, GetInfoRequest, GetSyncStatusRequest, Info, ListAccountsRequest, ListAccountsResponse, ListSyncHistoryRequest, ListSyncHistoryResponse, ListSyncJobsRequest, ListSyncJobsResponse, RegisterRequest, RegisterResponse, SwitchAccountRequest, SyncProgress, SyncStatus, UnlockRequest } from "./daemon_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -31,6 +31,7 @@ export const Daemon = {
     /**
      * After generating the seed, this call is used to commit the seed and
      * create an account binding between the device and account.
+     * More accounts can be registered on the same device, the first one becomes the active account.
      *
      * @generated from rpc com.mintter.daemon.v1alpha.Daemon.Register
      */
@@ -62,6 +63,116 @@ export const Daemon = {
       O: Empty,
       kind: MethodKind.Unary,
     },
+    /**
+     * Deletes the blobs that are not reachable from the content we want to keep,
+     * and evicts content from other accounts if the configured disk quota is exceeded.
+     * Use dry run to only get the report of what would be deleted.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.CollectGarbage
+     */
+    collectGarbage: {
+      name: "CollectGarbage",
+      I: CollectGarbageRequest,
+      O: GarbageCollectionReport,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the sync jobs that are currently queued or running, and the recently finished ones.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.ListSyncJobs
+     */
+    listSyncJobs: {
+      name: "ListSyncJobs",
+      I: ListSyncJobsRequest,
+      O: ListSyncJobsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Same as ForceSync, but blocks until the sync is finished, streaming its progress.
+     * The last message of the stream has the done flag set, and summarizes the results.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.ForceSyncWithProgress
+     */
+    forceSyncWithProgress: {
+      name: "ForceSyncWithProgress",
+      I: ForceSyncRequest,
+      O: SyncProgress,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * Gets the status of syncing with each peer, site, or discovered entity,
+     * based on the most recent attempts recorded in the sync history.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.GetSyncStatus
+     */
+    getSyncStatus: {
+      name: "GetSyncStatus",
+      I: GetSyncStatusRequest,
+      O: SyncStatus,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the recorded sync attempts, most recent first.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.ListSyncHistory
+     */
+    listSyncHistory: {
+      name: "ListSyncHistory",
+      I: ListSyncHistoryRequest,
+      O: ListSyncHistoryResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Unlocks the device key encrypted with a passphrase.
+     * While the device key is locked, the daemon only serves this method,
+     * and starts normally after the key is unlocked.
+     * After a wrong passphrase, the next attempts are rejected with RESOURCE_EXHAUSTED for an exponentially growing period.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.Unlock
+     */
+    unlock: {
+      name: "Unlock",
+      I: UnlockRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Changes the passphrase used to encrypt the device key.
+     * Setting a passphrase for a key that is not encrypted enables the encryption,
+     * and setting an empty passphrase disables it.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.ChangeKeyPassphrase
+     */
+    changeKeyPassphrase: {
+      name: "ChangeKeyPassphrase",
+      I: ChangeKeyPassphraseRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the accounts registered on this device.
+     * Requests can select the account to act on behalf of with the x-mintter-account metadata header,
+     * otherwise the active account is used.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.ListAccounts
+     */
+    listAccounts: {
+      name: "ListAccounts",
+      I: ListAccountsRequest,
+      O: ListAccountsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Makes another registered account the active one.
+     *
+     * @generated from rpc com.mintter.daemon.v1alpha.Daemon.SwitchAccount
+     */
+    switchAccount: {
+      name: "SwitchAccount",
+      I: SwitchAccountRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
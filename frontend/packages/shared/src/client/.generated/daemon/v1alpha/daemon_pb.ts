@@ -4,7 +4,99 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64, Timestamp } from "@bufbuild/protobuf";
+
+/**
+ * Kind of a sync job.
+ *
+ * @generated from enum com.mintter.daemon.v1alpha.SyncJobKind
+ */
+export enum SyncJobKind {
+  /**
+   * Invalid default value.
+   *
+   * @generated from enum value: SYNC_JOB_KIND_UNSPECIFIED = 0;
+   */
+  SYNC_JOB_KIND_UNSPECIFIED = 0,
+
+  /**
+   * Syncing with a peer.
+   *
+   * @generated from enum value: PEER = 1;
+   */
+  PEER = 1,
+
+  /**
+   * Syncing with a group site.
+   *
+   * @generated from enum value: SITE = 2;
+   */
+  SITE = 2,
+
+  /**
+   * Discovering an entity from the providers in the network.
+   *
+   * @generated from enum value: DISCOVERY = 3;
+   */
+  DISCOVERY = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(SyncJobKind)
+proto3.util.setEnumType(SyncJobKind, "com.mintter.daemon.v1alpha.SyncJobKind", [
+  { no: 0, name: "SYNC_JOB_KIND_UNSPECIFIED" },
+  { no: 1, name: "PEER" },
+  { no: 2, name: "SITE" },
+  { no: 3, name: "DISCOVERY" },
+]);
+
+/**
+ * State of a sync job.
+ *
+ * @generated from enum com.mintter.daemon.v1alpha.SyncJobState
+ */
+export enum SyncJobState {
+  /**
+   * Invalid default value.
+   *
+   * @generated from enum value: SYNC_JOB_STATE_UNSPECIFIED = 0;
+   */
+  SYNC_JOB_STATE_UNSPECIFIED = 0,
+
+  /**
+   * Job is waiting for a free slot.
+   *
+   * @generated from enum value: QUEUED = 1;
+   */
+  QUEUED = 1,
+
+  /**
+   * Job is running.
+   *
+   * @generated from enum value: RUNNING = 2;
+   */
+  RUNNING = 2,
+
+  /**
+   * Job has finished successfully.
+   *
+   * @generated from enum value: SUCCEEDED = 3;
+   */
+  SUCCEEDED = 3,
+
+  /**
+   * Job has failed.
+   *
+   * @generated from enum value: FAILED = 4;
+   */
+  FAILED = 4,
+}
+// Retrieve enum metadata with: proto3.getEnumType(SyncJobState)
+proto3.util.setEnumType(SyncJobState, "com.mintter.daemon.v1alpha.SyncJobState", [
+  { no: 0, name: "SYNC_JOB_STATE_UNSPECIFIED" },
+  { no: 1, name: "QUEUED" },
+  { no: 2, name: "RUNNING" },
+  { no: 3, name: "SUCCEEDED" },
+  { no: 4, name: "FAILED" },
+]);
 
 /**
  * @generated from message com.mintter.daemon.v1alpha.GenMnemonicRequest
@@ -228,59 +320,1133 @@ export class ForceSyncRequest extends Message<ForceSyncRequest> {
 }
 
 /**
- * Info is a generic information about the running node.
+ * @generated from message com.mintter.daemon.v1alpha.CollectGarbageRequest
+ */
+export class CollectGarbageRequest extends Message<CollectGarbageRequest> {
+  /**
+   * Only compute the report without deleting anything.
+   *
+   * @generated from field: bool dry_run = 1;
+   */
+  dryRun = false;
+
+  constructor(data?: PartialMessage<CollectGarbageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.CollectGarbageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "dry_run", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CollectGarbageRequest {
+    return new CollectGarbageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CollectGarbageRequest {
+    return new CollectGarbageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CollectGarbageRequest {
+    return new CollectGarbageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CollectGarbageRequest | PlainMessage<CollectGarbageRequest> | undefined, b: CollectGarbageRequest | PlainMessage<CollectGarbageRequest> | undefined): boolean {
+    return proto3.util.equals(CollectGarbageRequest, a, b);
+  }
+}
+
+/**
+ * Report of the garbage collection.
  *
- * @generated from message com.mintter.daemon.v1alpha.Info
+ * @generated from message com.mintter.daemon.v1alpha.GarbageCollectionReport
  */
-export class Info extends Message<Info> {
+export class GarbageCollectionReport extends Message<GarbageCollectionReport> {
   /**
-   * Account ID this node belongs to.
+   * Whether this was a dry run, i.e. nothing was actually deleted.
    *
-   * @generated from field: string account_id = 1;
+   * @generated from field: bool dry_run = 1;
    */
-  accountId = "";
+  dryRun = false;
 
   /**
-   * Libp2p Peer ID in CID form (a.k.a. Device ID) assigned to this node.
+   * Total number of blobs before the collection.
    *
-   * @generated from field: string device_id = 2;
+   * @generated from field: int64 total_blobs = 2;
    */
-  deviceId = "";
+  totalBlobs = protoInt64.zero;
 
   /**
-   * Start time of the node.
+   * Total size in bytes of the stored blob data before the collection.
    *
-   * @generated from field: google.protobuf.Timestamp start_time = 3;
+   * @generated from field: int64 total_bytes = 3;
    */
-  startTime?: Timestamp;
+  totalBytes = protoInt64.zero;
 
-  constructor(data?: PartialMessage<Info>) {
+  /**
+   * Number of blobs that were (or would be) deleted.
+   *
+   * @generated from field: int64 unreachable_blobs = 4;
+   */
+  unreachableBlobs = protoInt64.zero;
+
+  /**
+   * Size in bytes of the stored data that was (or would be) reclaimed.
+   *
+   * @generated from field: int64 reclaimable_bytes = 5;
+   */
+  reclaimableBytes = protoInt64.zero;
+
+  /**
+   * IDs of the entities that were (or would be) evicted to satisfy the disk quota.
+   *
+   * @generated from field: repeated string evicted_entities = 6;
+   */
+  evictedEntities: string[] = [];
+
+  constructor(data?: PartialMessage<GarbageCollectionReport>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "com.mintter.daemon.v1alpha.Info";
+  static readonly typeName = "com.mintter.daemon.v1alpha.GarbageCollectionReport";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "account_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "device_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "start_time", kind: "message", T: Timestamp },
+    { no: 1, name: "dry_run", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 2, name: "total_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "total_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "unreachable_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "reclaimable_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "evicted_entities", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Info {
-    return new Info().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GarbageCollectionReport {
+    return new GarbageCollectionReport().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Info {
-    return new Info().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GarbageCollectionReport {
+    return new GarbageCollectionReport().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Info {
-    return new Info().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GarbageCollectionReport {
+    return new GarbageCollectionReport().fromJsonString(jsonString, options);
   }
 
-  static equals(a: Info | PlainMessage<Info> | undefined, b: Info | PlainMessage<Info> | undefined): boolean {
-    return proto3.util.equals(Info, a, b);
+  static equals(a: GarbageCollectionReport | PlainMessage<GarbageCollectionReport> | undefined, b: GarbageCollectionReport | PlainMessage<GarbageCollectionReport> | undefined): boolean {
+    return proto3.util.equals(GarbageCollectionReport, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.ListSyncJobsRequest
+ */
+export class ListSyncJobsRequest extends Message<ListSyncJobsRequest> {
+  constructor(data?: PartialMessage<ListSyncJobsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.ListSyncJobsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSyncJobsRequest {
+    return new ListSyncJobsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSyncJobsRequest {
+    return new ListSyncJobsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSyncJobsRequest {
+    return new ListSyncJobsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSyncJobsRequest | PlainMessage<ListSyncJobsRequest> | undefined, b: ListSyncJobsRequest | PlainMessage<ListSyncJobsRequest> | undefined): boolean {
+    return proto3.util.equals(ListSyncJobsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.ListSyncJobsResponse
+ */
+export class ListSyncJobsResponse extends Message<ListSyncJobsResponse> {
+  /**
+   * Jobs that are currently running or waiting for a free slot.
+   * Running jobs go first, then queued jobs in the order they will be started.
+   *
+   * @generated from field: repeated com.mintter.daemon.v1alpha.SyncJob active_jobs = 1;
+   */
+  activeJobs: SyncJob[] = [];
+
+  /**
+   * Recently finished jobs, most recent first.
+   *
+   * @generated from field: repeated com.mintter.daemon.v1alpha.SyncJob recent_jobs = 2;
+   */
+  recentJobs: SyncJob[] = [];
+
+  constructor(data?: PartialMessage<ListSyncJobsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.ListSyncJobsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "active_jobs", kind: "message", T: SyncJob, repeated: true },
+    { no: 2, name: "recent_jobs", kind: "message", T: SyncJob, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSyncJobsResponse {
+    return new ListSyncJobsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSyncJobsResponse {
+    return new ListSyncJobsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSyncJobsResponse {
+    return new ListSyncJobsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSyncJobsResponse | PlainMessage<ListSyncJobsResponse> | undefined, b: ListSyncJobsResponse | PlainMessage<ListSyncJobsResponse> | undefined): boolean {
+    return proto3.util.equals(ListSyncJobsResponse, a, b);
+  }
+}
+
+/**
+ * Progress of a forced sync.
+ *
+ * @generated from message com.mintter.daemon.v1alpha.SyncProgress
+ */
+export class SyncProgress extends Message<SyncProgress> {
+  /**
+   * Sync jobs that are currently running or waiting for a free slot.
+   *
+   * @generated from field: repeated com.mintter.daemon.v1alpha.SyncJob active_jobs = 1;
+   */
+  activeJobs: SyncJob[] = [];
+
+  /**
+   * Sync jobs that have finished since the previous message.
+   *
+   * @generated from field: repeated com.mintter.daemon.v1alpha.SyncJob finished_jobs = 2;
+   */
+  finishedJobs: SyncJob[] = [];
+
+  /**
+   * Whether the sync is finished. Only set on the last message of the stream.
+   *
+   * @generated from field: bool done = 3;
+   */
+  done = false;
+
+  /**
+   * Number of peers we've synced with successfully. Only set when done.
+   *
+   * @generated from field: int64 num_sync_ok = 4;
+   */
+  numSyncOk = protoInt64.zero;
+
+  /**
+   * Number of peers we've failed to sync with. Only set when done.
+   *
+   * @generated from field: int64 num_sync_failed = 5;
+   */
+  numSyncFailed = protoInt64.zero;
+
+  constructor(data?: PartialMessage<SyncProgress>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.SyncProgress";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "active_jobs", kind: "message", T: SyncJob, repeated: true },
+    { no: 2, name: "finished_jobs", kind: "message", T: SyncJob, repeated: true },
+    { no: 3, name: "done", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "num_sync_ok", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "num_sync_failed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SyncProgress {
+    return new SyncProgress().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SyncProgress {
+    return new SyncProgress().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SyncProgress {
+    return new SyncProgress().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SyncProgress | PlainMessage<SyncProgress> | undefined, b: SyncProgress | PlainMessage<SyncProgress> | undefined): boolean {
+    return proto3.util.equals(SyncProgress, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.GetSyncStatusRequest
+ */
+export class GetSyncStatusRequest extends Message<GetSyncStatusRequest> {
+  /**
+   * Optional. Only return the status for targets of this kind.
+   *
+   * @generated from field: com.mintter.daemon.v1alpha.SyncJobKind kind = 1;
+   */
+  kind = SyncJobKind.SYNC_JOB_KIND_UNSPECIFIED;
+
+  /**
+   * Optional. Only return the status for this target.
+   *
+   * @generated from field: string target = 2;
+   */
+  target = "";
+
+  constructor(data?: PartialMessage<GetSyncStatusRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.GetSyncStatusRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "enum", T: proto3.getEnumType(SyncJobKind) },
+    { no: 2, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetSyncStatusRequest {
+    return new GetSyncStatusRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetSyncStatusRequest {
+    return new GetSyncStatusRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetSyncStatusRequest {
+    return new GetSyncStatusRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetSyncStatusRequest | PlainMessage<GetSyncStatusRequest> | undefined, b: GetSyncStatusRequest | PlainMessage<GetSyncStatusRequest> | undefined): boolean {
+    return proto3.util.equals(GetSyncStatusRequest, a, b);
+  }
+}
+
+/**
+ * Status of syncing with all the known targets.
+ *
+ * @generated from message com.mintter.daemon.v1alpha.SyncStatus
+ */
+export class SyncStatus extends Message<SyncStatus> {
+  /**
+   * Status for each target, ordered by the time of the last attempt, most recent first.
+   *
+   * @generated from field: repeated com.mintter.daemon.v1alpha.SyncTargetStatus targets = 1;
+   */
+  targets: SyncTargetStatus[] = [];
+
+  constructor(data?: PartialMessage<SyncStatus>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.SyncStatus";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "targets", kind: "message", T: SyncTargetStatus, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SyncStatus {
+    return new SyncStatus().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SyncStatus {
+    return new SyncStatus().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SyncStatus {
+    return new SyncStatus().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SyncStatus | PlainMessage<SyncStatus> | undefined, b: SyncStatus | PlainMessage<SyncStatus> | undefined): boolean {
+    return proto3.util.equals(SyncStatus, a, b);
+  }
+}
+
+/**
+ * Status of syncing with a single target.
+ *
+ * @generated from message com.mintter.daemon.v1alpha.SyncTargetStatus
+ */
+export class SyncTargetStatus extends Message<SyncTargetStatus> {
+  /**
+   * Kind of the target.
+   *
+   * @generated from field: com.mintter.daemon.v1alpha.SyncJobKind kind = 1;
+   */
+  kind = SyncJobKind.SYNC_JOB_KIND_UNSPECIFIED;
+
+  /**
+   * Peer ID, site URL, or entity ID of the target.
+   *
+   * @generated from field: string target = 2;
+   */
+  target = "";
+
+  /**
+   * The most recent sync attempt with the target.
+   *
+   * @generated from field: com.mintter.daemon.v1alpha.SyncAttempt last_attempt = 3;
+   */
+  lastAttempt?: SyncAttempt;
+
+  /**
+   * End time of the most recent successful attempt.
+   * Empty if there's no successful attempt in the history.
+   *
+   * @generated from field: google.protobuf.Timestamp last_ok_time = 4;
+   */
+  lastOkTime?: Timestamp;
+
+  /**
+   * Number of failed attempts since the last successful one.
+   *
+   * @generated from field: int32 consecutive_failures = 5;
+   */
+  consecutiveFailures = 0;
+
+  constructor(data?: PartialMessage<SyncTargetStatus>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.SyncTargetStatus";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "enum", T: proto3.getEnumType(SyncJobKind) },
+    { no: 2, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "last_attempt", kind: "message", T: SyncAttempt },
+    { no: 4, name: "last_ok_time", kind: "message", T: Timestamp },
+    { no: 5, name: "consecutive_failures", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SyncTargetStatus {
+    return new SyncTargetStatus().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SyncTargetStatus {
+    return new SyncTargetStatus().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SyncTargetStatus {
+    return new SyncTargetStatus().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SyncTargetStatus | PlainMessage<SyncTargetStatus> | undefined, b: SyncTargetStatus | PlainMessage<SyncTargetStatus> | undefined): boolean {
+    return proto3.util.equals(SyncTargetStatus, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.ListSyncHistoryRequest
+ */
+export class ListSyncHistoryRequest extends Message<ListSyncHistoryRequest> {
+  /**
+   * Optional. Only return the attempts for targets of this kind.
+   *
+   * @generated from field: com.mintter.daemon.v1alpha.SyncJobKind kind = 1;
+   */
+  kind = SyncJobKind.SYNC_JOB_KIND_UNSPECIFIED;
+
+  /**
+   * Optional. Only return the attempts for this target.
+   *
+   * @generated from field: string target = 2;
+   */
+  target = "";
+
+  /**
+   * Optional. Number of results per page.
+   *
+   * @generated from field: int32 page_size = 3;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. Token for the page to return.
+   *
+   * @generated from field: string page_token = 4;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListSyncHistoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.ListSyncHistoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "enum", T: proto3.getEnumType(SyncJobKind) },
+    { no: 2, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSyncHistoryRequest {
+    return new ListSyncHistoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSyncHistoryRequest {
+    return new ListSyncHistoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSyncHistoryRequest {
+    return new ListSyncHistoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSyncHistoryRequest | PlainMessage<ListSyncHistoryRequest> | undefined, b: ListSyncHistoryRequest | PlainMessage<ListSyncHistoryRequest> | undefined): boolean {
+    return proto3.util.equals(ListSyncHistoryRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.ListSyncHistoryResponse
+ */
+export class ListSyncHistoryResponse extends Message<ListSyncHistoryResponse> {
+  /**
+   * Sync attempts, most recent first.
+   *
+   * @generated from field: repeated com.mintter.daemon.v1alpha.SyncAttempt attempts = 1;
+   */
+  attempts: SyncAttempt[] = [];
+
+  /**
+   * Token for the next page if there's any.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListSyncHistoryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.ListSyncHistoryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "attempts", kind: "message", T: SyncAttempt, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSyncHistoryResponse {
+    return new ListSyncHistoryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSyncHistoryResponse {
+    return new ListSyncHistoryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSyncHistoryResponse {
+    return new ListSyncHistoryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSyncHistoryResponse | PlainMessage<ListSyncHistoryResponse> | undefined, b: ListSyncHistoryResponse | PlainMessage<ListSyncHistoryResponse> | undefined): boolean {
+    return proto3.util.equals(ListSyncHistoryResponse, a, b);
+  }
+}
+
+/**
+ * Record of a finished sync attempt in the sync history.
+ *
+ * @generated from message com.mintter.daemon.v1alpha.SyncAttempt
+ */
+export class SyncAttempt extends Message<SyncAttempt> {
+  /**
+   * ID of the record in the history.
+   *
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  /**
+   * Kind of the sync.
+   *
+   * @generated from field: com.mintter.daemon.v1alpha.SyncJobKind kind = 2;
+   */
+  kind = SyncJobKind.SYNC_JOB_KIND_UNSPECIFIED;
+
+  /**
+   * Peer ID, site URL, or entity ID we've synced with.
+   *
+   * @generated from field: string target = 3;
+   */
+  target = "";
+
+  /**
+   * Time when the attempt was started.
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 4;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * Time when the attempt was finished.
+   *
+   * @generated from field: google.protobuf.Timestamp end_time = 5;
+   */
+  endTime?: Timestamp;
+
+  /**
+   * Duration of the attempt in milliseconds.
+   *
+   * @generated from field: int64 duration_ms = 6;
+   */
+  durationMs = protoInt64.zero;
+
+  /**
+   * Number of blobs we wanted to fetch.
+   *
+   * @generated from field: int64 wanted_blobs = 7;
+   */
+  wantedBlobs = protoInt64.zero;
+
+  /**
+   * Number of blobs we've fetched.
+   *
+   * @generated from field: int64 fetched_blobs = 8;
+   */
+  fetchedBlobs = protoInt64.zero;
+
+  /**
+   * Number of blobs we've failed to fetch.
+   *
+   * @generated from field: int64 failed_blobs = 9;
+   */
+  failedBlobs = protoInt64.zero;
+
+  /**
+   * Number of blobs we've pushed to the remote peer.
+   *
+   * @generated from field: int64 pushed_blobs = 10;
+   */
+  pushedBlobs = protoInt64.zero;
+
+  /**
+   * Error message if the attempt has failed.
+   *
+   * @generated from field: string error = 11;
+   */
+  error = "";
+
+  /**
+   * Our sync cursor with the remote peer after the attempt.
+   * Empty if the sync doesn't use cursors, e.g. with set reconciliation.
+   *
+   * @generated from field: string cursor = 12;
+   */
+  cursor = "";
+
+  constructor(data?: PartialMessage<SyncAttempt>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.SyncAttempt";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "kind", kind: "enum", T: proto3.getEnumType(SyncJobKind) },
+    { no: 3, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "start_time", kind: "message", T: Timestamp },
+    { no: 5, name: "end_time", kind: "message", T: Timestamp },
+    { no: 6, name: "duration_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "wanted_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "fetched_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "failed_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 10, name: "pushed_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 11, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 12, name: "cursor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SyncAttempt {
+    return new SyncAttempt().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SyncAttempt {
+    return new SyncAttempt().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SyncAttempt {
+    return new SyncAttempt().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SyncAttempt | PlainMessage<SyncAttempt> | undefined, b: SyncAttempt | PlainMessage<SyncAttempt> | undefined): boolean {
+    return proto3.util.equals(SyncAttempt, a, b);
+  }
+}
+
+/**
+ * Job of the sync subsystem.
+ * All the syncing work, like syncing with peers and sites, or discovering content,
+ * is performed as jobs sharing the same concurrency limits and backoff policy.
+ *
+ * @generated from message com.mintter.daemon.v1alpha.SyncJob
+ */
+export class SyncJob extends Message<SyncJob> {
+  /**
+   * ID of the job. Unique within the lifetime of the daemon process.
+   *
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  /**
+   * Kind of work the job performs.
+   *
+   * @generated from field: com.mintter.daemon.v1alpha.SyncJobKind kind = 2;
+   */
+  kind = SyncJobKind.SYNC_JOB_KIND_UNSPECIFIED;
+
+  /**
+   * Target of the job. Peer ID for peer jobs, URL for site jobs,
+   * and entity ID with an optional version for discovery jobs.
+   *
+   * @generated from field: string target = 3;
+   */
+  target = "";
+
+  /**
+   * Priority of the job. Jobs with higher priority get free slots first.
+   *
+   * @generated from field: int32 priority = 4;
+   */
+  priority = 0;
+
+  /**
+   * Current state of the job.
+   *
+   * @generated from field: com.mintter.daemon.v1alpha.SyncJobState state = 5;
+   */
+  state = SyncJobState.SYNC_JOB_STATE_UNSPECIFIED;
+
+  /**
+   * Time when the job was scheduled.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 6;
+   */
+  createTime?: Timestamp;
+
+  /**
+   * Time when the job was started. Empty for queued jobs.
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 7;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * Time when the job was finished. Empty for active jobs.
+   *
+   * @generated from field: google.protobuf.Timestamp end_time = 8;
+   */
+  endTime?: Timestamp;
+
+  /**
+   * Number of blobs we wanted to fetch.
+   *
+   * @generated from field: int64 wanted_blobs = 9;
+   */
+  wantedBlobs = protoInt64.zero;
+
+  /**
+   * Number of blobs we've fetched.
+   *
+   * @generated from field: int64 fetched_blobs = 10;
+   */
+  fetchedBlobs = protoInt64.zero;
+
+  /**
+   * Number of blobs we've failed to fetch.
+   *
+   * @generated from field: int64 failed_blobs = 11;
+   */
+  failedBlobs = protoInt64.zero;
+
+  /**
+   * Number of blobs we've pushed to the remote peer.
+   *
+   * @generated from field: int64 pushed_blobs = 12;
+   */
+  pushedBlobs = protoInt64.zero;
+
+  /**
+   * Error message if the job has failed.
+   *
+   * @generated from field: string error = 13;
+   */
+  error = "";
+
+  /**
+   * Number of consecutive failures for the same target before this job.
+   *
+   * @generated from field: int32 attempt = 14;
+   */
+  attempt = 0;
+
+  constructor(data?: PartialMessage<SyncJob>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.SyncJob";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "kind", kind: "enum", T: proto3.getEnumType(SyncJobKind) },
+    { no: 3, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "priority", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "state", kind: "enum", T: proto3.getEnumType(SyncJobState) },
+    { no: 6, name: "create_time", kind: "message", T: Timestamp },
+    { no: 7, name: "start_time", kind: "message", T: Timestamp },
+    { no: 8, name: "end_time", kind: "message", T: Timestamp },
+    { no: 9, name: "wanted_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 10, name: "fetched_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 11, name: "failed_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 12, name: "pushed_blobs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 13, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 14, name: "attempt", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SyncJob {
+    return new SyncJob().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SyncJob {
+    return new SyncJob().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SyncJob {
+    return new SyncJob().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SyncJob | PlainMessage<SyncJob> | undefined, b: SyncJob | PlainMessage<SyncJob> | undefined): boolean {
+    return proto3.util.equals(SyncJob, a, b);
+  }
+}
+
+/**
+ * Info is a generic information about the running node.
+ *
+ * @generated from message com.mintter.daemon.v1alpha.Info
+ */
+export class Info extends Message<Info> {
+  /**
+   * Account ID this node belongs to.
+   *
+   * @generated from field: string account_id = 1;
+   */
+  accountId = "";
+
+  /**
+   * Libp2p Peer ID in CID form (a.k.a. Device ID) assigned to this node.
+   *
+   * @generated from field: string device_id = 2;
+   */
+  deviceId = "";
+
+  /**
+   * Start time of the node.
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 3;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * Whether the device key is stored encrypted with a passphrase.
+   *
+   * @generated from field: bool device_key_encrypted = 4;
+   */
+  deviceKeyEncrypted = false;
+
+  constructor(data?: PartialMessage<Info>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.Info";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "device_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "start_time", kind: "message", T: Timestamp },
+    { no: 4, name: "device_key_encrypted", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Info {
+    return new Info().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Info {
+    return new Info().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Info {
+    return new Info().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Info | PlainMessage<Info> | undefined, b: Info | PlainMessage<Info> | undefined): boolean {
+    return proto3.util.equals(Info, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.UnlockRequest
+ */
+export class UnlockRequest extends Message<UnlockRequest> {
+  /**
+   * Passphrase the device key is encrypted with.
+   *
+   * @generated from field: string passphrase = 1;
+   */
+  passphrase = "";
+
+  constructor(data?: PartialMessage<UnlockRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.UnlockRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "passphrase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UnlockRequest {
+    return new UnlockRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UnlockRequest {
+    return new UnlockRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UnlockRequest {
+    return new UnlockRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UnlockRequest | PlainMessage<UnlockRequest> | undefined, b: UnlockRequest | PlainMessage<UnlockRequest> | undefined): boolean {
+    return proto3.util.equals(UnlockRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.ChangeKeyPassphraseRequest
+ */
+export class ChangeKeyPassphraseRequest extends Message<ChangeKeyPassphraseRequest> {
+  /**
+   * Current passphrase of the device key. Ignored if the key is not encrypted.
+   *
+   * @generated from field: string old_passphrase = 1;
+   */
+  oldPassphrase = "";
+
+  /**
+   * New passphrase for the device key. Empty passphrase disables the encryption.
+   *
+   * @generated from field: string new_passphrase = 2;
+   */
+  newPassphrase = "";
+
+  constructor(data?: PartialMessage<ChangeKeyPassphraseRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.ChangeKeyPassphraseRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "old_passphrase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "new_passphrase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChangeKeyPassphraseRequest {
+    return new ChangeKeyPassphraseRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChangeKeyPassphraseRequest {
+    return new ChangeKeyPassphraseRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChangeKeyPassphraseRequest {
+    return new ChangeKeyPassphraseRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChangeKeyPassphraseRequest | PlainMessage<ChangeKeyPassphraseRequest> | undefined, b: ChangeKeyPassphraseRequest | PlainMessage<ChangeKeyPassphraseRequest> | undefined): boolean {
+    return proto3.util.equals(ChangeKeyPassphraseRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.ListAccountsRequest
+ */
+export class ListAccountsRequest extends Message<ListAccountsRequest> {
+  constructor(data?: PartialMessage<ListAccountsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.ListAccountsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAccountsRequest {
+    return new ListAccountsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAccountsRequest {
+    return new ListAccountsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAccountsRequest {
+    return new ListAccountsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAccountsRequest | PlainMessage<ListAccountsRequest> | undefined, b: ListAccountsRequest | PlainMessage<ListAccountsRequest> | undefined): boolean {
+    return proto3.util.equals(ListAccountsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.ListAccountsResponse
+ */
+export class ListAccountsResponse extends Message<ListAccountsResponse> {
+  /**
+   * Accounts registered on this device, in the order they were registered.
+   *
+   * @generated from field: repeated com.mintter.daemon.v1alpha.LocalAccount accounts = 1;
+   */
+  accounts: LocalAccount[] = [];
+
+  constructor(data?: PartialMessage<ListAccountsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.ListAccountsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "accounts", kind: "message", T: LocalAccount, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListAccountsResponse {
+    return new ListAccountsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListAccountsResponse {
+    return new ListAccountsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListAccountsResponse {
+    return new ListAccountsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListAccountsResponse | PlainMessage<ListAccountsResponse> | undefined, b: ListAccountsResponse | PlainMessage<ListAccountsResponse> | undefined): boolean {
+    return proto3.util.equals(ListAccountsResponse, a, b);
+  }
+}
+
+/**
+ * Account registered on this device.
+ *
+ * @generated from message com.mintter.daemon.v1alpha.LocalAccount
+ */
+export class LocalAccount extends Message<LocalAccount> {
+  /**
+   * ID of the account.
+   *
+   * @generated from field: string account_id = 1;
+   */
+  accountId = "";
+
+  /**
+   * Whether the account is used for requests that don't select any account.
+   *
+   * @generated from field: bool is_active = 2;
+   */
+  isActive = false;
+
+  constructor(data?: PartialMessage<LocalAccount>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.LocalAccount";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "is_active", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): LocalAccount {
+    return new LocalAccount().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): LocalAccount {
+    return new LocalAccount().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): LocalAccount {
+    return new LocalAccount().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: LocalAccount | PlainMessage<LocalAccount> | undefined, b: LocalAccount | PlainMessage<LocalAccount> | undefined): boolean {
+    return proto3.util.equals(LocalAccount, a, b);
+  }
+}
+
+/**
+ * @generated from message com.mintter.daemon.v1alpha.SwitchAccountRequest
+ */
+export class SwitchAccountRequest extends Message<SwitchAccountRequest> {
+  /**
+   * ID of the account to make active.
+   *
+   * @generated from field: string account_id = 1;
+   */
+  accountId = "";
+
+  constructor(data?: PartialMessage<SwitchAccountRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.daemon.v1alpha.SwitchAccountRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SwitchAccountRequest {
+    return new SwitchAccountRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SwitchAccountRequest {
+    return new SwitchAccountRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SwitchAccountRequest {
+    return new SwitchAccountRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SwitchAccountRequest | PlainMessage<SwitchAccountRequest> | undefined, b: SwitchAccountRequest | PlainMessage<SwitchAccountRequest> | undefined): boolean {
+    return proto3.util.equals(SwitchAccountRequest, a, b);
   }
 }
 
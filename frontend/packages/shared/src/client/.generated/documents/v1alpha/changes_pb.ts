@@ -76,6 +76,14 @@ export class ListChangesRequest extends Message<ListChangesRequest> {
    */
   pageToken = "";
 
+  /**
+   * Optional. Changes are sorted by their timestamps (oldest first).
+   * This flag allows to reverse the order.
+   *
+   * @generated from field: bool reverse_order = 4;
+   */
+  reverseOrder = false;
+
   constructor(data?: PartialMessage<ListChangesRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -87,6 +95,7 @@ export class ListChangesRequest extends Message<ListChangesRequest> {
     { no: 1, name: "document_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
     { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "reverse_order", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListChangesRequest {
@@ -136,6 +136,22 @@ export class ListCommentsRequest extends Message<ListCommentsRequest> {
    */
   pageToken = "";
 
+  /**
+   * Optional. ID of the top-level comment to list the thread for.
+   * If specified, only the root comment itself and the replies within its thread are returned.
+   *
+   * @generated from field: string thread_root = 4;
+   */
+  threadRoot = "";
+
+  /**
+   * Optional. Comments are sorted by their creation time (oldest first).
+   * This flag allows to reverse the order.
+   *
+   * @generated from field: bool reverse_order = 5;
+   */
+  reverseOrder = false;
+
   constructor(data?: PartialMessage<ListCommentsRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -147,6 +163,8 @@ export class ListCommentsRequest extends Message<ListCommentsRequest> {
     { no: 1, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
     { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "thread_root", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "reverse_order", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListCommentsRequest {
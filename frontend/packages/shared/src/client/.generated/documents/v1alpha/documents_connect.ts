@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { CreateDraftRequest, DeleteDraftRequest, Document, GetDraftRequest, GetPublicationRequest, ListAccountPublicationsRequest, ListDocumentDraftsRequest, ListDocumentDraftsResponse, ListDraftsRequest, ListDraftsResponse, ListPublicationsRequest, ListPublicationsResponse, MergeChangesRequest, Publication, PublishDraftRequest, PushPublicationRequest, RebaseChangesRequest, UpdateDraftRequest, UpdateDraftResponse } from "./documents_pb";
+import { CreateDraftRequest, DeleteDraftRequest, DiffVersionsRequest, DiffVersionsResponse, Document, ExportMarkdownRequest, ExportMarkdownResponse, GetDraftRequest, GetPublicationRequest, ImportMarkdownRequest, ListAccountPublicationsRequest, ListDocumentDraftsRequest, ListDocumentDraftsResponse, ListDraftsRequest, ListDraftsResponse, ListPublicationsRequest, ListPublicationsResponse, MergeChangesRequest, Publication, PublishDraftRequest, PushPublicationRequest, RebaseChangesRequest, UpdateDraftRequest, UpdateDraftResponse } from "./documents_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -91,6 +91,29 @@ export const Drafts = {
       O: Publication,
       kind: MethodKind.Unary,
     },
+    /**
+     * Creates a draft from Markdown. If document ID is specified,
+     * the content of the document's draft is replaced with the one parsed from Markdown.
+     *
+     * @generated from rpc com.mintter.documents.v1alpha.Drafts.ImportMarkdown
+     */
+    importMarkdown: {
+      name: "ImportMarkdown",
+      I: ImportMarkdownRequest,
+      O: Document,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Renders a draft or a publication as Markdown.
+     *
+     * @generated from rpc com.mintter.documents.v1alpha.Drafts.ExportMarkdown
+     */
+    exportMarkdown: {
+      name: "ExportMarkdown",
+      I: ExportMarkdownRequest,
+      O: ExportMarkdownResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -146,6 +169,17 @@ export const Publications = {
       O: ListPublicationsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Compares two versions of a document.
+     *
+     * @generated from rpc com.mintter.documents.v1alpha.Publications.DiffVersions
+     */
+    diffVersions: {
+      name: "DiffVersions",
+      I: DiffVersionsRequest,
+      O: DiffVersionsResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
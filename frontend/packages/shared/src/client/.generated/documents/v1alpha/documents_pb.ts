@@ -6,6 +6,48 @@
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
 import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
 
+/**
+ * Operation of a text diff.
+ *
+ * @generated from enum com.mintter.documents.v1alpha.DiffOp
+ */
+export enum DiffOp {
+  /**
+   * Invalid default value.
+   *
+   * @generated from enum value: DIFF_OP_UNSPECIFIED = 0;
+   */
+  DIFF_OP_UNSPECIFIED = 0,
+
+  /**
+   * Text is present in both versions.
+   *
+   * @generated from enum value: EQUAL = 1;
+   */
+  EQUAL = 1,
+
+  /**
+   * Text is only present in the target version.
+   *
+   * @generated from enum value: INSERT = 2;
+   */
+  INSERT = 2,
+
+  /**
+   * Text is only present in the base version.
+   *
+   * @generated from enum value: DELETE = 3;
+   */
+  DELETE = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(DiffOp)
+proto3.util.setEnumType(DiffOp, "com.mintter.documents.v1alpha.DiffOp", [
+  { no: 0, name: "DIFF_OP_UNSPECIFIED" },
+  { no: 1, name: "EQUAL" },
+  { no: 2, name: "INSERT" },
+  { no: 3, name: "DELETE" },
+]);
+
 /**
  * Request to create a new draft.
  *
@@ -29,6 +71,15 @@ export class CreateDraftRequest extends Message<CreateDraftRequest> {
    */
   version = "";
 
+  /**
+   * Optional. ID of the private group the new document belongs to.
+   * Content of the document will be encrypted, and only available to the members of the group.
+   * Can't be used together with existing_document_id.
+   *
+   * @generated from field: string private_group_id = 3;
+   */
+  privateGroupId = "";
+
   constructor(data?: PartialMessage<CreateDraftRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -39,6 +90,7 @@ export class CreateDraftRequest extends Message<CreateDraftRequest> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "existing_document_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "private_group_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateDraftRequest {
@@ -391,6 +443,14 @@ export class ListDraftsRequest extends Message<ListDraftsRequest> {
    */
   pageToken = "";
 
+  /**
+   * Optional. Drafts are sorted by their update time (newest first).
+   * This flag allows to reverse the order.
+   *
+   * @generated from field: bool reverse_order = 3;
+   */
+  reverseOrder = false;
+
   constructor(data?: PartialMessage<ListDraftsRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -401,6 +461,7 @@ export class ListDraftsRequest extends Message<ListDraftsRequest> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
     { no: 2, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "reverse_order", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListDraftsRequest {
@@ -594,6 +655,158 @@ export class PublishDraftRequest extends Message<PublishDraftRequest> {
   }
 }
 
+/**
+ * Request to import Markdown into a draft.
+ *
+ * @generated from message com.mintter.documents.v1alpha.ImportMarkdownRequest
+ */
+export class ImportMarkdownRequest extends Message<ImportMarkdownRequest> {
+  /**
+   * Optional. ID of an existing document to update.
+   * If the document doesn't have a draft, it will be created
+   * from the most recent known version. If empty, a new document is created.
+   *
+   * @generated from field: string document_id = 1;
+   */
+  documentId = "";
+
+  /**
+   * Required. Markdown content. The leading top-level heading becomes the title of the document.
+   * The content is parsed as CommonMark with GFM tables and strikethrough.
+   * Tables have no block counterpart, so each table row becomes a paragraph with the cells separated by " | ".
+   *
+   * @generated from field: string markdown = 2;
+   */
+  markdown = "";
+
+  constructor(data?: PartialMessage<ImportMarkdownRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.ImportMarkdownRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "document_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "markdown", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImportMarkdownRequest {
+    return new ImportMarkdownRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImportMarkdownRequest {
+    return new ImportMarkdownRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImportMarkdownRequest {
+    return new ImportMarkdownRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImportMarkdownRequest | PlainMessage<ImportMarkdownRequest> | undefined, b: ImportMarkdownRequest | PlainMessage<ImportMarkdownRequest> | undefined): boolean {
+    return proto3.util.equals(ImportMarkdownRequest, a, b);
+  }
+}
+
+/**
+ * Request to export a document as Markdown.
+ *
+ * @generated from message com.mintter.documents.v1alpha.ExportMarkdownRequest
+ */
+export class ExportMarkdownRequest extends Message<ExportMarkdownRequest> {
+  /**
+   * Required. ID of the document to export.
+   *
+   * @generated from field: string document_id = 1;
+   */
+  documentId = "";
+
+  /**
+   * Optional. Version of the publication to export.
+   * If empty, the latest known version is exported.
+   *
+   * @generated from field: string version = 2;
+   */
+  version = "";
+
+  /**
+   * Optional. Export the current draft of the document instead of the publication.
+   *
+   * @generated from field: bool draft = 3;
+   */
+  draft = false;
+
+  constructor(data?: PartialMessage<ExportMarkdownRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.ExportMarkdownRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "document_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "draft", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportMarkdownRequest {
+    return new ExportMarkdownRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportMarkdownRequest {
+    return new ExportMarkdownRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportMarkdownRequest {
+    return new ExportMarkdownRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportMarkdownRequest | PlainMessage<ExportMarkdownRequest> | undefined, b: ExportMarkdownRequest | PlainMessage<ExportMarkdownRequest> | undefined): boolean {
+    return proto3.util.equals(ExportMarkdownRequest, a, b);
+  }
+}
+
+/**
+ * Response with the document rendered as Markdown.
+ *
+ * @generated from message com.mintter.documents.v1alpha.ExportMarkdownResponse
+ */
+export class ExportMarkdownResponse extends Message<ExportMarkdownResponse> {
+  /**
+   * Markdown content of the document.
+   *
+   * @generated from field: string markdown = 1;
+   */
+  markdown = "";
+
+  constructor(data?: PartialMessage<ExportMarkdownResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.ExportMarkdownResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "markdown", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportMarkdownResponse {
+    return new ExportMarkdownResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportMarkdownResponse {
+    return new ExportMarkdownResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportMarkdownResponse {
+    return new ExportMarkdownResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportMarkdownResponse | PlainMessage<ExportMarkdownResponse> | undefined, b: ExportMarkdownResponse | PlainMessage<ExportMarkdownResponse> | undefined): boolean {
+    return proto3.util.equals(ExportMarkdownResponse, a, b);
+  }
+}
+
 /**
  * Request for getting a single publication.
  *
@@ -868,6 +1081,468 @@ export class ListAccountPublicationsRequest extends Message<ListAccountPublicati
   }
 }
 
+/**
+ * Request to compare two versions of a document.
+ *
+ * @generated from message com.mintter.documents.v1alpha.DiffVersionsRequest
+ */
+export class DiffVersionsRequest extends Message<DiffVersionsRequest> {
+  /**
+   * Required. ID of the document.
+   *
+   * @generated from field: string document_id = 1;
+   */
+  documentId = "";
+
+  /**
+   * Required. Version of the document to compare from.
+   *
+   * @generated from field: string base_version = 2;
+   */
+  baseVersion = "";
+
+  /**
+   * Required. Version of the document to compare to.
+   *
+   * @generated from field: string target_version = 3;
+   */
+  targetVersion = "";
+
+  constructor(data?: PartialMessage<DiffVersionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.DiffVersionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "document_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "base_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "target_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffVersionsRequest {
+    return new DiffVersionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffVersionsRequest {
+    return new DiffVersionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffVersionsRequest {
+    return new DiffVersionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffVersionsRequest | PlainMessage<DiffVersionsRequest> | undefined, b: DiffVersionsRequest | PlainMessage<DiffVersionsRequest> | undefined): boolean {
+    return proto3.util.equals(DiffVersionsRequest, a, b);
+  }
+}
+
+/**
+ * Differences between two versions of a document.
+ *
+ * @generated from message com.mintter.documents.v1alpha.DiffVersionsResponse
+ */
+export class DiffVersionsResponse extends Message<DiffVersionsResponse> {
+  /**
+   * Differences in the document-level metadata, like the title.
+   *
+   * @generated from field: repeated com.mintter.documents.v1alpha.MetadataDiff metadata = 1;
+   */
+  metadata: MetadataDiff[] = [];
+
+  /**
+   * Blocks that were added, removed, moved, or modified.
+   * Blocks of the target version come first in the document order,
+   * followed by the removed blocks in the document order of the base version.
+   *
+   * @generated from field: repeated com.mintter.documents.v1alpha.BlockDiff blocks = 2;
+   */
+  blocks: BlockDiff[] = [];
+
+  /**
+   * IDs of the changes that are in the target version, but not in the base version.
+   *
+   * @generated from field: repeated string added_changes = 3;
+   */
+  addedChanges: string[] = [];
+
+  /**
+   * IDs of the changes that are in the base version, but not in the target version.
+   * Only present when the base version is not an ancestor of the target version.
+   *
+   * @generated from field: repeated string removed_changes = 4;
+   */
+  removedChanges: string[] = [];
+
+  constructor(data?: PartialMessage<DiffVersionsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.DiffVersionsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "metadata", kind: "message", T: MetadataDiff, repeated: true },
+    { no: 2, name: "blocks", kind: "message", T: BlockDiff, repeated: true },
+    { no: 3, name: "added_changes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "removed_changes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffVersionsResponse {
+    return new DiffVersionsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffVersionsResponse {
+    return new DiffVersionsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffVersionsResponse {
+    return new DiffVersionsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffVersionsResponse | PlainMessage<DiffVersionsResponse> | undefined, b: DiffVersionsResponse | PlainMessage<DiffVersionsResponse> | undefined): boolean {
+    return proto3.util.equals(DiffVersionsResponse, a, b);
+  }
+}
+
+/**
+ * Difference in a document-level metadata field.
+ *
+ * @generated from message com.mintter.documents.v1alpha.MetadataDiff
+ */
+export class MetadataDiff extends Message<MetadataDiff> {
+  /**
+   * Name of the field, e.g. "title".
+   *
+   * @generated from field: string field = 1;
+   */
+  field = "";
+
+  /**
+   * Value of the field in the base version.
+   *
+   * @generated from field: string old_value = 2;
+   */
+  oldValue = "";
+
+  /**
+   * Value of the field in the target version.
+   *
+   * @generated from field: string new_value = 3;
+   */
+  newValue = "";
+
+  /**
+   * Account IDs of the authors of the changes responsible for the difference.
+   *
+   * @generated from field: repeated string authors = 4;
+   */
+  authors: string[] = [];
+
+  constructor(data?: PartialMessage<MetadataDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.MetadataDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "field", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "old_value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "new_value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "authors", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MetadataDiff {
+    return new MetadataDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MetadataDiff {
+    return new MetadataDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MetadataDiff {
+    return new MetadataDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MetadataDiff | PlainMessage<MetadataDiff> | undefined, b: MetadataDiff | PlainMessage<MetadataDiff> | undefined): boolean {
+    return proto3.util.equals(MetadataDiff, a, b);
+  }
+}
+
+/**
+ * Difference in a content block.
+ *
+ * @generated from message com.mintter.documents.v1alpha.BlockDiff
+ */
+export class BlockDiff extends Message<BlockDiff> {
+  /**
+   * ID of the block.
+   *
+   * @generated from field: string block_id = 1;
+   */
+  blockId = "";
+
+  /**
+   * State of the block in the base version. Not present for added blocks.
+   *
+   * @generated from field: com.mintter.documents.v1alpha.Block old_block = 2;
+   */
+  oldBlock?: Block;
+
+  /**
+   * State of the block in the target version. Not present for removed blocks.
+   *
+   * @generated from field: com.mintter.documents.v1alpha.Block new_block = 3;
+   */
+  newBlock?: Block;
+
+  /**
+   * Position of the block in the base version. Not present for added blocks.
+   *
+   * @generated from field: com.mintter.documents.v1alpha.BlockPosition old_position = 4;
+   */
+  oldPosition?: BlockPosition;
+
+  /**
+   * Position of the block in the target version. Not present for removed blocks.
+   *
+   * @generated from field: com.mintter.documents.v1alpha.BlockPosition new_position = 5;
+   */
+  newPosition?: BlockPosition;
+
+  /**
+   * Whether the block was moved to a different parent,
+   * or changed its order relative to the siblings it had in both versions.
+   *
+   * @generated from field: bool moved = 6;
+   */
+  moved = false;
+
+  /**
+   * Names of the block fields that differ, e.g. "text", "type", or "annotations".
+   * Empty for added and removed blocks.
+   *
+   * @generated from field: repeated string changed_fields = 7;
+   */
+  changedFields: string[] = [];
+
+  /**
+   * Character-level difference of the block text. Empty if the text didn't change.
+   *
+   * @generated from field: repeated com.mintter.documents.v1alpha.TextHunk text_diff = 8;
+   */
+  textDiff: TextHunk[] = [];
+
+  /**
+   * Differences in the annotations of the block.
+   *
+   * @generated from field: repeated com.mintter.documents.v1alpha.AnnotationDiff annotation_diffs = 9;
+   */
+  annotationDiffs: AnnotationDiff[] = [];
+
+  /**
+   * Account IDs of the authors of the changes responsible for the difference.
+   *
+   * @generated from field: repeated string authors = 10;
+   */
+  authors: string[] = [];
+
+  constructor(data?: PartialMessage<BlockDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.BlockDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "old_block", kind: "message", T: Block },
+    { no: 3, name: "new_block", kind: "message", T: Block },
+    { no: 4, name: "old_position", kind: "message", T: BlockPosition },
+    { no: 5, name: "new_position", kind: "message", T: BlockPosition },
+    { no: 6, name: "moved", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "changed_fields", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "text_diff", kind: "message", T: TextHunk, repeated: true },
+    { no: 9, name: "annotation_diffs", kind: "message", T: AnnotationDiff, repeated: true },
+    { no: 10, name: "authors", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BlockDiff {
+    return new BlockDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BlockDiff {
+    return new BlockDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BlockDiff {
+    return new BlockDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BlockDiff | PlainMessage<BlockDiff> | undefined, b: BlockDiff | PlainMessage<BlockDiff> | undefined): boolean {
+    return proto3.util.equals(BlockDiff, a, b);
+  }
+}
+
+/**
+ * Position of a block in the document hierarchy.
+ *
+ * @generated from message com.mintter.documents.v1alpha.BlockPosition
+ */
+export class BlockPosition extends Message<BlockPosition> {
+  /**
+   * ID of the parent block. Empty for top-level blocks.
+   *
+   * @generated from field: string parent = 1;
+   */
+  parent = "";
+
+  /**
+   * ID of the left sibling. Empty for the first child.
+   *
+   * @generated from field: string left_sibling = 2;
+   */
+  leftSibling = "";
+
+  constructor(data?: PartialMessage<BlockPosition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.BlockPosition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "parent", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "left_sibling", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BlockPosition {
+    return new BlockPosition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BlockPosition {
+    return new BlockPosition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BlockPosition {
+    return new BlockPosition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BlockPosition | PlainMessage<BlockPosition> | undefined, b: BlockPosition | PlainMessage<BlockPosition> | undefined): boolean {
+    return proto3.util.equals(BlockPosition, a, b);
+  }
+}
+
+/**
+ * Run of text in the difference between two versions of a block.
+ *
+ * @generated from message com.mintter.documents.v1alpha.TextHunk
+ */
+export class TextHunk extends Message<TextHunk> {
+  /**
+   * Whether the text was kept, inserted, or deleted.
+   *
+   * @generated from field: com.mintter.documents.v1alpha.DiffOp op = 1;
+   */
+  op = DiffOp.DIFF_OP_UNSPECIFIED;
+
+  /**
+   * Text of the run.
+   *
+   * @generated from field: string text = 2;
+   */
+  text = "";
+
+  /**
+   * Account IDs of the authors of the changes that inserted or deleted the text.
+   * Empty for the kept text.
+   *
+   * @generated from field: repeated string authors = 3;
+   */
+  authors: string[] = [];
+
+  constructor(data?: PartialMessage<TextHunk>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.TextHunk";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "op", kind: "enum", T: proto3.getEnumType(DiffOp) },
+    { no: 2, name: "text", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "authors", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TextHunk {
+    return new TextHunk().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TextHunk {
+    return new TextHunk().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TextHunk {
+    return new TextHunk().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: TextHunk | PlainMessage<TextHunk> | undefined, b: TextHunk | PlainMessage<TextHunk> | undefined): boolean {
+    return proto3.util.equals(TextHunk, a, b);
+  }
+}
+
+/**
+ * Difference in an annotation of a block.
+ * Annotations are matched by their type, ref, and attributes.
+ *
+ * @generated from message com.mintter.documents.v1alpha.AnnotationDiff
+ */
+export class AnnotationDiff extends Message<AnnotationDiff> {
+  /**
+   * Annotation in the base version. Not present for added annotations.
+   *
+   * @generated from field: com.mintter.documents.v1alpha.Annotation old_annotation = 1;
+   */
+  oldAnnotation?: Annotation;
+
+  /**
+   * Annotation in the target version. Not present for removed annotations.
+   *
+   * @generated from field: com.mintter.documents.v1alpha.Annotation new_annotation = 2;
+   */
+  newAnnotation?: Annotation;
+
+  constructor(data?: PartialMessage<AnnotationDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.documents.v1alpha.AnnotationDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "old_annotation", kind: "message", T: Annotation },
+    { no: 2, name: "new_annotation", kind: "message", T: Annotation },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AnnotationDiff {
+    return new AnnotationDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AnnotationDiff {
+    return new AnnotationDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AnnotationDiff {
+    return new AnnotationDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AnnotationDiff | PlainMessage<AnnotationDiff> | undefined, b: AnnotationDiff | PlainMessage<AnnotationDiff> | undefined): boolean {
+    return proto3.util.equals(AnnotationDiff, a, b);
+  }
+}
+
 /**
  * Request for merging changes in a document.
  *
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { Change, DeleteEntityRequest, DiscoverEntityRequest, DiscoverEntityResponse, EntityTimeline, GetChangeRequest, GetEntityTimelineRequest, ListDeletedEntitiesRequest, ListDeletedEntitiesResponse, ListEntityMentionsRequest, ListEntityMentionsResponse, SearchEntitiesRequest, SearchEntitiesResponse, UndeleteEntityRequest } from "./entities_pb";
+import { Change, DeleteEntityRequest, DiscoverEntityRequest, DiscoverEntityResponse, EntityTimeline, ExportEntityRequest, ExportEntityResponse, GetChangeRequest, GetEntityTimelineRequest, ImportEntityRequest, ImportEntityResponse, ListDeletedEntitiesRequest, ListDeletedEntitiesResponse, ListEntityMentionsRequest, ListEntityMentionsResponse, ListSubscriptionsRequest, ListSubscriptionsResponse, SearchEntitiesRequest, SearchEntitiesResponse, SubscribeRequest, UndeleteEntityRequest, UnsubscribeRequest } from "./entities_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -48,9 +48,10 @@ export const Entities = {
       kind: MethodKind.Unary,
     },
     /**
-     * Finds the list of local entities whose titles match the input string.
-     * A fuzzy search is performed among documents, groups and accounts.
-     * For groups and documents, we match the title, while we match alias in accounts.
+     * Performs a full-text search among the locally available entities.
+     * It matches titles and content of documents, titles and descriptions of groups,
+     * aliases of accounts, and the content of comments.
+     * Results are sorted by relevance, with one result per entity.
      *
      * @generated from rpc com.mintter.entities.v1alpha.Entities.SearchEntities
      */
@@ -105,6 +106,67 @@ export const Entities = {
       O: ListEntityMentionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Subscribes to an entity. Once there's at least one subscription,
+     * the node only syncs the blobs related to the subscribed entities from other peers,
+     * instead of syncing everything they have.
+     *
+     * @generated from rpc com.mintter.entities.v1alpha.Entities.Subscribe
+     */
+    subscribe: {
+      name: "Subscribe",
+      I: SubscribeRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Removes the subscription to an entity.
+     * Blobs that are no longer needed can be garbage collected afterwards.
+     *
+     * @generated from rpc com.mintter.entities.v1alpha.Entities.Unsubscribe
+     */
+    unsubscribe: {
+      name: "Unsubscribe",
+      I: UnsubscribeRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the current subscriptions.
+     *
+     * @generated from rpc com.mintter.entities.v1alpha.Entities.ListSubscriptions
+     */
+    listSubscriptions: {
+      name: "ListSubscriptions",
+      I: ListSubscriptionsRequest,
+      O: ListSubscriptionsResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Exports an entity with its full history into a CAR archive.
+     * The archive includes all the published changes, the comments targeting the entity,
+     * the key delegations needed to verify them, and the files embedded in the content.
+     *
+     * @generated from rpc com.mintter.entities.v1alpha.Entities.ExportEntity
+     */
+    exportEntity: {
+      name: "ExportEntity",
+      I: ExportEntityRequest,
+      O: ExportEntityResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Imports a CAR archive previously produced by ExportEntity.
+     * Signatures are verified before anything is stored.
+     *
+     * @generated from rpc com.mintter.entities.v1alpha.Entities.ImportEntity
+     */
+    importEntity: {
+      name: "ImportEntity",
+      I: ImportEntityRequest,
+      O: ImportEntityResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
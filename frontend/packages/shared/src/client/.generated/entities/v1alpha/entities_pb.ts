@@ -459,6 +459,43 @@ export class Entity extends Message<Entity> {
    */
   owner = "";
 
+  /**
+   * Only set in search results. Type of the content that matched the search query:
+   * title, document, description, alias, or comment.
+   *
+   * @generated from field: string content_type = 4;
+   */
+  contentType = "";
+
+  /**
+   * Only set in search results. Fragment of the matched content as HTML:
+   * the content itself is escaped, and the matched terms are wrapped in <b></b> tags.
+   *
+   * @generated from field: string snippet = 5;
+   */
+  snippet = "";
+
+  /**
+   * Only set in search results. ID of the matched block within the document or comment, if any.
+   *
+   * @generated from field: string block_id = 6;
+   */
+  blockId = "";
+
+  /**
+   * Only set in search results. CID of the blob with the matched content.
+   *
+   * @generated from field: string version = 7;
+   */
+  version = "";
+
+  /**
+   * Only set in search results. Account ID of the author of the matched content.
+   *
+   * @generated from field: string author = 8;
+   */
+  author = "";
+
   constructor(data?: PartialMessage<Entity>) {
     super();
     proto3.util.initPartial(data, this);
@@ -470,6 +507,11 @@ export class Entity extends Message<Entity> {
     { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "title", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "owner", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "content_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "snippet", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "author", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Entity {
@@ -561,14 +603,48 @@ export class DeletedEntity extends Message<DeletedEntity> {
  */
 export class SearchEntitiesRequest extends Message<SearchEntitiesRequest> {
   /**
-   * Query to find. Since we use
-   * Fuzzy search, a single query may return multiple
-   * entities.
+   * Query to find. Every word of the query must be present in the matched content,
+   * either as a full word or as a prefix of a word.
    *
    * @generated from field: string query = 1;
    */
   query = "";
 
+  /**
+   * Optional. Number of results per page. Default is defined by the server.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. Value from next_page_token obtained from a previous response.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken = "";
+
+  /**
+   * Optional. Only return entities of these types: document, group, account, or comment.
+   *
+   * @generated from field: repeated string entity_types = 4;
+   */
+  entityTypes: string[] = [];
+
+  /**
+   * Optional. Only return content authored by this account.
+   *
+   * @generated from field: string author = 5;
+   */
+  author = "";
+
+  /**
+   * Optional. Only return the group itself, the documents published in the group, and comments on them.
+   *
+   * @generated from field: string group_id = 6;
+   */
+  groupId = "";
+
   constructor(data?: PartialMessage<SearchEntitiesRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -578,6 +654,11 @@ export class SearchEntitiesRequest extends Message<SearchEntitiesRequest> {
   static readonly typeName = "com.mintter.entities.v1alpha.SearchEntitiesRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "query", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "entity_types", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "author", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "group_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SearchEntitiesRequest {
@@ -1111,3 +1192,389 @@ export class Mention_BlobInfo extends Message<Mention_BlobInfo> {
   }
 }
 
+/**
+ * Request to subscribe to an entity.
+ *
+ * @generated from message com.mintter.entities.v1alpha.SubscribeRequest
+ */
+export class SubscribeRequest extends Message<SubscribeRequest> {
+  /**
+   * Required. ID of the entity to subscribe to.
+   * Must be a document (hm://d/...), a group (hm://g/...), or an account (hm://a/...).
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<SubscribeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.SubscribeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SubscribeRequest {
+    return new SubscribeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SubscribeRequest {
+    return new SubscribeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SubscribeRequest {
+    return new SubscribeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SubscribeRequest | PlainMessage<SubscribeRequest> | undefined, b: SubscribeRequest | PlainMessage<SubscribeRequest> | undefined): boolean {
+    return proto3.util.equals(SubscribeRequest, a, b);
+  }
+}
+
+/**
+ * Request to unsubscribe from an entity.
+ *
+ * @generated from message com.mintter.entities.v1alpha.UnsubscribeRequest
+ */
+export class UnsubscribeRequest extends Message<UnsubscribeRequest> {
+  /**
+   * Required. ID of the entity to unsubscribe from.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<UnsubscribeRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.UnsubscribeRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UnsubscribeRequest {
+    return new UnsubscribeRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UnsubscribeRequest {
+    return new UnsubscribeRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UnsubscribeRequest {
+    return new UnsubscribeRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UnsubscribeRequest | PlainMessage<UnsubscribeRequest> | undefined, b: UnsubscribeRequest | PlainMessage<UnsubscribeRequest> | undefined): boolean {
+    return proto3.util.equals(UnsubscribeRequest, a, b);
+  }
+}
+
+/**
+ * Request to list subscriptions.
+ *
+ * @generated from message com.mintter.entities.v1alpha.ListSubscriptionsRequest
+ */
+export class ListSubscriptionsRequest extends Message<ListSubscriptionsRequest> {
+  constructor(data?: PartialMessage<ListSubscriptionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.ListSubscriptionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSubscriptionsRequest {
+    return new ListSubscriptionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSubscriptionsRequest {
+    return new ListSubscriptionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSubscriptionsRequest {
+    return new ListSubscriptionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSubscriptionsRequest | PlainMessage<ListSubscriptionsRequest> | undefined, b: ListSubscriptionsRequest | PlainMessage<ListSubscriptionsRequest> | undefined): boolean {
+    return proto3.util.equals(ListSubscriptionsRequest, a, b);
+  }
+}
+
+/**
+ * Response with the list of subscriptions.
+ *
+ * @generated from message com.mintter.entities.v1alpha.ListSubscriptionsResponse
+ */
+export class ListSubscriptionsResponse extends Message<ListSubscriptionsResponse> {
+  /**
+   * List of subscriptions.
+   *
+   * @generated from field: repeated com.mintter.entities.v1alpha.Subscription subscriptions = 1;
+   */
+  subscriptions: Subscription[] = [];
+
+  constructor(data?: PartialMessage<ListSubscriptionsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.ListSubscriptionsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "subscriptions", kind: "message", T: Subscription, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSubscriptionsResponse {
+    return new ListSubscriptionsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSubscriptionsResponse {
+    return new ListSubscriptionsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSubscriptionsResponse {
+    return new ListSubscriptionsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSubscriptionsResponse | PlainMessage<ListSubscriptionsResponse> | undefined, b: ListSubscriptionsResponse | PlainMessage<ListSubscriptionsResponse> | undefined): boolean {
+    return proto3.util.equals(ListSubscriptionsResponse, a, b);
+  }
+}
+
+/**
+ * Subscription to an entity.
+ *
+ * @generated from message com.mintter.entities.v1alpha.Subscription
+ */
+export class Subscription extends Message<Subscription> {
+  /**
+   * ID of the subscribed entity.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * When the subscription was created.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 2;
+   */
+  createTime?: Timestamp;
+
+  constructor(data?: PartialMessage<Subscription>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.Subscription";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "create_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Subscription {
+    return new Subscription().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Subscription {
+    return new Subscription().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Subscription {
+    return new Subscription().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Subscription | PlainMessage<Subscription> | undefined, b: Subscription | PlainMessage<Subscription> | undefined): boolean {
+    return proto3.util.equals(Subscription, a, b);
+  }
+}
+
+/**
+ * Request to export an entity.
+ *
+ * @generated from message com.mintter.entities.v1alpha.ExportEntityRequest
+ */
+export class ExportEntityRequest extends Message<ExportEntityRequest> {
+  /**
+   * Required. ID of the entity to export.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<ExportEntityRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.ExportEntityRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportEntityRequest {
+    return new ExportEntityRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportEntityRequest {
+    return new ExportEntityRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportEntityRequest {
+    return new ExportEntityRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportEntityRequest | PlainMessage<ExportEntityRequest> | undefined, b: ExportEntityRequest | PlainMessage<ExportEntityRequest> | undefined): boolean {
+    return proto3.util.equals(ExportEntityRequest, a, b);
+  }
+}
+
+/**
+ * Response with the exported entity.
+ *
+ * @generated from message com.mintter.entities.v1alpha.ExportEntityResponse
+ */
+export class ExportEntityResponse extends Message<ExportEntityResponse> {
+  /**
+   * CARv1 archive with the blobs of the entity.
+   *
+   * @generated from field: bytes car = 1;
+   */
+  car = new Uint8Array(0);
+
+  /**
+   * Number of blobs in the archive.
+   *
+   * @generated from field: int32 blob_count = 2;
+   */
+  blobCount = 0;
+
+  constructor(data?: PartialMessage<ExportEntityResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.ExportEntityResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "car", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 2, name: "blob_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportEntityResponse {
+    return new ExportEntityResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportEntityResponse {
+    return new ExportEntityResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportEntityResponse {
+    return new ExportEntityResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportEntityResponse | PlainMessage<ExportEntityResponse> | undefined, b: ExportEntityResponse | PlainMessage<ExportEntityResponse> | undefined): boolean {
+    return proto3.util.equals(ExportEntityResponse, a, b);
+  }
+}
+
+/**
+ * Request to import an entity.
+ *
+ * @generated from message com.mintter.entities.v1alpha.ImportEntityRequest
+ */
+export class ImportEntityRequest extends Message<ImportEntityRequest> {
+  /**
+   * Required. CARv1 archive produced by ExportEntity.
+   *
+   * @generated from field: bytes car = 1;
+   */
+  car = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<ImportEntityRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.ImportEntityRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "car", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImportEntityRequest {
+    return new ImportEntityRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImportEntityRequest {
+    return new ImportEntityRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImportEntityRequest {
+    return new ImportEntityRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImportEntityRequest | PlainMessage<ImportEntityRequest> | undefined, b: ImportEntityRequest | PlainMessage<ImportEntityRequest> | undefined): boolean {
+    return proto3.util.equals(ImportEntityRequest, a, b);
+  }
+}
+
+/**
+ * Response after importing an entity.
+ *
+ * @generated from message com.mintter.entities.v1alpha.ImportEntityResponse
+ */
+export class ImportEntityResponse extends Message<ImportEntityResponse> {
+  /**
+   * IDs of the entities found in the archive.
+   *
+   * @generated from field: repeated string ids = 1;
+   */
+  ids: string[] = [];
+
+  /**
+   * Number of blobs in the archive.
+   *
+   * @generated from field: int32 blob_count = 2;
+   */
+  blobCount = 0;
+
+  constructor(data?: PartialMessage<ImportEntityResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.entities.v1alpha.ImportEntityResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "blob_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImportEntityResponse {
+    return new ImportEntityResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImportEntityResponse {
+    return new ImportEntityResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImportEntityResponse {
+    return new ImportEntityResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImportEntityResponse | PlainMessage<ImportEntityResponse> | undefined, b: ImportEntityResponse | PlainMessage<ImportEntityResponse> | undefined): boolean {
+    return proto3.util.equals(ImportEntityResponse, a, b);
+  }
+}
+
@@ -33,12 +33,30 @@ export enum Role {
    * @generated from enum value: EDITOR = 2;
    */
   EDITOR = 2,
+
+  /**
+   * Admin role which allows members to manage content of the group,
+   * and to manage other members, except for other admins.
+   *
+   * @generated from enum value: ADMIN = 3;
+   */
+  ADMIN = 3,
+
+  /**
+   * Viewer role for members who can read and comment on the content of the group,
+   * but can't change it.
+   *
+   * @generated from enum value: VIEWER = 4;
+   */
+  VIEWER = 4,
 }
 // Retrieve enum metadata with: proto3.getEnumType(Role)
 proto3.util.setEnumType(Role, "com.mintter.groups.v1alpha.Role", [
   { no: 0, name: "ROLE_UNSPECIFIED" },
   { no: 1, name: "OWNER" },
   { no: 2, name: "EDITOR" },
+  { no: 3, name: "ADMIN" },
+  { no: 4, name: "VIEWER" },
 ]);
 
 /**
@@ -77,6 +95,15 @@ export class CreateGroupRequest extends Message<CreateGroupRequest> {
    */
   siteSetupUrl = "";
 
+  /**
+   * Optional. Makes the group private.
+   * Content of private groups is encrypted, and is only available to the members of the group.
+   * Privacy of the group can't be changed after creation.
+   *
+   * @generated from field: bool private = 5;
+   */
+  private = false;
+
   constructor(data?: PartialMessage<CreateGroupRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -89,6 +116,7 @@ export class CreateGroupRequest extends Message<CreateGroupRequest> {
     { no: 2, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "members", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "enum", T: proto3.getEnumType(Role)} },
     { no: 4, name: "site_setup_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "private", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateGroupRequest {
@@ -198,6 +226,8 @@ export class UpdateGroupRequest extends Message<UpdateGroupRequest> {
    * value is the Role.
    * To remove a member from the group, set the role to unspecified.
    * Only updated records have to be sent, not all the members of the group.
+   * Only the owner and admins can update members,
+   * and only the owner can grant or revoke the admin role.
    *
    * @generated from field: map<string, com.mintter.groups.v1alpha.Role> updated_members = 4;
    */
@@ -309,6 +339,14 @@ export class SyncGroupSiteResponse extends Message<SyncGroupSiteResponse> {
    */
   siteInfo?: Group_SiteInfo;
 
+  /**
+   * Explanation why our content was not pushed to the site, as reported by the site.
+   * Empty if the push was allowed.
+   *
+   * @generated from field: string push_denied_reason = 2;
+   */
+  pushDeniedReason = "";
+
   constructor(data?: PartialMessage<SyncGroupSiteResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -318,6 +356,7 @@ export class SyncGroupSiteResponse extends Message<SyncGroupSiteResponse> {
   static readonly typeName = "com.mintter.groups.v1alpha.SyncGroupSiteResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "site_info", kind: "message", T: Group_SiteInfo },
+    { no: 2, name: "push_denied_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SyncGroupSiteResponse {
@@ -1068,6 +1107,13 @@ export class Group extends Message<Group> {
    */
   siteInfo?: Group_SiteInfo;
 
+  /**
+   * Whether the group is private, i.e. its content is only available to the members.
+   *
+   * @generated from field: bool private = 9;
+   */
+  private = false;
+
   constructor(data?: PartialMessage<Group>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1084,6 +1130,7 @@ export class Group extends Message<Group> {
     { no: 6, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 7, name: "update_time", kind: "message", T: Timestamp },
     { no: 8, name: "site_info", kind: "message", T: Group_SiteInfo },
+    { no: 9, name: "private", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Group {
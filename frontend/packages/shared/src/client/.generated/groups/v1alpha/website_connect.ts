@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { GetSiteInfoRequest, InitializeServerRequest, InitializeServerResponse, PublicSiteInfo, PublishBlobsRequest, PublishBlobsResponse } from "./website_pb";
+import { CallerPermissions, GetCallerPermissionsRequest, GetSiteInfoRequest, InitializeServerRequest, InitializeServerResponse, PublicSiteInfo, PublishBlobsRequest, PublishBlobsResponse } from "./website_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -40,6 +40,8 @@ export const Website = {
     },
     /**
      * Publishes blobs to the website.
+     * The caller must be allowed to perform the PUBLISH_BLOBS operation,
+     * and the request must fit within the storage limits of the site.
      *
      * @generated from rpc com.mintter.groups.v1alpha.Website.PublishBlobs
      */
@@ -49,6 +51,18 @@ export const Website = {
       O: PublishBlobsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Gets the permissions and capabilities of the caller on this site.
+     * Clients should use it to check what they are allowed to do before doing it.
+     *
+     * @generated from rpc com.mintter.groups.v1alpha.Website.GetCallerPermissions
+     */
+    getCallerPermissions: {
+      name: "GetCallerPermissions",
+      I: GetCallerPermissionsRequest,
+      O: CallerPermissions,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -4,7 +4,43 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3 } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+import { Role } from "./groups_pb";
+
+/**
+ * Operations that can be performed on the site.
+ *
+ * @generated from enum com.mintter.groups.v1alpha.SiteOperation
+ */
+export enum SiteOperation {
+  /**
+   * Zero value which is an invalid operation.
+   *
+   * @generated from enum value: SITE_OPERATION_UNSPECIFIED = 0;
+   */
+  SITE_OPERATION_UNSPECIFIED = 0,
+
+  /**
+   * Initializing the site to serve a group.
+   * Also requires the setup secret.
+   *
+   * @generated from enum value: INITIALIZE_SERVER = 1;
+   */
+  INITIALIZE_SERVER = 1,
+
+  /**
+   * Publishing blobs to the site.
+   *
+   * @generated from enum value: PUBLISH_BLOBS = 2;
+   */
+  PUBLISH_BLOBS = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(SiteOperation)
+proto3.util.setEnumType(SiteOperation, "com.mintter.groups.v1alpha.SiteOperation", [
+  { no: 0, name: "SITE_OPERATION_UNSPECIFIED" },
+  { no: 1, name: "INITIALIZE_SERVER" },
+  { no: 2, name: "PUBLISH_BLOBS" },
+]);
 
 /**
  * Request for getting the public site information.
@@ -197,6 +233,172 @@ export class PublishBlobsResponse extends Message<PublishBlobsResponse> {
   }
 }
 
+/**
+ * Request for getting the permissions of the caller.
+ *
+ * @generated from message com.mintter.groups.v1alpha.GetCallerPermissionsRequest
+ */
+export class GetCallerPermissionsRequest extends Message<GetCallerPermissionsRequest> {
+  constructor(data?: PartialMessage<GetCallerPermissionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.groups.v1alpha.GetCallerPermissionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetCallerPermissionsRequest {
+    return new GetCallerPermissionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetCallerPermissionsRequest {
+    return new GetCallerPermissionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetCallerPermissionsRequest {
+    return new GetCallerPermissionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetCallerPermissionsRequest | PlainMessage<GetCallerPermissionsRequest> | undefined, b: GetCallerPermissionsRequest | PlainMessage<GetCallerPermissionsRequest> | undefined): boolean {
+    return proto3.util.equals(GetCallerPermissionsRequest, a, b);
+  }
+}
+
+/**
+ * Permissions and capabilities of the caller on the site.
+ *
+ * @generated from message com.mintter.groups.v1alpha.CallerPermissions
+ */
+export class CallerPermissions extends Message<CallerPermissions> {
+  /**
+   * Account ID of the caller.
+   *
+   * @generated from field: string account_id = 1;
+   */
+  accountId = "";
+
+  /**
+   * Role of the caller in the group served by the site.
+   * Unspecified if the caller is not a member of the group, or the site is not initialized yet.
+   *
+   * @generated from field: com.mintter.groups.v1alpha.Role role = 2;
+   */
+  role = Role.ROLE_UNSPECIFIED;
+
+  /**
+   * Operations the caller is allowed to perform on the site.
+   *
+   * @generated from field: repeated com.mintter.groups.v1alpha.SiteOperation allowed_operations = 3;
+   */
+  allowedOperations: SiteOperation[] = [];
+
+  /**
+   * Human-readable explanation why the caller is not allowed to publish to the site.
+   * Empty if publishing is allowed.
+   *
+   * @generated from field: string publish_denied_reason = 4;
+   */
+  publishDeniedReason = "";
+
+  /**
+   * Limits the site imposes on the published content.
+   *
+   * @generated from field: com.mintter.groups.v1alpha.StorageLimits storage_limits = 5;
+   */
+  storageLimits?: StorageLimits;
+
+  /**
+   * Protocol features supported by the site.
+   *
+   * @generated from field: repeated string features = 6;
+   */
+  features: string[] = [];
+
+  constructor(data?: PartialMessage<CallerPermissions>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.groups.v1alpha.CallerPermissions";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
+    { no: 3, name: "allowed_operations", kind: "enum", T: proto3.getEnumType(SiteOperation), repeated: true },
+    { no: 4, name: "publish_denied_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "storage_limits", kind: "message", T: StorageLimits },
+    { no: 6, name: "features", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CallerPermissions {
+    return new CallerPermissions().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CallerPermissions {
+    return new CallerPermissions().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CallerPermissions {
+    return new CallerPermissions().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CallerPermissions | PlainMessage<CallerPermissions> | undefined, b: CallerPermissions | PlainMessage<CallerPermissions> | undefined): boolean {
+    return proto3.util.equals(CallerPermissions, a, b);
+  }
+}
+
+/**
+ * Limits the site imposes on the published content.
+ * Zero values mean there's no limit.
+ *
+ * @generated from message com.mintter.groups.v1alpha.StorageLimits
+ */
+export class StorageLimits extends Message<StorageLimits> {
+  /**
+   * Maximum number of blobs in a single PublishBlobs request.
+   *
+   * @generated from field: int64 max_blobs_per_request = 1;
+   */
+  maxBlobsPerRequest = protoInt64.zero;
+
+  /**
+   * Maximum size of a single blob in bytes.
+   *
+   * @generated from field: int64 max_blob_size = 2;
+   */
+  maxBlobSize = protoInt64.zero;
+
+  constructor(data?: PartialMessage<StorageLimits>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.groups.v1alpha.StorageLimits";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "max_blobs_per_request", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "max_blob_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StorageLimits {
+    return new StorageLimits().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StorageLimits {
+    return new StorageLimits().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StorageLimits {
+    return new StorageLimits().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StorageLimits | PlainMessage<StorageLimits> | undefined, b: StorageLimits | PlainMessage<StorageLimits> | undefined): boolean {
+    return proto3.util.equals(StorageLimits, a, b);
+  }
+}
+
 /**
  * Publicly available information about the website.
  *
@@ -3,8 +3,8 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { ConnectRequest, ConnectResponse, GetPeerInfoRequest, ListPeersRequest, ListPeersResponse, PeerInfo } from "./networking_pb";
-import { MethodKind } from "@bufbuild/protobuf";
+import { BanPeerRequest, ConnectRequest, ConnectResponse, GetPeerInfoRequest, GetPeerPolicyRequest, ListPeersRequest, ListPeersResponse, PeerInfo, PeerPolicy, SetPeerAccessRequest, UnbanPeerRequest } from "./networking_pb";
+import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
  * Networking API service of the Mintter daemon.
@@ -47,6 +47,50 @@ export const Networking = {
       O: ConnectResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Returns the policy applied to the remote peers.
+     *
+     * @generated from rpc com.mintter.networking.v1alpha.Networking.GetPeerPolicy
+     */
+    getPeerPolicy: {
+      name: "GetPeerPolicy",
+      I: GetPeerPolicyRequest,
+      O: PeerPolicy,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Puts a peer or an account into the allow or deny list of the peer policy.
+     *
+     * @generated from rpc com.mintter.networking.v1alpha.Networking.SetPeerAccess
+     */
+    setPeerAccess: {
+      name: "SetPeerAccess",
+      I: SetPeerAccessRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Bans a peer from using our node until the ban expires.
+     *
+     * @generated from rpc com.mintter.networking.v1alpha.Networking.BanPeer
+     */
+    banPeer: {
+      name: "BanPeer",
+      I: BanPeerRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lifts the ban of a peer.
+     *
+     * @generated from rpc com.mintter.networking.v1alpha.Networking.UnbanPeer
+     */
+    unbanPeer: {
+      name: "UnbanPeer",
+      I: UnbanPeerRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
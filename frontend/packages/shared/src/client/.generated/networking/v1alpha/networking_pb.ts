@@ -4,7 +4,7 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3 } from "@bufbuild/protobuf";
+import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
 
 /**
  * Indicates connection status of our node with a remote peer.
@@ -50,6 +50,40 @@ proto3.util.setEnumType(ConnectionStatus, "com.mintter.networking.v1alpha.Connec
   { no: 3, name: "CANNOT_CONNECT" },
 ]);
 
+/**
+ * Access of a peer or an account in the peer policy.
+ *
+ * @generated from enum com.mintter.networking.v1alpha.PeerAccess
+ */
+export enum PeerAccess {
+  /**
+   * Peer or account is in none of the lists.
+   *
+   * @generated from enum value: PEER_ACCESS_UNSPECIFIED = 0;
+   */
+  PEER_ACCESS_UNSPECIFIED = 0,
+
+  /**
+   * Peer or account is in the allow list.
+   *
+   * @generated from enum value: ALLOW = 1;
+   */
+  ALLOW = 1,
+
+  /**
+   * Peer or account is in the deny list.
+   *
+   * @generated from enum value: DENY = 2;
+   */
+  DENY = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(PeerAccess)
+proto3.util.setEnumType(PeerAccess, "com.mintter.networking.v1alpha.PeerAccess", [
+  { no: 0, name: "PEER_ACCESS_UNSPECIFIED" },
+  { no: 1, name: "ALLOW" },
+  { no: 2, name: "DENY" },
+]);
+
 /**
  * Request to get peer's addresses.
  *
@@ -305,3 +339,290 @@ export class PeerInfo extends Message<PeerInfo> {
   }
 }
 
+/**
+ * Request to get the peer policy.
+ *
+ * @generated from message com.mintter.networking.v1alpha.GetPeerPolicyRequest
+ */
+export class GetPeerPolicyRequest extends Message<GetPeerPolicyRequest> {
+  constructor(data?: PartialMessage<GetPeerPolicyRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.networking.v1alpha.GetPeerPolicyRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetPeerPolicyRequest {
+    return new GetPeerPolicyRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetPeerPolicyRequest {
+    return new GetPeerPolicyRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetPeerPolicyRequest {
+    return new GetPeerPolicyRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetPeerPolicyRequest | PlainMessage<GetPeerPolicyRequest> | undefined, b: GetPeerPolicyRequest | PlainMessage<GetPeerPolicyRequest> | undefined): boolean {
+    return proto3.util.equals(GetPeerPolicyRequest, a, b);
+  }
+}
+
+/**
+ * Policy applied to the remote peers.
+ *
+ * @generated from message com.mintter.networking.v1alpha.PeerPolicy
+ */
+export class PeerPolicy extends Message<PeerPolicy> {
+  /**
+   * Peer IDs or account IDs that are allowed to use our node.
+   * When not empty, all the other peers are rejected.
+   *
+   * @generated from field: repeated string allowed = 1;
+   */
+  allowed: string[] = [];
+
+  /**
+   * Peer IDs or account IDs that are not allowed to use our node.
+   *
+   * @generated from field: repeated string denied = 2;
+   */
+  denied: string[] = [];
+
+  /**
+   * Currently banned peers.
+   *
+   * @generated from field: repeated com.mintter.networking.v1alpha.PeerBan bans = 3;
+   */
+  bans: PeerBan[] = [];
+
+  constructor(data?: PartialMessage<PeerPolicy>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.networking.v1alpha.PeerPolicy";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "allowed", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "denied", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "bans", kind: "message", T: PeerBan, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PeerPolicy {
+    return new PeerPolicy().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PeerPolicy {
+    return new PeerPolicy().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PeerPolicy {
+    return new PeerPolicy().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PeerPolicy | PlainMessage<PeerPolicy> | undefined, b: PeerPolicy | PlainMessage<PeerPolicy> | undefined): boolean {
+    return proto3.util.equals(PeerPolicy, a, b);
+  }
+}
+
+/**
+ * Details about a banned peer.
+ *
+ * @generated from message com.mintter.networking.v1alpha.PeerBan
+ */
+export class PeerBan extends Message<PeerBan> {
+  /**
+   * Libp2p peer ID of the banned peer.
+   *
+   * @generated from field: string device_id = 1;
+   */
+  deviceId = "";
+
+  /**
+   * Time when the ban expires.
+   *
+   * @generated from field: google.protobuf.Timestamp expire_time = 2;
+   */
+  expireTime?: Timestamp;
+
+  /**
+   * Number of protocol violations recorded for the peer.
+   *
+   * @generated from field: int32 violations = 3;
+   */
+  violations = 0;
+
+  constructor(data?: PartialMessage<PeerBan>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.networking.v1alpha.PeerBan";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "device_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "expire_time", kind: "message", T: Timestamp },
+    { no: 3, name: "violations", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PeerBan {
+    return new PeerBan().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PeerBan {
+    return new PeerBan().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PeerBan {
+    return new PeerBan().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PeerBan | PlainMessage<PeerBan> | undefined, b: PeerBan | PlainMessage<PeerBan> | undefined): boolean {
+    return proto3.util.equals(PeerBan, a, b);
+  }
+}
+
+/**
+ * Request to change the access of a peer or an account.
+ *
+ * @generated from message com.mintter.networking.v1alpha.SetPeerAccessRequest
+ */
+export class SetPeerAccessRequest extends Message<SetPeerAccessRequest> {
+  /**
+   * Required. Peer ID or account ID.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Access to set. Unspecified access removes the ID from both lists.
+   *
+   * @generated from field: com.mintter.networking.v1alpha.PeerAccess access = 2;
+   */
+  access = PeerAccess.PEER_ACCESS_UNSPECIFIED;
+
+  constructor(data?: PartialMessage<SetPeerAccessRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.networking.v1alpha.SetPeerAccessRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "access", kind: "enum", T: proto3.getEnumType(PeerAccess) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SetPeerAccessRequest {
+    return new SetPeerAccessRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SetPeerAccessRequest {
+    return new SetPeerAccessRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SetPeerAccessRequest {
+    return new SetPeerAccessRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SetPeerAccessRequest | PlainMessage<SetPeerAccessRequest> | undefined, b: SetPeerAccessRequest | PlainMessage<SetPeerAccessRequest> | undefined): boolean {
+    return proto3.util.equals(SetPeerAccessRequest, a, b);
+  }
+}
+
+/**
+ * Request to ban a peer.
+ *
+ * @generated from message com.mintter.networking.v1alpha.BanPeerRequest
+ */
+export class BanPeerRequest extends Message<BanPeerRequest> {
+  /**
+   * Required. Libp2p peer ID of the peer to ban.
+   *
+   * @generated from field: string device_id = 1;
+   */
+  deviceId = "";
+
+  /**
+   * Optional. Time when the ban expires. Defaults to one hour from now.
+   *
+   * @generated from field: google.protobuf.Timestamp expire_time = 2;
+   */
+  expireTime?: Timestamp;
+
+  constructor(data?: PartialMessage<BanPeerRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.networking.v1alpha.BanPeerRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "device_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "expire_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BanPeerRequest {
+    return new BanPeerRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BanPeerRequest {
+    return new BanPeerRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BanPeerRequest {
+    return new BanPeerRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BanPeerRequest | PlainMessage<BanPeerRequest> | undefined, b: BanPeerRequest | PlainMessage<BanPeerRequest> | undefined): boolean {
+    return proto3.util.equals(BanPeerRequest, a, b);
+  }
+}
+
+/**
+ * Request to lift the ban of a peer.
+ *
+ * @generated from message com.mintter.networking.v1alpha.UnbanPeerRequest
+ */
+export class UnbanPeerRequest extends Message<UnbanPeerRequest> {
+  /**
+   * Required. Libp2p peer ID of the banned peer.
+   *
+   * @generated from field: string device_id = 1;
+   */
+  deviceId = "";
+
+  constructor(data?: PartialMessage<UnbanPeerRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.mintter.networking.v1alpha.UnbanPeerRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "device_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UnbanPeerRequest {
+    return new UnbanPeerRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UnbanPeerRequest {
+    return new UnbanPeerRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UnbanPeerRequest {
+    return new UnbanPeerRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UnbanPeerRequest | PlainMessage<UnbanPeerRequest> | undefined, b: UnbanPeerRequest | PlainMessage<UnbanPeerRequest> | undefined): boolean {
+    return proto3.util.equals(UnbanPeerRequest, a, b);
+  }
+}
+